@@ -0,0 +1,443 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type adminResponse struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Active    bool      `json:"active"`
+	TypeID    int64     `json:"type_id"`
+	LastLogin time.Time `json:"last_login"`
+}
+
+func newAdminResponse(admin db.Admin) adminResponse {
+	return adminResponse{
+		ID:        admin.ID,
+		Username:  admin.Username,
+		Email:     admin.Email,
+		Active:    admin.Active,
+		TypeID:    admin.TypeID,
+		LastLogin: admin.LastLogin,
+	}
+}
+
+type createAdminRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+	TypeID   int64  `json:"type_id" binding:"required,min=1"`
+}
+
+func (server *Server) createAdmin(ctx *gin.Context) {
+	var req createAdminRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.Password)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAdminParams{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: hashedPassword,
+		TypeID:   req.TypeID,
+	}
+
+	admin, err := server.store.CreateAdmin(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAdminResponse(admin)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getAdminRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAdmin(ctx *gin.Context) {
+	var req getAdminRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	admin, err := server.store.GetAdmin(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAdminResponse(admin)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listAdminsRequest struct {
+	pageRequest
+	Search string `form:"search" binding:"max=100"`
+}
+
+// likePatternEscaper keeps the wildcards typed in a search from being
+// interpreted by ILIKE
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// listAdmins lists the admins, or only the ones whose username or email
+// contains the search term when one is given.
+func (server *Server) listAdmins(ctx *gin.Context) {
+	var req listAdminsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	search := likePatternEscaper.Replace(req.Search)
+
+	var admins []db.Admin
+	if req.Search == "" {
+		admins, err = server.store.ListAdmins(ctx, db.ListAdminsParams{
+			AfterID: page.cursor.ID,
+			Limit:   page.fetchLimit(),
+		})
+	} else {
+		admins, err = server.store.SearchAdmins(ctx, db.SearchAdminsParams{
+			Search:  search,
+			AfterID: page.cursor.ID,
+			Limit:   page.fetchLimit(),
+		})
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	n, more := page.size(len(admins))
+	items := make([]adminResponse, 0, n)
+	for _, admin := range admins[:n] {
+		items = append(items, newAdminResponse(admin))
+	}
+
+	rsp := listResponse{Items: items}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: admins[n-1].ID})
+	}
+
+	if page.includeTotal {
+		var total int64
+		if req.Search == "" {
+			total, err = server.store.CountAdmins(ctx)
+		} else {
+			total, err = server.store.CountSearchAdmins(ctx, search)
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type updateAdminUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateAdminJsonRequest struct {
+	Active *bool `json:"active" binding:"required"`
+	TypeID int64 `json:"type_id" binding:"required,min=1"`
+}
+
+// updateAdmin activates or deactivates an admin and changes their admin type.
+// The admin is logged out of every session when either changes.
+func (server *Server) updateAdmin(ctx *gin.Context) {
+	var uri updateAdminUriRequest
+	var req updateAdminJsonRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+	if uri.ID == authPayload.AdminID {
+		err := errors.New("cannot change your own admin account")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateAdminTxParams{
+		ID:     uri.ID,
+		Active: *req.Active,
+		TypeID: req.TypeID,
+	}
+
+	admin, err := server.store.UpdateAdminTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetAdmin(admin.ID)
+	server.apiKeys.forgetAdmin(admin.ID)
+
+	rsp := newAdminResponse(admin)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type deleteAdminRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteAdmin(ctx *gin.Context) {
+	var req deleteAdminRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+	if req.ID == authPayload.AdminID {
+		err := errors.New("cannot delete your own admin account")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	err := server.store.DeleteAdmin(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetAdmin(req.ID)
+	server.apiKeys.forgetAdmin(req.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+type loginAdminRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type loginAdminResponse struct {
+	AdminSessionID        uuid.UUID     `json:"admin_session_id"`
+	AccessToken           string        `json:"access_token"`
+	AccessTokenExpiresAt  time.Time     `json:"accesss_token_expires_at"`
+	RefreshToken          string        `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time     `json:"refreshs_token_expires_at"`
+	Admin                 adminResponse `json:"admin"`
+}
+
+func (server *Server) loginAdmin(ctx *gin.Context) {
+	var req loginAdminRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.allowLogin(ctx) {
+		return
+	}
+	clientIP := ctx.ClientIP()
+
+	admin, err := server.store.GetAdminByEmail(ctx, req.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// spend as long as for a wrong password, so that the response
+			// time doesn't tell which emails belong to an admin either
+			_ = util.CheckPassword(req.Password, getDummyPasswordHash())
+			server.logins.fail(clientIP)
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = util.CheckPassword(req.Password, admin.Password)
+	if admin.LockedUntil.Valid && time.Now().Before(admin.LockedUntil.Time) {
+		server.logins.fail(clientIP)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return
+	}
+
+	if err != nil {
+		server.logins.fail(clientIP)
+		err = server.recordFailedAdminLogin(ctx, admin)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return
+	}
+
+	if !admin.Active {
+		err := errors.New("admin account is deactivated")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	mfaRequired, enrollmentRequired, err := server.adminMFARequired(ctx, admin)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if mfaRequired {
+		server.respondMFARequired(ctx, admin.ID, true, enrollmentRequired)
+		return
+	}
+
+	rsp, err := server.createAdminLogin(ctx, admin)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// recordFailedAdminLogin counts a failed login of the admin and locks their
+// account once they have failed LoginMaxAttempts times in a row.
+func (server *Server) recordFailedAdminLogin(ctx *gin.Context, admin db.Admin) error {
+	admin, err := server.store.RecordFailedAdminLogin(ctx, admin.ID)
+	if err != nil {
+		return err
+	}
+
+	lockout := lockoutDuration(admin.FailedLoginAttempts, server.config.LoginMaxAttempts, server.config.LoginLockoutDuration)
+	if lockout <= 0 {
+		return nil
+	}
+
+	arg := db.LockAdminParams{
+		ID:          admin.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(lockout), Valid: true},
+	}
+
+	_, err = server.store.LockAdmin(ctx, arg)
+	return err
+}
+
+// createAdminLogin resets the failed logins of an admin who proved who they
+// are, opens a new session for them and returns the tokens bound to it.
+func (server *Server) createAdminLogin(ctx *gin.Context, admin db.Admin) (loginAdminResponse, error) {
+	var err error
+	if admin.FailedLoginAttempts > 0 || admin.LockedUntil.Valid {
+		admin, err = server.store.ResetFailedAdminLogins(ctx, admin.ID)
+		if err != nil {
+			return loginAdminResponse{}, err
+		}
+	}
+
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateTokenForAdmin(
+		admin.ID,
+		admin.Username,
+		admin.TypeID,
+		admin.Active,
+		uuid.Nil,
+		server.config.RefreshTokenDuration,
+	)
+	if err != nil {
+		return loginAdminResponse{}, err
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateTokenForAdmin(
+		admin.ID,
+		admin.Username,
+		admin.TypeID,
+		admin.Active,
+		refreshPayload.ID,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return loginAdminResponse{}, err
+	}
+
+	arg := db.CreateAdminSessionParams{
+		ID:           refreshPayload.ID,
+		AdminID:      admin.ID,
+		RefreshToken: refreshToken,
+		UserAgent:    ctx.Request.UserAgent(),
+		ClientIp:     ctx.ClientIP(),
+		ExpiresAt:    refreshPayload.ExpiredAt,
+	}
+
+	adminSession, err := server.store.CreateAdminSession(ctx, arg)
+	if err != nil {
+		return loginAdminResponse{}, err
+	}
+
+	admin, err = server.store.UpdateAdminLastLogin(ctx, admin.ID)
+	if err != nil {
+		return loginAdminResponse{}, err
+	}
+
+	rsp := loginAdminResponse{
+		AdminSessionID:        adminSession.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
+		Admin:                 newAdminResponse(admin),
+	}
+	return rsp, nil
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+// getAdminForMFA returns the active admin an mfa pending token was issued to,
+// as long as their account isn't locked.
+func (server *Server) getAdminForMFA(ctx *gin.Context, mfaToken string) (db.Admin, bool) {
+	mfaPayload, err := server.tokenMaker.VerifyMFAToken(mfaToken)
+	if err != nil || !mfaPayload.Admin {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(token.ErrInvalidToken))
+		return db.Admin{}, false
+	}
+
+	admin, err := server.store.GetAdmin(ctx, mfaPayload.OwnerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(token.ErrInvalidToken))
+			return db.Admin{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Admin{}, false
+	}
+
+	if !admin.Active {
+		err := errors.New("admin account is deactivated")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.Admin{}, false
+	}
+
+	if admin.LockedUntil.Valid && time.Now().Before(admin.LockedUntil.Time) {
+		server.logins.fail(ctx.ClientIP())
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return db.Admin{}, false
+	}
+
+	return admin, true
+}
+
+// startAdminTOTPEnrollment shares a new totp secret with an admin, which only
+// takes effect once confirmed with a first code.
+func (server *Server) startAdminTOTPEnrollment(ctx *gin.Context, adminID int64, username string) {
+	secret, err := util.RandomTOTPSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAdminTOTPParams{
+		AdminID: adminID,
+		Secret:  secret,
+	}
+
+	_, err = server.store.CreateAdminTOTP(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errMFAAlreadyEnabled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newEnrollTOTPResponse(username, secret))
+}
+
+// enableAdminTOTP confirms the pending enrollment of an admin with their first
+// valid code and returns their new recovery codes.
+func (server *Server) enableAdminTOTP(ctx *gin.Context, totp db.AdminTotp, code string) ([]string, error) {
+	step, ok := util.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.EnableAdminTOTPTxParams{
+		AdminID:            totp.AdminID,
+		Step:               step,
+		RecoveryCodeHashes: hashes,
+	}
+
+	_, err = server.store.EnableAdminTOTPTx(ctx, arg)
+	if err != nil {
+		if err == db.ErrTOTPCodeReused || err == sql.ErrNoRows {
+			return nil, errInvalidMFACode
+		}
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+type verifyAdminLoginMFAResponse struct {
+	loginAdminResponse
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// verifyAdminLoginMFA exchanges an mfa pending token and a second factor for the
+// real tokens of the admin. An admin enrolling during the login gets their recovery codes as well,
+// wrong codes of an enrolled admin count towards the lockout of the account.
+func (server *Server) verifyAdminLoginMFA(ctx *gin.Context) {
+	var req verifyLoginMFARequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.allowLogin(ctx) {
+		return
+	}
+
+	admin, ok := server.getAdminForMFA(ctx, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	totp, err := server.store.GetAdminTOTP(ctx, admin.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errMFANotEnrolled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var recoveryCodes []string
+	if totp.ConfirmedAt.Valid {
+		ok, err = server.checkAdminSecondFactor(ctx, totp, req.Code, req.RecoveryCode)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if !ok {
+			err = server.recordFailedAdminLogin(ctx, admin)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			server.failMFA(ctx)
+			return
+		}
+	} else {
+		recoveryCodes, err = server.enableAdminTOTP(ctx, totp, req.Code)
+		if err != nil {
+			if err == errInvalidMFACode {
+				server.failMFA(ctx)
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	rsp, err := server.createAdminLogin(ctx, admin)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, verifyAdminLoginMFAResponse{
+		loginAdminResponse: rsp,
+		RecoveryCodes:      recoveryCodes,
+	})
+}
+
+// enrollAdminLoginTOTP lets an admin whose type requires a second factor set it
+// up in the middle of their login, with their mfa pending token.
+func (server *Server) enrollAdminLoginTOTP(ctx *gin.Context) {
+	var req enrollLoginTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	admin, ok := server.getAdminForMFA(ctx, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	server.startAdminTOTPEnrollment(ctx, admin.ID, admin.Username)
+}
+
+func (server *Server) getAdminMFA(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+
+	totp, err := server.store.GetAdminTOTP(ctx, authPayload.AdminID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err == sql.ErrNoRows || !totp.ConfirmedAt.Valid {
+		ctx.JSON(http.StatusOK, mfaStatusResponse{})
+		return
+	}
+
+	count, err := server.store.CountAdminRecoveryCodes(ctx, authPayload.AdminID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mfaStatusResponse{
+		Enabled:           true,
+		RecoveryCodesLeft: count,
+	})
+}
+
+func (server *Server) enrollAdminTOTP(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+	server.startAdminTOTPEnrollment(ctx, authPayload.AdminID, authPayload.Username)
+}
+
+func (server *Server) confirmAdminTOTP(ctx *gin.Context) {
+	var req confirmTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+
+	totp, err := server.store.GetAdminTOTP(ctx, authPayload.AdminID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errMFANotEnrolled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if totp.ConfirmedAt.Valid {
+		ctx.JSON(http.StatusForbidden, errorResponse(errMFAAlreadyEnabled))
+		return
+	}
+
+	recoveryCodes, err := server.enableAdminTOTP(ctx, totp, req.Code)
+	if err != nil {
+		if err == errInvalidMFACode {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// disableAdminTOTP turns the second factor of an admin off, with a last code of
+// it, unless their admin type requires one.
+func (server *Server) disableAdminTOTP(ctx *gin.Context) {
+	var req disableTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+
+	adminType, err := server.store.GetAdminType(ctx, authPayload.TypeID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if adminType.RequireMfa {
+		ctx.JSON(http.StatusForbidden, errorResponse(errMFARequiredByAdminType))
+		return
+	}
+
+	totp, err := server.store.GetAdminTOTP(ctx, authPayload.AdminID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errMFANotEnrolled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// a pending enrollment can be called off without a code
+	if totp.ConfirmedAt.Valid {
+		ok, err := server.checkAdminSecondFactor(ctx, totp, req.Code, req.RecoveryCode)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if !ok {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidMFACode))
+			return
+		}
+	}
+
+	err = server.store.DisableAdminTOTPTx(ctx, authPayload.AdminID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,1215 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAdminLoginMFAAPI(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+
+	inactiveAdmin := admin
+	inactiveAdmin.Active = false
+
+	totp := randomAdminTOTP(t, admin.ID, true)
+	pendingTOTP := randomAdminTOTP(t, admin.ID, false)
+	recoveryCode, err := util.RandomRecoveryCode()
+	require.NoError(t, err)
+
+	var recoveryCodeHashes []string
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		mfaToken      func(t *testing.T, tokenMaker token.Maker) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					UseAdminTOTPStep(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UseAdminTOTPStepParams) (db.AdminTotp, error) {
+						require.Equal(t, admin.ID, arg.AdminID)
+						require.InDelta(t, util.TOTPStep(time.Now()), arg.LastUsedStep, 1)
+						return totp, nil
+					})
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotResponse verifyAdminLoginMFAResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &gotResponse)
+				require.NoError(t, err)
+				require.NotEmpty(t, gotResponse.AccessToken)
+				require.NotEmpty(t, gotResponse.RefreshToken)
+				require.Equal(t, admin.ID, gotResponse.Admin.ID)
+				require.Empty(t, gotResponse.RecoveryCodes)
+			},
+		},
+		{
+			name: "RecoveryCode",
+			body: gin.H{
+				"recovery_code": recoveryCode,
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				arg := db.UseAdminRecoveryCodeParams{
+					AdminID:  admin.ID,
+					CodeHash: util.HashRecoveryCode(recoveryCode),
+				}
+
+				store.EXPECT().
+					UseAdminRecoveryCode(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AdminRecoveryCode{AdminID: admin.ID}, nil)
+
+				store.EXPECT().
+					UseAdminTOTPStep(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLoginAdmin(t, recorder.Body, admin)
+			},
+		},
+		{
+			name: "EnrollmentCompleted",
+			body: gin.H{
+				"code": currentTOTPCode(t, pendingTOTP.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(pendingTOTP, nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.EnableAdminTOTPTxParams) (db.AdminTotp, error) {
+						require.Equal(t, admin.ID, arg.AdminID)
+						require.InDelta(t, util.TOTPStep(time.Now()), arg.Step, 1)
+						recoveryCodeHashes = arg.RecoveryCodeHashes
+						return totp, nil
+					})
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotResponse verifyAdminLoginMFAResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &gotResponse)
+				require.NoError(t, err)
+				require.NotEmpty(t, gotResponse.AccessToken)
+				requireRecoveryCodesMatchHashes(t, gotResponse.RecoveryCodes, recoveryCodeHashes)
+			},
+		},
+		{
+			name: "EnrollmentWithRecoveryCode",
+			body: gin.H{
+				"recovery_code": recoveryCode,
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(pendingTOTP, nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidMFACode)
+			},
+		},
+		{
+			name: "WrongCode",
+			body: gin.H{
+				"code": wrongTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					UseAdminTOTPStep(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidMFACode)
+			},
+		},
+		{
+			name: "ReusedCode",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					UseAdminTOTPStep(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UsedRecoveryCode",
+			body: gin.H{
+				"recovery_code": recoveryCode,
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					UseAdminRecoveryCode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminRecoveryCode{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UserMFAToken",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, false)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "AccessToken",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				accessToken, _, err := tokenMaker.CreateTokenForAdmin(admin.ID, admin.Username, admin.TypeID, admin.Active, uuid.New(), time.Minute)
+				require.NoError(t, err)
+				return accessToken
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InactiveAdmin",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(inactiveAdmin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "LockedAccount",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				lockedAdmin := admin
+				lockedAdmin.FailedLoginAttempts = 5
+				lockedAdmin.LockedUntil = sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true}
+
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(lockedAdmin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MissingCode",
+			body: gin.H{},
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			tc.body["mfa_token"] = tc.mfaToken(t, server.tokenMaker)
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/admins/login/mfa"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestEnrollAdminLoginTOTPAPI(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+
+	testCases := []struct {
+		name          string
+		mfaToken      func(t *testing.T, tokenMaker token.Maker) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateAdminTOTPParams) (db.AdminTotp, error) {
+						require.Equal(t, admin.ID, arg.AdminID)
+						require.NotEmpty(t, arg.Secret)
+						return db.AdminTotp{AdminID: arg.AdminID, Secret: arg.Secret}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEnrollTOTP(t, recorder.Body, admin.Username)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidToken",
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return "invalid"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			mfaToken: func(t *testing.T, tokenMaker token.Maker) string {
+				return createMFAToken(t, tokenMaker, admin.ID, true)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(gin.H{"mfa_token": tc.mfaToken(t, server.tokenMaker)})
+			require.NoError(t, err)
+
+			url := "/admins/login/mfa/enroll"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestConfirmAdminTOTPAPI(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+	totp := randomAdminTOTP(t, admin.ID, false)
+
+	var recoveryCodeHashes []string
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.EnableAdminTOTPTxParams) (db.AdminTotp, error) {
+						require.Equal(t, admin.ID, arg.AdminID)
+						recoveryCodeHashes = arg.RecoveryCodeHashes
+						return totp, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotResponse recoveryCodesResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &gotResponse)
+				require.NoError(t, err)
+				requireRecoveryCodesMatchHashes(t, gotResponse.RecoveryCodes, recoveryCodeHashes)
+			},
+		},
+		{
+			name: "WrongCode",
+			body: gin.H{
+				"code": wrongTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ReusedCode",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminTotp{}, db.ErrTOTPCodeReused)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(randomAdminTOTP(t, admin.ID, true), nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "UserToken",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					EnableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MissingCode",
+			body: gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/admins/me/mfa/totp/confirm"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDisableAdminTOTPAPI(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+	totp := randomAdminTOTP(t, admin.ID, true)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					UseAdminTOTPStep(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					DisableAdminTOTPTx(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "PendingEnrollment",
+			body: gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(randomAdminTOTP(t, admin.ID, false), nil)
+
+				store.EXPECT().
+					DisableAdminTOTPTx(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "RequiredByAdminType",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID, RequireMfa: true}, nil)
+
+				store.EXPECT().
+					DisableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errMFARequiredByAdminType)
+			},
+		},
+		{
+			name: "WrongCode",
+			body: gin.H{
+				"code": wrongTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					DisableAdminTOTPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"code": currentTOTPCode(t, totp.Secret),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					UseAdminTOTPStep(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(totp, nil)
+
+				store.EXPECT().
+					DisableAdminTOTPTx(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/admins/me/mfa/totp"
+			request, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetAdminMFAAPI(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Enabled",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(randomAdminTOTP(t, admin.ID, true), nil)
+
+				store.EXPECT().
+					CountAdminRecoveryCodes(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(int64(7), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMFAStatus(t, recorder.Body, mfaStatusResponse{Enabled: true, RecoveryCodesLeft: 7})
+			},
+		},
+		{
+			name: "PendingEnrollment",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(randomAdminTOTP(t, admin.ID, false), nil)
+
+				store.EXPECT().
+					CountAdminRecoveryCodes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMFAStatus(t, recorder.Body, mfaStatusResponse{})
+			},
+		},
+		{
+			name: "NotEnrolled",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMFAStatus(t, recorder.Body, mfaStatusResponse{})
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/admins/me/mfa"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorizationForAdmin(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomAdminTOTP(t *testing.T, adminID int64, confirmed bool) db.AdminTotp {
+	secret, err := util.RandomTOTPSecret()
+	require.NoError(t, err)
+
+	return db.AdminTotp{
+		AdminID:     adminID,
+		Secret:      secret,
+		ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: confirmed},
+		CreatedAt:   time.Now(),
+	}
+}
+
+func createMFAToken(t *testing.T, tokenMaker token.Maker, ownerID int64, admin bool) string {
+	mfaToken, _, err := tokenMaker.CreateMFAToken(ownerID, admin, time.Minute)
+	require.NoError(t, err)
+	return mfaToken
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := util.TOTPCode(secret, util.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+// wrongTOTPCode returns a code that isn't accepted for the secret around now
+func wrongTOTPCode(t *testing.T, secret string) string {
+	for offset := int64(100); ; offset++ {
+		code, err := util.TOTPCode(secret, util.TOTPStep(time.Now())+offset)
+		require.NoError(t, err)
+		if _, ok := util.ValidateTOTP(secret, code, time.Now()); !ok {
+			return code
+		}
+	}
+}
+
+func requireBodyMatchMFARequired(t *testing.T, body *bytes.Buffer, enrollmentRequired bool) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse mfaRequiredResponse
+	err = json.Unmarshal(data, &gotResponse)
+	require.NoError(t, err)
+
+	require.True(t, gotResponse.MFARequired)
+	require.NotEmpty(t, gotResponse.MFAToken)
+	require.Equal(t, enrollmentRequired, gotResponse.EnrollmentRequired)
+	require.WithinDuration(t, time.Now().Add(5*time.Minute), gotResponse.MFATokenExpiresAt, time.Second)
+
+	// it is no access token
+	var loginResponse loginAdminResponse
+	err = json.Unmarshal(data, &loginResponse)
+	require.NoError(t, err)
+	require.Empty(t, loginResponse.AccessToken)
+	require.Empty(t, loginResponse.RefreshToken)
+}
+
+func requireBodyMatchEnrollTOTP(t *testing.T, body *bytes.Buffer, accountName string) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse enrollTOTPResponse
+	err = json.Unmarshal(data, &gotResponse)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, gotResponse.Secret)
+	require.Equal(t, util.TOTPProvisioningURI("e-shop", accountName, gotResponse.Secret), gotResponse.ProvisioningURI)
+}
+
+func requireBodyMatchMFAStatus(t *testing.T, body *bytes.Buffer, status mfaStatusResponse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse mfaStatusResponse
+	err = json.Unmarshal(data, &gotResponse)
+	require.NoError(t, err)
+	require.Equal(t, status, gotResponse)
+}
+
+// requireRecoveryCodesMatchHashes checks that the recovery codes handed out are the ones stored
+func requireRecoveryCodesMatchHashes(t *testing.T, codes []string, hashes []string) {
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+	for i, code := range codes {
+		require.Equal(t, hashes[i], util.HashRecoveryCode(code))
+	}
+}
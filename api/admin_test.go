@@ -0,0 +1,1324 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+type eqCreateAdminParamsMatcher struct {
+	arg      db.CreateAdminParams
+	password string
+}
+
+func (e eqCreateAdminParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateAdminParams)
+	if !ok {
+		return false
+	}
+
+	err := util.CheckPassword(e.password, arg.Password)
+	if err != nil {
+		return false
+	}
+
+	e.arg.Password = arg.Password
+	return reflect.DeepEqual(e.arg, arg)
+}
+
+func (e eqCreateAdminParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
+}
+
+func EqCreateAdminParamsMatcher(arg db.CreateAdminParams, password string) gomock.Matcher {
+	return eqCreateAdminParamsMatcher{arg, password}
+}
+
+func TestLoginAdminAPI(t *testing.T) {
+	admin, password := randomSuperAdmin(t)
+
+	inactiveAdmin, inactivePassword := randomSuperAdmin(t)
+	inactiveAdmin.Active = false
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLoginAdmin(t, recorder.Body, admin)
+			},
+		},
+		{
+			name: "AdminNotFound",
+			body: gin.H{
+				"email":    "NotFound@NotFound.com",
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// unknown emails can't be told apart from wrong passwords
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "IncorrectPassword",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				failedAdmin := admin
+				failedAdmin.FailedLoginAttempts = 1
+
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(failedAdmin, nil)
+
+				store.EXPECT().
+					LockAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "IncorrectPasswordLocksAccount",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				failedAdmin := admin
+				failedAdmin.FailedLoginAttempts = 5
+
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(failedAdmin, nil)
+
+				store.EXPECT().
+					LockAdmin(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.LockAdminParams) (db.Admin, error) {
+						require.Equal(t, admin.ID, arg.ID)
+						require.True(t, arg.LockedUntil.Valid)
+						require.WithinDuration(t, time.Now().Add(15*time.Minute), arg.LockedUntil.Time, time.Second)
+						return failedAdmin, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "LockedAccount",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				lockedAdmin := admin
+				lockedAdmin.FailedLoginAttempts = 5
+				lockedAdmin.LockedUntil = sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true}
+
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(lockedAdmin, nil)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// even the right password is refused, the same way as a wrong one
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "ExpiredLockout",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				lockedAdmin := admin
+				lockedAdmin.FailedLoginAttempts = 5
+				lockedAdmin.LockedUntil = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(lockedAdmin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					ResetFailedAdminLogins(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "RecordFailedLoginError",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					RecordFailedAdminLogin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InactiveAdmin",
+			body: gin.H{
+				"email":    inactiveAdmin.Email,
+				"password": inactivePassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(inactiveAdmin.Email)).
+					Times(1).
+					Return(inactiveAdmin, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "CreateSessionInternalError",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID}, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminSession{}, sql.ErrConnDone)
+
+				store.EXPECT().
+					UpdateAdminLastLogin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MFAEnabled",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{
+						AdminID:     admin.ID,
+						ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMFARequired(t, recorder.Body, false)
+			},
+		},
+		{
+			name: "MFARequiredByAdminType",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetAdminType(gomock.Any(), gomock.Eq(admin.TypeID)).
+					Times(1).
+					Return(db.AdminType{ID: admin.TypeID, RequireMfa: true}, nil)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMFARequired(t, recorder.Body, true)
+			},
+		},
+		{
+			name: "GetTOTPInternalError",
+			body: gin.H{
+				"email":    admin.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAdminTOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdminTotp{}, sql.ErrConnDone)
+
+				store.EXPECT().
+					CreateAdminSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{
+				"email":    "invalid-email#1",
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdminByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/admins/login"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLoginAdminIPLimitAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	limit := server.config.LoginIPMaxAttempts
+
+	store.EXPECT().
+		GetAdminByEmail(gomock.Any(), gomock.Any()).
+		Times(limit).
+		Return(db.Admin{}, sql.ErrNoRows)
+
+	login := func() *httptest.ResponseRecorder {
+		data, err := json.Marshal(gin.H{
+			"email":    util.RandomEmail(),
+			"password": util.RandomString(6),
+		})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/admins/login", bytes.NewReader(data))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	for i := 0; i < limit; i++ {
+		recorder := login()
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	recorder := login()
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+}
+
+func requireBodyMatchLoginAdmin(t *testing.T, body *bytes.Buffer, admin db.Admin) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse loginAdminResponse
+	err = json.Unmarshal(data, &gotResponse)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, gotResponse.AccessToken)
+	require.NotEmpty(t, gotResponse.RefreshToken)
+	require.Equal(t, admin.ID, gotResponse.Admin.ID)
+	require.Equal(t, admin.Username, gotResponse.Admin.Username)
+	require.Equal(t, admin.Email, gotResponse.Admin.Email)
+	require.Equal(t, admin.TypeID, gotResponse.Admin.TypeID)
+	require.Equal(t, admin.Active, gotResponse.Admin.Active)
+}
+
+func TestCreateAdminAPI(t *testing.T) {
+	superAdmin, _ := randomSuperAdmin(t)
+	admin, password := randomAdmin(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"username": admin.Username,
+				"email":    admin.Email,
+				"password": password,
+				"type_id":  admin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAdminParams{
+					Username: admin.Username,
+					Email:    admin.Email,
+					TypeID:   admin.TypeID,
+				}
+
+				store.EXPECT().
+					CreateAdmin(gomock.Any(), EqCreateAdminParamsMatcher(arg, password)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAdmin(t, recorder.Body, admin)
+			},
+		},
+		{
+			name: "MissingPermission",
+			body: gin.H{
+				"username": admin.Username,
+				"email":    admin.Email,
+				"password": password,
+				"type_id":  admin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, 2, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateEmail",
+			body: gin.H{
+				"username": admin.Username,
+				"email":    admin.Email,
+				"password": password,
+				"type_id":  admin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAdmin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownAdminType",
+			body: gin.H{
+				"username": admin.Username,
+				"email":    admin.Email,
+				"password": password,
+				"type_id":  admin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAdmin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"username": admin.Username,
+				"email":    admin.Email,
+				"password": password,
+				"type_id":  admin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAdmin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "TooShortPassword",
+			body: gin.H{
+				"username": admin.Username,
+				"email":    admin.Email,
+				"password": "123",
+				"type_id":  admin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/admins"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetAdminAPI(t *testing.T) {
+	superAdmin, _ := randomSuperAdmin(t)
+	admin, _ := randomAdmin(t)
+
+	testCases := []struct {
+		name          string
+		AdminID       int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAdmin(t, recorder.Body, admin)
+			},
+		},
+		{
+			name:    "MissingPermission",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, 2, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.Admin{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			AdminID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/admins/%d", tc.AdminID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListAdminsAPI(t *testing.T) {
+	superAdmin, _ := randomSuperAdmin(t)
+
+	n := 5
+	admins := make([]db.Admin, n)
+	for i := 0; i < n; i++ {
+		admins[i], _ = randomAdmin(t)
+	}
+
+	type Query struct {
+		cursor string
+		limit  int
+		search string
+	}
+
+	testCases := []struct {
+		name          string
+		query         Query
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAdminsParams{
+					Limit: int32(n) + 1,
+				}
+
+				store.EXPECT().
+					ListAdmins(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(admins, nil)
+
+				store.EXPECT().
+					SearchAdmins(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAdmins(t, recorder.Body, admins)
+			},
+		},
+		{
+			name: "Search",
+			query: Query{
+				cursor: encodeCursor(pageCursor{ID: admins[n-1].ID}),
+				limit:  n,
+				search: "50%_off",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SearchAdminsParams{
+					Search:  `50\%\_off`,
+					AfterID: admins[n-1].ID,
+					Limit:   int32(n) + 1,
+				}
+
+				store.EXPECT().
+					SearchAdmins(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(admins, nil)
+
+				store.EXPECT().
+					ListAdmins(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAdmins(t, recorder.Body, admins)
+			},
+		},
+		{
+			name: "MissingPermission",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, 2, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdmins(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdmins(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLimit",
+			query: Query{
+				limit: 100000,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdmins(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/admins"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query parameters to request URL
+			q := request.URL.Query()
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
+			if tc.query.search != "" {
+				q.Add("search", tc.query.search)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateAdminAPI(t *testing.T) {
+	superAdmin, _ := randomSuperAdmin(t)
+	admin, _ := randomAdmin(t)
+
+	updatedAdmin := admin
+	updatedAdmin.Active = false
+	updatedAdmin.TypeID = admin.TypeID + 1
+
+	testCases := []struct {
+		name          string
+		AdminID       int64
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			AdminID: admin.ID,
+			body: gin.H{
+				"active":  updatedAdmin.Active,
+				"type_id": updatedAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateAdminTxParams{
+					ID:     admin.ID,
+					Active: updatedAdmin.Active,
+					TypeID: updatedAdmin.TypeID,
+				}
+
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updatedAdmin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAdmin(t, recorder.Body, updatedAdmin)
+			},
+		},
+		{
+			name:    "OwnAccount",
+			AdminID: superAdmin.ID,
+			body: gin.H{
+				"active":  false,
+				"type_id": superAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "MissingPermission",
+			AdminID: admin.ID,
+			body: gin.H{
+				"active":  updatedAdmin.Active,
+				"type_id": updatedAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, 2, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			AdminID: admin.ID,
+			body: gin.H{
+				"active":  updatedAdmin.Active,
+				"type_id": updatedAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "UnknownAdminType",
+			AdminID: admin.ID,
+			body: gin.H{
+				"active":  updatedAdmin.Active,
+				"type_id": updatedAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			AdminID: admin.ID,
+			body: gin.H{
+				"active":  updatedAdmin.Active,
+				"type_id": updatedAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Admin{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "MissingActive",
+			AdminID: admin.ID,
+			body: gin.H{
+				"type_id": updatedAdmin.TypeID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAdminTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/admins/%d", tc.AdminID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteAdminAPI(t *testing.T) {
+	superAdmin, _ := randomSuperAdmin(t)
+	admin, _ := randomAdmin(t)
+
+	testCases := []struct {
+		name          string
+		AdminID       int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteAdmin(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "OwnAccount",
+			AdminID: superAdmin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "MissingPermission",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, 2, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			AdminID: admin.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteAdmin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			AdminID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, superAdmin.ID, superAdmin.Username, superAdmin.TypeID, superAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteAdmin(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/admins/%d", tc.AdminID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomAdmin(t *testing.T) (admin db.Admin, password string) {
+	admin, password = randomSuperAdmin(t)
+	admin.TypeID = util.RandomInt(2, 1000)
+	return
+}
+
+func requireBodyMatchAdmin(t *testing.T, body *bytes.Buffer, admin db.Admin) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotAdmin adminResponse
+	err = json.Unmarshal(data, &gotAdmin)
+	require.NoError(t, err)
+	require.Equal(t, newAdminResponse(admin), gotAdmin)
+}
+
+func requireBodyMatchAdmins(t *testing.T, body *bytes.Buffer, admins []db.Admin) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotAdmins []adminResponse
+	unmarshalListResponse(t, data, &gotAdmins)
+	require.Len(t, gotAdmins, len(admins))
+	for i, admin := range admins {
+		require.Equal(t, newAdminResponse(admin), gotAdmins[i])
+	}
+}
@@ -0,0 +1,371 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type adminTypeResponse struct {
+	ID          int64     `json:"id"`
+	AdminType   string    `json:"admin_type"`
+	Permissions []string  `json:"permissions"`
+	RequireMFA  bool      `json:"require_mfa"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func newAdminTypeResponse(adminType db.AdminType, permissions []string) adminTypeResponse {
+	return adminTypeResponse{
+		ID:          adminType.ID,
+		AdminType:   adminType.AdminType,
+		Permissions: permissions,
+		RequireMFA:  adminType.RequireMfa,
+		CreatedAt:   adminType.CreatedAt,
+		UpdatedAt:   adminType.UpdatedAt,
+	}
+}
+
+type createAdminTypeRequest struct {
+	AdminType   string   `json:"admin_type" binding:"required"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+func (server *Server) createAdminType(ctx *gin.Context) {
+	var req createAdminTypeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validatePermissions(req.Permissions); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAdminTypeTxParams{
+		AdminType:   req.AdminType,
+		Permissions: req.Permissions,
+	}
+
+	result, err := server.store.CreateAdminTypeTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAdminTypeResponse(result.AdminType, result.Permissions)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getAdminTypeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAdminType(ctx *gin.Context) {
+	var req getAdminTypeRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	adminType, err := server.store.GetAdminType(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	permissions, err := server.store.ListAdminTypePermissions(ctx, adminType.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAdminTypeResponse(adminType, permissions)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listAdminTypesRequest struct {
+	pageRequest
+}
+
+func (server *Server) listAdminTypes(ctx *gin.Context) {
+	var req listAdminTypesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListAdminTypesParams{
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
+	}
+
+	adminTypes, err := server.store.ListAdminTypes(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	n, more := page.size(len(adminTypes))
+	items := make([]adminTypeResponse, 0, n)
+	for _, adminType := range adminTypes[:n] {
+		permissions, err := server.store.ListAdminTypePermissions(ctx, adminType.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		items = append(items, newAdminTypeResponse(adminType, permissions))
+	}
+
+	rsp := listResponse{Items: items}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: adminTypes[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountAdminTypes(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type updateAdminTypeUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateAdminTypeJsonRequest struct {
+	AdminType string `json:"admin_type" binding:"required"`
+}
+
+// updateAdminType renames an admin type, its permissions are left untouched.
+func (server *Server) updateAdminType(ctx *gin.Context) {
+	var uri updateAdminTypeUriRequest
+	var req updateAdminTypeJsonRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateAdminTypeParams{
+		ID:        uri.ID,
+		AdminType: req.AdminType,
+	}
+
+	adminType, err := server.store.UpdateAdminType(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	permissions, err := server.store.ListAdminTypePermissions(ctx, adminType.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAdminTypeResponse(adminType, permissions)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type updateAdminTypePermissionsUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateAdminTypePermissionsJsonRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// updateAdminTypePermissions replaces the permission set of an admin type.
+// Admins can't take the management of admins away from their own admin type,
+// so there is always somebody left to manage the permissions.
+func (server *Server) updateAdminTypePermissions(ctx *gin.Context) {
+	var uri updateAdminTypePermissionsUriRequest
+	var req updateAdminTypePermissionsJsonRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validatePermissions(req.Permissions); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+	if uri.ID == authPayload.TypeID && !containsPermission(req.Permissions, permissionManageAdmins) {
+		err := errors.New("cannot remove admins.manage from your own admin type")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateAdminTypePermissionsTxParams{
+		AdminTypeID: uri.ID,
+		Permissions: req.Permissions,
+	}
+
+	result, err := server.store.UpdateAdminTypePermissionsTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.permissions.forget(uri.ID)
+
+	rsp := newAdminTypeResponse(result.AdminType, result.Permissions)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type updateAdminTypeMFAPolicyUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateAdminTypeMFAPolicyJsonRequest struct {
+	RequireMFA *bool `json:"require_mfa" binding:"required"`
+}
+
+// updateAdminTypeMFAPolicy sets whether the admins of a type must log in with a
+// second factor. Those who didn't set one up yet are asked to at their next login.
+func (server *Server) updateAdminTypeMFAPolicy(ctx *gin.Context) {
+	var uri updateAdminTypeMFAPolicyUriRequest
+	var req updateAdminTypeMFAPolicyJsonRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateAdminTypeMFAPolicyParams{
+		ID:         uri.ID,
+		RequireMfa: *req.RequireMFA,
+	}
+
+	adminType, err := server.store.UpdateAdminTypeMFAPolicy(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	permissions, err := server.store.ListAdminTypePermissions(ctx, adminType.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAdminTypeResponse(adminType, permissions)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type deleteAdminTypeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteAdminType deletes an admin type along with its permissions, as long
+// as no admin belongs to it anymore.
+func (server *Server) deleteAdminType(ctx *gin.Context) {
+	var req deleteAdminTypeRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+	if req.ID == authPayload.TypeID {
+		err := errors.New("cannot delete your own admin type")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	err := server.store.DeleteAdminTypeByID(ctx, req.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.permissions.forget(req.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+func containsPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
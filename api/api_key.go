@@ -0,0 +1,238 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyPrefix starts every api key, so that a leaked one is easy to recognize
+const apiKeyPrefix = "esk_"
+
+type apiKeyResponse struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Permissions []string   `json:"permissions"`
+	AdminID     int64      `json:"admin_id"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func newAPIKeyResponse(apiKey db.ApiKey, permissions []string) apiKeyResponse {
+	rsp := apiKeyResponse{
+		ID:          apiKey.ID,
+		Name:        apiKey.Name,
+		Prefix:      apiKey.Prefix,
+		Permissions: permissions,
+		AdminID:     apiKey.AdminID,
+		CreatedAt:   apiKey.CreatedAt,
+	}
+	if apiKey.ExpiresAt.Valid {
+		rsp.ExpiresAt = &apiKey.ExpiresAt.Time
+	}
+	if apiKey.LastUsedAt.Valid {
+		rsp.LastUsedAt = &apiKey.LastUsedAt.Time
+	}
+	return rsp
+}
+
+// newAPIKey returns a new api key and its prefix, made of the start of the key
+func newAPIKey() (string, string, error) {
+	id, err := util.RandomSecret(6)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret, err := util.RandomSecret(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix := apiKeyPrefix + id
+	return prefix + "_" + secret, prefix, nil
+}
+
+type createAPIKeyRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Permissions []string   `json:"permissions" binding:"required,min=1"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type createAPIKeyResponse struct {
+	Key    string         `json:"key"`
+	APIKey apiKeyResponse `json:"api_key"`
+}
+
+// createAPIKey creates an api key for a server-to-server integration. The key
+// itself is only returned here, only its hash is stored.
+func (server *Server) createAPIKey(ctx *gin.Context) {
+	var req createAPIKeyRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validateAPIKeyPermissions(req.Permissions); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		err := errors.New("expires_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	key, prefix, err := newAPIKey()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
+
+	arg := db.CreateAPIKeyTxParams{
+		Name:        req.Name,
+		Prefix:      prefix,
+		KeyHash:     util.HashSecret(key),
+		AdminID:     authPayload.AdminID,
+		Permissions: req.Permissions,
+	}
+	if req.ExpiresAt != nil {
+		arg.ExpiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	result, err := server.store.CreateAPIKeyTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := createAPIKeyResponse{
+		Key:    key,
+		APIKey: newAPIKeyResponse(result.APIKey, result.Permissions),
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getAPIKeyRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAPIKey(ctx *gin.Context) {
+	var req getAPIKeyRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	apiKey, err := server.store.GetAPIKey(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	permissions, err := server.store.ListAPIKeyPermissions(ctx, apiKey.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newAPIKeyResponse(apiKey, permissions)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listAPIKeysRequest struct {
+	pageRequest
+}
+
+func (server *Server) listAPIKeys(ctx *gin.Context) {
+	var req listAPIKeysRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListAPIKeysParams{
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
+	}
+
+	apiKeys, err := server.store.ListAPIKeys(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	n, more := page.size(len(apiKeys))
+	items := make([]apiKeyResponse, 0, n)
+	for _, apiKey := range apiKeys[:n] {
+		permissions, err := server.store.ListAPIKeyPermissions(ctx, apiKey.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		items = append(items, newAPIKeyResponse(apiKey, permissions))
+	}
+
+	rsp := listResponse{Items: items}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: apiKeys[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountAPIKeys(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type deleteAPIKeyRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteAPIKey revokes an api key, it is refused from then on.
+func (server *Server) deleteAPIKey(ctx *gin.Context) {
+	var req deleteAPIKeyRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := server.store.DeleteAPIKey(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.apiKeys.forget(req.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/util"
+)
+
+// maxCachedAPIKeys bounds the memory used by the api key cache
+const maxCachedAPIKeys = 1000
+
+var (
+	errInvalidAPIKey = errors.New("api key is invalid")
+	errExpiredAPIKey = errors.New("api key has expired")
+)
+
+// apiKeyPayload describes the api key a request was authenticated with
+type apiKeyPayload struct {
+	ID          int64
+	AdminID     int64
+	Name        string
+	Prefix      string
+	Permissions map[string]bool
+}
+
+type cachedAPIKey struct {
+	payload   *apiKeyPayload
+	expiresAt time.Time
+}
+
+// apiKeyCache checks the api keys presented to authMiddleware, and remembers the
+// keys found valid for a short while so that their permissions aren't queried on
+// every request. The last use of a key is recorded whenever it is looked up again,
+// so it is at most ttl old. Keys deleted by this server, and the keys of the admins
+// it deactivates or deletes, are dropped right away.
+// A zero ttl disables the cache.
+type apiKeyCache struct {
+	store db.Store
+	ttl   time.Duration
+	mu    sync.Mutex
+	keys  map[string]cachedAPIKey
+}
+
+func newAPIKeyCache(store db.Store, ttl time.Duration) *apiKeyCache {
+	return &apiKeyCache{
+		store: store,
+		ttl:   ttl,
+		keys:  make(map[string]cachedAPIKey),
+	}
+}
+
+// check returns the payload of a valid api key, errInvalidAPIKey when the key is
+// unknown, was deleted or belongs to a deactivated admin and errExpiredAPIKey once
+// it has expired.
+func (cache *apiKeyCache) check(ctx context.Context, key string) (*apiKeyPayload, error) {
+	keyHash := util.HashSecret(key)
+	if payload, ok := cache.get(keyHash); ok {
+		return payload, nil
+	}
+
+	apiKey, err := cache.store.GetAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if apiKey.ExpiresAt.Valid && time.Now().After(apiKey.ExpiresAt.Time) {
+		return nil, errExpiredAPIKey
+	}
+
+	permissions, err := cache.store.ListAPIKeyPermissions(ctx, apiKey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cache.store.UpdateAPIKeyLastUsed(ctx, apiKey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &apiKeyPayload{
+		ID:          apiKey.ID,
+		AdminID:     apiKey.AdminID,
+		Name:        apiKey.Name,
+		Prefix:      apiKey.Prefix,
+		Permissions: make(map[string]bool, len(permissions)),
+	}
+	for _, permission := range permissions {
+		payload.Permissions[permission] = true
+	}
+
+	cache.add(keyHash, payload, apiKey.ExpiresAt)
+	return payload, nil
+}
+
+func (cache *apiKeyCache) get(keyHash string) (*apiKeyPayload, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cached, ok := cache.keys[keyHash]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(cached.expiresAt) {
+		delete(cache.keys, keyHash)
+		return nil, false
+	}
+
+	return cached.payload, true
+}
+
+func (cache *apiKeyCache) add(keyHash string, payload *apiKeyPayload, keyExpiresAt sql.NullTime) {
+	if cache.ttl <= 0 {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	if len(cache.keys) >= maxCachedAPIKeys {
+		for hash, cached := range cache.keys {
+			if now.After(cached.expiresAt) {
+				delete(cache.keys, hash)
+			}
+		}
+	}
+	if len(cache.keys) >= maxCachedAPIKeys {
+		cache.keys = make(map[string]cachedAPIKey)
+	}
+
+	// a key about to expire isn't kept past its expiry
+	expiresAt := now.Add(cache.ttl)
+	if keyExpiresAt.Valid && keyExpiresAt.Time.Before(expiresAt) {
+		expiresAt = keyExpiresAt.Time
+	}
+
+	cache.keys[keyHash] = cachedAPIKey{
+		payload:   payload,
+		expiresAt: expiresAt,
+	}
+}
+
+// forget drops an api key that has just been deleted
+func (cache *apiKeyCache) forget(apiKeyID int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for hash, cached := range cache.keys {
+		if cached.payload.ID == apiKeyID {
+			delete(cache.keys, hash)
+		}
+	}
+}
+
+// forgetAdmin drops the api keys of an admin who has just been deactivated or
+// deleted, along with their access.
+func (cache *apiKeyCache) forgetAdmin(adminID int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for hash, cached := range cache.keys {
+		if cached.payload.AdminID == adminID {
+			delete(cache.keys, hash)
+		}
+	}
+}
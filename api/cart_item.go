@@ -13,8 +13,8 @@ import (
 
 type createCartItemRequest struct {
 	SessionID int64 `json:"session_id" binding:"required,min=1"`
-	ProductID int64 `json:"product_id" binding:"required,min=1"`
-	Quantity  int32 `json:"quantity" binding:"required,numeric"`
+	VariantID int64 `json:"variant_id" binding:"required,min=1"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
 }
 
 func (server *Server) createCartItem(ctx *gin.Context) {
@@ -36,7 +36,7 @@ func (server *Server) createCartItem(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
-	if shoppingSession.UserID != authPayload.UserID {
+	if !ownsShoppingSession(shoppingSession, authPayload.UserID) {
 		err := errors.New("account deosn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -44,11 +44,12 @@ func (server *Server) createCartItem(ctx *gin.Context) {
 
 	arg := db.CreateCartItemParams{
 		SessionID: req.SessionID,
-		ProductID: req.ProductID,
 		Quantity:  req.Quantity,
+		VariantID: req.VariantID,
 	}
 
-	cartItem, err := server.store.CreateCartItem(ctx, arg)
+	// inactive variants are not found
+	result, err := server.store.CreateCartItemTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -56,20 +57,23 @@ func (server *Server) createCartItem(ctx *gin.Context) {
 				ctx.JSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, cartItem)
+	ctx.JSON(http.StatusOK, result.CartItem)
 }
 
-type getCartItemRequest struct {
+type listCartItemsBySessionIDRequest struct {
 	SessionID int64 `uri:"session_id" binding:"required,min=1"`
 }
 
-func (server *Server) getCartItemBySessionID(ctx *gin.Context) {
-	var req getCartItemRequest
+func (server *Server) listCartItemsBySessionID(ctx *gin.Context) {
+	var req listCartItemsBySessionIDRequest
 
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -87,22 +91,18 @@ func (server *Server) getCartItemBySessionID(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
-	if shoppingSession.UserID != authPayload.UserID {
+	if !ownsShoppingSession(shoppingSession, authPayload.UserID) {
 		err := errors.New("account deosn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	cartItem, err := server.store.GetCartItemBySessionID(ctx, shoppingSession.ID)
+	cartItems, err := server.store.ListCartItemsBySessionID(ctx, shoppingSession.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, cartItem)
+	ctx.JSON(http.StatusOK, listResponse{Items: cartItems})
 }
 
 // type listCartItemsRequest struct {
@@ -137,7 +137,7 @@ func (server *Server) getCartItemBySessionID(ctx *gin.Context) {
 type updateCartItemBySessionIDRequest struct {
 	SessionID int64 `json:"session_id" binding:"required,min=1"`
 	ID        int64 `json:"id" binding:"required,min=1"`
-	Quantity  int32 `json:"quantity" binding:"required,numeric"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
 }
 
 func (server *Server) updateCartItemBySessionID(ctx *gin.Context) {
@@ -159,18 +159,19 @@ func (server *Server) updateCartItemBySessionID(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
-	if shoppingSession.UserID != authPayload.UserID {
+	if !ownsShoppingSession(shoppingSession, authPayload.UserID) {
 		err := errors.New("account deosn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	arg := db.UpdateCartItemParams{
-		ID:       req.ID,
-		Quantity: req.Quantity,
+	arg := db.UpdateCartItemTxParams{
+		SessionID: shoppingSession.ID,
+		ID:        req.ID,
+		Quantity:  req.Quantity,
 	}
 
-	cartItem, err := server.store.UpdateCartItem(ctx, arg)
+	result, err := server.store.UpdateCartItemTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -178,12 +179,15 @@ func (server *Server) updateCartItemBySessionID(ctx *gin.Context) {
 				ctx.JSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, cartItem)
+	ctx.JSON(http.StatusOK, result.CartItem)
 }
 
 type deleteCartItemBySessionIDRequest struct {
@@ -210,13 +214,18 @@ func (server *Server) deleteCartItemBySessionID(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
-	if shoppingSession.UserID != authPayload.UserID {
+	if !ownsShoppingSession(shoppingSession, authPayload.UserID) {
 		err := errors.New("account deosn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	err = server.store.DeleteCartItem(ctx, req.ID)
+	arg := db.DeleteCartItemTxParams{
+		SessionID: shoppingSession.ID,
+		ID:        req.ID,
+	}
+
+	_, err = server.store.DeleteCartItemTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -36,7 +36,7 @@ func TestCreateCartItemAPI(t *testing.T) {
 			name: "OK",
 			body: gin.H{
 				"session_id": cartItem.SessionID,
-				"product_id": cartItem.ProductID,
+				"variant_id": cartItem.VariantID,
 				"quantity":   cartItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -51,14 +51,15 @@ func TestCreateCartItemAPI(t *testing.T) {
 
 				arg := db.CreateCartItemParams{
 					SessionID: shoppingSession.ID,
-					ProductID: cartItem.ProductID,
 					Quantity:  cartItem.Quantity,
+					VariantID: cartItem.VariantID,
 				}
 
 				store.EXPECT().
-					CreateCartItem(gomock.Any(), gomock.Eq(arg)).
+					CreateCartItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(cartItem, nil)
+					Return(db.CartItemTxResult{CartItem: cartItem, ShoppingSession: shoppingSession}, nil)
+
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -69,7 +70,7 @@ func TestCreateCartItemAPI(t *testing.T) {
 			name: "Unauthorized",
 			body: gin.H{
 				"session_id": cartItem.SessionID,
-				"product_id": cartItem.ProductID,
+				"variant_id": cartItem.VariantID,
 				"quantity":   cartItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -77,18 +78,37 @@ func TestCreateCartItemAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 
 				store.EXPECT().
-					CreateCartItem(gomock.Any(), gomock.Any()).
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "NegativeQuantity",
+			body: gin.H{
+				"session_id": cartItem.SessionID,
+				"variant_id": cartItem.VariantID,
+				"quantity":   -3,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "NoAuthorization",
 			body: gin.H{
 				"session_id": cartItem.SessionID,
-				"product_id": cartItem.ProductID,
+				"variant_id": cartItem.VariantID,
 				"quantity":   cartItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -102,7 +122,7 @@ func TestCreateCartItemAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					CreateCartItem(gomock.Any(), gomock.Any()).
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -113,7 +133,7 @@ func TestCreateCartItemAPI(t *testing.T) {
 			name: "InternalError",
 			body: gin.H{
 				"session_id": cartItem.SessionID,
-				"product_id": cartItem.ProductID,
+				"variant_id": cartItem.VariantID,
 				"quantity":   cartItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -128,24 +148,51 @@ func TestCreateCartItemAPI(t *testing.T) {
 
 				arg := db.CreateCartItemParams{
 					SessionID: cartItem.SessionID,
-					ProductID: cartItem.ProductID,
 					Quantity:  cartItem.Quantity,
+					VariantID: cartItem.VariantID,
 				}
 
 				store.EXPECT().
-					CreateCartItem(gomock.Any(), gomock.Eq(arg)).
+					CreateCartItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(db.CartItem{}, sql.ErrConnDone)
+					Return(db.CartItemTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "VariantNotFound",
+			body: gin.H{
+				"session_id": cartItem.SessionID,
+				"variant_id": cartItem.VariantID,
+				"quantity":   cartItem.Quantity,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CartItemTxResult{}, sql.ErrNoRows)
+
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidSessionID",
 			body: gin.H{
 				"session_id": 0,
-				"product_id": cartItem.ProductID,
+				"variant_id": cartItem.VariantID,
 				"quantity":   cartItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -158,7 +205,7 @@ func TestCreateCartItemAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					CreateCartItem(gomock.Any(), gomock.Any()).
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -194,10 +241,13 @@ func TestCreateCartItemAPI(t *testing.T) {
 	}
 }
 
-func TestGetCartItemByIDAPI(t *testing.T) {
+func TestListCartItemsBySessionIDAPI(t *testing.T) {
 	user, _ := randomCIUser(t)
 	shoppingSession := createRandomShoppingSessionForCartItem(t, user)
-	cartItem := createRandomCartItem(t, shoppingSession)
+	cartItems := []db.CartItem{
+		createRandomCartItem(t, shoppingSession),
+		createRandomCartItem(t, shoppingSession),
+	}
 
 	testCases := []struct {
 		name          string
@@ -209,7 +259,7 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 	}{
 		{
 			name:      "OK",
-			SessionID: cartItem.SessionID,
+			SessionID: shoppingSession.ID,
 			body: gin.H{
 				"session_id": shoppingSession.ID,
 			},
@@ -223,13 +273,13 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					GetCartItemBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
 					Times(1).
-					Return(cartItem, nil)
+					Return(cartItems, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchCartItem(t, recorder.Body, cartItem)
+				requireBodyMatchCartItems(t, recorder.Body, cartItems)
 			},
 		},
 		{
@@ -246,7 +296,7 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					GetCartItemBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -269,7 +319,7 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					GetCartItemBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -289,12 +339,11 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 				store.EXPECT().
 					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
 					Times(1).
-					Return(shoppingSession, nil)
+					Return(db.ShoppingSession{}, sql.ErrNoRows)
 
 				store.EXPECT().
-					GetCartItemBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
-					Times(1).
-					Return(db.CartItem{}, sql.ErrNoRows)
+					ListCartItemsBySessionID(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -316,9 +365,9 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					GetCartItemBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
 					Times(1).
-					Return(db.CartItem{}, sql.ErrConnDone)
+					Return([]db.CartItem{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -339,7 +388,7 @@ func TestGetCartItemByIDAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					GetCartItemBySessionID(gomock.Any(), gomock.Any()).
+					ListCartItemsBySessionID(gomock.Any(), gomock.Any()).
 					Times(0)
 
 			},
@@ -545,15 +594,17 @@ func TestUpdateCartItemByUserIDAPI(t *testing.T) {
 					Times(1).
 					Return(shoppingSession, nil)
 
-				arg := db.UpdateCartItemParams{
-					ID:       cartItem.ID,
-					Quantity: 4,
+				arg := db.UpdateCartItemTxParams{
+					SessionID: shoppingSession.ID,
+					ID:        cartItem.ID,
+					Quantity:  4,
 				}
 
 				store.EXPECT().
-					UpdateCartItem(gomock.Any(), gomock.Eq(arg)).
+					UpdateCartItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(cartItem, nil)
+					Return(db.CartItemTxResult{CartItem: cartItem, ShoppingSession: shoppingSession}, nil)
+
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -571,13 +622,33 @@ func TestUpdateCartItemByUserIDAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateCartItem(gomock.Any(), gomock.Any()).
+					UpdateCartItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name:      "NegativeQuantity",
+			SessionID: shoppingSession.ID,
+			body: gin.H{
+				"id":         cartItem.ID,
+				"session_id": cartItem.SessionID,
+				"quantity":   -4,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:      "InternalError",
 			SessionID: shoppingSession.ID,
@@ -595,19 +666,46 @@ func TestUpdateCartItemByUserIDAPI(t *testing.T) {
 					Times(1).
 					Return(shoppingSession, nil)
 
-				arg := db.UpdateCartItemParams{
-					ID:       cartItem.ID,
-					Quantity: 4,
+				arg := db.UpdateCartItemTxParams{
+					SessionID: shoppingSession.ID,
+					ID:        cartItem.ID,
+					Quantity:  4,
 				}
 				store.EXPECT().
-					UpdateCartItem(gomock.Any(), gomock.Eq(arg)).
+					UpdateCartItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(db.CartItem{}, sql.ErrConnDone)
+					Return(db.CartItemTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:      "CartItemOfAnotherSession",
+			SessionID: shoppingSession.ID,
+			body: gin.H{
+				"id":         cartItem.ID,
+				"session_id": cartItem.SessionID,
+				"quantity":   4,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					UpdateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CartItemTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:      "InvalidSessionID",
 			SessionID: 0,
@@ -621,7 +719,7 @@ func TestUpdateCartItemByUserIDAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					UpdateCartItem(gomock.Any(), gomock.Any()).
+					UpdateCartItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -688,9 +786,10 @@ func TestDeleteCartItemAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					DeleteCartItem(gomock.Any(), gomock.Eq(cartItem.ID)).
+					DeleteCartItemTx(gomock.Any(), gomock.Eq(db.DeleteCartItemTxParams{SessionID: shoppingSession.ID, ID: cartItem.ID})).
 					Times(1).
-					Return(nil)
+					Return(shoppingSession, nil)
+
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -714,9 +813,9 @@ func TestDeleteCartItemAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					DeleteCartItem(gomock.Any(), gomock.Eq(cartItem.ID)).
+					DeleteCartItemTx(gomock.Any(), gomock.Eq(db.DeleteCartItemTxParams{SessionID: shoppingSession.ID, ID: cartItem.ID})).
 					Times(1).
-					Return(sql.ErrNoRows)
+					Return(db.ShoppingSession{}, sql.ErrNoRows)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -740,9 +839,9 @@ func TestDeleteCartItemAPI(t *testing.T) {
 					Return(shoppingSession, nil)
 
 				store.EXPECT().
-					DeleteCartItem(gomock.Any(), gomock.Eq(cartItem.ID)).
+					DeleteCartItemTx(gomock.Any(), gomock.Eq(db.DeleteCartItemTxParams{SessionID: shoppingSession.ID, ID: cartItem.ID})).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.ShoppingSession{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -765,7 +864,7 @@ func TestDeleteCartItemAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					DeleteCartItem(gomock.Any(), gomock.Any()).
+					DeleteCartItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 
 			},
@@ -817,7 +916,7 @@ func randomCIUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(7, 7)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -826,7 +925,7 @@ func randomCIUser(t *testing.T) (user db.User, password string) {
 func createRandomShoppingSessionForCartItem(t *testing.T, user db.User) (shoppingSession db.ShoppingSession) {
 	shoppingSession = db.ShoppingSession{
 		ID:     util.RandomInt(1, 10),
-		UserID: user.ID,
+		UserID: sql.NullInt64{Int64: user.ID, Valid: true},
 		Total:  fmt.Sprint(util.RandomMoney()),
 	}
 	return
@@ -838,6 +937,7 @@ func createRandomCartItem(t *testing.T, shoppingSession db.ShoppingSession) (car
 		SessionID: shoppingSession.ID,
 		ProductID: util.RandomMoney(),
 		Quantity:  int32(util.RandomMoney()),
+		VariantID: util.RandomMoney(),
 	}
 	return
 }
@@ -852,10 +952,20 @@ func requireBodyMatchCartItem(t *testing.T, body *bytes.Buffer, cartItem db.Cart
 	require.NoError(t, err)
 	require.Equal(t, cartItem.ID, gotCartItem.ID)
 	require.Equal(t, cartItem.ProductID, gotCartItem.ProductID)
+	require.Equal(t, cartItem.VariantID, gotCartItem.VariantID)
 	require.Equal(t, cartItem.SessionID, gotCartItem.SessionID)
 	require.Equal(t, cartItem.Quantity, gotCartItem.Quantity)
 }
 
+func requireBodyMatchCartItems(t *testing.T, body *bytes.Buffer, cartItems []db.CartItem) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotCartItems []db.CartItem
+	unmarshalListResponse(t, data, &gotCartItems)
+	require.Equal(t, cartItems, gotCartItems)
+}
+
 // func requireBodyMatchcart-items(t *testing.T, body *bytes.Buffer, cart-items []db.CartItem) {
 // 	data, err := ioutil.ReadAll(body)
 // 	require.NoError(t, err)
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type checkoutRequest struct {
+	ShoppingSessionID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) checkout(ctx *gin.Context) {
+	var req checkoutRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shoppingSession, cartItems, ok := server.getCheckoutCart(ctx, req.ShoppingSessionID)
+	if !ok {
+		return
+	}
+
+	arg := db.FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       cartItems,
+	}
+
+	result, err := server.store.FinishedPurchaseTx(ctx, arg)
+	if err != nil {
+		writeStockTxError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type reserveStockRequest struct {
+	ShoppingSessionID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// reserveStock holds the stock of the cart while the payment is in progress.
+func (server *Server) reserveStock(ctx *gin.Context) {
+	var req reserveStockRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shoppingSession, cartItems, ok := server.getCheckoutCart(ctx, req.ShoppingSessionID)
+	if !ok {
+		return
+	}
+
+	arg := db.ReserveStockTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       cartItems,
+		ExpiresAt:       time.Now().Add(server.config.StockReservationDuration),
+	}
+
+	result, err := server.store.ReserveStockTx(ctx, arg)
+	if err != nil {
+		writeStockTxError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// getCheckoutCart loads the shopping session of the authenticated user with
+// its cart items and makes sure every product variant can still be sold. It writes the
+// error response itself and reports whether the caller may go on.
+func (server *Server) getCheckoutCart(ctx *gin.Context, shoppingSessionID int64) (db.ShoppingSession, []db.CartItem, bool) {
+	shoppingSession, err := server.store.GetShoppingSession(ctx, shoppingSessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.ShoppingSession{}, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ShoppingSession{}, nil, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	if !ownsShoppingSession(shoppingSession, authPayload.UserID) {
+		err := errors.New("account deosn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.ShoppingSession{}, nil, false
+	}
+
+	cartItems, err := server.store.ListCartItemsBySessionID(ctx, shoppingSession.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ShoppingSession{}, nil, false
+	}
+
+	if len(cartItems) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(db.ErrEmptyCart))
+		return db.ShoppingSession{}, nil, false
+	}
+
+	for _, cartItem := range cartItems {
+		product, err := server.store.GetProduct(ctx, cartItem.ProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return db.ShoppingSession{}, nil, false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return db.ShoppingSession{}, nil, false
+		}
+
+		variant, err := server.store.GetProductVariant(ctx, cartItem.VariantID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return db.ShoppingSession{}, nil, false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return db.ShoppingSession{}, nil, false
+		}
+
+		if !product.Active || !variant.Active {
+			ctx.JSON(http.StatusForbidden, errorResponse(db.ErrInactiveProduct))
+			return db.ShoppingSession{}, nil, false
+		}
+	}
+
+	return shoppingSession, cartItems, true
+}
+
+// writeStockTxError maps the errors of the checkout transactions to a response,
+// a cart that cannot be served from the available stock is a conflict.
+func writeStockTxError(ctx *gin.Context, err error) {
+	var stockErr *db.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+	if errors.Is(err, db.ErrEmptyCart) {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if errors.Is(err, db.ErrInvalidQuantity) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code.Name() {
+		case "foreign_key_violation", "unique_violation":
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
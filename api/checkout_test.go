@@ -0,0 +1,642 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutAPI(t *testing.T) {
+	user, _ := randomCOUser(t)
+	shoppingSession := createRandomShoppingSession(t, user)
+	products := make([]db.Product, 2)
+	variants := make([]db.ProductVariant, 2)
+	productInventories := make([]db.ProductInventory, 2)
+	cartItems := make([]db.CartItem, 2)
+	for i := range products {
+		products[i] = randomProduct()
+		products[i].ID = int64(i + 1)
+		products[i].InventoryID = int64(i + 1)
+		products[i].DiscountID = int64(i + 1)
+		products[i].Price = "100"
+		products[i].Active = true
+
+		variants[i] = randomProductVariant(products[i])
+
+		productInventories[i] = db.ProductInventory{
+			ID:       products[i].InventoryID,
+			Quantity: 10,
+			Active:   true,
+		}
+
+		cartItems[i] = createRandomCartItem(t, shoppingSession)
+		cartItems[i].ProductID = products[i].ID
+		cartItems[i].VariantID = variants[i].ID
+		cartItems[i].Quantity = 2
+	}
+
+	result := db.FinishedPurchaseTxResult{
+		OrderDetail: db.OrderDetail{
+			ID:     util.RandomInt(1, 100),
+			UserID: user.ID,
+			Total:  "380.00",
+		},
+	}
+	for _, cartItem := range cartItems {
+		result.OrderItems = append(result.OrderItems, db.OrderItem{
+			ID:        util.RandomInt(1, 100),
+			OrderID:   result.OrderDetail.ID,
+			ProductID: cartItem.ProductID,
+			Quantity:  cartItem.Quantity,
+		})
+	}
+
+	// buildLineStubs expects the per line availability checks.
+	buildLineStubs := func(store *mockdb.MockStore) {
+		for i := range products {
+			store.EXPECT().
+				GetProduct(gomock.Any(), gomock.Eq(products[i].ID)).
+				Times(1).
+				Return(products[i], nil)
+
+			store.EXPECT().
+				GetProductVariant(gomock.Any(), gomock.Eq(variants[i].ID)).
+				Times(1).
+				Return(variants[i], nil)
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		ShoppingSessionID int64
+		setupAuth         func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs        func(store *mockdb.MockStore)
+		checkResponse     func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:              "OK",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				buildLineStubs(store)
+
+				arg := db.FinishedPurchaseTxParams{
+					ShoppingSession: shoppingSession,
+					CartItems:       cartItems,
+				}
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCheckout(t, recorder.Body, result)
+			},
+		},
+		{
+			name:              "NoAuthorization",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:              "UnauthorizedUser",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 0, "unauthorizedUser", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:              "EmptyCart",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return([]db.CartItem{}, nil)
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:              "InsufficientStock",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				buildLineStubs(store)
+
+				stockErr := &db.InsufficientStockError{
+					InventoryID: productInventories[0].ID,
+					Requested:   cartItems[0].Quantity,
+					Available:   cartItems[0].Quantity - 1,
+				}
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FinishedPurchaseTxResult{}, stockErr)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:              "InactiveProduct",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				inactiveProduct := products[0]
+				inactiveProduct.Active = false
+
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(products[0].ID)).
+					Times(1).
+					Return(inactiveProduct, nil)
+
+				store.EXPECT().
+					GetProductVariant(gomock.Any(), gomock.Eq(variants[0].ID)).
+					Times(1).
+					Return(variants[0], nil)
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:              "InactiveVariant",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				inactiveVariant := variants[0]
+				inactiveVariant.Active = false
+
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(products[0].ID)).
+					Times(1).
+					Return(products[0], nil)
+
+				store.EXPECT().
+					GetProductVariant(gomock.Any(), gomock.Eq(variants[0].ID)).
+					Times(1).
+					Return(inactiveVariant, nil)
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:              "InternalError",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				buildLineStubs(store)
+
+				store.EXPECT().
+					FinishedPurchaseTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FinishedPurchaseTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:              "InvalidID",
+			ShoppingSessionID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shopping-sessions/%d/checkout", tc.ShoppingSessionID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReserveStockAPI(t *testing.T) {
+	user, _ := randomCOUser(t)
+	shoppingSession := createRandomShoppingSession(t, user)
+	product := randomProduct()
+	product.Active = true
+
+	variant := randomProductVariant(product)
+
+	cartItem := createRandomCartItem(t, shoppingSession)
+	cartItem.ProductID = product.ID
+	cartItem.VariantID = variant.ID
+	cartItems := []db.CartItem{cartItem}
+
+	result := db.ReserveStockTxResult{
+		Reservations: []db.StockReservation{
+			{
+				ID:          util.RandomInt(1, 100),
+				SessionID:   shoppingSession.ID,
+				InventoryID: variant.InventoryID,
+				Quantity:    cartItem.Quantity,
+				ExpiresAt:   time.Now().Add(time.Minute).Truncate(time.Second).UTC(),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		ShoppingSessionID int64
+		setupAuth         func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs        func(store *mockdb.MockStore)
+		checkResponse     func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:              "OK",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(product.ID)).
+					Times(1).
+					Return(product, nil)
+
+				store.EXPECT().
+					GetProductVariant(gomock.Any(), gomock.Eq(variant.ID)).
+					Times(1).
+					Return(variant, nil)
+
+				store.EXPECT().
+					ReserveStockTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReserveStockTxParams) (db.ReserveStockTxResult, error) {
+						require.Equal(t, shoppingSession, arg.ShoppingSession)
+						require.Equal(t, cartItems, arg.CartItems)
+						require.False(t, arg.ExpiresAt.IsZero())
+						return result, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReservations(t, recorder.Body, result)
+			},
+		},
+		{
+			name:              "NoAuthorization",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					ReserveStockTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:              "EmptyCart",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return([]db.CartItem{}, nil)
+
+				store.EXPECT().
+					ReserveStockTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:              "InsufficientStock",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(product.ID)).
+					Times(1).
+					Return(product, nil)
+
+				store.EXPECT().
+					GetProductVariant(gomock.Any(), gomock.Eq(variant.ID)).
+					Times(1).
+					Return(variant, nil)
+
+				store.EXPECT().
+					ReserveStockTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReserveStockTxResult{}, &db.InsufficientStockError{
+						InventoryID: variant.InventoryID,
+						Requested:   cartItem.Quantity,
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:              "InternalError",
+			ShoppingSessionID: shoppingSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(shoppingSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(shoppingSession.ID)).
+					Times(1).
+					Return(cartItems, nil)
+
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(product.ID)).
+					Times(1).
+					Return(product, nil)
+
+				store.EXPECT().
+					GetProductVariant(gomock.Any(), gomock.Eq(variant.ID)).
+					Times(1).
+					Return(variant, nil)
+
+				store.EXPECT().
+					ReserveStockTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReserveStockTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:              "InvalidID",
+			ShoppingSessionID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/shopping-sessions/%d/reserve", tc.ShoppingSessionID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomCOUser(t *testing.T) (user db.User, password string) {
+	password = util.RandomString(6)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	user = db.User{
+		ID:        util.RandomMoney(),
+		Username:  util.RandomUser(),
+		Password:  hashedPassword,
+		Telephone: util.RandomPhoneNumber(),
+		Email:     util.RandomEmail(),
+	}
+	return
+}
+
+func requireBodyMatchCheckout(t *testing.T, body *bytes.Buffer, result db.FinishedPurchaseTxResult) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResult db.FinishedPurchaseTxResult
+	err = json.Unmarshal(data, &gotResult)
+
+	require.NoError(t, err)
+	require.Equal(t, result.OrderDetail.ID, gotResult.OrderDetail.ID)
+	require.Equal(t, result.OrderDetail.Total, gotResult.OrderDetail.Total)
+	require.Len(t, gotResult.OrderItems, len(result.OrderItems))
+	for i := range result.OrderItems {
+		require.Equal(t, result.OrderItems[i].ProductID, gotResult.OrderItems[i].ProductID)
+		require.Equal(t, result.OrderItems[i].Quantity, gotResult.OrderItems[i].Quantity)
+	}
+}
+
+func requireBodyMatchReservations(t *testing.T, body *bytes.Buffer, result db.ReserveStockTxResult) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResult db.ReserveStockTxResult
+	err = json.Unmarshal(data, &gotResult)
+
+	require.NoError(t, err)
+	require.Equal(t, result, gotResult)
+}
@@ -2,11 +2,9 @@ package api
 
 import (
 	"database/sql"
-	"errors"
 	"net/http"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
-	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 )
@@ -20,13 +18,6 @@ type createDiscountRequest struct {
 func (server *Server) createDiscount(ctx *gin.Context) {
 	var req createDiscountRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -79,8 +70,7 @@ func (server *Server) getDiscount(ctx *gin.Context) {
 }
 
 type listDiscountRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listDiscount(ctx *gin.Context) {
@@ -91,9 +81,15 @@ func (server *Server) listDiscount(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.ListDiscountsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	discounts, err := server.store.ListDiscounts(ctx, arg)
 	if err != nil {
@@ -104,7 +100,25 @@ func (server *Server) listDiscount(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, discounts)
+
+	n, more := page.size(len(discounts))
+	discounts = discounts[:n]
+
+	rsp := listResponse{Items: discounts}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: discounts[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountDiscounts(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateProductDiscountRequest struct {
@@ -115,13 +129,6 @@ type updateProductDiscountRequest struct {
 func (server *Server) updateDiscount(ctx *gin.Context) {
 	var req updateProductDiscountRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -155,13 +162,6 @@ type deleteProductDiscountRequest struct {
 func (server *Server) deleteDiscount(ctx *gin.Context) {
 	var req deleteProductDiscountRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.BindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
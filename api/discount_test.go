@@ -297,8 +297,8 @@ func TestListProductDiscountAPI(t *testing.T) {
 	}
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -310,14 +310,12 @@ func TestListProductDiscountAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListDiscountsParams{
-					Limit:  int32(n),
-					Offset: 0,
+					Limit: int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -333,8 +331,7 @@ func TestListProductDiscountAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -348,10 +345,10 @@ func TestListProductDiscountAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -364,10 +361,9 @@ func TestListProductDiscountAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -399,8 +395,10 @@ func TestListProductDiscountAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, request)
@@ -761,7 +759,6 @@ func requireBodyMatchProductDiscounts(t *testing.T, body *bytes.Buffer, discount
 	require.NoError(t, err)
 
 	var gotDiscounts []db.Discount
-	err = json.Unmarshal(data, &gotDiscounts)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotDiscounts)
 	require.Equal(t, discounts, gotDiscounts)
 }
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+var errEmailNotVerified = errors.New("email address is not verified")
+
+// sendVerificationEmail issues a new email verification token for the user
+// and emails them the link to verify their address with it.
+func (server *Server) sendVerificationEmail(ctx *gin.Context, user db.User) error {
+	verificationToken, err := util.RandomSecret(32)
+	if err != nil {
+		return err
+	}
+
+	arg := db.CreateEmailVerificationTokenParams{
+		UserID:    user.ID,
+		TokenHash: util.HashSecret(verificationToken),
+		ExpiresAt: time.Now().Add(server.config.EmailVerificationDuration),
+	}
+
+	_, err = server.store.CreateEmailVerificationToken(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	subject := "Verify your e-shop email address"
+	content := fmt.Sprintf(`Hello %s,
+
+Thank you for signing up! Follow this link within %s to verify your email address:
+
+%s?token=%s
+`, user.Username, server.config.EmailVerificationDuration, server.config.EmailVerificationURL, verificationToken)
+
+	return server.mailer.SendEmail(subject, content, []string{user.Email})
+}
+
+type verifyEmailRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// verifyEmail is the target of the link sent by sendVerificationEmail.
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var req verifyEmailRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.VerifyEmailTx(ctx, util.HashSecret(req.Token))
+	if err != nil {
+		if err == db.ErrInvalidEmailVerificationToken {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newUserResponse(user)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// resendVerificationEmail sends a new verification email to the authenticated
+// user, at most once per EmailVerificationResendInterval.
+func (server *Server) resendVerificationEmail(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	user, err := server.store.GetUser(ctx, authPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.VerifiedAt.Valid {
+		err := errors.New("email address is already verified")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	lastToken, err := server.store.GetLastEmailVerificationToken(ctx, user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err == nil {
+		wait := time.Until(lastToken.CreatedAt.Add(server.config.EmailVerificationResendInterval))
+		if wait > 0 {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			err := errors.New("a verification email was sent recently, try again later")
+			ctx.JSON(http.StatusTooManyRequests, errorResponse(err))
+			return
+		}
+	}
+
+	err = server.sendVerificationEmail(ctx, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// requireVerifiedEmail keeps the users who haven't verified their email
+// address from checking out, when RequireVerifiedEmailForCheckout is set.
+// It must run after authMiddleware.
+func (server *Server) requireVerifiedEmail() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !server.config.RequireVerifiedEmailForCheckout {
+			ctx.Next()
+			return
+		}
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+		user, err := server.store.GetUser(ctx, authPayload.UserID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !user.VerifiedAt.Valid {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errEmailNotVerified))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// cartTokenHeader carries the cart token identifying the shopping session of a guest
+const cartTokenHeader = "X-Cart-Token"
+
+var errCartItemNotInCart = errors.New("cart item doesn't belong to the cart")
+
+type guestCartResponse struct {
+	CartToken       string                  `json:"cart_token,omitempty"`
+	ShoppingSession shoppingSessionResponse `json:"shopping_session"`
+	CartItems       []db.CartItem           `json:"cart_items"`
+}
+
+// createGuestCart opens a shopping session for an anonymous visitor. The cart token
+// is only returned here, the guest has to send it in the X-Cart-Token header to use
+// their cart and along with their credentials when they sign in or up.
+func (server *Server) createGuestCart(ctx *gin.Context) {
+	// guest carts are cleaned up whenever a new one is opened
+	err := server.store.DeleteExpiredGuestShoppingSessions(ctx, time.Now().Add(-server.config.GuestCartDuration))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	cartToken, err := util.RandomSecret(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateGuestShoppingSessionParams{
+		GuestTokenHash: util.HashSecret(cartToken),
+		Total:          db.FormatTotal(decimal.Zero),
+	}
+
+	shoppingSession, err := server.store.CreateGuestShoppingSession(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := guestCartResponse{
+		CartToken:       cartToken,
+		ShoppingSession: newShoppingSessionResponse(shoppingSession),
+		CartItems:       []db.CartItem{},
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+func (server *Server) getGuestCart(ctx *gin.Context) {
+	shoppingSession, ok := server.getGuestShoppingSession(ctx)
+	if !ok {
+		return
+	}
+
+	cartItems, err := server.store.ListCartItemsBySessionID(ctx, shoppingSession.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := guestCartResponse{
+		ShoppingSession: newShoppingSessionResponse(shoppingSession),
+		CartItems:       cartItems,
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type createGuestCartItemRequest struct {
+	VariantID int64 `json:"variant_id" binding:"required,min=1"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
+}
+
+func (server *Server) createGuestCartItem(ctx *gin.Context) {
+	var req createGuestCartItemRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shoppingSession, ok := server.getGuestShoppingSession(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.CreateCartItemParams{
+		SessionID: shoppingSession.ID,
+		Quantity:  req.Quantity,
+		VariantID: req.VariantID,
+	}
+
+	// inactive variants are not found
+	result, err := server.store.CreateCartItemTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result.CartItem)
+}
+
+type guestCartItemURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateGuestCartItemRequest struct {
+	Quantity int32 `json:"quantity" binding:"required,min=1"`
+}
+
+func (server *Server) updateGuestCartItem(ctx *gin.Context) {
+	var uri guestCartItemURIRequest
+	var req updateGuestCartItemRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shoppingSession, ok := server.getGuestShoppingSession(ctx)
+	if !ok {
+		return
+	}
+
+	if !server.checkGuestCartItem(ctx, shoppingSession, uri.ID) {
+		return
+	}
+
+	arg := db.UpdateCartItemTxParams{
+		SessionID: shoppingSession.ID,
+		ID:        uri.ID,
+		Quantity:  req.Quantity,
+	}
+
+	result, err := server.store.UpdateCartItemTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result.CartItem)
+}
+
+func (server *Server) deleteGuestCartItem(ctx *gin.Context) {
+	var uri guestCartItemURIRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shoppingSession, ok := server.getGuestShoppingSession(ctx)
+	if !ok {
+		return
+	}
+
+	if !server.checkGuestCartItem(ctx, shoppingSession, uri.ID) {
+		return
+	}
+
+	arg := db.DeleteCartItemTxParams{
+		SessionID: shoppingSession.ID,
+		ID:        uri.ID,
+	}
+
+	_, err := server.store.DeleteCartItemTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// getGuestShoppingSession looks up the shopping session of the cart token sent by
+// the guest, and writes the error response when it is missing, unknown or expired.
+func (server *Server) getGuestShoppingSession(ctx *gin.Context) (db.ShoppingSession, bool) {
+	cartToken := ctx.GetHeader(cartTokenHeader)
+	if cartToken == "" {
+		err := errors.New("cart token header is not provided")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return db.ShoppingSession{}, false
+	}
+
+	shoppingSession, err := server.store.GetShoppingSessionByGuestTokenHash(ctx, util.HashSecret(cartToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(db.ErrInvalidCartToken))
+			return db.ShoppingSession{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ShoppingSession{}, false
+	}
+
+	if time.Since(shoppingSession.CreatedAt) > server.config.GuestCartDuration {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(db.ErrInvalidCartToken))
+		return db.ShoppingSession{}, false
+	}
+
+	return shoppingSession, true
+}
+
+// checkGuestCartItem makes sure the cart item is in the shopping session of the guest
+func (server *Server) checkGuestCartItem(ctx *gin.Context, shoppingSession db.ShoppingSession, cartItemID int64) bool {
+	cartItem, err := server.store.GetCartItemByID(ctx, cartItemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if cartItem.SessionID != shoppingSession.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errCartItemNotInCart))
+		return false
+	}
+
+	return true
+}
+
+// mergeGuestCart hands the guest cart of the cart token over to a user who just
+// signed in or up, and returns the shopping session of the user. Signing in
+// doesn't depend on the cart, so an unknown or expired cart token is ignored.
+func (server *Server) mergeGuestCart(ctx *gin.Context, userID int64, cartToken string) (db.ShoppingSession, error) {
+	arg := db.MergeGuestCartTxParams{
+		UserID:         userID,
+		GuestTokenHash: util.HashSecret(cartToken),
+		Rule:           server.cartMerge,
+		CreatedAfter:   time.Now().Add(-server.config.GuestCartDuration),
+	}
+
+	shoppingSession, err := server.store.MergeGuestCartTx(ctx, arg)
+	if errors.Is(err, db.ErrInvalidCartToken) {
+		return db.ShoppingSession{}, nil
+	}
+	return shoppingSession, err
+}
@@ -0,0 +1,540 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGuestCartAPI(t *testing.T) {
+	guestSession := randomGuestShoppingSession(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteExpiredGuestShoppingSessions(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, createdBefore time.Time) error {
+						require.WithinDuration(t, time.Now().Add(-30*24*time.Hour), createdBefore, time.Second)
+						return nil
+					})
+
+				store.EXPECT().
+					CreateGuestShoppingSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateGuestShoppingSessionParams) (db.ShoppingSession, error) {
+						require.NotEmpty(t, arg.GuestTokenHash)
+						require.Equal(t, "0.00", arg.Total)
+						return guestSession, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp guestCartResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotEmpty(t, rsp.CartToken)
+				require.Equal(t, guestSession.ID, rsp.ShoppingSession.ID)
+				require.Zero(t, rsp.ShoppingSession.UserID)
+				require.Empty(t, rsp.CartItems)
+				require.NotContains(t, recorder.Body.String(), guestSession.GuestTokenHash.String)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteExpiredGuestShoppingSessions(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					CreateGuestShoppingSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ShoppingSession{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/guest-carts", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetGuestCartAPI(t *testing.T) {
+	cartToken := util.RandomString(32)
+	guestSession := randomGuestShoppingSession(t)
+	guestSession.GuestTokenHash = sql.NullString{String: util.HashSecret(cartToken), Valid: true}
+	cartItem := createRandomCartItem(t, guestSession)
+
+	expiredSession := guestSession
+	expiredSession.CreatedAt = time.Now().Add(-31 * 24 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		cartToken     string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			cartToken: cartToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Eq(util.HashSecret(cartToken))).
+					Times(1).
+					Return(guestSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Eq(guestSession.ID)).
+					Times(1).
+					Return([]db.CartItem{cartItem}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp guestCartResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Empty(t, rsp.CartToken)
+				require.Equal(t, guestSession.ID, rsp.ShoppingSession.ID)
+				require.Len(t, rsp.CartItems, 1)
+				require.Equal(t, cartItem.ID, rsp.CartItems[0].ID)
+			},
+		},
+		{
+			name:      "NoCartToken",
+			cartToken: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "UnknownCartToken",
+			cartToken: cartToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ShoppingSession{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, db.ErrInvalidCartToken)
+			},
+		},
+		{
+			name:      "ExpiredCartToken",
+			cartToken: cartToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(expiredSession, nil)
+
+				store.EXPECT().
+					ListCartItemsBySessionID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, db.ErrInvalidCartToken)
+			},
+		},
+		{
+			name:      "InternalError",
+			cartToken: cartToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ShoppingSession{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/guest-carts", nil)
+			require.NoError(t, err)
+			if tc.cartToken != "" {
+				request.Header.Set(cartTokenHeader, tc.cartToken)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateGuestCartItemAPI(t *testing.T) {
+	cartToken := util.RandomString(32)
+	guestSession := randomGuestShoppingSession(t)
+	cartItem := createRandomCartItem(t, guestSession)
+	cartItem.Quantity = 2
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"variant_id": cartItem.VariantID,
+				"quantity":   cartItem.Quantity,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Eq(util.HashSecret(cartToken))).
+					Times(1).
+					Return(guestSession, nil)
+
+				arg := db.CreateCartItemParams{
+					SessionID: guestSession.ID,
+					Quantity:  cartItem.Quantity,
+					VariantID: cartItem.VariantID,
+				}
+
+				store.EXPECT().
+					CreateCartItemTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CartItemTxResult{CartItem: cartItem, ShoppingSession: guestSession}, nil)
+
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCartItem(t, recorder.Body, cartItem)
+			},
+		},
+		{
+			name: "InvalidQuantity",
+			body: gin.H{
+				"variant_id": cartItem.VariantID,
+				"quantity":   -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownCartToken",
+			body: gin.H{
+				"variant_id": cartItem.VariantID,
+				"quantity":   cartItem.Quantity,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ShoppingSession{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/guest-carts/items", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set(cartTokenHeader, cartToken)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateGuestCartItemAPI(t *testing.T) {
+	cartToken := util.RandomString(32)
+	guestSession := randomGuestShoppingSession(t)
+	cartItem := createRandomCartItem(t, guestSession)
+
+	otherCartItem := cartItem
+	otherCartItem.SessionID = guestSession.ID + 1
+
+	testCases := []struct {
+		name          string
+		cartItemID    int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			cartItemID: cartItem.ID,
+			body: gin.H{
+				"quantity": 3,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(guestSession, nil)
+
+				store.EXPECT().
+					GetCartItemByID(gomock.Any(), gomock.Eq(cartItem.ID)).
+					Times(1).
+					Return(cartItem, nil)
+
+				arg := db.UpdateCartItemTxParams{
+					SessionID: guestSession.ID,
+					ID:        cartItem.ID,
+					Quantity:  3,
+				}
+
+				store.EXPECT().
+					UpdateCartItemTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CartItemTxResult{CartItem: cartItem, ShoppingSession: guestSession}, nil)
+
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCartItem(t, recorder.Body, cartItem)
+			},
+		},
+		{
+			name:       "CartItemOfAnotherCart",
+			cartItemID: cartItem.ID,
+			body: gin.H{
+				"quantity": 3,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(guestSession, nil)
+
+				store.EXPECT().
+					GetCartItemByID(gomock.Any(), gomock.Eq(cartItem.ID)).
+					Times(1).
+					Return(otherCartItem, nil)
+
+				store.EXPECT().
+					UpdateCartItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errCartItemNotInCart)
+			},
+		},
+		{
+			name:       "InvalidID",
+			cartItemID: 0,
+			body: gin.H{
+				"quantity": 3,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guest-carts/items/%d", tc.cartItemID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set(cartTokenHeader, cartToken)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteGuestCartItemAPI(t *testing.T) {
+	cartToken := util.RandomString(32)
+	guestSession := randomGuestShoppingSession(t)
+	cartItem := createRandomCartItem(t, guestSession)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(guestSession, nil)
+
+				store.EXPECT().
+					GetCartItemByID(gomock.Any(), gomock.Eq(cartItem.ID)).
+					Times(1).
+					Return(cartItem, nil)
+
+				store.EXPECT().
+					DeleteCartItemTx(gomock.Any(), gomock.Eq(db.DeleteCartItemTxParams{SessionID: guestSession.ID, ID: cartItem.ID})).
+					Times(1)
+
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetShoppingSessionByGuestTokenHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(guestSession, nil)
+
+				store.EXPECT().
+					GetCartItemByID(gomock.Any(), gomock.Eq(cartItem.ID)).
+					Times(1).
+					Return(db.CartItem{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					DeleteCartItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/guest-carts/items/%d", cartItem.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+			request.Header.Set(cartTokenHeader, cartToken)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomGuestShoppingSession(t *testing.T) db.ShoppingSession {
+	return db.ShoppingSession{
+		ID:             util.RandomInt(1, 1000),
+		Total:          "0.00",
+		CreatedAt:      time.Now(),
+		GuestTokenHash: sql.NullString{String: util.HashSecret(util.RandomString(32)), Valid: true},
+	}
+}
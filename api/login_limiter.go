@@ -0,0 +1,135 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxTrackedClients bounds the memory used by the login limiter
+const maxTrackedClients = 10000
+
+// maxLockoutDuration caps the progressive lockout of an account
+const maxLockoutDuration = 24 * time.Hour
+
+type clientFailures struct {
+	count   int
+	resetAt time.Time
+}
+
+// loginLimiter counts the failed logins of each client IP over a fixed window,
+// the clients going over the limit can't try again until their window ends.
+// It complements the lockout of accounts, which doesn't slow down an attacker
+// trying a few passwords against many accounts.
+// A zero limit disables the limiter.
+type loginLimiter struct {
+	limit   int
+	window  time.Duration
+	mu      sync.Mutex
+	clients map[string]clientFailures
+}
+
+func newLoginLimiter(limit int, window time.Duration) *loginLimiter {
+	return &loginLimiter{
+		limit:   limit,
+		window:  window,
+		clients: make(map[string]clientFailures),
+	}
+}
+
+// retryAfter returns how long the client has to wait before it may log in
+// again, zero when it may right away.
+func (limiter *loginLimiter) retryAfter(clientIP string) time.Duration {
+	if limiter.limit <= 0 {
+		return 0
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	failures, ok := limiter.clients[clientIP]
+	if !ok {
+		return 0
+	}
+
+	wait := time.Until(failures.resetAt)
+	if wait <= 0 {
+		delete(limiter.clients, clientIP)
+		return 0
+	}
+
+	if failures.count < limiter.limit {
+		return 0
+	}
+	return wait
+}
+
+// fail records a failed login of the client
+func (limiter *loginLimiter) fail(clientIP string) {
+	if limiter.limit <= 0 {
+		return
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := time.Now()
+	failures, ok := limiter.clients[clientIP]
+	if !ok || now.After(failures.resetAt) {
+		if len(limiter.clients) >= maxTrackedClients {
+			for ip, failures := range limiter.clients {
+				if now.After(failures.resetAt) {
+					delete(limiter.clients, ip)
+				}
+			}
+		}
+		if len(limiter.clients) >= maxTrackedClients {
+			limiter.clients = make(map[string]clientFailures)
+		}
+
+		failures = clientFailures{resetAt: now.Add(limiter.window)}
+	}
+
+	failures.count++
+	limiter.clients[clientIP] = failures
+}
+
+// lockoutDuration returns how long an account is locked after its given
+// number of consecutive failed logins: every maxAttempts failures lock it,
+// first for the base duration, then twice as long as the previous time,
+// up to maxLockoutDuration. A zero maxAttempts disables the lockout.
+func lockoutDuration(failedAttempts int32, maxAttempts int, base time.Duration) time.Duration {
+	if maxAttempts <= 0 || failedAttempts <= 0 || int(failedAttempts)%maxAttempts != 0 {
+		return 0
+	}
+
+	duration := base
+	for i := 1; i < int(failedAttempts)/maxAttempts; i++ {
+		duration *= 2
+		if duration >= maxLockoutDuration {
+			break
+		}
+	}
+
+	if duration > maxLockoutDuration {
+		return maxLockoutDuration
+	}
+	return duration
+}
+
+// allowLogin rejects the login attempts of the clients that failed too often
+// lately, and tells them when they may try again.
+func (server *Server) allowLogin(ctx *gin.Context) bool {
+	wait := server.logins.retryAfter(ctx.ClientIP())
+	if wait <= 0 {
+		return true
+	}
+
+	ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	ctx.JSON(http.StatusTooManyRequests, errorResponse(errTooManyLoginAttempts))
+	return false
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLimiter(t *testing.T) {
+	limiter := newLoginLimiter(3, time.Minute)
+	clientIP := "127.0.0.1"
+
+	for i := 0; i < 3; i++ {
+		require.Zero(t, limiter.retryAfter(clientIP))
+		limiter.fail(clientIP)
+	}
+
+	wait := limiter.retryAfter(clientIP)
+	require.Greater(t, wait, time.Duration(0))
+	require.LessOrEqual(t, wait, time.Minute)
+
+	// other clients are not affected
+	require.Zero(t, limiter.retryAfter("127.0.0.2"))
+}
+
+func TestLoginLimiterWindow(t *testing.T) {
+	limiter := newLoginLimiter(1, time.Millisecond)
+	clientIP := "127.0.0.1"
+
+	limiter.fail(clientIP)
+	time.Sleep(5 * time.Millisecond)
+	require.Zero(t, limiter.retryAfter(clientIP))
+}
+
+func TestLoginLimiterDisabled(t *testing.T) {
+	limiter := newLoginLimiter(0, time.Minute)
+	clientIP := "127.0.0.1"
+
+	for i := 0; i < 10; i++ {
+		limiter.fail(clientIP)
+	}
+	require.Zero(t, limiter.retryAfter(clientIP))
+}
+
+func TestLockoutDuration(t *testing.T) {
+	base := 15 * time.Minute
+
+	testCases := []struct {
+		name           string
+		failedAttempts int32
+		maxAttempts    int
+		duration       time.Duration
+	}{
+		{
+			name:           "BelowMaxAttempts",
+			failedAttempts: 4,
+			maxAttempts:    5,
+			duration:       0,
+		},
+		{
+			name:           "FirstLockout",
+			failedAttempts: 5,
+			maxAttempts:    5,
+			duration:       base,
+		},
+		{
+			name:           "BetweenLockouts",
+			failedAttempts: 7,
+			maxAttempts:    5,
+			duration:       0,
+		},
+		{
+			name:           "SecondLockout",
+			failedAttempts: 10,
+			maxAttempts:    5,
+			duration:       2 * base,
+		},
+		{
+			name:           "ThirdLockout",
+			failedAttempts: 15,
+			maxAttempts:    5,
+			duration:       4 * base,
+		},
+		{
+			name:           "Capped",
+			failedAttempts: 500,
+			maxAttempts:    5,
+			duration:       maxLockoutDuration,
+		},
+		{
+			name:           "Disabled",
+			failedAttempts: 5,
+			maxAttempts:    0,
+			duration:       0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.duration, lockoutDuration(tc.failedAttempts, tc.maxAttempts, base))
+		})
+	}
+}
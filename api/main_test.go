@@ -1,24 +1,87 @@
 package api
 
 import (
+	"context"
+	"io"
 	"os"
 	"testing"
 	"time"
 
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/mail"
 	"github.com/DarkHeros09/e-shop/v2/util"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
+	return newTestServerWithMailer(t, store, mail.NewLogMailer(io.Discard, "test@eshop.local"))
+}
+
+func newTestServerWithMailer(t *testing.T, store db.Store, mailer mail.Mailer) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:     util.RandomString(32),
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Hour,
+		PasswordResetDuration: 30 * time.Minute,
+		PasswordResetURL:      "http://localhost:3000/password/reset",
+
+		PasswordResetResendInterval: time.Minute,
+		PasswordResetIPMaxRequests:  10,
+		PasswordResetIPWindow:       15 * time.Minute,
+
+		EmailVerificationDuration:       24 * time.Hour,
+		EmailVerificationURL:            "http://localhost:8080/users/verify",
+		EmailVerificationResendInterval: time.Minute,
+		EmailChangeDuration:             time.Hour,
+		EmailChangeURL:                  "http://localhost:3000/email/confirm",
+		LoginMaxAttempts:                5,
+		LoginLockoutDuration:            15 * time.Minute,
+		LoginIPMaxAttempts:              20,
+		LoginIPWindow:                   15 * time.Minute,
+		MFATokenDuration:                5 * time.Minute,
+		MFAIssuer:                       "e-shop",
+		PhoneDefaultCountryCode:         "218",
+		GuestCartDuration:               30 * 24 * time.Hour,
+		CartMergeRule:                   "sum",
+		DefaultPageSize:                 10,
+		MaxPageSize:                     50,
 	}
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, mailer)
 	require.NoError(t, err)
 
+	// unless a test expects otherwise, every token is bound to a live session
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().
+			CheckUserSession(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(false, nil)
+
+		mockStore.EXPECT().
+			CheckAdminSession(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(db.CheckAdminSessionRow{Active: true}, nil)
+
+		// and, as seeded by the migrations, the admin type 1 is the super-admin
+		mockStore.EXPECT().
+			ListAdminTypePermissions(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			DoAndReturn(func(_ context.Context, adminTypeID int64) ([]string, error) {
+				if adminTypeID != 1 {
+					return []string{}, nil
+				}
+				return []string{
+					permissionManageAdmins,
+					permissionManageCatalog,
+					permissionManageOrders,
+					permissionManageUsers,
+					permissionReadUsers,
+				}, nil
+			})
+	}
+
 	return server
 }
 
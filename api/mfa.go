@@ -0,0 +1,196 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+// recoveryCodeCount is the number of recovery codes handed out when totp is enabled
+const recoveryCodeCount = 10
+
+var (
+	errInvalidMFACode         = errors.New("invalid authentication code")
+	errMFANotEnrolled         = errors.New("two-factor authentication is not set up")
+	errMFAAlreadyEnabled      = errors.New("two-factor authentication is already enabled")
+	errMFARequiredByAdminType = errors.New("two-factor authentication is required for this admin type")
+)
+
+type mfaRequiredResponse struct {
+	MFARequired        bool      `json:"mfa_required"`
+	MFAToken           string    `json:"mfa_token"`
+	MFATokenExpiresAt  time.Time `json:"mfa_token_expires_at"`
+	EnrollmentRequired bool      `json:"enrollment_required"`
+}
+
+// respondMFARequired answers a login whose password was right with a short-lived
+// token, to exchange for the real ones together with a second factor.
+func (server *Server) respondMFARequired(ctx *gin.Context, ownerID int64, admin bool, enrollmentRequired bool) {
+	mfaToken, mfaPayload, err := server.tokenMaker.CreateMFAToken(ownerID, admin, server.config.MFATokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := mfaRequiredResponse{
+		MFARequired:        true,
+		MFAToken:           mfaToken,
+		MFATokenExpiresAt:  mfaPayload.ExpiredAt,
+		EnrollmentRequired: enrollmentRequired,
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// failMFA counts a wrong second factor against the client like a wrong password.
+func (server *Server) failMFA(ctx *gin.Context) {
+	server.logins.fail(ctx.ClientIP())
+	ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidMFACode))
+}
+
+// newRecoveryCodes returns a fresh set of recovery codes, to show once, and the hashes to store.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := util.RandomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = code
+		hashes[i] = util.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+type enrollTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func (server *Server) newEnrollTOTPResponse(accountName string, secret string) enrollTOTPResponse {
+	return enrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: util.TOTPProvisioningURI(server.config.MFAIssuer, accountName, secret),
+	}
+}
+
+type mfaStatusResponse struct {
+	Enabled           bool  `json:"enabled"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type verifyLoginMFARequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code" binding:"required_without=Code"`
+}
+
+type enrollLoginTOTPRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+type confirmTOTPRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type disableTOTPRequest struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// adminMFARequired tells whether the admin has to show a second factor to log in,
+// either because they enabled totp or because their admin type requires it, in which
+// case they have to enroll first if they didn't yet.
+func (server *Server) adminMFARequired(ctx *gin.Context, admin db.Admin) (bool, bool, error) {
+	totp, err := server.store.GetAdminTOTP(ctx, admin.ID)
+	if err == nil && totp.ConfirmedAt.Valid {
+		return true, false, nil
+	} else if err != nil && err != sql.ErrNoRows {
+		return false, false, err
+	}
+
+	adminType, err := server.store.GetAdminType(ctx, admin.TypeID)
+	if err != nil {
+		return false, false, err
+	}
+	return adminType.RequireMfa, adminType.RequireMfa, nil
+}
+
+// checkAdminSecondFactor checks a code of the enabled totp of an admin, or one of
+// their recovery codes, and uses it up so that it can't be presented again.
+func (server *Server) checkAdminSecondFactor(ctx *gin.Context, totp db.AdminTotp, code string, recoveryCode string) (bool, error) {
+	if recoveryCode != "" {
+		_, err := server.store.UseAdminRecoveryCode(ctx, db.UseAdminRecoveryCodeParams{
+			AdminID:  totp.AdminID,
+			CodeHash: util.HashRecoveryCode(recoveryCode),
+		})
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	step, ok := util.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	_, err := server.store.UseAdminTOTPStep(ctx, db.UseAdminTOTPStepParams{
+		AdminID:      totp.AdminID,
+		LastUsedStep: step,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// userMFARequired tells whether the user enabled totp and has to show a second factor to log in.
+func (server *Server) userMFARequired(ctx *gin.Context, user db.User) (bool, error) {
+	totp, err := server.store.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return totp.ConfirmedAt.Valid, nil
+}
+
+// checkUserSecondFactor checks a code of the enabled totp of a user, or one of
+// their recovery codes, and uses it up so that it can't be presented again.
+func (server *Server) checkUserSecondFactor(ctx *gin.Context, totp db.UserTotp, code string, recoveryCode string) (bool, error) {
+	if recoveryCode != "" {
+		_, err := server.store.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{
+			UserID:   totp.UserID,
+			CodeHash: util.HashRecoveryCode(recoveryCode),
+		})
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	step, ok := util.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	_, err := server.store.UseUserTOTPStep(ctx, db.UseUserTOTPStepParams{
+		UserID:       totp.UserID,
+		LastUsedStep: step,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
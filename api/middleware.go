@@ -13,10 +13,21 @@ import (
 const (
 	authorizationHeaderKey  = "authorization"
 	authorizationTypeBearer = "bearer"
+	authorizationTypeAPIKey = "apikey"
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker, admin bool) gin.HandlerFunc {
+// errRefreshTokenAsAccessToken is returned when a refresh token is presented in
+// place of an access token. A refresh token names the session it starts, an
+// access token is bound to the session of the refresh token it was issued with.
+var errRefreshTokenAsAccessToken = errors.New("refresh token can't be used as an access token")
+
+// authMiddleware verifies the bearer token of the request and, through the
+// session cache, that its session hasn't been revoked since it was issued.
+// Refresh tokens are only accepted by the renew endpoints, never here.
+// When apiKeys is given, an api key is accepted in place of the token, the
+// permissions it is scoped to are then checked by requirePermissions.
+func authMiddleware(tokenMaker token.Maker, sessions *sessionCache, apiKeys *apiKeyCache, admin bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 
@@ -34,6 +45,22 @@ func authMiddleware(tokenMaker token.Maker, admin bool) gin.HandlerFunc {
 		}
 
 		authorizationType := strings.ToLower(fields[0])
+		if authorizationType == authorizationTypeAPIKey && apiKeys != nil {
+			apiKey, err := apiKeys.check(ctx, fields[1])
+			if err != nil {
+				if err == errInvalidAPIKey || err == errExpiredAPIKey {
+					ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+					return
+				}
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
+			ctx.Set(authorizationPayloadKey, apiKey)
+			ctx.Next()
+			return
+		}
+
 		if authorizationType != authorizationTypeBearer {
 			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
@@ -51,6 +78,17 @@ func authMiddleware(tokenMaker token.Maker, admin bool) gin.HandlerFunc {
 				return
 			}
 
+			if adminPayload.ID == adminPayload.SessionID {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errRefreshTokenAsAccessToken))
+				return
+			}
+
+			err = sessions.checkAdmin(ctx, adminPayload)
+			if err != nil {
+				abortWithSessionError(ctx, err)
+				return
+			}
+
 			ctx.Set(authorizationPayloadKey, adminPayload)
 			ctx.Next()
 		}
@@ -62,6 +100,17 @@ func authMiddleware(tokenMaker token.Maker, admin bool) gin.HandlerFunc {
 				return
 			}
 
+			if userPayload.ID == userPayload.SessionID {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errRefreshTokenAsAccessToken))
+				return
+			}
+
+			err = sessions.checkUser(ctx, userPayload)
+			if err != nil {
+				abortWithSessionError(ctx, err)
+				return
+			}
+
 			ctx.Set(authorizationPayloadKey, userPayload)
 			ctx.Next()
 
@@ -69,3 +118,11 @@ func authMiddleware(tokenMaker token.Maker, admin bool) gin.HandlerFunc {
 
 	}
 }
+
+func abortWithSessionError(ctx *gin.Context, err error) {
+	if err == errRevokedToken {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+}
@@ -1,14 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
 	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,7 +28,7 @@ func addAuthorization(
 	username string,
 	duration time.Duration,
 ) {
-	token, payload, err := tokenMaker.CreateTokenForUser(userID, username, duration)
+	token, payload, err := tokenMaker.CreateTokenForUser(userID, username, uuid.New(), duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -40,7 +47,7 @@ func addAuthorizationForAdmin(
 	active bool,
 	duration time.Duration,
 ) {
-	token, payload, err := tokenMaker.CreateTokenForAdmin(adminID, username, typeID, active, duration)
+	token, payload, err := tokenMaker.CreateTokenForAdmin(adminID, username, typeID, active, uuid.New(), duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -52,6 +59,7 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 	testCases := []struct {
 		name          string
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -59,6 +67,12 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 1, "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
@@ -67,6 +81,11 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 			name: "NoAuthorization",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -76,6 +95,11 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, "unsupported", 1, "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -85,6 +109,11 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, "", 1, "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -94,22 +123,94 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 1, "user", -time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RefreshToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				// a refresh token starts its own session
+				refreshToken, _, err := tokenMaker.CreateTokenForUser(1, "user", uuid.Nil, time.Hour)
+				require.NoError(t, err)
+				request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, refreshToken))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "BlockedSession",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 1, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "DeletedSession",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 1, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, sql.ErrNoRows)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "InternalError",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 1, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
 		tc := testCases[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil)
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
 
 			authPath := "/auth"
 			server.router.GET(
 				authPath,
-				authMiddleware(server.tokenMaker, false),
+				authMiddleware(server.tokenMaker, server.sessions, nil, false),
 				func(ctx *gin.Context) {
 					ctx.JSON(http.StatusOK, gin.H{})
 				},
@@ -125,3 +226,591 @@ func TestAuthMiddlewareForUser(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthMiddlewareForAdmin(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckAdminSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CheckAdminSessionRow{Active: true}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DeactivatedAdmin",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckAdminSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CheckAdminSessionRow{Active: false}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "BlockedSession",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckAdminSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CheckAdminSessionRow{IsBlocked: true, Active: true}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "DeletedSession",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckAdminSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CheckAdminSessionRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckAdminSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CheckAdminSessionRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				authMiddleware(server.tokenMaker, server.sessions, server.apiKeys, true),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+
+			addAuthorizationForAdmin(t, request, server.tokenMaker, authorizationTypeBearer, 1, "admin", 1, true, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAuthMiddlewareRefreshTokenForAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CheckAdminSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server := newTestServer(t, store)
+
+	authPath := "/auth"
+	server.router.GET(
+		authPath,
+		authMiddleware(server.tokenMaker, server.sessions, server.apiKeys, true),
+		func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{})
+		},
+	)
+
+	// a refresh token starts its own session
+	refreshToken, _, err := server.tokenMaker.CreateTokenForAdmin(1, "admin", 1, true, uuid.Nil, time.Hour)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, authPath, nil)
+	require.NoError(t, err)
+
+	request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, refreshToken))
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestAuthMiddlewareSessionCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	sessionStore := mockdb.NewMockStore(ctrl)
+	sessionStore.EXPECT().
+		CheckUserSession(gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(false, nil)
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	server.sessions = newSessionCache(sessionStore, time.Minute)
+
+	authPath := "/auth"
+	server.router.GET(
+		authPath,
+		authMiddleware(server.tokenMaker, server.sessions, nil, false),
+		func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{})
+		},
+	)
+
+	accessToken, payload, err := server.tokenMaker.CreateTokenForUser(1, "user", uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	serve := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, authPath, nil)
+		require.NoError(t, err)
+
+		request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// the second request is served from the cache
+	require.Equal(t, http.StatusOK, serve().Code)
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	// until the session is revoked by the server
+	server.sessions.forgetSession(payload.SessionID)
+	require.Equal(t, http.StatusOK, serve().Code)
+}
+
+func TestRequirePermissionsMiddleware(t *testing.T) {
+	catalogManagerTypeID := int64(2)
+
+	testCases := []struct {
+		name          string
+		typeID        int64
+		active        bool
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			typeID:      catalogManagerTypeID,
+			active:      true,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdminTypePermissions(gomock.Any(), gomock.Eq(catalogManagerTypeID)).
+					Times(1).
+					Return([]string{permissionManageCatalog}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "MissingPermission",
+			typeID:      catalogManagerTypeID,
+			active:      true,
+			permissions: []string{permissionManageCatalog, permissionManageUsers},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdminTypePermissions(gomock.Any(), gomock.Eq(catalogManagerTypeID)).
+					Times(1).
+					Return([]string{permissionManageCatalog}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "InactiveAdmin",
+			typeID:      catalogManagerTypeID,
+			active:      false,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdminTypePermissions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			typeID:      catalogManagerTypeID,
+			active:      true,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAdminTypePermissions(gomock.Any(), gomock.Eq(catalogManagerTypeID)).
+					Times(1).
+					Return([]string{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				authMiddleware(server.tokenMaker, server.sessions, server.apiKeys, true),
+				server.requirePermissions(tc.permissions...),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+
+			addAuthorizationForAdmin(t, request, server.tokenMaker, authorizationTypeBearer, 1, "admin", tc.typeID, tc.active, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAuthMiddlewareWithAPIKey(t *testing.T) {
+	apiKey, key := randomAPIKey(t, sql.NullTime{})
+
+	testCases := []struct {
+		name          string
+		key           string
+		admin         bool
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			key:         key,
+			admin:       true,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return([]string{permissionManageCatalog}, nil)
+				store.EXPECT().
+					UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "MissingPermission",
+			key:         key,
+			admin:       true,
+			permissions: []string{permissionManageCatalog, permissionManageOrders},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return([]string{permissionManageCatalog}, nil)
+				store.EXPECT().
+					UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "AdminOnlyRoute",
+			key:   key,
+			admin: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return([]string{permissionManageCatalog}, nil)
+				store.EXPECT().
+					UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "UserRoute",
+			key:         key,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "UnknownKey",
+			key:         key,
+			admin:       true,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "ExpiredKey",
+			key:         key,
+			admin:       true,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				expiredAPIKey := apiKey
+				expiredAPIKey.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(expiredAPIKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			key:         key,
+			admin:       true,
+			permissions: []string{permissionManageCatalog},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			apiKeys := server.apiKeys
+			if !tc.admin {
+				apiKeys = nil
+			}
+
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				authMiddleware(server.tokenMaker, server.sessions, apiKeys, tc.admin),
+				server.requirePermissions(tc.permissions...),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+
+			addAPIKeyAuthorization(request, tc.key)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAuthMiddlewareAPIKeyCache(t *testing.T) {
+	apiKey, key := randomAPIKey(t, sql.NullTime{})
+
+	ctrl := gomock.NewController(t)
+
+	apiKeyStore := mockdb.NewMockStore(ctrl)
+	apiKeyStore.EXPECT().
+		GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+		Times(2).
+		Return(apiKey, nil)
+	apiKeyStore.EXPECT().
+		ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+		Times(2).
+		Return([]string{permissionManageCatalog}, nil)
+	apiKeyStore.EXPECT().
+		UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+		Times(2).
+		Return(nil)
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	server.apiKeys = newAPIKeyCache(apiKeyStore, time.Minute)
+
+	authPath := "/auth"
+	server.router.GET(
+		authPath,
+		authMiddleware(server.tokenMaker, server.sessions, server.apiKeys, true),
+		server.requirePermissions(permissionManageCatalog),
+		func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{})
+		},
+	)
+
+	serve := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, authPath, nil)
+		require.NoError(t, err)
+
+		addAPIKeyAuthorization(request, key)
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// the second request is served from the cache
+	require.Equal(t, http.StatusOK, serve().Code)
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	// until the key is deleted by the server
+	server.apiKeys.forget(apiKey.ID)
+	require.Equal(t, http.StatusOK, serve().Code)
+}
+
+func TestAuthMiddlewareAPIKeyOfDeactivatedAdmin(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+	apiKey, key := randomAPIKey(t, sql.NullTime{})
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	// the key is refused by the store once its admin is deactivated
+	gomock.InOrder(
+		store.EXPECT().
+			GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+			Times(1).
+			Return(apiKey, nil),
+		store.EXPECT().
+			GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+			Times(1).
+			Return(db.ApiKey{}, sql.ErrNoRows),
+	)
+	store.EXPECT().
+		ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+		Times(1).
+		Return([]string{permissionManageCatalog}, nil)
+	store.EXPECT().
+		UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+		Times(1).
+		Return(nil)
+
+	deactivatedAdmin := admin
+	deactivatedAdmin.ID = apiKey.AdminID
+	deactivatedAdmin.Active = false
+
+	store.EXPECT().
+		UpdateAdminTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(deactivatedAdmin, nil)
+
+	server := newTestServer(t, store)
+	server.apiKeys = newAPIKeyCache(store, time.Minute)
+
+	authPath := "/auth"
+	server.router.GET(
+		authPath,
+		authMiddleware(server.tokenMaker, server.sessions, server.apiKeys, true),
+		server.requirePermissions(permissionManageCatalog),
+		func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{})
+		},
+	)
+
+	serve := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, authPath, nil)
+		require.NoError(t, err)
+
+		addAPIKeyAuthorization(request, key)
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	data, err := json.Marshal(gin.H{"active": false, "type_id": admin.TypeID})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("/admins/%d", apiKey.AdminID)
+	request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorizationForAdmin(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// the cached key went away with the access of its admin
+	require.Equal(t, http.StatusUnauthorized, serve().Code)
+}
@@ -9,11 +9,11 @@ import (
 	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
 type createOrderDetailRequest struct {
-	UserID int64  `json:"user_id" binding:"required,min=1"`
-	Total  string `json:"total" binding:"required,min=0"`
+	UserID int64 `json:"user_id" binding:"required,min=1"`
 }
 
 func (server *Server) createOrderDetail(ctx *gin.Context) {
@@ -31,9 +31,10 @@ func (server *Server) createOrderDetail(ctx *gin.Context) {
 		return
 	}
 
+	// the order starts empty, its total follows the items added to it
 	arg := db.CreateOrderDetailAndPaymentDetailParams{
 		UserID: req.UserID,
-		Total:  req.Total,
+		Total:  db.FormatTotal(decimal.Zero),
 	}
 
 	orderDetail, err := server.store.CreateOrderDetailAndPaymentDetail(ctx, arg)
@@ -52,6 +53,45 @@ func (server *Server) createOrderDetail(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, orderDetail)
 }
 
+type orderItemResponse struct {
+	db.OrderItem
+	Subtotal string `json:"subtotal"`
+}
+
+type orderDetailResponse struct {
+	db.OrderDetail
+	Items    []orderItemResponse `json:"items"`
+	Subtotal string              `json:"subtotal"`
+}
+
+// newOrderDetailResponse joins the order header with its line items and
+// computes the subtotals from the unit prices captured at purchase time.
+func newOrderDetailResponse(orderDetail db.OrderDetail, orderItems []db.OrderItem) (orderDetailResponse, error) {
+	rsp := orderDetailResponse{
+		OrderDetail: orderDetail,
+		Items:       make([]orderItemResponse, 0, len(orderItems)),
+	}
+
+	subtotal := decimal.Zero
+	for _, orderItem := range orderItems {
+		price, err := decimal.NewFromString(orderItem.Price)
+		if err != nil {
+			return orderDetailResponse{}, err
+		}
+
+		line := price.Mul(decimal.NewFromInt32(orderItem.Quantity))
+		subtotal = subtotal.Add(line)
+
+		rsp.Items = append(rsp.Items, orderItemResponse{
+			OrderItem: orderItem,
+			Subtotal:  line.StringFixedBank(2),
+		})
+	}
+	rsp.Subtotal = subtotal.StringFixedBank(2)
+
+	return rsp, nil
+}
+
 type getOrderDetailRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
@@ -81,12 +121,28 @@ func (server *Server) getOrderDetail(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, orderDetail)
+	server.respondOrderDetail(ctx, orderDetail)
+}
+
+// respondOrderDetail responds with the order along with its line items.
+func (server *Server) respondOrderDetail(ctx *gin.Context, orderDetail db.OrderDetail) {
+	orderItems, err := server.store.ListOrderItemsByOrderID(ctx, orderDetail.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp, err := newOrderDetailResponse(orderDetail, orderItems)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type listOrderDetailsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listOrderDetails(ctx *gin.Context) {
@@ -97,11 +153,17 @@ func (server *Server) listOrderDetails(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
 	arg := db.ListOrderDetailsParams{
-		UserID: authPayload.UserID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		UserID:  authPayload.UserID,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 
 	orderDetails, err := server.store.ListOrderDetails(ctx, arg)
@@ -113,5 +175,96 @@ func (server *Server) listOrderDetails(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, orderDetails)
+
+	n, more := page.size(len(orderDetails))
+	orderDetails = orderDetails[:n]
+
+	rsp := listResponse{Items: orderDetails}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: orderDetails[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountOrderDetails(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// getOrder returns any order to the admins and api keys managing the orders.
+func (server *Server) getOrder(ctx *gin.Context) {
+	var req getOrderDetailRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	orderDetail, err := server.store.GetOrderDetail(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.respondOrderDetail(ctx, orderDetail)
+}
+
+type listOrdersRequest struct {
+	pageRequest
+}
+
+// listOrders lists the orders of every user to the admins and api keys
+// managing the orders.
+func (server *Server) listOrders(ctx *gin.Context) {
+	var req listOrdersRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListOrderDetailsOfAllUsersParams{
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
+	}
+
+	orderDetails, err := server.store.ListOrderDetailsOfAllUsers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	n, more := page.size(len(orderDetails))
+	orderDetails = orderDetails[:n]
+
+	rsp := listResponse{Items: orderDetails}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: orderDetails[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountOrderDetailsOfAllUsers(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
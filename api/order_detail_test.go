@@ -23,6 +23,7 @@ import (
 func TestCreateOrderDetailAPI(t *testing.T) {
 	user, _ := randomODUser(t)
 	orderDetail := createRandomOrderDetail(t, user)
+	orderDetail.Total = "0.00"
 
 	testCases := []struct {
 		name          string
@@ -35,7 +36,7 @@ func TestCreateOrderDetailAPI(t *testing.T) {
 			name: "OK",
 			body: gin.H{
 				"user_id": orderDetail.UserID,
-				"total":   orderDetail.Total,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -61,7 +62,7 @@ func TestCreateOrderDetailAPI(t *testing.T) {
 			name: "NoAuthorization",
 			body: gin.H{
 				"user_id": orderDetail.UserID,
-				"total":   orderDetail.Total,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
@@ -78,7 +79,7 @@ func TestCreateOrderDetailAPI(t *testing.T) {
 			name: "InternalError",
 			body: gin.H{
 				"user_id": orderDetail.UserID,
-				"total":   orderDetail.Total,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -102,7 +103,7 @@ func TestCreateOrderDetailAPI(t *testing.T) {
 			name: "InvalidUserID",
 			body: gin.H{
 				"user_id": 0,
-				"total":   orderDetail.Total,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 0, user.Username, time.Minute)
@@ -149,6 +150,15 @@ func TestGetOrderDetailAPI(t *testing.T) {
 	user, _ := randomODUser(t)
 	orderDetail := createRandomOrderDetail(t, user)
 
+	orderItems := make([]db.OrderItem, 2)
+	for i := range orderItems {
+		orderItems[i] = createRandomOrderItem(t, orderDetail)
+	}
+	orderItems[0].Price = "12.50"
+	orderItems[0].Quantity = 2
+	orderItems[1].Price = "7.255"
+	orderItems[1].Quantity = 3
+
 	testCases := []struct {
 		name          string
 		ID            int64
@@ -172,10 +182,14 @@ func TestGetOrderDetailAPI(t *testing.T) {
 					Times(1).
 					Return(orderDetail, nil)
 
+				store.EXPECT().
+					ListOrderItemsByOrderID(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(orderItems, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchOrderDetail(t, recorder.Body, orderDetail)
+				requireBodyMatchOrderDetailWithItems(t, recorder.Body, orderDetail, orderItems, []string{"25.00", "21.76"}, "46.76")
 			},
 		},
 		{
@@ -249,6 +263,27 @@ func TestGetOrderDetailAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "ListOrderItemsInternalError",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(orderDetail, nil)
+
+				store.EXPECT().
+					ListOrderItemsByOrderID(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return([]db.OrderItem{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidID",
 			ID:   0,
@@ -301,7 +336,7 @@ func TestGetOrderDetailAPI(t *testing.T) {
 
 func TestListOrderDetailAPI(t *testing.T) {
 	n := 5
-	orderDetails := make([]db.OrderDetail, n)
+	orderDetails := make([]db.OrderDetail, 0, n)
 	user, _ := randomOIUser(t)
 	orderDetail1 := createRandomOrderDetail(t, user)
 	orderDetail2 := createRandomOrderDetail(t, user)
@@ -310,8 +345,8 @@ func TestListOrderDetailAPI(t *testing.T) {
 	orderDetails = append(orderDetails, orderDetail1, orderDetail2, orderDetail3)
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -324,8 +359,7 @@ func TestListOrderDetailAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -333,8 +367,7 @@ func TestListOrderDetailAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListOrderDetailsParams{
 					UserID: user.ID,
-					Limit:  int32(n),
-					Offset: 0,
+					Limit:  int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -350,8 +383,7 @@ func TestListOrderDetailAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -367,10 +399,10 @@ func TestListOrderDetailAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -385,10 +417,9 @@ func TestListOrderDetailAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -422,8 +453,10 @@ func TestListOrderDetailAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -433,6 +466,423 @@ func TestListOrderDetailAPI(t *testing.T) {
 	}
 }
 
+func TestGetOrderAPI(t *testing.T) {
+	admin, _ := randomSuperAdmin(t)
+	catalogAdmin, _ := randomAdmin(t)
+	user, _ := randomODUser(t)
+	orderDetail := createRandomOrderDetail(t, user)
+	apiKey, key := randomAPIKey(t, sql.NullTime{})
+
+	orderItems := make([]db.OrderItem, 2)
+	for i := range orderItems {
+		orderItems[i] = createRandomOrderItem(t, orderDetail)
+	}
+	orderItems[0].Price = "12.50"
+	orderItems[0].Quantity = 2
+	orderItems[1].Price = "7.255"
+	orderItems[1].Quantity = 3
+
+	testCases := []struct {
+		name          string
+		ID            int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(orderDetail, nil)
+
+				store.EXPECT().
+					ListOrderItemsByOrderID(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(orderItems, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchOrderDetailWithItems(t, recorder.Body, orderDetail, orderItems, []string{"25.00", "21.76"}, "46.76")
+			},
+		},
+		{
+			name: "APIKey",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAPIKeyAuthorization(request, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return([]string{permissionManageOrders}, nil)
+				store.EXPECT().
+					UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(orderDetail, nil)
+
+				store.EXPECT().
+					ListOrderItemsByOrderID(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(orderItems, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchOrderDetailWithItems(t, recorder.Body, orderDetail, orderItems, []string{"25.00", "21.76"}, "46.76")
+			},
+		},
+		{
+			name: "APIKeyWithoutPermission",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAPIKeyAuthorization(request, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return([]string{permissionManageCatalog}, nil)
+				store.EXPECT().
+					UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "AdminWithoutPermission",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, catalogAdmin.ID, catalogAdmin.Username, catalogAdmin.TypeID, catalogAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UserToken",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(db.OrderDetail{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					ListOrderItemsByOrderID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			ID:   orderDetail.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderDetail.ID)).
+					Times(1).
+					Return(db.OrderDetail{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidID",
+			ID:   0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/orders/%d", tc.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListOrdersAPI(t *testing.T) {
+	n := 5
+	admin, _ := randomSuperAdmin(t)
+	catalogAdmin, _ := randomAdmin(t)
+	apiKey, key := randomAPIKey(t, sql.NullTime{})
+
+	// the orders of several users
+	orderDetails := make([]db.OrderDetail, 3)
+	for i := range orderDetails {
+		user, _ := randomODUser(t)
+		orderDetails[i] = createRandomOrderDetail(t, user)
+	}
+
+	type Query struct {
+		cursor       string
+		limit        int
+		includeTotal bool
+	}
+
+	testCases := []struct {
+		name          string
+		query         Query
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListOrderDetailsOfAllUsersParams{
+					Limit: int32(n) + 1,
+				}
+
+				store.EXPECT().
+					ListOrderDetailsOfAllUsers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(orderDetails, nil)
+
+				store.EXPECT().
+					CountOrderDetailsOfAllUsers(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchOrderDetails(t, recorder.Body, orderDetails)
+			},
+		},
+		{
+			name: "APIKeyWithTotal",
+			query: Query{
+				limit:        n,
+				includeTotal: true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAPIKeyAuthorization(request, key)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).
+					Times(1).
+					Return(apiKey, nil)
+				store.EXPECT().
+					ListAPIKeyPermissions(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return([]string{permissionManageOrders}, nil)
+				store.EXPECT().
+					UpdateAPIKeyLastUsed(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					ListOrderDetailsOfAllUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(orderDetails, nil)
+
+				store.EXPECT().
+					CountOrderDetailsOfAllUsers(gomock.Any()).
+					Times(1).
+					Return(int64(len(orderDetails)), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp struct {
+					Total *int64 `json:"total"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotNil(t, rsp.Total)
+				require.Equal(t, int64(len(orderDetails)), *rsp.Total)
+			},
+		},
+		{
+			name: "AdminWithoutPermission",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, catalogAdmin.ID, catalogAdmin.Username, catalogAdmin.TypeID, catalogAdmin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderDetailsOfAllUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderDetailsOfAllUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: Query{
+				limit: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderDetailsOfAllUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.OrderDetail{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCursor",
+			query: Query{
+				cursor: "not-a-cursor",
+				limit:  n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListOrderDetailsOfAllUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/orders"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query parameters to request URL
+			q := request.URL.Query()
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
+			if tc.query.includeTotal {
+				q.Add("include_total", "true")
+			}
+			request.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func randomODUser(t *testing.T) (user db.User, password string) {
 	password = util.RandomString(6)
 	hashedPassword, err := util.HashPassword(password)
@@ -442,7 +892,7 @@ func randomODUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(7, 7)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -474,12 +924,33 @@ func requireBodyMatchOrderDetail(t *testing.T, body *bytes.Buffer, orderDetail d
 	require.Equal(t, orderDetail.PaymentID, gotOrderDetail.PaymentID)
 }
 
+func requireBodyMatchOrderDetailWithItems(t *testing.T, body *bytes.Buffer, orderDetail db.OrderDetail, orderItems []db.OrderItem, lineSubtotals []string, subtotal string) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse orderDetailResponse
+	err = json.Unmarshal(data, &gotResponse)
+
+	require.NoError(t, err)
+	require.Equal(t, orderDetail.ID, gotResponse.ID)
+	require.Equal(t, orderDetail.Total, gotResponse.Total)
+	require.Equal(t, orderDetail.PaymentID, gotResponse.PaymentID)
+	require.Equal(t, subtotal, gotResponse.Subtotal)
+	require.Len(t, gotResponse.Items, len(orderItems))
+	for i, item := range gotResponse.Items {
+		require.Equal(t, orderItems[i].ID, item.ID)
+		require.Equal(t, orderItems[i].ProductName, item.ProductName)
+		require.Equal(t, orderItems[i].ProductSku, item.ProductSku)
+		require.Equal(t, orderItems[i].Price, item.Price)
+		require.Equal(t, lineSubtotals[i], item.Subtotal)
+	}
+}
+
 func requireBodyMatchOrderDetails(t *testing.T, body *bytes.Buffer, orderDetails []db.OrderDetail) {
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
 
 	var gotOrderDetails []db.OrderDetail
-	err = json.Unmarshal(data, &gotOrderDetails)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotOrderDetails)
 	require.Equal(t, orderDetails, gotOrderDetails)
 }
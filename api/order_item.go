@@ -13,8 +13,8 @@ import (
 
 type createOrderItemRequest struct {
 	OrderID   int64 `json:"order_id" binding:"required,min=1"`
-	ProductID int64 `json:"product_id" binding:"required,min=1"`
-	Quantity  int32 `json:"quantity" binding:"required,numeric"`
+	VariantID int64 `json:"variant_id" binding:"required,min=1"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
 }
 
 func (server *Server) createOrderItem(ctx *gin.Context) {
@@ -42,13 +42,13 @@ func (server *Server) createOrderItem(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateOrderItemParams{
-		OrderID:   req.OrderID,
-		ProductID: req.ProductID,
+	arg := db.CreateOrderItemTxParams{
+		OrderID:   orderDetail.ID,
+		VariantID: req.VariantID,
 		Quantity:  req.Quantity,
 	}
 
-	orderItem, err := server.store.CreateOrderItem(ctx, arg)
+	result, err := server.store.CreateOrderItemTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -56,12 +56,21 @@ func (server *Server) createOrderItem(ctx *gin.Context) {
 				ctx.JSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		} else if errors.Is(err, db.ErrOrderFinished) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		} else if errors.Is(err, db.ErrInactiveProduct) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, orderItem)
+	ctx.JSON(http.StatusOK, result.OrderItem)
 }
 
 type getOrderItemRequest struct {
@@ -96,8 +105,7 @@ func (server *Server) getOrderItem(ctx *gin.Context) {
 }
 
 type listOrderItemsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listOrderItems(ctx *gin.Context) {
@@ -108,11 +116,17 @@ func (server *Server) listOrderItems(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
 	arg := db.ListOrderItemsParams{
-		UserID: authPayload.UserID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		UserID:  authPayload.UserID,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	orderItems, err := server.store.ListOrderItems(ctx, arg)
 	if err != nil {
@@ -123,5 +137,23 @@ func (server *Server) listOrderItems(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, orderItems)
+
+	n, more := page.size(len(orderItems))
+	orderItems = orderItems[:n]
+
+	rsp := listResponse{Items: orderItems}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: orderItems[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountOrderItems(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
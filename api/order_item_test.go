@@ -23,7 +23,16 @@ import (
 func TestCreateOrderItemAPI(t *testing.T) {
 	user, _ := randomOIUser(t)
 	orderDetail := createRandomOrderDetail(t, user)
+	product := randomProduct()
+	variant := randomProductVariant(product)
+
 	orderItem := createRandomOrderItem(t, orderDetail)
+	orderItem.ProductID = product.ID
+	orderItem.ProductName = product.Name
+	orderItem.ProductSku = variant.Sku
+	orderItem.Price = product.Price
+	orderItem.VariantID = variant.ID
+	orderItem.VariantName = "M / Red"
 
 	testCases := []struct {
 		name          string
@@ -36,29 +45,28 @@ func TestCreateOrderItemAPI(t *testing.T) {
 			name: "OK",
 			body: gin.H{
 				"order_id":   orderItem.OrderID,
-				"product_id": orderItem.ProductID,
+				"variant_id": orderItem.VariantID,
 				"quantity":   orderItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-
 				store.EXPECT().
 					GetOrderDetail(gomock.Any(), gomock.Eq(orderItem.OrderID)).
 					Times(1).
 					Return(orderDetail, nil)
 
-				arg := db.CreateOrderItemParams{
+				arg := db.CreateOrderItemTxParams{
 					OrderID:   orderItem.OrderID,
-					ProductID: orderItem.ProductID,
+					VariantID: orderItem.VariantID,
 					Quantity:  orderItem.Quantity,
 				}
 
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), gomock.Eq(arg)).
+					CreateOrderItemTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(orderItem, nil)
+					Return(db.OrderItemTxResult{OrderDetail: orderDetail, OrderItem: orderItem}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -69,7 +77,7 @@ func TestCreateOrderItemAPI(t *testing.T) {
 			name: "NoAuthorization",
 			body: gin.H{
 				"order_id":   orderItem.OrderID,
-				"product_id": orderItem.ProductID,
+				"variant_id": orderItem.VariantID,
 				"quantity":   orderItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -80,7 +88,7 @@ func TestCreateOrderItemAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), gomock.Any()).
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -91,43 +99,134 @@ func TestCreateOrderItemAPI(t *testing.T) {
 			name: "InternalError",
 			body: gin.H{
 				"order_id":   orderItem.OrderID,
-				"product_id": orderItem.ProductID,
+				"variant_id": orderItem.VariantID,
 				"quantity":   orderItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderItem.OrderID)).
+					Times(1).
+					Return(orderDetail, nil)
 
+				store.EXPECT().
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderItemTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "VariantNotFound",
+			body: gin.H{
+				"order_id":   orderItem.OrderID,
+				"variant_id": orderItem.VariantID,
+				"quantity":   orderItem.Quantity,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetOrderDetail(gomock.Any(), gomock.Eq(orderItem.OrderID)).
 					Times(1).
 					Return(orderDetail, nil)
 
-				arg := db.CreateOrderItemParams{
-					OrderID:   orderItem.OrderID,
-					ProductID: orderItem.ProductID,
-					Quantity:  orderItem.Quantity,
-				}
+				store.EXPECT().
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderItemTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "OrderFinished",
+			body: gin.H{
+				"order_id":   orderItem.OrderID,
+				"variant_id": orderItem.VariantID,
+				"quantity":   orderItem.Quantity,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderItem.OrderID)).
+					Times(1).
+					Return(orderDetail, nil)
 
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), gomock.Eq(arg)).
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.OrderItem{}, sql.ErrConnDone)
+					Return(db.OrderItemTxResult{}, db.ErrOrderFinished)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InactiveProduct",
+			body: gin.H{
+				"order_id":   orderItem.OrderID,
+				"variant_id": orderItem.VariantID,
+				"quantity":   orderItem.Quantity,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Eq(orderItem.OrderID)).
+					Times(1).
+					Return(orderDetail, nil)
+
+				store.EXPECT().
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderItemTxResult{}, db.ErrInactiveProduct)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeQuantity",
+			body: gin.H{
+				"order_id":   orderItem.OrderID,
+				"variant_id": orderItem.VariantID,
+				"quantity":   -1,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderDetail(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
 			name: "InvalidOrderID",
 			body: gin.H{
 				"order_id":   0,
-				"product_id": orderItem.ProductID,
+				"variant_id": orderItem.VariantID,
 				"quantity":   orderItem.Quantity,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 0, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -135,7 +234,7 @@ func TestCreateOrderItemAPI(t *testing.T) {
 					Times(0)
 
 				store.EXPECT().
-					CreateOrderItem(gomock.Any(), gomock.Any()).
+					CreateOrderItemTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -320,7 +419,7 @@ func TestGetOrderItemAPI(t *testing.T) {
 
 func TestListOrderItemAPI(t *testing.T) {
 	n := 5
-	orderItems := make([]db.OrderItem, n)
+	orderItems := make([]db.OrderItem, 0, n)
 	user, _ := randomOIUser(t)
 	orderDetail1 := createRandomOrderDetail(t, user)
 	orderDetail2 := createRandomOrderDetail(t, user)
@@ -332,8 +431,8 @@ func TestListOrderItemAPI(t *testing.T) {
 	orderItems = append(orderItems, orderItem1, orderItem2, orderItem3)
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -346,8 +445,7 @@ func TestListOrderItemAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -355,8 +453,7 @@ func TestListOrderItemAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListOrderItemsParams{
 					UserID: user.ID,
-					Limit:  int32(n),
-					Offset: 0,
+					Limit:  int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -372,8 +469,7 @@ func TestListOrderItemAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -389,10 +485,10 @@ func TestListOrderItemAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -407,10 +503,9 @@ func TestListOrderItemAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -444,8 +539,10 @@ func TestListOrderItemAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -464,7 +561,7 @@ func randomOIUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(7, 7)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -472,10 +569,13 @@ func randomOIUser(t *testing.T) (user db.User, password string) {
 
 func createRandomOrderItem(t *testing.T, orderDetail db.OrderDetail) (orderItem db.OrderItem) {
 	orderItem = db.OrderItem{
-		ID:        util.RandomMoney(),
-		OrderID:   orderDetail.ID,
-		ProductID: util.RandomMoney(),
-		Quantity:  int32(util.RandomMoney()),
+		ID:          util.RandomMoney(),
+		OrderID:     orderDetail.ID,
+		ProductID:   util.RandomMoney(),
+		Quantity:    int32(util.RandomMoney()),
+		ProductName: util.RandomUser(),
+		ProductSku:  util.RandomUser(),
+		Price:       fmt.Sprint(util.RandomMoney()),
 	}
 	return
 }
@@ -492,6 +592,9 @@ func requireBodyMatchOrderItem(t *testing.T, body *bytes.Buffer, orderItem db.Or
 	require.Equal(t, orderItem.ProductID, gotOrderItem.ProductID)
 	require.Equal(t, orderItem.OrderID, gotOrderItem.OrderID)
 	require.Equal(t, orderItem.Quantity, gotOrderItem.Quantity)
+	require.Equal(t, orderItem.ProductName, gotOrderItem.ProductName)
+	require.Equal(t, orderItem.ProductSku, gotOrderItem.ProductSku)
+	require.Equal(t, orderItem.Price, gotOrderItem.Price)
 }
 
 func requireBodyMatchOrderItems(t *testing.T, body *bytes.Buffer, orderItems []db.OrderItem) {
@@ -499,7 +602,6 @@ func requireBodyMatchOrderItems(t *testing.T, body *bytes.Buffer, orderItems []d
 	require.NoError(t, err)
 
 	var gotOrderItems []db.OrderItem
-	err = json.Unmarshal(data, &gotOrderItems)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotOrderItems)
 	require.Equal(t, orderItems, gotOrderItems)
 }
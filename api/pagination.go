@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	errInvalidCursor  = errors.New("cursor is invalid")
+	errCursorMismatch = errors.New("cursor belongs to another sort order")
+)
+
+// pageRequest is embedded in the request of every list endpoint. The first page is
+// listed without a cursor, the next ones with the next_cursor of the previous page.
+type pageRequest struct {
+	Cursor       string `form:"cursor" binding:"max=512"`
+	Limit        int32  `form:"limit" binding:"omitempty,min=1"`
+	IncludeTotal bool   `form:"include_total"`
+}
+
+// pageCursor is the position of the last item of a page. The key is the sorted value
+// of the item, for the lists that are not sorted by id alone.
+type pageCursor struct {
+	ID   int64  `json:"id"`
+	Key  string `json:"key,omitempty"`
+	Sort string `json:"sort,omitempty"`
+}
+
+// encodeCursor turns the cursor into the opaque string handed to the client
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads a cursor sent back by the client
+func decodeCursor(s string) (pageCursor, error) {
+	var cursor pageCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return pageCursor{}, errInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID < 1 {
+		return pageCursor{}, errInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// page is a parsed pageRequest
+type page struct {
+	cursor       pageCursor
+	limit        int32
+	includeTotal bool
+}
+
+// parsePage decodes the cursor of the request, and checks its limit against the page
+// sizes of the config
+func (server *Server) parsePage(req pageRequest) (page, error) {
+	p := page{
+		limit:        int32(server.config.DefaultPageSize),
+		includeTotal: req.IncludeTotal,
+	}
+
+	if req.Limit != 0 {
+		if req.Limit > int32(server.config.MaxPageSize) {
+			return page{}, fmt.Errorf("limit can't be greater than %d", server.config.MaxPageSize)
+		}
+		p.limit = req.Limit
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return page{}, err
+		}
+		p.cursor = cursor
+	}
+
+	return p, nil
+}
+
+// fetchLimit is the number of rows to query, one more than the page holds so that
+// the last one tells whether there is a next page
+func (p page) fetchLimit() int32 {
+	return p.limit + 1
+}
+
+// size returns how many of the n rows queried belong to the page, and whether there
+// is a next page
+func (p page) size(n int) (int, bool) {
+	if n > int(p.limit) {
+		return int(p.limit), true
+	}
+	return n, false
+}
+
+// listResponse is the envelope of every list endpoint. next_cursor is left out on
+// the last page, and total is only counted when include_total is set.
+type listResponse struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Total      *int64      `json:"total,omitempty"`
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testListResponse is a listResponse whose items are decoded by the test
+type testListResponse struct {
+	Items      json.RawMessage `json:"items"`
+	NextCursor string          `json:"next_cursor"`
+	Total      *int64          `json:"total"`
+}
+
+// unmarshalListResponse decodes the envelope of a list response, and its items into items
+func unmarshalListResponse(t *testing.T, data []byte, items interface{}) testListResponse {
+	var rsp testListResponse
+	err := json.Unmarshal(data, &rsp)
+	require.NoError(t, err)
+
+	err = json.Unmarshal(rsp.Items, items)
+	require.NoError(t, err)
+	return rsp
+}
+
+func TestPageCursor(t *testing.T) {
+	cursor := pageCursor{ID: 42, Key: "10.50", Sort: "price"}
+
+	encoded := encodeCursor(cursor)
+	require.NotContains(t, encoded, "price")
+
+	decoded, err := decodeCursor(encoded)
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	for _, s := range []string{
+		"not-a-cursor!",
+		"bm90IGpzb24",                    // not json
+		encodeCursor(pageCursor{ID: 0}),  // before the first id
+		encodeCursor(pageCursor{ID: -5}), // negative id
+	} {
+		_, err := decodeCursor(s)
+		require.ErrorIs(t, err, errInvalidCursor, s)
+	}
+}
+
+func TestParsePage(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	p, err := server.parsePage(pageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(server.config.DefaultPageSize), p.limit)
+	require.Equal(t, pageCursor{}, p.cursor)
+	require.Equal(t, p.limit+1, p.fetchLimit())
+
+	p, err = server.parsePage(pageRequest{
+		Cursor:       encodeCursor(pageCursor{ID: 7}),
+		Limit:        int32(server.config.MaxPageSize),
+		IncludeTotal: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(server.config.MaxPageSize), p.limit)
+	require.Equal(t, int64(7), p.cursor.ID)
+	require.True(t, p.includeTotal)
+
+	_, err = server.parsePage(pageRequest{Limit: int32(server.config.MaxPageSize) + 1})
+	require.Error(t, err)
+
+	_, err = server.parsePage(pageRequest{Cursor: "not-a-cursor"})
+	require.ErrorIs(t, err, errInvalidCursor)
+}
+
+func TestPageSize(t *testing.T) {
+	p := page{limit: 5}
+
+	n, more := p.size(6)
+	require.Equal(t, 5, n)
+	require.True(t, more)
+
+	n, more = p.size(5)
+	require.Equal(t, 5, n)
+	require.False(t, more)
+
+	n, more = p.size(0)
+	require.Equal(t, 0, n)
+	require.False(t, more)
+}
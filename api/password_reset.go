@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+var errTooManyPasswordResets = errors.New("too many password reset requests, try again later")
+
+// forgotPassword emails a single-use password reset link to the user.
+// It answers the same way, and as fast, whether the email belongs to a user or
+// not, so that it can't be used to find out who has an account: the user is
+// looked up and mailed after the response. The requests are throttled per email
+// address and per client IP, whether the address is known or not.
+func (server *Server) forgotPassword(ctx *gin.Context) {
+	var req forgotPasswordRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	email := strings.ToLower(req.Email)
+	clientIP := ctx.ClientIP()
+
+	wait := server.resetEmails.retryAfter(email)
+	if ipWait := server.resetIPs.retryAfter(clientIP); ipWait > wait {
+		wait = ipWait
+	}
+	if wait > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(errTooManyPasswordResets))
+		return
+	}
+	server.resetEmails.fail(email)
+	server.resetIPs.fail(clientIP)
+
+	server.runInBackground("cannot send password reset email", func() error {
+		return server.sendPasswordResetEmail(context.Background(), req.Email)
+	})
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// sendPasswordResetEmail creates a password reset token for the user with the
+// email and mails it to them, nothing is sent when no user has the email.
+func (server *Server) sendPasswordResetEmail(ctx context.Context, email string) error {
+	user, err := server.store.GetUserByEmail(ctx, email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	resetToken, err := util.RandomSecret(32)
+	if err != nil {
+		return err
+	}
+
+	arg := db.CreatePasswordResetTokenParams{
+		UserID:    user.ID,
+		TokenHash: util.HashSecret(resetToken),
+		ExpiresAt: time.Now().Add(server.config.PasswordResetDuration),
+	}
+
+	_, err = server.store.CreatePasswordResetToken(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	subject := "Reset your e-shop password"
+	content := fmt.Sprintf(`Hello %s,
+
+We received a request to reset the password of your account.
+Follow this link within %s to choose a new password:
+
+%s?token=%s
+
+If you didn't ask for it, you can safely ignore this email.
+`, user.Username, server.config.PasswordResetDuration, server.config.PasswordResetURL, resetToken)
+
+	return server.mailer.SendEmail(subject, content, []string{user.Email})
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// resetPassword sets a new password with a reset token sent by forgotPassword
+// and logs the user out of all of their sessions.
+func (server *Server) resetPassword(ctx *gin.Context) {
+	var req resetPasswordRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.Password)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ResetPasswordTxParams{
+		TokenHash:      util.HashSecret(req.Token),
+		HashedPassword: hashedPassword,
+	}
+
+	user, err := server.store.ResetPasswordTx(ctx, arg)
+	if err != nil {
+		if err == db.ErrInvalidPasswordResetToken {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(user.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -42,8 +42,7 @@ func (server *Server) getPaymentDetail(ctx *gin.Context) {
 }
 
 type listPaymentDetailsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listPaymentDetails(ctx *gin.Context) {
@@ -54,11 +53,17 @@ func (server *Server) listPaymentDetails(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
 	arg := db.ListPaymentDetailsParams{
-		UserID: authPayload.UserID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		UserID:  authPayload.UserID,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	paymentDetails, err := server.store.ListPaymentDetails(ctx, arg)
 	if err != nil {
@@ -69,7 +74,25 @@ func (server *Server) listPaymentDetails(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, paymentDetails)
+
+	n, more := page.size(len(paymentDetails))
+	paymentDetails = paymentDetails[:n]
+
+	rsp := listResponse{Items: paymentDetails}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: paymentDetails[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountPaymentDetails(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updatePaymentDetailRequest struct {
@@ -201,8 +201,8 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 	paymentDetails = append(paymentDetails, paymentDetail1, paymentDetail2, paymentDetail3)
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -215,8 +215,7 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -224,8 +223,7 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListPaymentDetailsParams{
 					UserID: user.ID,
-					Limit:  int32(n),
-					Offset: 0,
+					Limit:  int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -241,8 +239,7 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -258,10 +255,10 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -276,10 +273,9 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -313,8 +309,10 @@ func TestListPaymentDetailsAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -503,7 +501,7 @@ func randomPDUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(7, 7)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -551,7 +549,6 @@ func requireBodyMatchPaymentDetails(t *testing.T, body *bytes.Buffer, paymentDet
 	require.NoError(t, err)
 
 	var gotPaymentDetails []db.ListPaymentDetailsRow
-	err = json.Unmarshal(data, &gotPaymentDetails)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotPaymentDetails)
 	require.Equal(t, paymentDetails, gotPaymentDetails)
 }
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/gin-gonic/gin"
+)
+
+// Permissions granted to admin types through the admin_type_permission table
+const (
+	permissionManageAdmins  = "admins.manage"
+	permissionManageCatalog = "catalog.manage"
+	permissionManageOrders  = "orders.manage"
+	permissionManageUsers   = "users.manage"
+	permissionReadUsers     = "users.read"
+)
+
+var knownPermissions = map[string]bool{
+	permissionManageAdmins:  true,
+	permissionManageCatalog: true,
+	permissionManageOrders:  true,
+	permissionManageUsers:   true,
+	permissionReadUsers:     true,
+}
+
+func validatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		if !knownPermissions[permission] {
+			return fmt.Errorf("unknown permission %q", permission)
+		}
+	}
+	return nil
+}
+
+// validateAPIKeyPermissions also keeps api keys from managing the admins, and
+// through them their own access
+func validateAPIKeyPermissions(permissions []string) error {
+	if err := validatePermissions(permissions); err != nil {
+		return err
+	}
+	if containsPermission(permissions, permissionManageAdmins) {
+		return fmt.Errorf("permission %q can't be granted to an api key", permissionManageAdmins)
+	}
+	return nil
+}
+
+type cachedPermissions struct {
+	granted   map[string]bool
+	expiresAt time.Time
+}
+
+// permissionCache holds the permission sets of the admin types for a short
+// while, changes made through this server are applied right away.
+// A zero ttl disables the cache.
+type permissionCache struct {
+	store       db.Store
+	ttl         time.Duration
+	mu          sync.Mutex
+	permissions map[int64]cachedPermissions
+}
+
+func newPermissionCache(store db.Store, ttl time.Duration) *permissionCache {
+	return &permissionCache{
+		store:       store,
+		ttl:         ttl,
+		permissions: make(map[int64]cachedPermissions),
+	}
+}
+
+func (cache *permissionCache) get(ctx context.Context, adminTypeID int64) (map[string]bool, error) {
+	cache.mu.Lock()
+	cached, ok := cache.permissions[adminTypeID]
+	cache.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.granted, nil
+	}
+
+	permissions, err := cache.store.ListAdminTypePermissions(ctx, adminTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		granted[permission] = true
+	}
+
+	if cache.ttl > 0 {
+		cache.mu.Lock()
+		cache.permissions[adminTypeID] = cachedPermissions{
+			granted:   granted,
+			expiresAt: time.Now().Add(cache.ttl),
+		}
+		cache.mu.Unlock()
+	}
+
+	return granted, nil
+}
+
+// forget drops the permissions of an admin type that has just been changed
+func (cache *permissionCache) forget(adminTypeID int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	delete(cache.permissions, adminTypeID)
+}
+
+// requirePermissions only lets through the admins whose admin type has been
+// granted every one of the permissions, and the api keys scoped to all of them.
+// Without permissions it only lets the admins through. It must run after authMiddleware.
+func (server *Server) requirePermissions(permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var granted map[string]bool
+		switch authPayload := ctx.MustGet(authorizationPayloadKey).(type) {
+		case *apiKeyPayload:
+			if len(permissions) == 0 {
+				err := errors.New("account unauthorized")
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			granted = authPayload.Permissions
+		case *token.AdminPayload:
+			if authPayload.AdminID == 0 || !authPayload.Active {
+				err := errors.New("account unauthorized")
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+
+			var err error
+			granted, err = server.permissions.get(ctx, authPayload.TypeID)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+		default:
+			err := errors.New("account unauthorized")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
+		for _, permission := range permissions {
+			if !granted[permission] {
+				err := errors.New("account unauthorized")
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
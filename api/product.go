@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
-	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
 type createProductRequest struct {
@@ -24,13 +26,6 @@ type createProductRequest struct {
 func (server *Server) createProduct(ctx *gin.Context) {
 	var req createProductRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -83,12 +78,60 @@ func (server *Server) getProduct(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, product)
+
+	matrix, err := server.store.GetProductVariantMatrixTx(ctx, product.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newProductResponse(product, matrix))
 }
 
 type listProductsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
+	CategoryID  int64  `form:"category_id" binding:"omitempty,min=1"`
+	MinPrice    string `form:"min_price" binding:"omitempty,numeric"`
+	MaxPrice    string `form:"max_price" binding:"omitempty,numeric"`
+	Active      *bool  `form:"active"`
+	InStock     bool   `form:"in_stock"`
+	HasDiscount bool   `form:"has_discount"`
+	Sort        string `form:"sort" binding:"omitempty,oneof=price -price newest name"`
+}
+
+var (
+	errNegativePrice     = errors.New("price filter can't be negative")
+	errInvalidPriceRange = errors.New("min_price can't be greater than max_price")
+)
+
+// parsePriceFilter reads an optional price bound of the products filter
+func parsePriceFilter(price string) (sql.NullString, decimal.Decimal, error) {
+	if price == "" {
+		return sql.NullString{}, decimal.Zero, nil
+	}
+
+	value, err := decimal.NewFromString(price)
+	if err != nil {
+		return sql.NullString{}, decimal.Zero, err
+	}
+	if value.IsNegative() {
+		return sql.NullString{}, decimal.Zero, errNegativePrice
+	}
+
+	return sql.NullString{String: value.String(), Valid: true}, value, nil
+}
+
+// validProductSortKey checks the sort key of a cursor, so that a tampered cursor
+// can't fail the query
+func validProductSortKey(sort db.ProductSort, key string) bool {
+	switch sort {
+	case db.ProductSortPrice, db.ProductSortPriceDesc:
+		_, err := decimal.NewFromString(key)
+		return err == nil
+	case db.ProductSortNewest:
+		_, err := time.Parse(time.RFC3339Nano, key)
+		return err == nil
+	}
+	return true
 }
 
 func (server *Server) listProducts(ctx *gin.Context) {
@@ -99,11 +142,58 @@ func (server *Server) listProducts(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.ListProductsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sort := db.ProductSort(req.Sort)
+	if page.cursor.ID != 0 {
+		// the position of a cursor only makes sense in the order it was made for
+		if page.cursor.Sort != req.Sort {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errCursorMismatch))
+			return
+		}
+		if !validProductSortKey(sort, page.cursor.Key) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidCursor))
+			return
+		}
+	}
+
+	minPrice, minValue, err := parsePriceFilter(req.MinPrice)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	maxPrice, maxValue, err := parsePriceFilter(req.MaxPrice)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if minPrice.Valid && maxPrice.Valid && minValue.GreaterThan(maxValue) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPriceRange))
+		return
+	}
+
+	arg := db.FilterProductsParams{
+		CategoryID:  sql.NullInt64{Int64: req.CategoryID, Valid: req.CategoryID != 0},
+		MinPrice:    minPrice,
+		MaxPrice:    maxPrice,
+		InStock:     req.InStock,
+		HasDiscount: req.HasDiscount,
+		Sort:        sort,
+		AfterID:     page.cursor.ID,
+		AfterKey:    page.cursor.Key,
+		Limit:       page.fetchLimit(),
+	}
+	if req.Active != nil {
+		arg.Active = sql.NullBool{Bool: *req.Active, Valid: true}
 	}
-	products, err := server.store.ListProducts(ctx, arg)
+
+	products, err := server.store.FilterProducts(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -124,8 +214,105 @@ func (server *Server) listProducts(ctx *gin.Context) {
 	// 	ctx.JSON(http.StatusOK, products)
 	// }
 
-	ctx.JSON(http.StatusOK, products)
+	n, more := page.size(len(products))
+	products = products[:n]
+
+	rsp := listResponse{Items: products}
+	if more {
+		last := products[n-1]
+		rsp.NextCursor = encodeCursor(pageCursor{
+			ID:   last.ID,
+			Key:  sort.Key(last),
+			Sort: req.Sort,
+		})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountFilteredProducts(ctx, arg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+var errEmptySearchQuery = errors.New("search query has no words to look for")
 
+type searchProductsRequest struct {
+	pageRequest
+	Query string `form:"q" binding:"required,max=200"`
+}
+
+// searchProducts looks the products up by their name, description and sku, the
+// most relevant first. Words are matched as prefixes to serve autocompletion, and
+// the matches are highlighted in the name and in snippets of the description.
+// Products that are not on sale are left out.
+func (server *Server) searchProducts(ctx *gin.Context) {
+	var req searchProductsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query := db.ProductSearchQuery(req.Query)
+	if query == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errEmptySearchQuery))
+		return
+	}
+
+	// the results are listed by rank, the cursor keeps the rank of the last one
+	arg := db.SearchProductsParams{
+		Query:   query,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
+	}
+	if page.cursor.ID != 0 {
+		rank, err := strconv.ParseFloat(page.cursor.Key, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidCursor))
+			return
+		}
+		arg.AfterRank = sql.NullFloat64{Float64: rank, Valid: true}
+	}
+
+	results, err := server.store.SearchProducts(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	n, more := page.size(len(results))
+	results = results[:n]
+
+	rsp := listResponse{Items: results}
+	if more {
+		last := results[n-1]
+		rsp.NextCursor = encodeCursor(pageCursor{
+			ID:  last.ID,
+			Key: strconv.FormatFloat(float64(last.Rank), 'g', -1, 32),
+		})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountSearchProducts(ctx, query)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateProductRequest struct {
@@ -140,13 +327,6 @@ type updateProductRequest struct {
 func (server *Server) updateProduct(ctx *gin.Context) {
 	var req updateProductRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -183,13 +363,6 @@ type deleteProductRequest struct {
 func (server *Server) deleteProduct(ctx *gin.Context) {
 	var req deleteProductRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
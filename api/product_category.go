@@ -2,11 +2,9 @@ package api
 
 import (
 	"database/sql"
-	"errors"
 	"net/http"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
-	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 )
@@ -19,13 +17,6 @@ type createProductCategoryRequest struct {
 func (server *Server) createCategory(ctx *gin.Context) {
 	var req createProductCategoryRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.BindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -77,8 +68,7 @@ func (server *Server) getCategory(ctx *gin.Context) {
 }
 
 type listProductCategoriesRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listCategories(ctx *gin.Context) {
@@ -89,9 +79,15 @@ func (server *Server) listCategories(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.ListProductCategoriesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	categories, err := server.store.ListProductCategories(ctx, arg)
 	if err != nil {
@@ -102,7 +98,25 @@ func (server *Server) listCategories(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, categories)
+
+	n, more := page.size(len(categories))
+	categories = categories[:n]
+
+	rsp := listResponse{Items: categories}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: categories[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountProductCategories(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateProductCategoryRequest struct {
@@ -113,13 +127,6 @@ type updateProductCategoryRequest struct {
 func (server *Server) updateCategory(ctx *gin.Context) {
 	var req updateProductCategoryRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -153,13 +160,6 @@ type deleteProductCategoryRequest struct {
 func (server *Server) deleteCategory(ctx *gin.Context) {
 	var req deleteProductCategoryRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.BindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
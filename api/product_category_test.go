@@ -288,8 +288,8 @@ func TestListProductCategoryAPI(t *testing.T) {
 	}
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -301,14 +301,12 @@ func TestListProductCategoryAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListProductCategoriesParams{
-					Limit:  int32(n),
-					Offset: 0,
+					Limit: int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -324,8 +322,7 @@ func TestListProductCategoryAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -339,10 +336,10 @@ func TestListProductCategoryAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -355,10 +352,9 @@ func TestListProductCategoryAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -390,8 +386,10 @@ func TestListProductCategoryAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, request)
@@ -749,7 +747,6 @@ func requireBodyMatchProductCategories(t *testing.T, body *bytes.Buffer, product
 	require.NoError(t, err)
 
 	var gotProductCategories []db.ProductCategory
-	err = json.Unmarshal(data, &gotProductCategories)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotProductCategories)
 	require.Equal(t, productCategories, gotProductCategories)
 }
@@ -2,11 +2,9 @@ package api
 
 import (
 	"database/sql"
-	"errors"
 	"net/http"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
-	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 )
@@ -18,13 +16,6 @@ type createProductInventoryRequest struct {
 func (server *Server) createInventory(ctx *gin.Context) {
 	var req createProductInventoryRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -73,8 +64,7 @@ func (server *Server) getInventory(ctx *gin.Context) {
 }
 
 type listProductInventoriesRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listInventories(ctx *gin.Context) {
@@ -85,9 +75,15 @@ func (server *Server) listInventories(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.ListProductInventoriesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	inventories, err := server.store.ListProductInventories(ctx, arg)
 	if err != nil {
@@ -98,7 +94,25 @@ func (server *Server) listInventories(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, inventories)
+
+	n, more := page.size(len(inventories))
+	inventories = inventories[:n]
+
+	rsp := listResponse{Items: inventories}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: inventories[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountProductInventories(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateProductInventoryRequest struct {
@@ -110,13 +124,6 @@ type updateProductInventoryRequest struct {
 func (server *Server) updateInventory(ctx *gin.Context) {
 	var req updateProductInventoryRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -151,13 +158,6 @@ type deleteProductInventoryRequest struct {
 func (server *Server) deleteInventory(ctx *gin.Context) {
 	var req deleteProductInventoryRequest
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
 	if err := ctx.BindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
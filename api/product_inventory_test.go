@@ -271,8 +271,8 @@ func TestListProductInventoryAPI(t *testing.T) {
 	}
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -284,14 +284,12 @@ func TestListProductInventoryAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListProductInventoriesParams{
-					Limit:  int32(n),
-					Offset: 0,
+					Limit: int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -307,8 +305,7 @@ func TestListProductInventoryAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -322,10 +319,10 @@ func TestListProductInventoryAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -338,10 +335,9 @@ func TestListProductInventoryAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 
 			buildStubs: func(store *mockdb.MockStore) {
@@ -373,8 +369,10 @@ func TestListProductInventoryAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, request)
@@ -736,7 +734,6 @@ func requireBodyMatchProductInventories(t *testing.T, body *bytes.Buffer, produc
 	require.NoError(t, err)
 
 	var gotProductInventories []db.ProductInventory
-	err = json.Unmarshal(data, &gotProductInventories)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotProductInventories)
 	require.Equal(t, productInventories, gotProductInventories)
 }
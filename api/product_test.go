@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 
 func TestGetProductAPI(t *testing.T) {
 	product := randomProduct()
+	matrix := randomProductVariantMatrix(product)
 
 	testCases := []struct {
 		name          string
@@ -37,10 +39,15 @@ func TestGetProductAPI(t *testing.T) {
 					GetProduct(gomock.Any(), gomock.Eq(product.ID)).
 					Times(1).
 					Return(product, nil)
+
+				store.EXPECT().
+					GetProductVariantMatrixTx(gomock.Any(), gomock.Eq(product.ID)).
+					Times(1).
+					Return(matrix, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchProduct(t, recorder.Body, product)
+				requireBodyMatchProductResponse(t, recorder.Body, newProductResponse(product, matrix))
 			},
 		},
 
@@ -70,6 +77,24 @@ func TestGetProductAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:      "VariantsInternalError",
+			productID: product.ID,
+			buildStub: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(product.ID)).
+					Times(1).
+					Return(product, nil)
+
+				store.EXPECT().
+					GetProductVariantMatrixTx(gomock.Any(), gomock.Eq(product.ID)).
+					Times(1).
+					Return(db.ProductVariantMatrix{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name:      "InvalidID",
 			productID: 0,
@@ -282,14 +307,15 @@ func TestCreateProductAPI(t *testing.T) {
 
 func TestListProductsAPI(t *testing.T) {
 	n := 5
-	products := make([]db.Product, n)
+	products := make([]db.FilterProductsRow, n)
 	for i := 0; i < n; i++ {
-		products[i] = randomProduct()
+		products[i] = randomFilterProductsRow()
 	}
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor  string
+		limit   int
+		filters map[string]string
 	}
 
 	testCases := []struct {
@@ -301,17 +327,15 @@ func TestListProductsAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.ListProductsParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.FilterProductsParams{
+					Limit: int32(n) + 1,
 				}
 
 				store.EXPECT().
-					ListProducts(gomock.Any(), gomock.Eq(arg)).
+					FilterProducts(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(products, nil)
 			},
@@ -320,31 +344,298 @@ func TestListProductsAPI(t *testing.T) {
 				requireBodyMatchProducts(t, recorder.Body, products)
 			},
 		},
+		{
+			name: "AllFilters",
+			query: Query{
+				cursor: encodeCursor(pageCursor{ID: products[0].ID, Key: products[0].MaxPrice, Sort: "-price"}),
+				limit:  n,
+				filters: map[string]string{
+					"category_id":  "7",
+					"min_price":    "10.50",
+					"max_price":    "99",
+					"active":       "true",
+					"in_stock":     "true",
+					"has_discount": "true",
+					"sort":         "-price",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.FilterProductsParams{
+					CategoryID:  sql.NullInt64{Int64: 7, Valid: true},
+					MinPrice:    sql.NullString{String: "10.5", Valid: true},
+					MaxPrice:    sql.NullString{String: "99", Valid: true},
+					Active:      sql.NullBool{Bool: true, Valid: true},
+					InStock:     true,
+					HasDiscount: true,
+					Sort:        db.ProductSortPriceDesc,
+					AfterID:     products[0].ID,
+					AfterKey:    products[0].MaxPrice,
+					Limit:       int32(n) + 1,
+				}
+
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchProducts(t, recorder.Body, products)
+			},
+		},
+		{
+			name: "NextPage",
+			query: Query{
+				limit: n - 1,
+				filters: map[string]string{
+					"sort":          "newest",
+					"include_total": "true",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.FilterProductsParams{
+					Sort:  db.ProductSortNewest,
+					Limit: int32(n),
+				}
+
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(products, nil)
+
+				store.EXPECT().
+					CountFilteredProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(int64(12), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotProducts []db.FilterProductsRow
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotProducts)
+				require.Equal(t, products[:n-1], gotProducts)
+				require.Equal(t, int64(12), *rsp.Total)
+
+				last := products[n-2]
+				cursor, err := decodeCursor(rsp.NextCursor)
+				require.NoError(t, err)
+				require.Equal(t, pageCursor{
+					ID:   last.ID,
+					Key:  last.CreatedAt.Format(time.RFC3339Nano),
+					Sort: "newest",
+				}, cursor)
+			},
+		},
+		{
+			name: "NextPageByPrice",
+			query: Query{
+				limit: n - 1,
+				filters: map[string]string{
+					"sort": "price",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.FilterProductsParams{
+					Sort:  db.ProductSortPrice,
+					Limit: int32(n),
+				}
+
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotProducts []db.FilterProductsRow
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotProducts)
+				require.Equal(t, products[:n-1], gotProducts)
+
+				// the page goes on from the lowest price the last product is sold at
+				last := products[n-2]
+				cursor, err := decodeCursor(rsp.NextCursor)
+				require.NoError(t, err)
+				require.Equal(t, pageCursor{
+					ID:   last.ID,
+					Key:  last.MinPrice,
+					Sort: "price",
+				}, cursor)
+			},
+		},
+		{
+			name: "CursorOfAnotherSort",
+			query: Query{
+				cursor: encodeCursor(pageCursor{ID: products[0].ID, Key: products[0].MinPrice, Sort: "price"}),
+				limit:  n,
+				filters: map[string]string{
+					"sort": "name",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errCursorMismatch)
+			},
+		},
+		{
+			name: "InvalidCursorKey",
+			query: Query{
+				cursor: encodeCursor(pageCursor{ID: products[0].ID, Key: "'; DROP TABLE product", Sort: "price"}),
+				limit:  n,
+				filters: map[string]string{
+					"sort": "price",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCursor)
+			},
+		},
+		{
+			name: "InactiveOnly",
+			query: Query{
+				limit: n,
+				filters: map[string]string{
+					"active": "false",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.FilterProductsParams{
+					Active: sql.NullBool{Bool: false, Valid: true},
+					Limit:  int32(n) + 1,
+				}
+
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SortByNewest",
+			query: Query{
+				limit: n,
+				filters: map[string]string{
+					"sort": "newest",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.FilterProductsParams{
+					Sort:  db.ProductSortNewest,
+					Limit: int32(n) + 1,
+				}
+
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
+					FilterProducts(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]db.Product{}, sql.ErrConnDone)
+					Return([]db.FilterProductsRow{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
+			query: Query{
+				cursor: "not-a-cursor",
+				limit:  n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLimit",
+			query: Query{
+				limit: 100000,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCategoryID",
+			query: Query{
+				limit: n,
+				filters: map[string]string{
+					"category_id": "-1",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPrice",
+			query: Query{
+				limit: n,
+				filters: map[string]string{
+					"min_price": "cheap",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativePrice",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				limit: n,
+				filters: map[string]string{
+					"max_price": "-5",
+				},
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
+					FilterProducts(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -352,14 +643,51 @@ func TestListProductsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidPriceRange",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: n,
+				filters: map[string]string{
+					"min_price": "50",
+					"max_price": "10",
+				},
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidActive",
+			query: Query{
+				limit: n,
+				filters: map[string]string{
+					"active": "maybe",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSort",
+			query: Query{
+				limit: n,
+				filters: map[string]string{
+					"sort": "id; DROP TABLE product",
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterProducts(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -386,8 +714,231 @@ func TestListProductsAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
+			for key, value := range tc.query.filters {
+				q.Add(key, value)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSearchProductsAPI(t *testing.T) {
+	product := randomProduct()
+	results := []db.SearchProductsRow{
+		{
+			ID:                 product.ID,
+			Name:               product.Name,
+			Description:        product.Description,
+			Sku:                product.Sku,
+			Price:              product.Price,
+			Rank:               0.6,
+			NameHighlight:      "<b>" + product.Name + "</b>",
+			DescriptionSnippet: product.Description,
+		},
+	}
+
+	type Query struct {
+		q            string
+		cursor       string
+		limit        int
+		includeTotal bool
+	}
+
+	testCases := []struct {
+		name          string
+		query         Query
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: Query{
+				q:      "Red sh",
+				cursor: encodeCursor(pageCursor{ID: 3, Key: "0.75"}),
+				limit:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SearchProductsParams{
+					Query:     "red:* & sh:*",
+					AfterRank: sql.NullFloat64{Float64: 0.75, Valid: true},
+					AfterID:   3,
+					Limit:     6,
+				}
+
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(results, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotResults []db.SearchProductsRow
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotResults)
+				require.Equal(t, results, gotResults)
+				require.Empty(t, rsp.NextCursor)
+			},
+		},
+		{
+			name: "NextPage",
+			query: Query{
+				q:     "shoe",
+				limit: 1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				more := append(results, db.SearchProductsRow{ID: product.ID + 1, Rank: 0.1})
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Eq(db.SearchProductsParams{Query: "shoe:*", Limit: 2})).
+					Times(1).
+					Return(more, nil)
+
+				store.EXPECT().
+					CountSearchProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotResults []db.SearchProductsRow
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotResults)
+				require.Equal(t, results, gotResults)
+				require.Nil(t, rsp.Total)
+
+				// the rank makes it back into the next query as the very same real
+				cursor, err := decodeCursor(rsp.NextCursor)
+				require.NoError(t, err)
+				require.Equal(t, product.ID, cursor.ID)
+				rank, err := strconv.ParseFloat(cursor.Key, 32)
+				require.NoError(t, err)
+				require.Equal(t, results[0].Rank, float32(rank))
+			},
+		},
+		{
+			name: "IncludeTotal",
+			query: Query{
+				q:            "shoe",
+				limit:        5,
+				includeTotal: true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(results, nil)
+
+				store.EXPECT().
+					CountSearchProducts(gomock.Any(), gomock.Eq("shoe:*")).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotResults []db.SearchProductsRow
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotResults)
+				require.Equal(t, int64(1), *rsp.Total)
+			},
+		},
+		{
+			name: "InvalidCursorRank",
+			query: Query{
+				q:      "shoe",
+				cursor: encodeCursor(pageCursor{ID: 3, Key: "high"}),
+				limit:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCursor)
+			},
+		},
+		{
+			name: "NoWords",
+			query: Query{
+				q:     "&|!",
+				limit: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errEmptySearchQuery)
+			},
+		},
+		{
+			name: "MissingQuery",
+			query: Query{
+				limit: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: Query{
+				q:     "shoe",
+				limit: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.SearchProductsRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/products/search"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query parameters to request URL
+			q := request.URL.Query()
+			if tc.query.q != "" {
+				q.Add("q", tc.query.q)
+			}
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
+			if tc.query.includeTotal {
+				q.Add("include_total", "true")
+			}
 			request.URL.RawQuery = q.Encode()
 
 			server.router.ServeHTTP(recorder, request)
@@ -768,6 +1319,23 @@ func randomProduct() db.Product {
 	}
 }
 
+func randomFilterProductsRow() db.FilterProductsRow {
+	product := randomProduct()
+	return db.FilterProductsRow{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Sku:         product.Sku,
+		CategoryID:  product.CategoryID,
+		InventoryID: product.InventoryID,
+		Price:       product.Price,
+		Active:      product.Active,
+		DiscountID:  product.DiscountID,
+		MinPrice:    fmt.Sprint(util.RandomMoney()),
+		MaxPrice:    fmt.Sprint(util.RandomMoney()),
+	}
+}
+
 func requireBodyMatchProduct(t *testing.T, body *bytes.Buffer, product db.Product) {
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
@@ -778,12 +1346,11 @@ func requireBodyMatchProduct(t *testing.T, body *bytes.Buffer, product db.Produc
 	require.Equal(t, product, gotProduct)
 }
 
-func requireBodyMatchProducts(t *testing.T, body *bytes.Buffer, products []db.Product) {
+func requireBodyMatchProducts(t *testing.T, body *bytes.Buffer, products []db.FilterProductsRow) {
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotProducts []db.Product
-	err = json.Unmarshal(data, &gotProducts)
-	require.NoError(t, err)
+	var gotProducts []db.FilterProductsRow
+	unmarshalListResponse(t, data, &gotProducts)
 	require.Equal(t, products, gotProducts)
 }
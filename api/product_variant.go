@@ -0,0 +1,284 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+var errInvalidVariantPrice = errors.New("variant price must be positive")
+
+type productOptionValueResponse struct {
+	ID    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+type productOptionResponse struct {
+	ID     int64                        `json:"id"`
+	Name   string                       `json:"name"`
+	Values []productOptionValueResponse `json:"values"`
+}
+
+func newProductOptionResponse(option db.ProductOptionValues) productOptionResponse {
+	rsp := productOptionResponse{
+		ID:     option.Option.ID,
+		Name:   option.Option.Name,
+		Values: make([]productOptionValueResponse, len(option.Values)),
+	}
+	for i, value := range option.Values {
+		rsp.Values[i] = productOptionValueResponse{ID: value.ID, Value: value.Value}
+	}
+	return rsp
+}
+
+// productVariantResponse is a variant as sold, its price is the one of its
+// product unless the variant overrides it
+type productVariantResponse struct {
+	ID             int64   `json:"id"`
+	Sku            string  `json:"sku"`
+	Price          string  `json:"price"`
+	InventoryID    int64   `json:"inventory_id"`
+	Active         bool    `json:"active"`
+	InStock        bool    `json:"in_stock"`
+	OptionValueIDs []int64 `json:"option_value_ids"`
+}
+
+// productResponse is a product along with its variant matrix: the options it
+// comes in, and the variants made of one value of every option
+type productResponse struct {
+	ID          int64                    `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Sku         string                   `json:"sku"`
+	CategoryID  int64                    `json:"category_id"`
+	InventoryID int64                    `json:"inventory_id"`
+	Price       string                   `json:"price"`
+	Active      bool                     `json:"active"`
+	DiscountID  int64                    `json:"discount_id"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Options     []productOptionResponse  `json:"options"`
+	Variants    []productVariantResponse `json:"variants"`
+}
+
+func newProductResponse(product db.Product, matrix db.ProductVariantMatrix) productResponse {
+	rsp := productResponse{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Sku:         product.Sku,
+		CategoryID:  product.CategoryID,
+		InventoryID: product.InventoryID,
+		Price:       product.Price,
+		Active:      product.Active,
+		DiscountID:  product.DiscountID,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Options:     make([]productOptionResponse, len(matrix.Options)),
+		Variants:    make([]productVariantResponse, len(matrix.Variants)),
+	}
+
+	for i, option := range matrix.Options {
+		rsp.Options[i] = newProductOptionResponse(option)
+	}
+
+	for i, variant := range matrix.Variants {
+		rsp.Variants[i] = productVariantResponse{
+			ID:             variant.Variant.ID,
+			Sku:            variant.Variant.Sku,
+			Price:          db.VariantPrice(product, db.ProductVariant{Price: variant.Variant.Price}),
+			InventoryID:    variant.Variant.InventoryID,
+			Active:         variant.Variant.Active,
+			InStock:        variant.Variant.InStock,
+			OptionValueIDs: variant.OptionValueIDs,
+		}
+	}
+
+	return rsp
+}
+
+// parseVariantPrice reads the price overriding the one of the product, the
+// variant is sold at the price of its product when it is empty
+func parseVariantPrice(price string) (sql.NullString, error) {
+	if price == "" {
+		return sql.NullString{}, nil
+	}
+
+	value, err := decimal.NewFromString(price)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	if !value.IsPositive() {
+		return sql.NullString{}, errInvalidVariantPrice
+	}
+
+	return sql.NullString{String: value.String(), Valid: true}, nil
+}
+
+type productURIRequest struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type createProductOptionRequest struct {
+	Name   string   `json:"name" binding:"required,max=50"`
+	Values []string `json:"values" binding:"required,min=1,dive,required,max=50"`
+}
+
+func (server *Server) createProductOption(ctx *gin.Context) {
+	var uri productURIRequest
+	var req createProductOptionRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateProductOptionTxParams{
+		ProductID: uri.ProductID,
+		Name:      req.Name,
+		Values:    req.Values,
+	}
+
+	option, err := server.store.CreateProductOptionTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		} else if errors.Is(err, db.ErrActiveVariantsWithoutOption) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newProductOptionResponse(option))
+}
+
+type createProductVariantRequest struct {
+	Sku            string  `json:"sku" binding:"required,alphanum"`
+	Price          string  `json:"price" binding:"omitempty,numeric"`
+	Quantity       int32   `json:"quantity" binding:"min=0"`
+	OptionValueIDs []int64 `json:"option_value_ids" binding:"dive,min=1"`
+}
+
+func (server *Server) createProductVariant(ctx *gin.Context) {
+	var uri productURIRequest
+	var req createProductVariantRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	price, err := parseVariantPrice(req.Price)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateProductVariantTxParams{
+		ProductID:      uri.ProductID,
+		Sku:            req.Sku,
+		Price:          price,
+		Quantity:       req.Quantity,
+		OptionValueIDs: req.OptionValueIDs,
+	}
+
+	result, err := server.store.CreateProductVariantTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		} else if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		} else if errors.Is(err, db.ErrInvalidVariantOptions) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		} else if errors.Is(err, db.ErrDuplicateVariant) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type productVariantURIRequest struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+	VariantID int64 `uri:"variant_id" binding:"required,min=1"`
+}
+
+type updateProductVariantRequest struct {
+	// an empty price sells the variant at the price of its product
+	Price  string `json:"price" binding:"omitempty,numeric"`
+	Active *bool  `json:"active" binding:"required"`
+}
+
+func (server *Server) updateProductVariant(ctx *gin.Context) {
+	var uri productVariantURIRequest
+	var req updateProductVariantRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	price, err := parseVariantPrice(req.Price)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateProductVariantParams{
+		ID:        uri.VariantID,
+		ProductID: uri.ProductID,
+		Price:     price,
+		Active:    *req.Active,
+	}
+
+	variant, err := server.store.UpdateProductVariant(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, variant)
+}
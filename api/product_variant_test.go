@@ -0,0 +1,633 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProductOptionAPI(t *testing.T) {
+	admin, _ := randomPSuperAdmin(t)
+	product := randomProduct()
+	option := randomProductOption(product, "size", "S", "M", "L")
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		productID     int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			productID: product.ID,
+			body: gin.H{
+				"name":   "size",
+				"values": []string{"S", "M", "L"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateProductOptionTxParams{
+					ProductID: product.ID,
+					Name:      "size",
+					Values:    []string{"S", "M", "L"},
+				}
+
+				store.EXPECT().
+					CreateProductOptionTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(option, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got productOptionResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, newProductOptionResponse(option), got)
+			},
+		},
+		{
+			name:      "Unauthorized",
+			productID: product.ID,
+			body: gin.H{
+				"name":   "size",
+				"values": []string{"S", "M", "L"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, false, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductOptionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "ProductNotFound",
+			productID: product.ID,
+			body: gin.H{
+				"name":   "size",
+				"values": []string{"S", "M", "L"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductOptionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductOptionValues{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "DuplicateOption",
+			productID: product.ID,
+			body: gin.H{
+				"name":   "size",
+				"values": []string{"S", "M", "L"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductOptionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductOptionValues{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "ActiveVariantsWithoutOption",
+			productID: product.ID,
+			body: gin.H{
+				"name":   "size",
+				"values": []string{"S", "M", "L"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductOptionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductOptionValues{}, db.ErrActiveVariantsWithoutOption)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NoValues",
+			productID: product.ID,
+			body: gin.H{
+				"name":   "size",
+				"values": []string{},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductOptionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/products/%d/options", tc.productID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateProductVariantAPI(t *testing.T) {
+	admin, _ := randomPSuperAdmin(t)
+	product := randomProduct()
+	variant := randomProductVariant(product)
+	variant.Price = sql.NullString{String: "12.5", Valid: true}
+
+	result := db.ProductVariantTxResult{
+		Variant: variant,
+		Inventory: db.ProductInventory{
+			ID:       variant.InventoryID,
+			Quantity: 5,
+			Active:   true,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		productID     int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"price":            "12.50",
+				"quantity":         5,
+				"option_value_ids": []int64{3, 7},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateProductVariantTxParams{
+					ProductID:      product.ID,
+					Sku:            variant.Sku,
+					Price:          sql.NullString{String: "12.5", Valid: true},
+					Quantity:       5,
+					OptionValueIDs: []int64{3, 7},
+				}
+
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.ProductVariantTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, result.Variant, got.Variant)
+				require.Equal(t, result.Inventory, got.Inventory)
+			},
+		},
+		{
+			name:      "ProductPrice",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"quantity":         5,
+				"option_value_ids": []int64{3, 7},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateProductVariantTxParams{
+					ProductID:      product.ID,
+					Sku:            variant.Sku,
+					Quantity:       5,
+					OptionValueIDs: []int64{3, 7},
+				}
+
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "NegativePrice",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"price":            "-1",
+				"quantity":         5,
+				"option_value_ids": []int64{3, 7},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidOptions",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"quantity":         5,
+				"option_value_ids": []int64{3},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductVariantTxResult{}, db.ErrInvalidVariantOptions)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "DuplicateVariant",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"quantity":         5,
+				"option_value_ids": []int64{3, 7},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductVariantTxResult{}, db.ErrDuplicateVariant)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "ProductNotFound",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"quantity":         5,
+				"option_value_ids": []int64{3, 7},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductVariantTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			productID: product.ID,
+			body: gin.H{
+				"sku":              variant.Sku,
+				"quantity":         5,
+				"option_value_ids": []int64{3, 7},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductVariantTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductVariantTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/products/%d/variants", tc.productID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateProductVariantAPI(t *testing.T) {
+	admin, _ := randomPSuperAdmin(t)
+	product := randomProduct()
+	variant := randomProductVariant(product)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		variantID     int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			variantID: variant.ID,
+			body: gin.H{
+				"price":  "20",
+				"active": false,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateProductVariantParams{
+					ID:        variant.ID,
+					ProductID: product.ID,
+					Price:     sql.NullString{String: "20", Valid: true},
+					Active:    false,
+				}
+
+				store.EXPECT().
+					UpdateProductVariant(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(variant, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "ClearPrice",
+			variantID: variant.ID,
+			body: gin.H{
+				"active": true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateProductVariantParams{
+					ID:        variant.ID,
+					ProductID: product.ID,
+					Active:    true,
+				}
+
+				store.EXPECT().
+					UpdateProductVariant(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(variant, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			variantID: variant.ID,
+			body: gin.H{
+				"active": true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductVariant(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ProductVariant{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "MissingActive",
+			variantID: variant.ID,
+			body: gin.H{
+				"price": "20",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductVariant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			variantID: 0,
+			body: gin.H{
+				"active": true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductVariant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/products/%d/variants/%d", product.ID, tc.variantID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomProductVariant(product db.Product) db.ProductVariant {
+	return db.ProductVariant{
+		ID:          util.RandomInt(1, 1000),
+		ProductID:   product.ID,
+		Sku:         util.RandomString(6),
+		InventoryID: util.RandomInt(1, 500),
+		Active:      true,
+	}
+}
+
+func randomProductOption(product db.Product, name string, values ...string) db.ProductOptionValues {
+	option := db.ProductOptionValues{
+		Option: db.ProductOption{
+			ID:        util.RandomInt(1, 1000),
+			ProductID: product.ID,
+			Name:      name,
+		},
+	}
+	for i, value := range values {
+		option.Values = append(option.Values, db.ProductOptionValue{
+			ID:       util.RandomInt(1, 1000),
+			OptionID: option.Option.ID,
+			Value:    value,
+			Position: int32(i),
+		})
+	}
+	return option
+}
+
+// randomProductVariantMatrix returns a product sold in two sizes, one of them
+// at its own price
+func randomProductVariantMatrix(product db.Product) db.ProductVariantMatrix {
+	size := randomProductOption(product, "size", "S", "M")
+
+	matrix := db.ProductVariantMatrix{Options: []db.ProductOptionValues{size}}
+	for i, value := range size.Values {
+		variant := randomProductVariant(product)
+		row := db.ListProductVariantsByProductIDRow{
+			ID:          variant.ID,
+			ProductID:   product.ID,
+			Sku:         variant.Sku,
+			InventoryID: variant.InventoryID,
+			Active:      true,
+			InStock:     i == 0,
+		}
+		if i == 1 {
+			row.Price = sql.NullString{String: "12.50", Valid: true}
+		}
+		matrix.Variants = append(matrix.Variants, db.ProductVariantOptions{
+			Variant:        row,
+			OptionValueIDs: []int64{value.ID},
+		})
+	}
+	return matrix
+}
+
+func requireBodyMatchProductResponse(t *testing.T, body *bytes.Buffer, product productResponse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotProduct productResponse
+	err = json.Unmarshal(data, &gotProduct)
+	require.NoError(t, err)
+	require.Equal(t, product, gotProduct)
+
+	require.Len(t, gotProduct.Variants, 2)
+	require.Equal(t, product.Price, gotProduct.Variants[0].Price)
+	require.Equal(t, "12.50", gotProduct.Variants[1].Price)
+}
@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/mail"
 	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/DarkHeros09/e-shop/v2/util"
 	"github.com/gin-gonic/gin"
@@ -18,23 +20,64 @@ import (
 
 // Server serves HTTP requests for our eshop service.
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
+	config      util.Config
+	store       db.Store
+	tokenMaker  token.Maker
+	mailer      mail.Mailer
+	sessions    *sessionCache
+	permissions *permissionCache
+	apiKeys     *apiKeyCache
+	logins      *loginLimiter
+	// resetEmails and resetIPs count the password reset requests of every
+	// email address and of every client IP
+	resetEmails *loginLimiter
+	resetIPs    *loginLimiter
+	// background tracks the work left running after the response is sent
+	background sync.WaitGroup
+	phoneRules util.PhoneRules
+	cartMerge  db.CartMergeRule
 	router     *gin.Engine
 }
 
 // NewServer creates a new HTTP server and setup routing.
-func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store db.Store, mailer mail.Mailer) (*Server, error) {
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	phoneRules, err := util.NewPhoneRules(config.PhoneDefaultCountryCode, config.PhoneAllowedPrefixes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse phone rules: %w", err)
+	}
+
+	cartMerge, err := db.ParseCartMergeRule(config.CartMergeRule)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cart merge rule: %w", err)
+	}
+
+	if config.DefaultPageSize < 1 || config.DefaultPageSize > config.MaxPageSize {
+		return nil, fmt.Errorf("default page size %d must be between 1 and the max page size %d", config.DefaultPageSize, config.MaxPageSize)
+	}
+
+	err = registerValidators(phoneRules)
+	if err != nil {
+		return nil, fmt.Errorf("cannot register validators: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:      config,
+		store:       store,
+		tokenMaker:  tokenMaker,
+		mailer:      mailer,
+		sessions:    newSessionCache(store, config.SessionCacheDuration),
+		permissions: newPermissionCache(store, config.SessionCacheDuration),
+		apiKeys:     newAPIKeyCache(store, config.SessionCacheDuration),
+		logins:      newLoginLimiter(config.LoginIPMaxAttempts, config.LoginIPWindow),
+		resetEmails: newLoginLimiter(1, config.PasswordResetResendInterval),
+		resetIPs:    newLoginLimiter(config.PasswordResetIPMaxRequests, config.PasswordResetIPWindow),
+		phoneRules:  phoneRules,
+		cartMerge:   cartMerge,
 	}
 
 	server.setupRouter()
@@ -47,15 +90,65 @@ func (server *Server) setupRouter() {
 
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
+	router.POST("/users/login/mfa", server.verifyUserLoginMFA)
+	router.POST("/users/password/forgot", server.forgotPassword)
+	router.POST("/users/password/reset", server.resetPassword)
+	router.GET("/users/verify", server.verifyEmail)
 	router.POST("/tokens/renew_access", server.renewAccessToken)
-
-	userRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, false))
-	adminRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, true))
-
-	userRoutes.GET("/users/:id", server.getUser)        //* Finished With tests (token and changed response... No Etag)
-	adminRoutes.GET("/users", server.listUsers)         //! Admin Only # Finished With tests (token and changed response... No Etag)
-	userRoutes.PUT("/users/:id", server.updateUser)     //* Finished With tests (token and changed response... No Etag)
-	adminRoutes.DELETE("/users/:id", server.deleteUser) //! Admin Only # Finished With tests (token and changed response... No Etag)
+	router.POST("/admins/login", server.loginAdmin)
+	router.POST("/admins/login/mfa", server.verifyAdminLoginMFA)
+	router.POST("/admins/login/mfa/enroll", server.enrollAdminLoginTOTP)
+	router.POST("/admins/tokens/renew_access", server.renewAccessTokenForAdmin)
+	router.GET("/.well-known/jwks.json", server.listTokenKeys)
+
+	userRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.sessions, nil, false))
+	adminRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.sessions, server.apiKeys, true))
+
+	userRoutes.GET("/users/:id", server.getUser)                                                          //* Finished With tests (token and changed response... No Etag)
+	adminRoutes.GET("/users", server.requirePermissions(permissionReadUsers), server.listUsers)           //! Admin Only # Finished With tests (token and changed response... No Etag)
+	userRoutes.PUT("/users/:id", server.updateUser)                                                       //* Finished With tests (token and changed response... No Etag)
+	adminRoutes.DELETE("/users/:id", server.requirePermissions(permissionManageUsers), server.deleteUser) //! Admin Only # Finished With tests (token and changed response... No Etag)
+
+	userRoutes.POST("/users/verify/resend", server.resendVerificationEmail)
+	userRoutes.PUT("/users/me/password", server.changePassword)
+	userRoutes.POST("/users/me/email", server.requestEmailChange)
+	userRoutes.POST("/users/me/email/confirm", server.confirmEmailChange)
+	userRoutes.GET("/users/me/mfa", server.getUserMFA)
+	userRoutes.POST("/users/me/mfa/totp", server.enrollUserTOTP)
+	userRoutes.POST("/users/me/mfa/totp/confirm", server.confirmUserTOTP)
+	userRoutes.DELETE("/users/me/mfa/totp", server.disableUserTOTP)
+	userRoutes.GET("/users/me/export", server.exportUserData)
+	userRoutes.DELETE("/users/me", server.eraseUser)
+	userRoutes.POST("/users/logout", server.logoutUser)
+	userRoutes.GET("/users/sessions", server.listUserSessions)
+	userRoutes.DELETE("/users/sessions/:id", server.revokeUserSession)
+	userRoutes.DELETE("/users/sessions", server.revokeAllUserSessions)
+	adminRoutes.DELETE("/users/:id/sessions", server.requirePermissions(permissionManageUsers), server.blockUserSessions) //! Admin Only
+	adminRoutes.GET("/users/locked", server.requirePermissions(permissionReadUsers), server.listLockedUsers)              //! Admin Only
+	adminRoutes.DELETE("/users/:id/lock", server.requirePermissions(permissionManageUsers), server.unlockUser)            //! Admin Only
+
+	adminRoutes.POST("/admins", server.requirePermissions(permissionManageAdmins), server.createAdmin)       //! Admin Only
+	adminRoutes.GET("/admins/:id", server.requirePermissions(permissionManageAdmins), server.getAdmin)       //! Admin Only
+	adminRoutes.GET("/admins", server.requirePermissions(permissionManageAdmins), server.listAdmins)         //! Admin Only
+	adminRoutes.PUT("/admins/:id", server.requirePermissions(permissionManageAdmins), server.updateAdmin)    //! Admin Only
+	adminRoutes.DELETE("/admins/:id", server.requirePermissions(permissionManageAdmins), server.deleteAdmin) //! Admin Only
+	adminRoutes.GET("/admins/me/mfa", server.requirePermissions(), server.getAdminMFA)                       //! Admin Only
+	adminRoutes.POST("/admins/me/mfa/totp", server.requirePermissions(), server.enrollAdminTOTP)             //! Admin Only
+	adminRoutes.POST("/admins/me/mfa/totp/confirm", server.requirePermissions(), server.confirmAdminTOTP)    //! Admin Only
+	adminRoutes.DELETE("/admins/me/mfa/totp", server.requirePermissions(), server.disableAdminTOTP)          //! Admin Only
+
+	adminRoutes.POST("/api-keys", server.requirePermissions(permissionManageAdmins), server.createAPIKey)       //! Admin Only
+	adminRoutes.GET("/api-keys/:id", server.requirePermissions(permissionManageAdmins), server.getAPIKey)       //! Admin Only
+	adminRoutes.GET("/api-keys", server.requirePermissions(permissionManageAdmins), server.listAPIKeys)         //! Admin Only
+	adminRoutes.DELETE("/api-keys/:id", server.requirePermissions(permissionManageAdmins), server.deleteAPIKey) //! Admin Only
+
+	adminRoutes.POST("/admin-types", server.requirePermissions(permissionManageAdmins), server.createAdminType)                           //! Admin Only
+	adminRoutes.GET("/admin-types/:id", server.requirePermissions(permissionManageAdmins), server.getAdminType)                           //! Admin Only
+	adminRoutes.GET("/admin-types", server.requirePermissions(permissionManageAdmins), server.listAdminTypes)                             //! Admin Only
+	adminRoutes.PUT("/admin-types/:id", server.requirePermissions(permissionManageAdmins), server.updateAdminType)                        //! Admin Only
+	adminRoutes.PUT("/admin-types/:id/permissions", server.requirePermissions(permissionManageAdmins), server.updateAdminTypePermissions) //! Admin Only
+	adminRoutes.PUT("/admin-types/:id/mfa", server.requirePermissions(permissionManageAdmins), server.updateAdminTypeMFAPolicy)           //! Admin Only
+	adminRoutes.DELETE("/admin-types/:id", server.requirePermissions(permissionManageAdmins), server.deleteAdminType)                     //! Admin Only
 
 	userRoutes.POST("/users/addresses", server.createUserAddress)                 //* Finished With tests (token and changed response... No Etag)
 	userRoutes.GET("/users/addresses/:id", server.getUserAddress)                 //* Finished With tests (token and changed response... No Etag)
@@ -69,35 +162,47 @@ func (server *Server) setupRouter() {
 	userRoutes.PUT("/users/payments/:user_id", server.updateUserPayment) //* Finished With tests (token and changed response... No Etag)
 	userRoutes.DELETE("/users/payments/:id", server.deleteUserPayment)   //* Finished With tests (token and changed response... No Etag)
 
-	adminRoutes.POST("/products/categories", server.createCategory)       //! Admin Only # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/categories/:id", server.getCategory)            //? no auth required # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/categories", server.listCategories)             //? no auth required # Finished With tests (token and changed response... No Etag)
-	adminRoutes.PUT("/products/categories/:id", server.updateCategory)    //! Admin Only # Finished With tests (token and changed response... No Etag)
-	adminRoutes.DELETE("/products/categories/:id", server.deleteCategory) //! Admin Only # Finished With tests (token and changed response... No Etag)
-
-	adminRoutes.POST("/products/inventories", server.createInventory)       //! Admin Only # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/inventories/:id", server.getInventory)            //? no auth required # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/inventories", server.listInventories)             //? no auth required # Finished With tests (token and changed response... No Etag)
-	adminRoutes.PUT("/products/inventories/:id", server.updateInventory)    //! Admin Only # Finished With tests (token and changed response... No Etag)
-	adminRoutes.DELETE("/products/inventories/:id", server.deleteInventory) //! Admin Only # Finished With tests (token and changed response... No Etag)
-
-	adminRoutes.POST("/products/discounts", server.createDiscount)       //! Admin Only # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/discounts/:id", server.getDiscount)            //? no auth required # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/discounts", server.listDiscount)               //? no auth required # Finished With tests (token and changed response... No Etag)
-	adminRoutes.PUT("/products/discounts/:id", server.updateDiscount)    //! Admin Only # Finished With tests (token and changed response... No Etag)
-	adminRoutes.DELETE("/products/discounts/:id", server.deleteDiscount) //! Admin Only # Finished With tests (token and changed response... No Etag)
-
-	adminRoutes.POST("/products", server.createProduct)       //! Admin Only # Finished With tests (token and changed response... No Etag)
-	router.GET("/products/:id", server.getProduct)            //? no auth required # Finished With tests (token and changed response... No Etag)
-	router.GET("/products", server.listProducts)              //? no auth required # Finished With tests (token and changed response.)
-	adminRoutes.PUT("/products/:id", server.updateProduct)    //! Admin Only # Finished With tests (token and changed response... No Etag)
-	adminRoutes.DELETE("/products/:id", server.deleteProduct) //! Admin Only # Finished With tests (token and changed response... No Etag)
+	adminRoutes.POST("/products/categories", server.requirePermissions(permissionManageCatalog), server.createCategory)       //! Admin Only # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/categories/:id", server.getCategory)                                                                //? no auth required # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/categories", server.listCategories)                                                                 //? no auth required # Finished With tests (token and changed response... No Etag)
+	adminRoutes.PUT("/products/categories/:id", server.requirePermissions(permissionManageCatalog), server.updateCategory)    //! Admin Only # Finished With tests (token and changed response... No Etag)
+	adminRoutes.DELETE("/products/categories/:id", server.requirePermissions(permissionManageCatalog), server.deleteCategory) //! Admin Only # Finished With tests (token and changed response... No Etag)
+
+	adminRoutes.POST("/products/inventories", server.requirePermissions(permissionManageCatalog), server.createInventory)       //! Admin Only # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/inventories/:id", server.getInventory)                                                                //? no auth required # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/inventories", server.listInventories)                                                                 //? no auth required # Finished With tests (token and changed response... No Etag)
+	adminRoutes.PUT("/products/inventories/:id", server.requirePermissions(permissionManageCatalog), server.updateInventory)    //! Admin Only # Finished With tests (token and changed response... No Etag)
+	adminRoutes.DELETE("/products/inventories/:id", server.requirePermissions(permissionManageCatalog), server.deleteInventory) //! Admin Only # Finished With tests (token and changed response... No Etag)
+
+	adminRoutes.POST("/products/discounts", server.requirePermissions(permissionManageCatalog), server.createDiscount)       //! Admin Only # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/discounts/:id", server.getDiscount)                                                                //? no auth required # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/discounts", server.listDiscount)                                                                   //? no auth required # Finished With tests (token and changed response... No Etag)
+	adminRoutes.PUT("/products/discounts/:id", server.requirePermissions(permissionManageCatalog), server.updateDiscount)    //! Admin Only # Finished With tests (token and changed response... No Etag)
+	adminRoutes.DELETE("/products/discounts/:id", server.requirePermissions(permissionManageCatalog), server.deleteDiscount) //! Admin Only # Finished With tests (token and changed response... No Etag)
+
+	adminRoutes.POST("/products", server.requirePermissions(permissionManageCatalog), server.createProduct)       //! Admin Only # Finished With tests (token and changed response... No Etag)
+	router.GET("/products/search", server.searchProducts)                                                         //? no auth required
+	router.GET("/products/:id", server.getProduct)                                                                //? no auth required # Finished With tests (token and changed response... No Etag)
+	router.GET("/products", server.listProducts)                                                                  //? no auth required # Finished With tests (token and changed response.)
+	adminRoutes.PUT("/products/:id", server.requirePermissions(permissionManageCatalog), server.updateProduct)    //! Admin Only # Finished With tests (token and changed response... No Etag)
+	adminRoutes.DELETE("/products/:id", server.requirePermissions(permissionManageCatalog), server.deleteProduct) //! Admin Only # Finished With tests (token and changed response... No Etag)
+	adminRoutes.POST("/products/:id/options", server.requirePermissions(permissionManageCatalog), server.createProductOption)
+	adminRoutes.POST("/products/:id/variants", server.requirePermissions(permissionManageCatalog), server.createProductVariant)
+	adminRoutes.PUT("/products/:id/variants/:variant_id", server.requirePermissions(permissionManageCatalog), server.updateProductVariant)
 
 	userRoutes.POST("/shopping-sessions", server.createShoppingSession) //* Finished With tests (token and changed response... No Etag)
 	userRoutes.GET("/shopping-sessions/:id", server.getShoppingSession) //* Finished With tests (token and changed response... No Etag)
+	userRoutes.POST("/shopping-sessions/:id/reserve", server.requireVerifiedEmail(), server.reserveStock)
+	userRoutes.POST("/shopping-sessions/:id/checkout", server.requireVerifiedEmail(), server.checkout)
+
+	router.POST("/guest-carts", server.createGuestCart)                 //? no auth required, identified by the cart token
+	router.GET("/guest-carts", server.getGuestCart)                     //? no auth required, identified by the cart token
+	router.POST("/guest-carts/items", server.createGuestCartItem)       //? no auth required, identified by the cart token
+	router.PUT("/guest-carts/items/:id", server.updateGuestCartItem)    //? no auth required, identified by the cart token
+	router.DELETE("/guest-carts/items/:id", server.deleteGuestCartItem) //? no auth required, identified by the cart token
 
 	userRoutes.POST("/cart-items", server.createCartItem)                       //* Finished With tests (token and changed response... No Etag)
-	userRoutes.GET("/cart-items/:session_id", server.getCartItemBySessionID)    //* Finished With tests (token and changed response... No Etag)
+	userRoutes.GET("/cart-items/:session_id", server.listCartItemsBySessionID)  //* Finished With tests (token and changed response... No Etag)
 	userRoutes.PUT("/cart-items/:session_id", server.updateCartItemBySessionID) //* Finished With tests (token and changed response... No Etag)
 	userRoutes.DELETE("/cart-items/:id", server.deleteCartItemBySessionID)      //* Finished With tests (token and changed response... No Etag)
 
@@ -109,6 +214,9 @@ func (server *Server) setupRouter() {
 	userRoutes.GET("/order-details/:id", server.getOrderDetail) //* Finished With tests (token and changed response... No Etag)
 	userRoutes.GET("/order-details", server.listOrderDetails)   //* Finished With tests (token and changed response... No Etag)
 
+	adminRoutes.GET("/orders/:id", server.requirePermissions(permissionManageOrders), server.getOrder) //! Admin Only
+	adminRoutes.GET("/orders", server.requirePermissions(permissionManageOrders), server.listOrders)   //! Admin Only
+
 	userRoutes.GET("/payment-details/:id", server.getPaymentDetail)    //* Finished With tests (token and changed response... No Etag)
 	userRoutes.GET("/payment-details", server.listPaymentDetails)      //* Finished With tests (token and changed response... No Etag)
 	userRoutes.PUT("/payment-details/:id", server.updatePaymentDetail) //* Finished With tests (token and changed response... No Etag)
@@ -126,6 +234,18 @@ func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
 
+// runInBackground runs fn after the response, the error it returns is only logged
+func (server *Server) runInBackground(name string, fn func() error) {
+	server.background.Add(1)
+	go func() {
+		defer server.background.Done()
+
+		if err := fn(); err != nil {
+			log.Printf("%s: %v", name, err)
+		}
+	}()
+}
+
 func (server *Server) gracefullShutDown(router *gin.Engine) {
 	srv := &http.Server{
 		Addr:    server.config.ServerAddress,
@@ -150,13 +270,14 @@ func (server *Server) gracefullShutDown(router *gin.Engine) {
 		log.Fatal("Server forced to shutdown: ", err)
 	}
 
+	// the emails still being sent are not dropped
+	server.background.Wait()
+
 	log.Println("Server exiting")
 }
 
 // DONE: write the default tests for all the methods
 
-// TODO: seperate superadmin from normal admin
-
 // TODO: implement symmetrickey in .env file
 
 // TODO: make auth checks before calling db
@@ -169,9 +290,9 @@ func (server *Server) gracefullShutDown(router *gin.Engine) {
 
 // TODO: add caching logic with tests, try groupcache
 
-// TODO: fix user phone number tag, with regexp along with tests
+// DONE: fix user phone number tag, with regexp along with tests
 
-// TODO: change for phoneNumber to string in DB?
+// DONE: change for phoneNumber to string in DB?
 
 // DONE: add refresh token
 
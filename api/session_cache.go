@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/google/uuid"
+)
+
+// maxCachedSessions bounds the memory used by the session cache
+const maxCachedSessions = 10000
+
+var errRevokedToken = errors.New("token has been revoked")
+
+type cachedSession struct {
+	admin     bool
+	ownerID   int64
+	expiresAt time.Time
+}
+
+// sessionCache checks that the session an access token is bound to is still
+// usable, and remembers the sessions found valid for a short while so that
+// authMiddleware doesn't query the database on every request. Revocations
+// made by this server are applied to the cache right away, revocations made
+// elsewhere are picked up once the cached entry expires.
+// A zero ttl disables the cache.
+type sessionCache struct {
+	store    db.Store
+	ttl      time.Duration
+	mu       sync.Mutex
+	sessions map[uuid.UUID]cachedSession
+}
+
+func newSessionCache(store db.Store, ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		store:    store,
+		ttl:      ttl,
+		sessions: make(map[uuid.UUID]cachedSession),
+	}
+}
+
+// checkUser returns errRevokedToken when the session of the user token was
+// blocked or deleted, for instance along with the user.
+func (cache *sessionCache) checkUser(ctx context.Context, payload *token.UserPayload) error {
+	if cache.valid(payload.SessionID, false, payload.UserID) {
+		return nil
+	}
+
+	isBlocked, err := cache.store.CheckUserSession(ctx, db.CheckUserSessionParams{
+		ID:     payload.SessionID,
+		UserID: payload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errRevokedToken
+		}
+		return err
+	}
+
+	if isBlocked {
+		return errRevokedToken
+	}
+
+	cache.add(payload.SessionID, false, payload.UserID)
+	return nil
+}
+
+// checkAdmin returns errRevokedToken when the session of the admin token was
+// blocked or deleted, or when the admin has been deactivated since.
+func (cache *sessionCache) checkAdmin(ctx context.Context, payload *token.AdminPayload) error {
+	if cache.valid(payload.SessionID, true, payload.AdminID) {
+		return nil
+	}
+
+	adminSession, err := cache.store.CheckAdminSession(ctx, db.CheckAdminSessionParams{
+		ID:      payload.SessionID,
+		AdminID: payload.AdminID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errRevokedToken
+		}
+		return err
+	}
+
+	if adminSession.IsBlocked || !adminSession.Active {
+		return errRevokedToken
+	}
+
+	cache.add(payload.SessionID, true, payload.AdminID)
+	return nil
+}
+
+func (cache *sessionCache) valid(sessionID uuid.UUID, admin bool, ownerID int64) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	session, ok := cache.sessions[sessionID]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(session.expiresAt) {
+		delete(cache.sessions, sessionID)
+		return false
+	}
+
+	return session.admin == admin && session.ownerID == ownerID
+}
+
+func (cache *sessionCache) add(sessionID uuid.UUID, admin bool, ownerID int64) {
+	if cache.ttl <= 0 {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	if len(cache.sessions) >= maxCachedSessions {
+		for id, session := range cache.sessions {
+			if now.After(session.expiresAt) {
+				delete(cache.sessions, id)
+			}
+		}
+	}
+	if len(cache.sessions) >= maxCachedSessions {
+		cache.sessions = make(map[uuid.UUID]cachedSession)
+	}
+
+	cache.sessions[sessionID] = cachedSession{
+		admin:     admin,
+		ownerID:   ownerID,
+		expiresAt: now.Add(cache.ttl),
+	}
+}
+
+// forgetSession drops a session that has just been revoked
+func (cache *sessionCache) forgetSession(sessionID uuid.UUID) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	delete(cache.sessions, sessionID)
+}
+
+// forgetUser drops every session of a user whose sessions have just been revoked
+func (cache *sessionCache) forgetUser(userID int64) {
+	cache.forgetOwner(false, userID)
+}
+
+// forgetAdmin drops every session of an admin whose access has just been changed
+func (cache *sessionCache) forgetAdmin(adminID int64) {
+	cache.forgetOwner(true, adminID)
+}
+
+func (cache *sessionCache) forgetOwner(admin bool, ownerID int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for id, session := range cache.sessions {
+		if session.admin == admin && session.ownerID == ownerID {
+			delete(cache.sessions, id)
+		}
+	}
+}
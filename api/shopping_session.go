@@ -4,16 +4,41 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
 	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
+type shoppingSessionResponse struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id,omitempty"`
+	Total     string    `json:"total"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newShoppingSessionResponse(shoppingSession db.ShoppingSession) shoppingSessionResponse {
+	return shoppingSessionResponse{
+		ID:        shoppingSession.ID,
+		UserID:    shoppingSession.UserID.Int64,
+		Total:     shoppingSession.Total,
+		CreatedAt: shoppingSession.CreatedAt,
+		UpdatedAt: shoppingSession.UpdatedAt,
+	}
+}
+
+// ownsShoppingSession tells whether the shopping session belongs to the user,
+// the ones of guests belong to nobody
+func ownsShoppingSession(shoppingSession db.ShoppingSession, userID int64) bool {
+	return shoppingSession.UserID.Valid && shoppingSession.UserID.Int64 == userID
+}
+
 type createShoppingSessionRequest struct {
-	UserID int64  `json:"user_id" binding:"required,min=1"`
-	Total  string `json:"total" binding:"required,min=0"`
+	UserID int64 `json:"user_id" binding:"required,min=1"`
 }
 
 func (server *Server) createShoppingSession(ctx *gin.Context) {
@@ -27,7 +52,7 @@ func (server *Server) createShoppingSession(ctx *gin.Context) {
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
 	arg := db.CreateShoppingSessionParams{
 		UserID: authPayload.UserID,
-		Total:  req.Total,
+		Total:  db.FormatTotal(decimal.Zero),
 	}
 
 	shoppingSession, err := server.store.CreateShoppingSession(ctx, arg)
@@ -43,7 +68,7 @@ func (server *Server) createShoppingSession(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, shoppingSession)
+	ctx.JSON(http.StatusOK, newShoppingSessionResponse(shoppingSession))
 }
 
 type getShoppingSessionRequest struct {
@@ -69,10 +94,10 @@ func (server *Server) getShoppingSession(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
-	if shoppingSession.UserID != authPayload.UserID {
+	if !ownsShoppingSession(shoppingSession, authPayload.UserID) {
 		err := errors.New("account deosn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, shoppingSession)
+	ctx.JSON(http.StatusOK, newShoppingSessionResponse(shoppingSession))
 }
@@ -23,6 +23,7 @@ import (
 func TestCreateShoppingSessionAPI(t *testing.T) {
 	user, _ := randomSSUser(t)
 	shoppingSession := createRandomShoppingSession(t, user)
+	shoppingSession.Total = "0.00"
 
 	testCases := []struct {
 		name          string
@@ -34,15 +35,15 @@ func TestCreateShoppingSessionAPI(t *testing.T) {
 		{
 			name: "OK",
 			body: gin.H{
-				"user_id": shoppingSession.UserID,
-				"total":   shoppingSession.Total,
+				"user_id": shoppingSession.UserID.Int64,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.CreateShoppingSessionParams{
-					UserID: shoppingSession.UserID,
+					UserID: shoppingSession.UserID.Int64,
 					Total:  shoppingSession.Total,
 				}
 
@@ -59,8 +60,8 @@ func TestCreateShoppingSessionAPI(t *testing.T) {
 		{
 			name: "NoAuthorization",
 			body: gin.H{
-				"user_id": shoppingSession.UserID,
-				"total":   shoppingSession.Total,
+				"user_id": shoppingSession.UserID.Int64,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
@@ -76,15 +77,15 @@ func TestCreateShoppingSessionAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			body: gin.H{
-				"user_id": shoppingSession.UserID,
-				"total":   shoppingSession.Total,
+				"user_id": shoppingSession.UserID.Int64,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.CreateShoppingSessionParams{
-					UserID: shoppingSession.UserID,
+					UserID: shoppingSession.UserID.Int64,
 					Total:  shoppingSession.Total,
 				}
 
@@ -102,7 +103,7 @@ func TestCreateShoppingSessionAPI(t *testing.T) {
 			name: "InvalidUserID",
 			body: gin.H{
 				"user_id": 0,
-				"total":   shoppingSession.Total,
+				"total":   "999.99",
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, 0, user.Username, time.Minute)
@@ -290,7 +291,7 @@ func randomSSUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(7, 7)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -299,7 +300,7 @@ func randomSSUser(t *testing.T) (user db.User, password string) {
 func createRandomShoppingSession(t *testing.T, user db.User) (shoppingSession db.ShoppingSession) {
 	shoppingSession = db.ShoppingSession{
 		ID:        util.RandomInt(1, 10),
-		UserID:    user.ID,
+		UserID:    sql.NullInt64{Int64: user.ID, Valid: true},
 		Total:     fmt.Sprint(util.RandomMoney()),
 		CreatedAt: time.Time{},
 		UpdatedAt: time.Time{},
@@ -311,10 +312,11 @@ func requireBodyMatchShoppingSession(t *testing.T, body *bytes.Buffer, shoppingS
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotShoppingSession db.ShoppingSession
+	var gotShoppingSession shoppingSessionResponse
 	err = json.Unmarshal(data, &gotShoppingSession)
 
 	require.NoError(t, err)
-	require.Equal(t, shoppingSession.UserID, gotShoppingSession.UserID)
+	require.Equal(t, shoppingSession.ID, gotShoppingSession.ID)
+	require.Equal(t, shoppingSession.UserID.Int64, gotShoppingSession.UserID)
 	require.Equal(t, shoppingSession.Total, gotShoppingSession.Total)
 }
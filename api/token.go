@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"time"
 
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type renewAccessTokenRequest struct {
@@ -18,6 +21,17 @@ type renewAccessTokenResponse struct {
 	AccessTokenExpiresAt time.Time `json:"accesss_token_expires_at"`
 }
 
+type renewUserTokensResponse struct {
+	UserSessionID         uuid.UUID `json:"user_session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"accesss_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refreshs_token_expires_at"`
+}
+
+// renewAccessToken exchanges a refresh token for a new access token and a new
+// refresh token. The old refresh token is rotated out and can't be used again:
+// presenting it a second time blocks every session of its family.
 func (server *Server) renewAccessToken(ctx *gin.Context) {
 	var req renewAccessTokenRequest
 
@@ -60,15 +74,144 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	if userSession.RotatedAt.Valid {
+		server.blockUserSessionFamily(ctx, userSession)
+		return
+	}
+
 	if time.Now().After(userSession.ExpiresAt) {
 		err := fmt.Errorf("expired session")
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateTokenForUser(
+		refreshPayload.UserID,
+		refreshPayload.Username,
+		uuid.Nil,
+		server.config.RefreshTokenDuration,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateTokenForUser(
 		refreshPayload.UserID,
 		refreshPayload.Username,
+		newRefreshPayload.ID,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.RotateUserSessionTxParams{
+		SessionID: userSession.ID,
+		NewSession: db.CreateUserSessionParams{
+			ID:           newRefreshPayload.ID,
+			RefreshToken: refreshToken,
+			UserAgent:    ctx.Request.UserAgent(),
+			ClientIp:     ctx.ClientIP(),
+			ExpiresAt:    newRefreshPayload.ExpiredAt,
+		},
+	}
+
+	newSession, err := server.store.RotateUserSessionTx(ctx, arg)
+	if err != nil {
+		if err == db.ErrRefreshTokenReused {
+			server.blockUserSessionFamily(ctx, userSession)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := renewUserTokensResponse{
+		UserSessionID:         newSession.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: newRefreshPayload.ExpiredAt,
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// blockUserSessionFamily is called when a rotated refresh token is presented
+// again, meaning it has leaked. Every session created from the same login is
+// blocked, so neither the thief nor the user can keep renewing tokens with it.
+func (server *Server) blockUserSessionFamily(ctx *gin.Context, userSession db.UserSession) {
+	arg := db.BlockUserSessionFamilyParams{
+		FamilyID: userSession.FamilyID,
+		UserID:   userSession.UserID,
+	}
+
+	err := server.store.BlockUserSessionFamily(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(userSession.UserID)
+
+	ctx.JSON(http.StatusUnauthorized, errorResponse(db.ErrRefreshTokenReused))
+}
+
+func (server *Server) renewAccessTokenForAdmin(ctx *gin.Context) {
+	var req renewAccessTokenRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyTokenForAdmin(req.RefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	adminSession, err := server.store.GetAdminSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if adminSession.IsBlocked {
+		err := fmt.Errorf("blocked session")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if adminSession.AdminID != refreshPayload.AdminID {
+		err := fmt.Errorf("incorrect session admin")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if adminSession.RefreshToken != req.RefreshToken {
+		err := fmt.Errorf("mismatched session token")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if time.Now().After(adminSession.ExpiresAt) {
+		err := fmt.Errorf("expired session")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateTokenForAdmin(
+		refreshPayload.AdminID,
+		refreshPayload.Username,
+		refreshPayload.TypeID,
+		refreshPayload.Active,
+		adminSession.ID,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -82,3 +225,19 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, rsp)
 }
+
+type listTokenKeysResponse struct {
+	Keys []token.JWK `json:"keys"`
+}
+
+// listTokenKeys publishes the public keys the tokens can be verified with as a
+// JSON Web Key Set. While keys are rotated it lists the retiring ones as well.
+func (server *Server) listTokenKeys(ctx *gin.Context) {
+	rsp := listTokenKeysResponse{
+		Keys: []token.JWK{},
+	}
+	for _, key := range server.tokenMaker.PublicKeys() {
+		rsp.Keys = append(rsp.Keys, key.JWK())
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,363 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/DarkHeros09/e-shop/v2/db/mock"
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUSUser(t)
+	familyID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		refreshToken  func(refreshToken string) string
+		setupSession  func(userSession *db.UserSession)
+		buildStubs    func(store *mockdb.MockStore, userSession db.UserSession)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(userSession, nil)
+
+				store.EXPECT().
+					RotateUserSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RotateUserSessionTxParams) (db.UserSession, error) {
+						require.Equal(t, userSession.ID, arg.SessionID)
+						require.NotEqual(t, userSession.ID, arg.NewSession.ID)
+						require.NotEqual(t, userSession.RefreshToken, arg.NewSession.RefreshToken)
+
+						return db.UserSession{
+							ID:           arg.NewSession.ID,
+							UserID:       userSession.UserID,
+							RefreshToken: arg.NewSession.RefreshToken,
+							ExpiresAt:    arg.NewSession.ExpiresAt,
+							FamilyID:     userSession.FamilyID,
+						}, nil
+					})
+
+				store.EXPECT().
+					BlockUserSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchRenewedTokens(t, recorder.Body, tokenMaker, userSession)
+			},
+		},
+		{
+			name: "ReusedRefreshToken",
+			setupSession: func(userSession *db.UserSession) {
+				userSession.RotatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			},
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(userSession, nil)
+
+				store.EXPECT().
+					RotateUserSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				arg := db.BlockUserSessionFamilyParams{
+					FamilyID: userSession.FamilyID,
+					UserID:   userSession.UserID,
+				}
+
+				store.EXPECT().
+					BlockUserSessionFamily(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ConcurrentlyReusedRefreshToken",
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(userSession, nil)
+
+				store.EXPECT().
+					RotateUserSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, db.ErrRefreshTokenReused)
+
+				arg := db.BlockUserSessionFamilyParams{
+					FamilyID: userSession.FamilyID,
+					UserID:   userSession.UserID,
+				}
+
+				store.EXPECT().
+					BlockUserSessionFamily(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "BlockFamilyInternalError",
+			setupSession: func(userSession *db.UserSession) {
+				userSession.RotatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			},
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(userSession, nil)
+
+				store.EXPECT().
+					BlockUserSessionFamily(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "BlockedSession",
+			setupSession: func(userSession *db.UserSession) {
+				userSession.IsBlocked = true
+			},
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(userSession, nil)
+
+				store.EXPECT().
+					RotateUserSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(db.UserSession{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					RotateUserSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "RotateInternalError",
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Eq(userSession.ID)).
+					Times(1).
+					Return(userSession, nil)
+
+				store.EXPECT().
+					RotateUserSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, sql.ErrConnDone)
+
+				store.EXPECT().
+					BlockUserSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRefreshToken",
+			refreshToken: func(refreshToken string) string {
+				return "invalid-token"
+			},
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "MissingRefreshToken",
+			refreshToken: func(refreshToken string) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore, userSession db.UserSession) {
+				store.EXPECT().
+					GetUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker, userSession db.UserSession) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			refreshToken, refreshPayload, err := server.tokenMaker.CreateTokenForUser(user.ID, user.Username, uuid.Nil, time.Hour)
+			require.NoError(t, err)
+
+			userSession := db.UserSession{
+				ID:           refreshPayload.ID,
+				UserID:       user.ID,
+				RefreshToken: refreshToken,
+				ExpiresAt:    refreshPayload.ExpiredAt,
+				FamilyID:     familyID,
+			}
+			if tc.setupSession != nil {
+				tc.setupSession(&userSession)
+			}
+			tc.buildStubs(store, userSession)
+
+			if tc.refreshToken != nil {
+				refreshToken = tc.refreshToken(refreshToken)
+			}
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(map[string]string{"refresh_token": refreshToken})
+			require.NoError(t, err)
+
+			url := "/tokens/renew_access"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, server.tokenMaker, userSession)
+		})
+	}
+}
+
+func requireBodyMatchRenewedTokens(t *testing.T, body *bytes.Buffer, tokenMaker token.Maker, userSession db.UserSession) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotTokens renewUserTokensResponse
+	err = json.Unmarshal(data, &gotTokens)
+	require.NoError(t, err)
+
+	require.NotEqual(t, userSession.ID, gotTokens.UserSessionID)
+	require.NotEqual(t, userSession.RefreshToken, gotTokens.RefreshToken)
+
+	refreshPayload, err := tokenMaker.VerifyTokenForUser(gotTokens.RefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, gotTokens.UserSessionID, refreshPayload.ID)
+	require.Equal(t, userSession.UserID, refreshPayload.UserID)
+
+	accessPayload, err := tokenMaker.VerifyTokenForUser(gotTokens.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, userSession.UserID, accessPayload.UserID)
+}
+
+func TestListTokenKeysAPI(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+
+	keys, err := token.NewKeySet("key-1", base64.StdEncoding.EncodeToString(seed), "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		tokenMaker    token.Maker
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "PublicKeys",
+			tokenMaker: token.NewJWTMaker(keys),
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTokenKeys(t, recorder.Body, keys.PublicKeys())
+			},
+		},
+		{
+			name: "SymmetricKey",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTokenKeys(t, recorder.Body, nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+
+			server := newTestServer(t, store)
+			if tc.tokenMaker != nil {
+				server.tokenMaker = tc.tokenMaker
+			}
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func requireBodyMatchTokenKeys(t *testing.T, body *bytes.Buffer, publicKeys []token.PublicKey) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotKeys listTokenKeysResponse
+	err = json.Unmarshal(data, &gotKeys)
+	require.NoError(t, err)
+	require.NotNil(t, gotKeys.Keys)
+	require.Len(t, gotKeys.Keys, len(publicKeys))
+
+	for i, publicKey := range publicKeys {
+		require.Equal(t, publicKey.JWK(), gotKeys.Keys[i])
+	}
+}
@@ -18,25 +18,34 @@ type createUserRequest struct {
 	Username  string `json:"username" binding:"required,alphanum"`
 	Email     string `json:"email" binding:"required,email"`
 	Password  string `json:"password" binding:"required,min=6"`
-	Telephone int32  `json:"telephone" binding:"required,numeric,min=910000000,max=929999999"`
+	Telephone string `json:"telephone" binding:"required,phone"`
+	// CartToken is the token of the guest cart to hand over to the new user
+	CartToken string `json:"cart_token"`
 }
 
 type userResponse struct {
-	ID        int64  `json:"id"`
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	Telephone int32  `json:"telephone"`
+	ID            int64  `json:"id"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Telephone     string `json:"telephone"`
 }
 
 func newUserResponse(user db.User) userResponse {
 	return userResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Telephone: user.Telephone,
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.VerifiedAt.Valid,
+		Telephone:     user.Telephone,
 	}
 }
 
+type createUserResponse struct {
+	userResponse
+	ShoppingSessionID int64 `json:"shopping_session_id,omitempty"`
+}
+
 func (server *Server) createUser(ctx *gin.Context) {
 	var req createUserRequest
 
@@ -45,6 +54,12 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
+	telephone, err := server.phoneRules.Normalize(req.Telephone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	hashedPassword, err := util.HashPassword(req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -55,7 +70,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		Username:  req.Username,
 		Email:     req.Email,
 		Password:  hashedPassword,
-		Telephone: req.Telephone,
+		Telephone: telephone,
 	}
 
 	user, err := server.store.CreateUser(ctx, arg)
@@ -71,7 +86,20 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	rsp := newUserResponse(user)
+	// the account is created anyway, the user can ask for another email
+	if err := server.sendVerificationEmail(ctx, user); err != nil {
+		_ = ctx.Error(err)
+	}
+
+	rsp := createUserResponse{userResponse: newUserResponse(user)}
+	// the account is created anyway, the guest cart is just lost
+	if req.CartToken != "" {
+		shoppingSession, err := server.mergeGuestCart(ctx, user.ID, req.CartToken)
+		if err != nil {
+			_ = ctx.Error(err)
+		}
+		rsp.ShoppingSessionID = shoppingSession.ID
+	}
 	ctx.JSON(http.StatusOK, rsp)
 }
 
@@ -108,8 +136,7 @@ func (server *Server) getUser(ctx *gin.Context) {
 }
 
 type listUsersRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listUsers(ctx *gin.Context) {
@@ -120,16 +147,15 @@ func (server *Server) listUsers(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.ListUsersParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
+	arg := db.ListUsersParams{
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 
 	users, err := server.store.ListUsers(ctx, arg)
@@ -141,13 +167,33 @@ func (server *Server) listUsers(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, users)
 
+	n, more := page.size(len(users))
+	items := make([]userResponse, 0, n)
+	for _, user := range users[:n] {
+		items = append(items, newUserResponse(user))
+	}
+
+	rsp := listResponse{Items: items}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: users[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountUsers(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateUserRequest struct {
-	ID        int64 `json:"id" binding:"required,min=1"`
-	Telephone int32 `json:"telephone" binding:"required,numeric,min=910000000,max=929999999"`
+	ID        int64  `json:"id" binding:"required,min=1"`
+	Telephone string `json:"telephone" binding:"required,phone"`
 }
 
 func (server *Server) updateUser(ctx *gin.Context) {
@@ -158,9 +204,15 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		return
 	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	telephone, err := server.phoneRules.Normalize(req.Telephone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.UpdateUserParams{
 		ID:        authPayload.UserID,
-		Telephone: req.Telephone,
+		Telephone: telephone,
 	}
 
 	user, err := server.store.UpdateUser(ctx, arg)
@@ -184,29 +236,19 @@ type deleteUserRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+// deleteUser erases the personal data of a user, their orders are kept for
+// accounting under an anonymized account.
 func (server *Server) deleteUser(ctx *gin.Context) {
 	var req deleteUserRequest
 
-	if err := ctx.BindJSON(&req); err != nil {
+	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.AdminPayload)
-	if authPayload.AdminID == 0 || authPayload.TypeID != 1 || !authPayload.Active {
-		err := errors.New("account unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-	err := server.store.DeleteUser(ctx, req.ID)
+	_, err := server.store.EraseUserTx(ctx, req.ID)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "foreign_key_violation", "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		} else if err == sql.ErrNoRows {
+		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
@@ -214,14 +256,23 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 		return
 	}
 
+	server.sessions.forgetUser(req.ID)
+
 	ctx.JSON(http.StatusOK, gin.H{})
 }
 
 type loginUserRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	// CartToken is the token of the guest cart to merge into the one of the user
+	CartToken string `json:"cart_token"`
 }
 
+var (
+	errInvalidCredentials   = errors.New("invalid email or password")
+	errTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+)
+
 type loginUserResponse struct {
 	UseSessionID          uuid.UUID    `json:"user_session_id"`
 	AccessToken           string       `json:"access_token"`
@@ -229,6 +280,7 @@ type loginUserResponse struct {
 	RefreshToken          string       `json:"refresh_token"`
 	RefreshTokenExpiresAt time.Time    `json:"refreshs_token_expires_at"`
 	User                  userResponse `json:"user"`
+	ShoppingSessionID     int64        `json:"shopping_session_id,omitempty"`
 }
 
 func (server *Server) loginUser(ctx *gin.Context) {
@@ -239,10 +291,23 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	if !server.allowLogin(ctx) {
+		return
+	}
+	clientIP := ctx.ClientIP()
+
 	user, err := server.store.GetUserByEmail(ctx, req.Email)
+	// an erased account is only kept for its orders, it can't be logged into
+	if err == nil && user.ErasedAt.Valid {
+		err = sql.ErrNoRows
+	}
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			// spend as long as for a wrong password, so that the response
+			// time doesn't tell which emails are registered either
+			_ = util.CheckPassword(req.Password, getDummyPasswordHash())
+			server.logins.fail(clientIP)
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -250,29 +315,74 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	err = util.CheckPassword(req.Password, user.Password)
+	if user.LockedUntil.Valid && time.Now().Before(user.LockedUntil.Time) {
+		server.logins.fail(clientIP)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return
+	}
+
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		server.logins.fail(clientIP)
+		err = server.recordFailedLogin(ctx, user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateTokenForUser(
-		user.ID,
-		user.Username,
-		server.config.AccessTokenDuration,
-	)
+	mfaRequired, err := server.userMFARequired(ctx, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the failed logins are only reset once the second factor is checked too,
+	// so that knowing the password doesn't allow to guess codes endlessly
+	if mfaRequired {
+		server.respondMFARequired(ctx, user.ID, false, false)
+		return
+	}
+
+	rsp, err := server.createUserLogin(ctx, user, req.CartToken)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// createUserLogin resets the failed logins of a user who proved who they are,
+// opens a new session for them and returns the tokens bound to it. The guest
+// cart of the cart token, if any, is merged into the shopping session of the user.
+func (server *Server) createUserLogin(ctx *gin.Context, user db.User, cartToken string) (loginUserResponse, error) {
+	var err error
+	if user.FailedLoginAttempts > 0 || user.LockedUntil.Valid {
+		user, err = server.store.ResetFailedUserLogins(ctx, user.ID)
+		if err != nil {
+			return loginUserResponse{}, err
+		}
+	}
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateTokenForUser(
 		user.ID,
 		user.Username,
+		uuid.Nil,
 		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		return loginUserResponse{}, err
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateTokenForUser(
+		user.ID,
+		user.Username,
+		refreshPayload.ID,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return loginUserResponse{}, err
 	}
 
 	arg := db.CreateUserSessionParams{
@@ -282,12 +392,12 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		UserAgent:    ctx.Request.UserAgent(),
 		ClientIp:     ctx.ClientIP(),
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     refreshPayload.ID,
 	}
 
 	userSession, err := server.store.CreateUserSession(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		return loginUserResponse{}, err
 	}
 
 	rsp := loginUserResponse{
@@ -298,5 +408,14 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
 		User:                  newUserResponse(user),
 	}
-	ctx.JSON(http.StatusOK, rsp)
+
+	// the user is logged in anyway, the guest cart is just lost
+	if cartToken != "" {
+		shoppingSession, err := server.mergeGuestCart(ctx, user.ID, cartToken)
+		if err != nil {
+			_ = ctx.Error(err)
+		}
+		rsp.ShoppingSessionID = shoppingSession.ID
+	}
+	return rsp, nil
 }
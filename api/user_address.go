@@ -15,7 +15,7 @@ type createUserAddressRequest struct {
 	UserID      int64  `json:"user_id" binding:"required,min=1"`
 	AddressLine string `json:"address_line" binding:"required"`
 	City        string `json:"city" binding:"required"`
-	Telephone   int32  `json:"telephone" binding:"required,numeric,min=910000000,max=929999999"`
+	Telephone   string `json:"telephone" binding:"required,phone"`
 }
 
 func (server *Server) createUserAddress(ctx *gin.Context) {
@@ -27,11 +27,17 @@ func (server *Server) createUserAddress(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	telephone, err := server.phoneRules.Normalize(req.Telephone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.CreateUserAddressParams{
 		UserID:      authPayload.UserID,
 		AddressLine: req.AddressLine,
 		City:        req.City,
-		Telephone:   req.Telephone,
+		Telephone:   telephone,
 	}
 
 	userAddress, err := server.store.CreateUserAddress(ctx, arg)
@@ -80,8 +86,7 @@ func (server *Server) getUserAddress(ctx *gin.Context) {
 }
 
 type listUserAddressesRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listUserAddresses(ctx *gin.Context) {
@@ -91,11 +96,17 @@ func (server *Server) listUserAddresses(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
 	arg := db.ListUserAddressesParams{
-		UserID: authPayload.UserID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		UserID:  authPayload.UserID,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	userAddresses, err := server.store.ListUserAddresses(ctx, arg)
 	if err != nil {
@@ -106,14 +117,32 @@ func (server *Server) listUserAddresses(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, userAddresses)
+
+	n, more := page.size(len(userAddresses))
+	userAddresses = userAddresses[:n]
+
+	rsp := listResponse{Items: userAddresses}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: userAddresses[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountUserAddresses(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateUserAddressByUserIDRequest struct {
 	ID          int64  `json:"id" binding:"required,min=1"`
 	AddressLine string `json:"address_line"`
 	City        string `json:"city"`
-	Telephone   int32  `json:"telephone" binding:"required,numeric,min=910000000,max=929999999"`
+	Telephone   string `json:"telephone" binding:"required,phone"`
 }
 
 func (server *Server) updateUserAddressByUserID(ctx *gin.Context) {
@@ -124,12 +153,18 @@ func (server *Server) updateUserAddressByUserID(ctx *gin.Context) {
 		return
 	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	telephone, err := server.phoneRules.Normalize(req.Telephone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.UpdateUserAddressByUserIDParams{
 		ID:          req.ID,
 		UserID:      authPayload.UserID,
 		AddressLine: req.AddressLine,
 		City:        req.City,
-		Telephone:   req.Telephone,
+		Telephone:   telephone,
 	}
 
 	userAddress, err := server.store.UpdateUserAddressByUserID(ctx, arg)
@@ -302,7 +302,7 @@ func TestGetUserAddressAPI(t *testing.T) {
 
 func TestListUsersAddressAPI(t *testing.T) {
 	n := 5
-	userAddresses := make([]db.UserAddress, n)
+	userAddresses := make([]db.UserAddress, 0, n)
 	user, _ := randomUAUser(t)
 	userAddress1 := createRandomUserAddress(t, user)
 	userAddress2 := createRandomUserAddress(t, user)
@@ -311,8 +311,8 @@ func TestListUsersAddressAPI(t *testing.T) {
 	userAddresses = append(userAddresses, userAddress1, userAddress2, userAddress3)
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -325,8 +325,7 @@ func TestListUsersAddressAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -334,8 +333,7 @@ func TestListUsersAddressAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListUserAddressesParams{
 					UserID: user.ID,
-					Limit:  int32(n),
-					Offset: 0,
+					Limit:  int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -351,8 +349,7 @@ func TestListUsersAddressAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -368,10 +365,10 @@ func TestListUsersAddressAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -386,10 +383,9 @@ func TestListUsersAddressAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -423,8 +419,10 @@ func TestListUsersAddressAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -705,7 +703,7 @@ func randomUAUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(910000000, 929999999)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -742,7 +740,6 @@ func requireBodyMatchUserAddresses(t *testing.T, body *bytes.Buffer, userAddress
 	require.NoError(t, err)
 
 	var gotUserAddresses []db.UserAddress
-	err = json.Unmarshal(data, &gotUserAddresses)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotUserAddresses)
 	require.Equal(t, userAddresses, gotUserAddresses)
 }
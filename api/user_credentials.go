@@ -0,0 +1,203 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// changePassword sets a new password for the authenticated user, who has to
+// confirm it with their current one. Every other session of the user is revoked.
+func (server *Server) changePassword(ctx *gin.Context) {
+	var req changePasswordRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	user, ok := server.getUserWithPassword(ctx, authPayload.UserID, req.CurrentPassword)
+	if !ok {
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ChangePasswordTxParams{
+		UserID:         user.ID,
+		SessionID:      authPayload.SessionID,
+		HashedPassword: hashedPassword,
+	}
+
+	_, err = server.store.ChangePasswordTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(user.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+type requestEmailChangeRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// requestEmailChange emails a confirmation link to the new address of the
+// authenticated user, the email is only swapped once confirmEmailChange is
+// called with the token of that link.
+func (server *Server) requestEmailChange(ctx *gin.Context) {
+	var req requestEmailChangeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	user, ok := server.getUserWithPassword(ctx, authPayload.UserID, req.Password)
+	if !ok {
+		return
+	}
+
+	if strings.EqualFold(req.NewEmail, user.Email) {
+		err := errors.New("new email is the same as the current one")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetUserByEmail(ctx, req.NewEmail)
+	if err == nil {
+		err := errors.New("email is already in use")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	changeToken, err := util.RandomSecret(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateEmailChangeTokenParams{
+		UserID:    user.ID,
+		NewEmail:  req.NewEmail,
+		TokenHash: util.HashSecret(changeToken),
+		ExpiresAt: time.Now().Add(server.config.EmailChangeDuration),
+	}
+
+	_, err = server.store.CreateEmailChangeToken(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	subject := "Confirm your new e-shop email address"
+	content := fmt.Sprintf(`Hello %s,
+
+We received a request to use this address for your account.
+Follow this link within %s to confirm it:
+
+%s?token=%s
+
+If you didn't ask for it, you can safely ignore this email.
+`, user.Username, server.config.EmailChangeDuration, server.config.EmailChangeURL, changeToken)
+
+	err = server.mailer.SendEmail(subject, content, []string{req.NewEmail})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+type confirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// confirmEmailChange swaps the email of the authenticated user for the one
+// confirmed by the token sent by requestEmailChange. Every other session of
+// the user is revoked.
+func (server *Server) confirmEmailChange(ctx *gin.Context) {
+	var req confirmEmailChangeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	arg := db.ConfirmEmailChangeTxParams{
+		UserID:    authPayload.UserID,
+		SessionID: authPayload.SessionID,
+		TokenHash: util.HashSecret(req.Token),
+	}
+
+	user, err := server.store.ConfirmEmailChangeTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		} else if err == db.ErrInvalidEmailChangeToken {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(user.ID)
+
+	rsp := newUserResponse(user)
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// getUserWithPassword loads the user and checks their password before a change
+// of credentials, it writes the error response and returns false on failure.
+func (server *Server) getUserWithPassword(ctx *gin.Context, userID int64, password string) (db.User, bool) {
+	user, err := server.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return user, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return user, false
+	}
+
+	err = util.CheckPassword(password, user.Password)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return user, false
+	}
+
+	return user, true
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type userDataProfile struct {
+	ID            int64     `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	Telephone     string    `json:"telephone"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type userDataPayment struct {
+	ID          int64     `json:"id"`
+	PaymentType string    `json:"payment_type"`
+	Provider    string    `json:"provider"`
+	AccountNo   string    `json:"account_no"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+type userDataSession struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	IsBlocked bool      `json:"is_blocked"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type exportUserDataResponse struct {
+	ExportedAt time.Time          `json:"exported_at"`
+	Profile    userDataProfile    `json:"profile"`
+	Addresses  []db.UserAddress   `json:"addresses"`
+	Payments   []userDataPayment  `json:"payments"`
+	Orders     []db.UserDataOrder `json:"orders"`
+	Sessions   []userDataSession  `json:"sessions"`
+}
+
+// maskAccountNo hides all but the last 4 digits of an account number
+func maskAccountNo(accountNo int32) string {
+	digits := strconv.FormatInt(int64(accountNo), 10)
+	if len(digits) <= 4 {
+		return strings.Repeat("*", len(digits))
+	}
+	return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+}
+
+func newExportUserDataResponse(data db.ExportUserDataTxResult) exportUserDataResponse {
+	rsp := exportUserDataResponse{
+		ExportedAt: time.Now(),
+		Profile: userDataProfile{
+			ID:            data.User.ID,
+			Username:      data.User.Username,
+			Email:         data.User.Email,
+			EmailVerified: data.User.VerifiedAt.Valid,
+			Telephone:     data.User.Telephone,
+			CreatedAt:     data.User.CreatedAt,
+		},
+		Addresses: data.Addresses,
+		Payments:  make([]userDataPayment, 0, len(data.Payments)),
+		Orders:    data.Orders,
+		Sessions:  make([]userDataSession, 0, len(data.Sessions)),
+	}
+
+	for _, payment := range data.Payments {
+		rsp.Payments = append(rsp.Payments, userDataPayment{
+			ID:          payment.ID,
+			PaymentType: payment.PaymentType,
+			Provider:    payment.Provider,
+			AccountNo:   maskAccountNo(payment.AccountNo),
+			Expiry:      payment.Expiry,
+		})
+	}
+
+	// the refresh tokens are credentials, not personal data
+	for _, session := range data.Sessions {
+		rsp.Sessions = append(rsp.Sessions, userDataSession{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			ClientIP:  session.ClientIp,
+			IsBlocked: session.IsBlocked,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+
+	if rsp.Addresses == nil {
+		rsp.Addresses = []db.UserAddress{}
+	}
+	if rsp.Orders == nil {
+		rsp.Orders = []db.UserDataOrder{}
+	}
+	return rsp
+}
+
+// exportUserData returns an archive of the personal data held about the
+// authenticated user, with their payment account numbers masked.
+func (server *Server) exportUserData(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	data, err := server.store.ExportUserDataTx(ctx, authPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("e-shop-user-%d.json", authPayload.UserID)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.JSON(http.StatusOK, newExportUserDataResponse(data))
+}
+
+type eraseUserRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// eraseUser anonymizes the account of the authenticated user, who has to confirm
+// it with their password. Their orders are kept for accounting, everything else
+// identifying them is deleted and all of their sessions are revoked.
+func (server *Server) eraseUser(ctx *gin.Context) {
+	var req eraseUserRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	user, ok := server.getUserWithPassword(ctx, authPayload.UserID, req.Password)
+	if !ok {
+		return
+	}
+
+	_, err := server.store.EraseUserTx(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(user.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	dummyPasswordHashOnce sync.Once
+	dummyPasswordHash     string
+)
+
+// getDummyPasswordHash returns a hash to check the passwords of unknown users
+// against, it is only computed on first use since hashing is slow on purpose.
+func getDummyPasswordHash() string {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = util.HashPassword(util.RandomString(32))
+	})
+	return dummyPasswordHash
+}
+
+// recordFailedLogin counts a failed login of the user and locks their account
+// once they have failed LoginMaxAttempts times in a row.
+func (server *Server) recordFailedLogin(ctx *gin.Context, user db.User) error {
+	user, err := server.store.RecordFailedUserLogin(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	lockout := lockoutDuration(user.FailedLoginAttempts, server.config.LoginMaxAttempts, server.config.LoginLockoutDuration)
+	if lockout <= 0 {
+		return nil
+	}
+
+	arg := db.LockUserParams{
+		ID:          user.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(lockout), Valid: true},
+	}
+
+	_, err = server.store.LockUser(ctx, arg)
+	return err
+}
+
+type lockedUserResponse struct {
+	ID                  int64     `json:"id"`
+	Username            string    `json:"username"`
+	Email               string    `json:"email"`
+	FailedLoginAttempts int32     `json:"failed_login_attempts"`
+	LockedUntil         time.Time `json:"locked_until"`
+}
+
+func newLockedUserResponse(user db.User) lockedUserResponse {
+	return lockedUserResponse{
+		ID:                  user.ID,
+		Username:            user.Username,
+		Email:               user.Email,
+		FailedLoginAttempts: user.FailedLoginAttempts,
+		LockedUntil:         user.LockedUntil.Time,
+	}
+}
+
+type listLockedUsersRequest struct {
+	pageRequest
+}
+
+// listLockedUsers lists the users who can't log in for now, the ones whose
+// lockout ends last first.
+func (server *Server) listLockedUsers(ctx *gin.Context) {
+	var req listLockedUsersRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the locked users are listed by the end of their lockout, the cursor keeps the
+	// lockout of the last one
+	arg := db.ListLockedUsersParams{
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
+	}
+	if page.cursor.ID != 0 {
+		lockedUntil, err := time.Parse(time.RFC3339Nano, page.cursor.Key)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidCursor))
+			return
+		}
+		arg.AfterLockedUntil = sql.NullTime{Time: lockedUntil, Valid: true}
+	}
+
+	users, err := server.store.ListLockedUsers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	n, more := page.size(len(users))
+	items := make([]lockedUserResponse, 0, n)
+	for _, user := range users[:n] {
+		items = append(items, newLockedUserResponse(user))
+	}
+
+	rsp := listResponse{Items: items}
+	if more {
+		last := users[n-1]
+		rsp.NextCursor = encodeCursor(pageCursor{
+			ID:  last.ID,
+			Key: last.LockedUntil.Time.Format(time.RFC3339Nano),
+		})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountLockedUsers(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type unlockUserRequest struct {
+	UserID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// unlockUser lifts the lockout of an account and resets its failed logins.
+func (server *Server) unlockUser(ctx *gin.Context) {
+	var req unlockUserRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.ResetFailedUserLogins(ctx, req.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
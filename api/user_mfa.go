@@ -0,0 +1,260 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin"
+)
+
+// getUserForMFA returns the user an mfa pending token was issued to, as long as
+// their account isn't locked.
+func (server *Server) getUserForMFA(ctx *gin.Context, mfaToken string) (db.User, bool) {
+	mfaPayload, err := server.tokenMaker.VerifyMFAToken(mfaToken)
+	if err != nil || mfaPayload.Admin {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(token.ErrInvalidToken))
+		return db.User{}, false
+	}
+
+	user, err := server.store.GetUser(ctx, mfaPayload.OwnerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(token.ErrInvalidToken))
+			return db.User{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.User{}, false
+	}
+
+	if user.LockedUntil.Valid && time.Now().Before(user.LockedUntil.Time) {
+		server.logins.fail(ctx.ClientIP())
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return db.User{}, false
+	}
+
+	return user, true
+}
+
+// verifyUserLoginMFA exchanges an mfa pending token and a second factor for the
+// real tokens of the user. Wrong codes count towards the lockout of the account.
+type verifyUserLoginMFARequest struct {
+	verifyLoginMFARequest
+	// CartToken is the token of the guest cart to merge into the one of the user
+	CartToken string `json:"cart_token"`
+}
+
+func (server *Server) verifyUserLoginMFA(ctx *gin.Context) {
+	var req verifyUserLoginMFARequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.allowLogin(ctx) {
+		return
+	}
+
+	user, ok := server.getUserForMFA(ctx, req.MFAToken)
+	if !ok {
+		return
+	}
+
+	totp, err := server.store.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errMFANotEnrolled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !totp.ConfirmedAt.Valid {
+		ctx.JSON(http.StatusForbidden, errorResponse(errMFANotEnrolled))
+		return
+	}
+
+	ok, err = server.checkUserSecondFactor(ctx, totp, req.Code, req.RecoveryCode)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !ok {
+		err = server.recordFailedLogin(ctx, user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		server.failMFA(ctx)
+		return
+	}
+
+	rsp, err := server.createUserLogin(ctx, user, req.CartToken)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+func (server *Server) getUserMFA(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	totp, err := server.store.GetUserTOTP(ctx, authPayload.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err == sql.ErrNoRows || !totp.ConfirmedAt.Valid {
+		ctx.JSON(http.StatusOK, mfaStatusResponse{})
+		return
+	}
+
+	count, err := server.store.CountUserRecoveryCodes(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mfaStatusResponse{
+		Enabled:           true,
+		RecoveryCodesLeft: count,
+	})
+}
+
+// enrollUserTOTP shares a new totp secret with the user, which only takes
+// effect once confirmed with a first code.
+func (server *Server) enrollUserTOTP(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	secret, err := util.RandomTOTPSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateUserTOTPParams{
+		UserID: authPayload.UserID,
+		Secret: secret,
+	}
+
+	_, err = server.store.CreateUserTOTP(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(errMFAAlreadyEnabled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newEnrollTOTPResponse(authPayload.Username, secret))
+}
+
+// confirmUserTOTP turns the second factor of the user on with their first valid
+// code and returns their recovery codes.
+func (server *Server) confirmUserTOTP(ctx *gin.Context) {
+	var req confirmTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	totp, err := server.store.GetUserTOTP(ctx, authPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errMFANotEnrolled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if totp.ConfirmedAt.Valid {
+		ctx.JSON(http.StatusForbidden, errorResponse(errMFAAlreadyEnabled))
+		return
+	}
+
+	step, ok := util.ValidateTOTP(totp.Secret, req.Code, time.Now())
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidMFACode))
+		return
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.EnableUserTOTPTxParams{
+		UserID:             authPayload.UserID,
+		Step:               step,
+		RecoveryCodeHashes: hashes,
+	}
+
+	_, err = server.store.EnableUserTOTPTx(ctx, arg)
+	if err != nil {
+		if err == db.ErrTOTPCodeReused || err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidMFACode))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// disableUserTOTP turns the second factor of the user off, with a last code of it.
+func (server *Server) disableUserTOTP(ctx *gin.Context) {
+	var req disableTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	totp, err := server.store.GetUserTOTP(ctx, authPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errMFANotEnrolled))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// a pending enrollment can be called off without a code
+	if totp.ConfirmedAt.Valid {
+		ok, err := server.checkUserSecondFactor(ctx, totp, req.Code, req.RecoveryCode)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if !ok {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidMFACode))
+			return
+		}
+	}
+
+	err = server.store.DisableUserTOTPTx(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -83,8 +83,7 @@ func (server *Server) getUserPayment(ctx *gin.Context) {
 }
 
 type listUserPaymentsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
 }
 
 func (server *Server) listUserPayments(ctx *gin.Context) {
@@ -95,11 +94,17 @@ func (server *Server) listUserPayments(ctx *gin.Context) {
 		return
 	}
 
+	page, err := server.parsePage(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
 	arg := db.ListUserPaymentsParams{
-		UserID: authPayload.UserID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		UserID:  authPayload.UserID,
+		AfterID: page.cursor.ID,
+		Limit:   page.fetchLimit(),
 	}
 	userPayments, err := server.store.ListUserPayments(ctx, arg)
 	if err != nil {
@@ -110,7 +115,25 @@ func (server *Server) listUserPayments(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, userPayments)
+
+	n, more := page.size(len(userPayments))
+	userPayments = userPayments[:n]
+
+	rsp := listResponse{Items: userPayments}
+	if more {
+		rsp.NextCursor = encodeCursor(pageCursor{ID: userPayments[n-1].ID})
+	}
+
+	if page.includeTotal {
+		total, err := server.store.CountUserPayments(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		rsp.Total = &total
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type updateUserPaymentRequest struct {
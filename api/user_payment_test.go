@@ -308,7 +308,7 @@ func TestGetUserPaymentAPI(t *testing.T) {
 
 func TestListUsersPaymentAPI(t *testing.T) {
 	n := 5
-	userPayments := make([]db.UserPayment, n)
+	userPayments := make([]db.UserPayment, 0, n)
 	user, _ := randomUPUser(t)
 	userPayment1 := createRandomUserPayment(t, user)
 	userPayment2 := createRandomUserPayment(t, user)
@@ -317,8 +317,8 @@ func TestListUsersPaymentAPI(t *testing.T) {
 	userPayments = append(userPayments, userPayment1, userPayment2, userPayment3)
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -331,8 +331,7 @@ func TestListUsersPaymentAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -340,8 +339,7 @@ func TestListUsersPaymentAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListUserPaymentsParams{
 					UserID: user.ID,
-					Limit:  int32(n),
-					Offset: 0,
+					Limit:  int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -357,8 +355,7 @@ func TestListUsersPaymentAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -374,10 +371,10 @@ func TestListUsersPaymentAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -392,10 +389,9 @@ func TestListUsersPaymentAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, user.Username, time.Minute)
@@ -429,8 +425,10 @@ func TestListUsersPaymentAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -716,7 +714,7 @@ func randomUPUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(7, 7)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -754,7 +752,6 @@ func requireBodyMatchUserPayments(t *testing.T, body *bytes.Buffer, userPayments
 	require.NoError(t, err)
 
 	var gotUserPayments []db.UserPayment
-	err = json.Unmarshal(data, &gotUserPayments)
-	require.NoError(t, err)
+	unmarshalListResponse(t, data, &gotUserPayments)
 	require.Equal(t, userPayments, gotUserPayments)
 }
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type userSessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIp  string    `json:"client_ip"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func newUserSessionResponse(userSession db.UserSession) userSessionResponse {
+	return userSessionResponse{
+		ID:        userSession.ID,
+		UserAgent: userSession.UserAgent,
+		ClientIp:  userSession.ClientIp,
+		CreatedAt: userSession.CreatedAt,
+		ExpiresAt: userSession.ExpiresAt,
+	}
+}
+
+type logoutUserRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// logoutUser blocks the refresh session the client is holding along with the
+// sessions it was rotated from, so neither the refresh token nor the access
+// tokens issued since the login can be used anymore.
+func (server *Server) logoutUser(ctx *gin.Context) {
+	var req logoutUserRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyTokenForUser(req.RefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	if refreshPayload.UserID != authPayload.UserID {
+		err := errors.New("account deosn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if !server.revokeUserSessionFamily(ctx, refreshPayload.ID, authPayload.UserID) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+func (server *Server) listUserSessions(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	userSessions, err := server.store.ListActiveUserSessions(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items := make([]userSessionResponse, 0, len(userSessions))
+	for _, userSession := range userSessions {
+		items = append(items, newUserSessionResponse(userSession))
+	}
+	ctx.JSON(http.StatusOK, listResponse{Items: items})
+}
+
+type revokeUserSessionRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) revokeUserSession(ctx *gin.Context) {
+	var req revokeUserSessionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+	if !server.revokeUserSessionFamily(ctx, uuid.MustParse(req.ID), authPayload.UserID) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// revokeUserSessionFamily blocks a session of the user together with every
+// session rotated from the same login, since the access tokens bound to the
+// earlier sessions of the family are still in circulation.
+func (server *Server) revokeUserSessionFamily(ctx *gin.Context, sessionID uuid.UUID, userID int64) bool {
+	userSession, err := server.store.GetUserSession(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if userSession.UserID != userID {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return false
+	}
+
+	arg := db.BlockUserSessionFamilyParams{
+		FamilyID: userSession.FamilyID,
+		UserID:   userID,
+	}
+
+	err = server.store.BlockUserSessionFamily(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	// the cache doesn't know the family of the sessions it holds
+	server.sessions.forgetUser(userID)
+
+	return true
+}
+
+// revokeAllUserSessions logs the authenticated user out everywhere.
+func (server *Server) revokeAllUserSessions(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.UserPayload)
+
+	err := server.store.BlockAllUserSessions(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(authPayload.UserID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+type blockUserSessionsRequest struct {
+	UserID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// blockUserSessions lets an admin log a user out everywhere.
+func (server *Server) blockUserSessions(ctx *gin.Context) {
+	var req blockUserSessionsRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.BlockAllUserSessions(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sessions.forgetUser(user.ID)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -79,6 +81,124 @@ func TestCreateUserAPI(t *testing.T) {
 					CreateUser(gomock.Any(), EqCreateUserParamsMatcher(arg, password)).
 					Times(1).
 					Return(user, nil)
+
+				store.EXPECT().
+					CreateEmailVerificationToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateEmailVerificationTokenParams) (db.EmailVerificationToken, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.NotEmpty(t, arg.TokenHash)
+						return db.EmailVerificationToken{UserID: arg.UserID, TokenHash: arg.TokenHash}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUser(t, recorder.Body, user)
+			},
+		},
+		{
+			name: "OKWithCartToken",
+			body: gin.H{
+				"username":   user.Username,
+				"email":      user.Email,
+				"password":   password,
+				"telephone":  user.Telephone,
+				"cart_token": "guest-cart-token",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CreateEmailVerificationToken(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					MergeGuestCartTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.MergeGuestCartTxParams) (db.ShoppingSession, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, util.HashSecret("guest-cart-token"), arg.GuestTokenHash)
+						return db.ShoppingSession{ID: 7, UserID: sql.NullInt64{Int64: user.ID, Valid: true}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp createUserResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, user.ID, rsp.ID)
+				require.Equal(t, int64(7), rsp.ShoppingSessionID)
+			},
+		},
+		{
+			name: "NationalTelephone",
+			body: gin.H{
+				"username":  user.Username,
+				"email":     user.Email,
+				"password":  password,
+				"telephone": "0" + strings.TrimPrefix(user.Telephone, "+218"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				// the number is stored in E.164 format
+				arg := db.CreateUserParams{
+					Username:  user.Username,
+					Email:     user.Email,
+					Telephone: user.Telephone,
+				}
+
+				store.EXPECT().
+					CreateUser(gomock.Any(), EqCreateUserParamsMatcher(arg, password)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CreateEmailVerificationToken(gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUser(t, recorder.Body, user)
+			},
+		},
+		{
+			name: "InvalidTelephone",
+			body: gin.H{
+				"username":  user.Username,
+				"email":     user.Email,
+				"password":  password,
+				"telephone": "+218-91x",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "VerificationEmailError",
+			body: gin.H{
+				"username":  user.Username,
+				"email":     user.Email,
+				"password":  password,
+				"telephone": user.Telephone,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CreateEmailVerificationToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.EmailVerificationToken{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -250,6 +370,11 @@ func TestLoginUserAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 
+				store.EXPECT().
+					GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.UserTotp{}, sql.ErrNoRows)
+
 				store.EXPECT().
 					CreateUserSession(gomock.Any(), gomock.Any()).
 					Times(1)
@@ -258,6 +383,116 @@ func TestLoginUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "OKWithCartToken",
+			body: gin.H{
+				"email":      user.Email,
+				"password":   password,
+				"cart_token": "guest-cart-token",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.UserTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					MergeGuestCartTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.MergeGuestCartTxParams) (db.ShoppingSession, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, util.HashSecret("guest-cart-token"), arg.GuestTokenHash)
+						require.Equal(t, db.CartMergeRuleSum, arg.Rule)
+						require.WithinDuration(t, time.Now().Add(-30*24*time.Hour), arg.CreatedAfter, time.Second)
+						return db.ShoppingSession{ID: 7, UserID: sql.NullInt64{Int64: user.ID, Valid: true}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp loginUserResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, int64(7), rsp.ShoppingSessionID)
+			},
+		},
+		{
+			name: "InvalidCartToken",
+			body: gin.H{
+				"email":      user.Email,
+				"password":   password,
+				"cart_token": "expired-cart-token",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.UserTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					MergeGuestCartTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ShoppingSession{}, db.ErrInvalidCartToken)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// the cart is not needed to log in
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp loginUserResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Zero(t, rsp.ShoppingSessionID)
+			},
+		},
+		{
+			name: "MergeGuestCartError",
+			body: gin.H{
+				"email":      user.Email,
+				"password":   password,
+				"cart_token": "guest-cart-token",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.UserTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1)
+
+				store.EXPECT().
+					MergeGuestCartTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ShoppingSession{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name: "UserNotFound",
 			body: gin.H{
@@ -271,7 +506,37 @@ func TestLoginUserAPI(t *testing.T) {
 					Return(db.User{}, sql.ErrNoRows)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				// unknown emails can't be told apart from wrong passwords
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "ErasedUser",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				erasedUser := user
+				erasedUser.ErasedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(erasedUser, nil)
+
+				store.EXPECT().
+					RecordFailedUserLogin(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
 			},
 		},
 		{
@@ -281,15 +546,188 @@ func TestLoginUserAPI(t *testing.T) {
 				"password": "incorrect",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				failedUser := user
+				failedUser.FailedLoginAttempts = 1
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					RecordFailedUserLogin(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(failedUser, nil)
+
+				store.EXPECT().
+					LockUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "IncorrectPasswordLocksAccount",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				failedUser := user
+				failedUser.FailedLoginAttempts = 5
+
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+
+				store.EXPECT().
+					RecordFailedUserLogin(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(failedUser, nil)
+
+				store.EXPECT().
+					LockUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.LockUserParams) (db.User, error) {
+						require.Equal(t, user.ID, arg.ID)
+						require.True(t, arg.LockedUntil.Valid)
+						require.WithinDuration(t, time.Now().Add(15*time.Minute), arg.LockedUntil.Time, time.Second)
+						return failedUser, nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "LockedAccount",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				lockedUser := user
+				lockedUser.FailedLoginAttempts = 5
+				lockedUser.LockedUntil = sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true}
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(lockedUser, nil)
+
+				store.EXPECT().
+					RecordFailedUserLogin(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// even the right password is refused, the same way as a wrong one
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireBodyMatchError(t, recorder.Body, errInvalidCredentials)
+			},
+		},
+		{
+			name: "ExpiredLockout",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				lockedUser := user
+				lockedUser.FailedLoginAttempts = 5
+				lockedUser.LockedUntil = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(lockedUser, nil)
+
+				store.EXPECT().
+					GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.UserTotp{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					ResetFailedUserLogins(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MFAEnabled",
+			body: gin.H{
+				"email":    user.Email,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				failingUser := user
+				failingUser.FailedLoginAttempts = 2
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(failingUser, nil)
+
+				store.EXPECT().
+					GetUserTOTP(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.UserTotp{
+						UserID:      user.ID,
+						ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil)
+
+				// the failed logins are kept until the second factor is checked
+				store.EXPECT().
+					ResetFailedUserLogins(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMFARequired(t, recorder.Body, false)
+			},
+		},
+		{
+			name: "RecordFailedLoginError",
+			body: gin.H{
+				"email":    user.Email,
+				"password": "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					RecordFailedUserLogin(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.User{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			body: gin.H{
@@ -349,6 +787,45 @@ func TestLoginUserAPI(t *testing.T) {
 	}
 }
 
+func TestLoginUserIPLimitAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	limit := server.config.LoginIPMaxAttempts
+
+	// the limit is reached with unknown emails, so it can't be dodged by
+	// spreading the attempts over several accounts
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Any()).
+		Times(limit).
+		Return(db.User{}, sql.ErrNoRows)
+
+	login := func() *httptest.ResponseRecorder {
+		data, err := json.Marshal(gin.H{
+			"email":    util.RandomEmail(),
+			"password": util.RandomString(6),
+		})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewReader(data))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	for i := 0; i < limit; i++ {
+		recorder := login()
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	recorder := login()
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+}
+
 func TestGetUserAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	require.NotEmpty(t, user)
@@ -630,8 +1107,9 @@ func TestListUsersAPI(t *testing.T) {
 	admin, _ := randomSuperAdmin(t)
 
 	type Query struct {
-		pageID   int
-		pageSize int
+		cursor       string
+		limit        int
+		includeTotal bool
 	}
 
 	testCases := []struct {
@@ -644,16 +1122,14 @@ func TestListUsersAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListUsersParams{
-					Limit:  int32(n),
-					Offset: 0,
+					Limit: int32(n) + 1,
 				}
 
 				store.EXPECT().
@@ -666,11 +1142,118 @@ func TestListUsersAPI(t *testing.T) {
 				requireBodyMatchUsers(t, recorder.Body, users)
 			},
 		},
+		{
+			name: "NextPage",
+			query: Query{
+				limit: n - 1,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListUsersParams{
+					Limit: int32(n),
+				}
+
+				store.EXPECT().
+					ListUsers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(users, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotUsers []db.User
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotUsers)
+				require.Len(t, gotUsers, n-1)
+				require.Equal(t, encodeCursor(pageCursor{ID: users[n-2].ID}), rsp.NextCursor)
+				require.Nil(t, rsp.Total)
+			},
+		},
+		{
+			name: "Cursor",
+			query: Query{
+				cursor: encodeCursor(pageCursor{ID: users[0].ID}),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				// without a limit, the default page size of the config is used
+				arg := db.ListUsersParams{
+					AfterID: users[0].ID,
+					Limit:   11,
+				}
+
+				store.EXPECT().
+					ListUsers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(users[1:], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUsers(t, recorder.Body, users[1:])
+			},
+		},
+		{
+			name: "IncludeTotal",
+			query: Query{
+				limit:        n,
+				includeTotal: true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(users, nil)
+
+				store.EXPECT().
+					CountUsers(gomock.Any()).
+					Times(1).
+					Return(int64(42), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotUsers []db.User
+				rsp := unmarshalListResponse(t, recorder.Body.Bytes(), &gotUsers)
+				require.Len(t, gotUsers, n)
+				require.Empty(t, rsp.NextCursor)
+				require.NotNil(t, rsp.Total)
+				require.Equal(t, int64(42), *rsp.Total)
+			},
+		},
+		{
+			name: "CountError",
+			query: Query{
+				limit:        n,
+				includeTotal: true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(users, nil)
+
+				store.EXPECT().
+					CountUsers(gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "Unauthorized",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, false, time.Minute)
@@ -688,8 +1271,7 @@ func TestListUsersAPI(t *testing.T) {
 		{
 			name: "No authorization",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
@@ -705,8 +1287,7 @@ func TestListUsersAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
-				pageSize: n,
+				limit: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
@@ -722,10 +1303,10 @@ func TestListUsersAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
-				pageSize: n,
+				cursor: "not-a-cursor",
+				limit:  n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
@@ -740,10 +1321,9 @@ func TestListUsersAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageSize",
+			name: "InvalidLimit",
 			query: Query{
-				pageID:   1,
-				pageSize: 100000,
+				limit: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorizationForAdmin(t, request, tokenMaker, authorizationTypeBearer, admin.ID, admin.Username, admin.TypeID, admin.Active, time.Minute)
@@ -777,8 +1357,15 @@ func TestListUsersAPI(t *testing.T) {
 
 			// Add query parameters to request URL
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			if tc.query.limit != 0 {
+				q.Add("limit", fmt.Sprintf("%d", tc.query.limit))
+			}
+			if tc.query.includeTotal {
+				q.Add("include_total", "true")
+			}
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -811,9 +1398,9 @@ func TestDeleteUserAPI(t *testing.T) {
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Eq(user.ID)).
+					EraseUserTx(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
-					Return(nil)
+					Return(db.User{}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -829,7 +1416,7 @@ func TestDeleteUserAPI(t *testing.T) {
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Any()).
+					EraseUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -847,9 +1434,9 @@ func TestDeleteUserAPI(t *testing.T) {
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Eq(user.ID)).
+					EraseUserTx(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
-					Return(sql.ErrNoRows)
+					Return(db.User{}, sql.ErrNoRows)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -866,9 +1453,9 @@ func TestDeleteUserAPI(t *testing.T) {
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Eq(user.ID)).
+					EraseUserTx(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.User{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -885,7 +1472,7 @@ func TestDeleteUserAPI(t *testing.T) {
 			},
 			buildStub: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Any()).
+					EraseUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 
 			},
@@ -937,7 +1524,7 @@ func randomUser(t *testing.T) (user db.User, password string) {
 		ID:        util.RandomMoney(),
 		Username:  util.RandomUser(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(910000000, 929999999)),
+		Telephone: util.RandomPhoneNumber(),
 		Email:     util.RandomEmail(),
 	}
 	return
@@ -973,12 +1560,27 @@ func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
 	require.Empty(t, gotUser.Password)
 }
 
+func requireBodyMatchError(t *testing.T, body *bytes.Buffer, err error) {
+	data, readErr := ioutil.ReadAll(body)
+	require.NoError(t, readErr)
+
+	var gotError gin.H
+	readErr = json.Unmarshal(data, &gotError)
+	require.NoError(t, readErr)
+	require.Equal(t, err.Error(), gotError["error"])
+}
+
 func requireBodyMatchUsers(t *testing.T, body *bytes.Buffer, users []db.User) {
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotUsers []db.User
-	err = json.Unmarshal(data, &gotUsers)
-	require.NoError(t, err)
-	require.Equal(t, users, gotUsers)
+	var gotUsers []userResponse
+	unmarshalListResponse(t, data, &gotUsers)
+	require.Len(t, gotUsers, len(users))
+	for i, user := range users {
+		require.Equal(t, newUserResponse(user), gotUsers[i])
+	}
+
+	// password hashes never leave the server
+	require.NotContains(t, string(data), "password")
 }
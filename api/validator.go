@@ -0,0 +1,34 @@
+package api
+
+import (
+	"reflect"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// newPhoneNumberValidator returns the validator behind the "phone" binding tag, which
+// accepts the phone numbers the rules can normalize. gin validates a copy of the
+// request, so handlers still have to normalize the numbers they store.
+func newPhoneNumberValidator(rules util.PhoneRules) validator.Func {
+	return func(fieldLevel validator.FieldLevel) bool {
+		field := fieldLevel.Field()
+		if field.Kind() != reflect.String {
+			return false
+		}
+
+		_, err := rules.Normalize(field.String())
+		return err == nil
+	}
+}
+
+// registerValidators adds the custom binding tags to the validator of gin
+func registerValidators(phoneRules util.PhoneRules) error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	return v.RegisterValidation("phone", newPhoneNumberValidator(phoneRules))
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "admin_session";
\ No newline at end of file
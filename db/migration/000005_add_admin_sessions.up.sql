@@ -0,0 +1,12 @@
+CREATE TABLE "admin_session" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "admin_id" bigint NOT NULL,
+  "refresh_token" varchar NOT NULL,
+  "user_agent" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "is_blocked" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+ALTER TABLE "admin_session" ADD FOREIGN KEY ("admin_id") REFERENCES "admin" ("id");
\ No newline at end of file
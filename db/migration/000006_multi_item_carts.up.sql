@@ -0,0 +1,8 @@
+ALTER TABLE "cart_item"
+DROP CONSTRAINT "cart_item_session_id_key";
+
+ALTER TABLE "cart_item"
+DROP CONSTRAINT "cart_item_product_id_key";
+
+ALTER TABLE "cart_item"
+ADD CONSTRAINT "cart_item_session_id_product_id_key" UNIQUE ("session_id", "product_id");
\ No newline at end of file
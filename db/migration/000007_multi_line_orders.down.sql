@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "order_item_order_id_idx";
+
+ALTER TABLE "order_item"
+DROP COLUMN "price";
+
+ALTER TABLE "order_item"
+DROP COLUMN "product_sku";
+
+ALTER TABLE "order_item"
+DROP COLUMN "product_name";
+
+ALTER TABLE "order_item"
+ADD CONSTRAINT "order_item_order_id_key" UNIQUE ("order_id");
\ No newline at end of file
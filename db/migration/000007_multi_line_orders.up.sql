@@ -0,0 +1,26 @@
+ALTER TABLE "order_item"
+DROP CONSTRAINT "order_item_order_id_key";
+
+ALTER TABLE "order_item"
+ADD COLUMN "product_name" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "order_item"
+ADD COLUMN "product_sku" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "order_item"
+ADD COLUMN "price" decimal NOT NULL DEFAULT 0;
+
+UPDATE "order_item"
+SET product_name = "product".name,
+product_sku = "product".sku,
+price = "product".price
+FROM "product"
+WHERE "product".id = "order_item".product_id;
+
+CREATE INDEX ON "order_item" ("order_id");
+
+COMMENT ON COLUMN "order_item"."product_name" IS 'snapshot at purchase time';
+
+COMMENT ON COLUMN "order_item"."product_sku" IS 'snapshot at purchase time';
+
+COMMENT ON COLUMN "order_item"."price" IS 'unit price at purchase time';
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE "product_inventory"
+DROP CONSTRAINT IF EXISTS "product_inventory_quantity_check";
+
+DROP TABLE IF EXISTS "stock_reservation";
\ No newline at end of file
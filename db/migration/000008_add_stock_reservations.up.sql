@@ -0,0 +1,22 @@
+CREATE TABLE "stock_reservation" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "session_id" bigint NOT NULL,
+  "inventory_id" bigint NOT NULL,
+  "quantity" int NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "stock_reservation" ADD FOREIGN KEY ("session_id") REFERENCES "shopping_session" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "stock_reservation" ADD FOREIGN KEY ("inventory_id") REFERENCES "product_inventory" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "stock_reservation"
+ADD CONSTRAINT "stock_reservation_session_id_inventory_id_key" UNIQUE ("session_id", "inventory_id");
+
+CREATE INDEX ON "stock_reservation" ("inventory_id", "expires_at");
+
+ALTER TABLE "product_inventory"
+ADD CONSTRAINT "product_inventory_quantity_check" CHECK ("quantity" >= 0) NOT VALID;
+
+COMMENT ON COLUMN "stock_reservation"."quantity" IS 'must be positive';
\ No newline at end of file
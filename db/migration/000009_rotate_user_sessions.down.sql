@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "user_session_family_id_idx";
+
+ALTER TABLE "user_session" DROP COLUMN IF EXISTS "rotated_at";
+
+ALTER TABLE "user_session" DROP COLUMN IF EXISTS "family_id";
\ No newline at end of file
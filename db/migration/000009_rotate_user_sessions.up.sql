@@ -0,0 +1,13 @@
+ALTER TABLE "user_session" ADD COLUMN "family_id" uuid;
+
+UPDATE "user_session" SET "family_id" = "id";
+
+ALTER TABLE "user_session" ALTER COLUMN "family_id" SET NOT NULL;
+
+ALTER TABLE "user_session" ADD COLUMN "rotated_at" timestamptz;
+
+CREATE INDEX ON "user_session" ("family_id");
+
+COMMENT ON COLUMN "user_session"."family_id" IS 'id of the session created at login, shared by every rotated session';
+
+COMMENT ON COLUMN "user_session"."rotated_at" IS 'set once the refresh token has been exchanged for a new one';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "user_session" DROP CONSTRAINT IF EXISTS "user_session_user_id_fkey";
+
+ALTER TABLE "user_session" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "admin_type_permission";
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE TABLE "admin_type_permission" (
+  "admin_type_id" bigint NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("admin_type_id", "permission")
+);
+
+ALTER TABLE "admin_type_permission" ADD FOREIGN KEY ("admin_type_id") REFERENCES "admin_type" ("id") ON DELETE CASCADE;
+
+INSERT INTO "admin_type" ("admin_type")
+VALUES ('super-admin'), ('catalog-manager'), ('order-manager'), ('support')
+ON CONFLICT ("admin_type") DO NOTHING;
+
+-- the admin type 1 has always been the super-admin
+INSERT INTO "admin_type_permission" ("admin_type_id", "permission")
+SELECT t.id, p.permission FROM "admin_type" AS t
+CROSS JOIN (
+  VALUES ('admins.manage'), ('catalog.manage'), ('orders.manage'), ('users.manage'), ('users.read')
+) AS p (permission)
+WHERE t.id = 1 OR t.admin_type = 'super-admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "admin_type_permission" ("admin_type_id", "permission")
+SELECT t.id, p.permission FROM "admin_type" AS t
+JOIN (
+  VALUES
+    ('catalog-manager', 'catalog.manage'),
+    ('order-manager', 'orders.manage'),
+    ('order-manager', 'users.read'),
+    ('support', 'users.manage'),
+    ('support', 'users.read')
+) AS p (admin_type, permission) ON p.admin_type = t.admin_type
+ON CONFLICT DO NOTHING;
+
+COMMENT ON COLUMN "admin_type_permission"."permission" IS 'one of the permissions known by the api package';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "admin_session" DROP CONSTRAINT IF EXISTS "admin_session_admin_id_fkey";
+
+ALTER TABLE "admin_session" ADD FOREIGN KEY ("admin_id") REFERENCES "admin" ("id") ON DELETE CASCADE;
\ No newline at end of file
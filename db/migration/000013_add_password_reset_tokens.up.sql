@@ -0,0 +1,14 @@
+CREATE TABLE "password_reset_token" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "user_id" bigint NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "password_reset_token" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "password_reset_token" ("user_id");
+
+COMMENT ON COLUMN "password_reset_token"."token_hash" IS 'SHA-256 of the token sent by email';
\ No newline at end of file
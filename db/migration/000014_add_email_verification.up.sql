@@ -0,0 +1,18 @@
+ALTER TABLE "user" ADD COLUMN "verified_at" timestamptz;
+
+CREATE TABLE "email_verification_token" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "user_id" bigint NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "email_verification_token" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "email_verification_token" ("user_id", "created_at");
+
+COMMENT ON COLUMN "user"."verified_at" IS 'set once the user followed the link of the verification email';
+
+COMMENT ON COLUMN "email_verification_token"."token_hash" IS 'SHA-256 of the token sent by email';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "email_change_token";
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE "email_change_token" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "user_id" bigint NOT NULL,
+  "new_email" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "email_change_token" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "email_change_token" ("user_id");
+
+COMMENT ON COLUMN "email_change_token"."new_email" IS 'the address replacing the email of the user once confirmed';
+
+COMMENT ON COLUMN "email_change_token"."token_hash" IS 'SHA-256 of the token sent by email';
\ No newline at end of file
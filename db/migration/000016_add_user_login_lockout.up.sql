@@ -0,0 +1,9 @@
+ALTER TABLE "user" ADD COLUMN "failed_login_attempts" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "user" ADD COLUMN "locked_until" timestamptz;
+
+CREATE INDEX ON "user" ("locked_until");
+
+COMMENT ON COLUMN "user"."failed_login_attempts" IS 'consecutive failed logins, reset by a successful one';
+
+COMMENT ON COLUMN "user"."locked_until" IS 'logins are refused until then';
\ No newline at end of file
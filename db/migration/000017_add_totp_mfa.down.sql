@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "user_recovery_code";
+
+DROP TABLE IF EXISTS "admin_recovery_code";
+
+DROP TABLE IF EXISTS "user_totp";
+
+DROP TABLE IF EXISTS "admin_totp";
+
+ALTER TABLE "admin_type" DROP COLUMN IF EXISTS "require_mfa";
\ No newline at end of file
@@ -0,0 +1,59 @@
+ALTER TABLE "admin_type" ADD COLUMN "require_mfa" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "admin_totp" (
+  "admin_id" bigint PRIMARY KEY NOT NULL,
+  "secret" varchar NOT NULL,
+  "confirmed_at" timestamptz,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "user_totp" (
+  "user_id" bigint PRIMARY KEY NOT NULL,
+  "secret" varchar NOT NULL,
+  "confirmed_at" timestamptz,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "admin_recovery_code" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "admin_id" bigint NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "user_recovery_code" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "user_id" bigint NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "admin_totp" ADD FOREIGN KEY ("admin_id") REFERENCES "admin" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "user_totp" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "admin_recovery_code" ADD FOREIGN KEY ("admin_id") REFERENCES "admin" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "user_recovery_code" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ON "admin_recovery_code" ("admin_id", "code_hash");
+
+CREATE UNIQUE INDEX ON "user_recovery_code" ("user_id", "code_hash");
+
+COMMENT ON COLUMN "admin_type"."require_mfa" IS 'admins of this type must log in with a second factor';
+
+COMMENT ON COLUMN "admin_totp"."confirmed_at" IS 'set once a first code was checked, the second factor is enforced from then on';
+
+COMMENT ON COLUMN "admin_totp"."last_used_step" IS 'time step of the last accepted code, so that no code is accepted twice';
+
+COMMENT ON COLUMN "user_totp"."confirmed_at" IS 'set once a first code was checked, the second factor is enforced from then on';
+
+COMMENT ON COLUMN "user_totp"."last_used_step" IS 'time step of the last accepted code, so that no code is accepted twice';
+
+COMMENT ON COLUMN "admin_recovery_code"."code_hash" IS 'SHA-256 of the normalized recovery code';
+
+COMMENT ON COLUMN "user_recovery_code"."code_hash" IS 'SHA-256 of the normalized recovery code';
\ No newline at end of file
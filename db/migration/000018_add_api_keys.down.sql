@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "api_key_permission";
+
+DROP TABLE IF EXISTS "api_key";
\ No newline at end of file
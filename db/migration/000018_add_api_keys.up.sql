@@ -0,0 +1,31 @@
+CREATE TABLE "api_key" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar UNIQUE NOT NULL,
+  "key_hash" varchar UNIQUE NOT NULL,
+  "admin_id" bigint NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "api_key_permission" (
+  "api_key_id" bigint NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("api_key_id", "permission")
+);
+
+ALTER TABLE "api_key" ADD FOREIGN KEY ("admin_id") REFERENCES "admin" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "api_key_permission" ADD FOREIGN KEY ("api_key_id") REFERENCES "api_key" ("id") ON DELETE CASCADE;
+
+COMMENT ON COLUMN "api_key"."prefix" IS 'public part of the key, shown to tell the keys apart';
+
+COMMENT ON COLUMN "api_key"."key_hash" IS 'SHA-256 of the whole key';
+
+COMMENT ON COLUMN "api_key"."admin_id" IS 'admin who created the key';
+
+COMMENT ON COLUMN "api_key"."expires_at" IS 'the key never expires when null';
+
+COMMENT ON COLUMN "api_key_permission"."permission" IS 'one of the permissions known by the api package';
\ No newline at end of file
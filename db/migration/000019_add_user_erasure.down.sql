@@ -0,0 +1 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS "erased_at";
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "user" ADD COLUMN "erased_at" timestamptz;
+
+COMMENT ON COLUMN "user"."erased_at" IS 'set once the personal data of the user was anonymized, the account can''t be used anymore';
\ No newline at end of file
@@ -0,0 +1,11 @@
+COMMENT ON COLUMN "user_address"."telephone" IS NULL;
+
+COMMENT ON COLUMN "user"."telephone" IS NULL;
+
+ALTER TABLE "user_address"
+ALTER COLUMN "telephone" TYPE int
+USING (CASE WHEN "telephone" ~ '^\+218[0-9]{9}$' THEN substr("telephone", 5)::int ELSE 0 END);
+
+ALTER TABLE "user"
+ALTER COLUMN "telephone" TYPE int
+USING (CASE WHEN "telephone" ~ '^\+218[0-9]{9}$' THEN substr("telephone", 5)::int ELSE 0 END);
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- the numbers stored so far are libyan mobiles in national format without
+-- their leading 0, as enforced by the api, anything else can't be recovered
+ALTER TABLE "user"
+ALTER COLUMN "telephone" TYPE varchar
+USING (CASE WHEN "telephone" BETWEEN 100000000 AND 999999999 THEN '+218' || "telephone"::text ELSE '' END);
+
+ALTER TABLE "user_address"
+ALTER COLUMN "telephone" TYPE varchar
+USING (CASE WHEN "telephone" BETWEEN 100000000 AND 999999999 THEN '+218' || "telephone"::text ELSE '' END);
+
+COMMENT ON COLUMN "user"."telephone" IS 'E.164 format, empty once the user is erased';
+
+COMMENT ON COLUMN "user_address"."telephone" IS 'E.164 format';
\ No newline at end of file
@@ -0,0 +1,13 @@
+DELETE FROM "shopping_session" WHERE "user_id" IS NULL;
+
+ALTER TABLE "cart_item" DROP CONSTRAINT IF EXISTS "cart_item_session_id_fkey";
+
+ALTER TABLE "cart_item" ADD FOREIGN KEY ("session_id") REFERENCES "shopping_session" ("id");
+
+ALTER TABLE "shopping_session" DROP CONSTRAINT IF EXISTS "shopping_session_owner_check";
+
+ALTER TABLE "shopping_session" DROP COLUMN IF EXISTS "guest_token_hash";
+
+COMMENT ON COLUMN "shopping_session"."user_id" IS NULL;
+
+ALTER TABLE "shopping_session" ALTER COLUMN "user_id" SET NOT NULL;
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE "shopping_session" ALTER COLUMN "user_id" DROP NOT NULL;
+
+ALTER TABLE "shopping_session" ADD COLUMN "guest_token_hash" varchar UNIQUE;
+
+ALTER TABLE "shopping_session"
+ADD CONSTRAINT "shopping_session_owner_check" CHECK (("user_id" IS NULL) <> ("guest_token_hash" IS NULL));
+
+CREATE INDEX ON "shopping_session" ("created_at") WHERE "user_id" IS NULL;
+
+ALTER TABLE "cart_item" DROP CONSTRAINT "cart_item_session_id_fkey";
+
+ALTER TABLE "cart_item" ADD FOREIGN KEY ("session_id") REFERENCES "shopping_session" ("id") ON DELETE CASCADE;
+
+COMMENT ON COLUMN "shopping_session"."user_id" IS 'NULL while the shopping session is the cart of a guest';
+
+COMMENT ON COLUMN "shopping_session"."guest_token_hash" IS 'SHA-256 of the cart token of a guest, NULL once the shopping session belongs to a user';
\ No newline at end of file
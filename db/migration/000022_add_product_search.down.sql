@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS set_search_vector_product ON "product";
+
+DROP FUNCTION IF EXISTS trigger_set_product_search_vector();
+
+DROP FUNCTION IF EXISTS product_search_vector(varchar, text, varchar);
+
+DROP TABLE IF EXISTS "product_search";
\ No newline at end of file
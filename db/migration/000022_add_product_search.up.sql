@@ -0,0 +1,35 @@
+CREATE TABLE "product_search" (
+  "product_id" bigint PRIMARY KEY NOT NULL,
+  "search_vector" tsvector NOT NULL
+);
+
+ALTER TABLE "product_search" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "product_search" USING GIN ("search_vector");
+
+COMMENT ON COLUMN "product_search"."search_vector" IS 'kept in sync with the name, description and sku of the product by the set_search_vector_product trigger';
+
+CREATE OR REPLACE FUNCTION product_search_vector(name varchar, description text, sku varchar)
+RETURNS tsvector AS $$
+  SELECT setweight(to_tsvector('simple', coalesce(sku, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B');
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION trigger_set_product_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO "product_search" ("product_id", "search_vector")
+  VALUES (NEW.id, product_search_vector(NEW.name, NEW.description, NEW.sku))
+  ON CONFLICT ("product_id") DO UPDATE SET "search_vector" = EXCLUDED."search_vector";
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_search_vector_product
+AFTER INSERT OR UPDATE OF "name", "description", "sku" ON "product"
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_product_search_vector();
+
+INSERT INTO "product_search" ("product_id", "search_vector")
+SELECT "id", product_search_vector("name", "description", "sku") FROM "product";
\ No newline at end of file
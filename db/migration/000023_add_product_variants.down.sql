@@ -0,0 +1,31 @@
+COMMENT ON COLUMN "order_item"."product_sku" IS 'snapshot at purchase time';
+
+ALTER TABLE "order_item" DROP COLUMN IF EXISTS "variant_name";
+
+ALTER TABLE "order_item" DROP COLUMN IF EXISTS "variant_id";
+
+-- a cart holds a product once again, the other variants of it are dropped
+DELETE FROM "cart_item" AS c
+USING "cart_item" AS other
+WHERE other.session_id = c.session_id
+AND other.product_id = c.product_id
+AND other.id < c.id;
+
+ALTER TABLE "cart_item" DROP CONSTRAINT IF EXISTS "cart_item_session_id_variant_id_key";
+
+ALTER TABLE "cart_item" DROP COLUMN IF EXISTS "variant_id";
+
+ALTER TABLE "cart_item"
+ADD CONSTRAINT "cart_item_session_id_product_id_key" UNIQUE ("session_id", "product_id");
+
+DROP TRIGGER IF EXISTS create_default_variant_product ON "product";
+
+DROP FUNCTION IF EXISTS trigger_create_default_product_variant();
+
+DROP TABLE IF EXISTS "product_variant_option_value";
+
+DROP TABLE IF EXISTS "product_variant";
+
+DROP TABLE IF EXISTS "product_option_value";
+
+DROP TABLE IF EXISTS "product_option";
@@ -0,0 +1,125 @@
+CREATE TABLE "product_option" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "product_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "position" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "product_option_value" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "option_id" bigint NOT NULL,
+  "value" varchar NOT NULL,
+  "position" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "product_variant" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "product_id" bigint NOT NULL,
+  "sku" varchar UNIQUE NOT NULL,
+  "price" decimal,
+  "inventory_id" bigint UNIQUE NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE TABLE "product_variant_option_value" (
+  "variant_id" bigint NOT NULL,
+  "option_id" bigint NOT NULL,
+  "option_value_id" bigint NOT NULL,
+  PRIMARY KEY ("variant_id", "option_id")
+);
+
+ALTER TABLE "product_option" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "product_option"
+ADD CONSTRAINT "product_option_product_id_name_key" UNIQUE ("product_id", "name");
+
+ALTER TABLE "product_option_value" ADD FOREIGN KEY ("option_id") REFERENCES "product_option" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "product_option_value"
+ADD CONSTRAINT "product_option_value_option_id_value_key" UNIQUE ("option_id", "value");
+
+ALTER TABLE "product_option_value"
+ADD CONSTRAINT "product_option_value_id_option_id_key" UNIQUE ("id", "option_id");
+
+ALTER TABLE "product_variant" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "product_variant" ADD FOREIGN KEY ("inventory_id") REFERENCES "product_inventory" ("id");
+
+ALTER TABLE "product_variant"
+ADD CONSTRAINT "product_variant_id_product_id_key" UNIQUE ("id", "product_id");
+
+ALTER TABLE "product_variant"
+ADD CONSTRAINT "product_variant_price_check" CHECK ("price" > 0);
+
+CREATE INDEX ON "product_variant" ("product_id");
+
+CREATE TRIGGER set_timestamp_product_variant
+BEFORE UPDATE ON "product_variant"
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+ALTER TABLE "product_variant_option_value" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variant" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "product_variant_option_value" ADD FOREIGN KEY ("option_value_id", "option_id") REFERENCES "product_option_value" ("id", "option_id") ON DELETE CASCADE;
+
+COMMENT ON COLUMN "product_option"."name" IS 'such as size or colour';
+
+COMMENT ON COLUMN "product_variant"."price" IS 'NULL when the variant is sold at the price of its product';
+
+COMMENT ON COLUMN "product_variant_option_value"."option_id" IS 'a variant takes a single value of every option of its product';
+
+-- every product is sold through at least one variant, the one sharing its sku and inventory
+CREATE OR REPLACE FUNCTION trigger_create_default_product_variant()
+RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO "product_variant" ("product_id", "sku", "inventory_id")
+  VALUES (NEW.id, NEW.sku, NEW.inventory_id);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER create_default_variant_product
+AFTER INSERT ON "product"
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_create_default_product_variant();
+
+INSERT INTO "product_variant" ("product_id", "sku", "inventory_id")
+SELECT "id", "sku", "inventory_id" FROM "product";
+
+ALTER TABLE "cart_item" ADD COLUMN "variant_id" bigint;
+
+UPDATE "cart_item"
+SET variant_id = "product_variant".id
+FROM "product_variant"
+WHERE "product_variant".product_id = "cart_item".product_id;
+
+ALTER TABLE "cart_item" ALTER COLUMN "variant_id" SET NOT NULL;
+
+ALTER TABLE "cart_item" ADD FOREIGN KEY ("variant_id", "product_id") REFERENCES "product_variant" ("id", "product_id") ON DELETE CASCADE;
+
+ALTER TABLE "cart_item"
+DROP CONSTRAINT "cart_item_session_id_product_id_key";
+
+ALTER TABLE "cart_item"
+ADD CONSTRAINT "cart_item_session_id_variant_id_key" UNIQUE ("session_id", "variant_id");
+
+ALTER TABLE "order_item" ADD COLUMN "variant_id" bigint;
+
+ALTER TABLE "order_item" ADD COLUMN "variant_name" varchar NOT NULL DEFAULT '';
+
+UPDATE "order_item"
+SET variant_id = "product_variant".id
+FROM "product_variant"
+WHERE "product_variant".product_id = "order_item".product_id;
+
+ALTER TABLE "order_item" ALTER COLUMN "variant_id" SET NOT NULL;
+
+ALTER TABLE "order_item" ADD FOREIGN KEY ("variant_id", "product_id") REFERENCES "product_variant" ("id", "product_id");
+
+COMMENT ON COLUMN "order_item"."product_sku" IS 'snapshot at purchase time, the sku of the variant';
+
+COMMENT ON COLUMN "order_item"."variant_name" IS 'snapshot at purchase time, the option values of the variant such as M / Red';
@@ -0,0 +1,2 @@
+-- the stale totals the orders had before are not worth restoring
+SELECT 1;
\ No newline at end of file
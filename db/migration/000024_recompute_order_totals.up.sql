@@ -0,0 +1,16 @@
+-- orders built by hand were created with a total of 0 that was never updated
+-- as items were added, their total is now the sum of their lines; the totals
+-- of checked out orders were computed at purchase time and are left alone
+UPDATE "order_detail"
+SET total = lines.total
+FROM (
+  SELECT order_id, ROUND(SUM(price * quantity), 2) AS total
+  FROM "order_item"
+  GROUP BY order_id
+) AS lines
+WHERE lines.order_id = "order_detail".id
+AND NOT EXISTS (
+  SELECT 1 FROM "payment_detail"
+  WHERE "payment_detail".id = "order_detail".payment_id
+  AND "payment_detail".status = 'Finished'
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "admin" DROP COLUMN IF EXISTS "locked_until";
+
+ALTER TABLE "admin" DROP COLUMN IF EXISTS "failed_login_attempts";
\ No newline at end of file
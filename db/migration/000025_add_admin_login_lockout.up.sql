@@ -0,0 +1,7 @@
+ALTER TABLE "admin" ADD COLUMN "failed_login_attempts" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "admin" ADD COLUMN "locked_until" timestamptz;
+
+COMMENT ON COLUMN "admin"."failed_login_attempts" IS 'consecutive failed logins, reset by a successful one';
+
+COMMENT ON COLUMN "admin"."locked_until" IS 'logins are refused until then';
\ No newline at end of file
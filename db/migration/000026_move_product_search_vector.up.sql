@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS set_search_vector_product ON "product";
+
+DROP FUNCTION IF EXISTS trigger_set_product_search_vector();
+
+DROP TABLE IF EXISTS "product_search";
+
+-- a generated column replaces the trigger: postgres computes it for the existing rows when the
+-- column is added, without an UPDATE of every product that would also fire trigger_set_timestamp,
+-- and it can not fall out of sync with the name, description and sku it is built from
+ALTER TABLE "product" ADD COLUMN "search_vector" tsvector NOT NULL
+GENERATED ALWAYS AS (product_search_vector("name", "description", "sku")) STORED;
+
+CREATE INDEX ON "product" USING GIN ("search_vector");
+
+COMMENT ON COLUMN "product"."search_vector" IS 'generated from the name, description and sku of the product';
\ No newline at end of file
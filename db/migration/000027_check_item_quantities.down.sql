@@ -0,0 +1,5 @@
+ALTER TABLE "order_item"
+DROP CONSTRAINT IF EXISTS "order_item_quantity_check";
+
+ALTER TABLE "cart_item"
+DROP CONSTRAINT IF EXISTS "cart_item_quantity_check";
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- cart lines without a positive quantity lowered the total of their session, they are dropped
+DELETE FROM "cart_item" WHERE "quantity" <= 0;
+
+ALTER TABLE "cart_item"
+ADD CONSTRAINT "cart_item_quantity_check" CHECK ("quantity" > 0);
+
+-- past orders are left as they were checked out
+ALTER TABLE "order_item"
+ADD CONSTRAINT "order_item_quantity_check" CHECK ("quantity" > 0) NOT VALID;
\ No newline at end of file
@@ -7,6 +7,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
 	gomock "github.com/golang/mock/gomock"
@@ -36,994 +37,2991 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
-// CreateAdmin mocks base method.
-func (m *MockStore) CreateAdmin(arg0 context.Context, arg1 db.CreateAdminParams) (db.Admin, error) {
+// BlockAdminSessions mocks base method.
+func (m *MockStore) BlockAdminSessions(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAdmin", arg0, arg1)
-	ret0, _ := ret[0].(db.Admin)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "BlockAdminSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateAdmin indicates an expected call of CreateAdmin.
-func (mr *MockStoreMockRecorder) CreateAdmin(arg0, arg1 interface{}) *gomock.Call {
+// BlockAdminSessions indicates an expected call of BlockAdminSessions.
+func (mr *MockStoreMockRecorder) BlockAdminSessions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdmin", reflect.TypeOf((*MockStore)(nil).CreateAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAdminSessions", reflect.TypeOf((*MockStore)(nil).BlockAdminSessions), arg0, arg1)
 }
 
-// CreateAdminType mocks base method.
-func (m *MockStore) CreateAdminType(arg0 context.Context, arg1 string) (db.AdminType, error) {
+// BlockAllUserSessions mocks base method.
+func (m *MockStore) BlockAllUserSessions(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAdminType", arg0, arg1)
-	ret0, _ := ret[0].(db.AdminType)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "BlockAllUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateAdminType indicates an expected call of CreateAdminType.
-func (mr *MockStoreMockRecorder) CreateAdminType(arg0, arg1 interface{}) *gomock.Call {
+// BlockAllUserSessions indicates an expected call of BlockAllUserSessions.
+func (mr *MockStoreMockRecorder) BlockAllUserSessions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminType", reflect.TypeOf((*MockStore)(nil).CreateAdminType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAllUserSessions", reflect.TypeOf((*MockStore)(nil).BlockAllUserSessions), arg0, arg1)
 }
 
-// CreateCartItem mocks base method.
-func (m *MockStore) CreateCartItem(arg0 context.Context, arg1 db.CreateCartItemParams) (db.CartItem, error) {
+// BlockOtherUserSessions mocks base method.
+func (m *MockStore) BlockOtherUserSessions(arg0 context.Context, arg1 db.BlockOtherUserSessionsParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCartItem", arg0, arg1)
-	ret0, _ := ret[0].(db.CartItem)
+	ret := m.ctrl.Call(m, "BlockOtherUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockOtherUserSessions indicates an expected call of BlockOtherUserSessions.
+func (mr *MockStoreMockRecorder) BlockOtherUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockOtherUserSessions", reflect.TypeOf((*MockStore)(nil).BlockOtherUserSessions), arg0, arg1)
+}
+
+// BlockUserSession mocks base method.
+func (m *MockStore) BlockUserSession(arg0 context.Context, arg1 db.BlockUserSessionParams) (db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSession", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCartItem indicates an expected call of CreateCartItem.
-func (mr *MockStoreMockRecorder) CreateCartItem(arg0, arg1 interface{}) *gomock.Call {
+// BlockUserSession indicates an expected call of BlockUserSession.
+func (mr *MockStoreMockRecorder) BlockUserSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCartItem", reflect.TypeOf((*MockStore)(nil).CreateCartItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSession", reflect.TypeOf((*MockStore)(nil).BlockUserSession), arg0, arg1)
 }
 
-// CreateDiscount mocks base method.
-func (m *MockStore) CreateDiscount(arg0 context.Context, arg1 db.CreateDiscountParams) (db.Discount, error) {
+// BlockUserSessionFamily mocks base method.
+func (m *MockStore) BlockUserSessionFamily(arg0 context.Context, arg1 db.BlockUserSessionFamilyParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDiscount", arg0, arg1)
-	ret0, _ := ret[0].(db.Discount)
+	ret := m.ctrl.Call(m, "BlockUserSessionFamily", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUserSessionFamily indicates an expected call of BlockUserSessionFamily.
+func (mr *MockStoreMockRecorder) BlockUserSessionFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessionFamily", reflect.TypeOf((*MockStore)(nil).BlockUserSessionFamily), arg0, arg1)
+}
+
+// ChangePasswordTx mocks base method.
+func (m *MockStore) ChangePasswordTx(arg0 context.Context, arg1 db.ChangePasswordTxParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateDiscount indicates an expected call of CreateDiscount.
-func (mr *MockStoreMockRecorder) CreateDiscount(arg0, arg1 interface{}) *gomock.Call {
+// ChangePasswordTx indicates an expected call of ChangePasswordTx.
+func (mr *MockStoreMockRecorder) ChangePasswordTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiscount", reflect.TypeOf((*MockStore)(nil).CreateDiscount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePasswordTx", reflect.TypeOf((*MockStore)(nil).ChangePasswordTx), arg0, arg1)
 }
 
-// CreateOrderDetailAndPaymentDetail mocks base method.
-func (m *MockStore) CreateOrderDetailAndPaymentDetail(arg0 context.Context, arg1 db.CreateOrderDetailAndPaymentDetailParams) (db.OrderDetail, error) {
+// CheckAdminSession mocks base method.
+func (m *MockStore) CheckAdminSession(arg0 context.Context, arg1 db.CheckAdminSessionParams) (db.CheckAdminSessionRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrderDetailAndPaymentDetail", arg0, arg1)
-	ret0, _ := ret[0].(db.OrderDetail)
+	ret := m.ctrl.Call(m, "CheckAdminSession", arg0, arg1)
+	ret0, _ := ret[0].(db.CheckAdminSessionRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateOrderDetailAndPaymentDetail indicates an expected call of CreateOrderDetailAndPaymentDetail.
-func (mr *MockStoreMockRecorder) CreateOrderDetailAndPaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+// CheckAdminSession indicates an expected call of CheckAdminSession.
+func (mr *MockStoreMockRecorder) CheckAdminSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderDetailAndPaymentDetail", reflect.TypeOf((*MockStore)(nil).CreateOrderDetailAndPaymentDetail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAdminSession", reflect.TypeOf((*MockStore)(nil).CheckAdminSession), arg0, arg1)
 }
 
-// CreateOrderItem mocks base method.
-func (m *MockStore) CreateOrderItem(arg0 context.Context, arg1 db.CreateOrderItemParams) (db.OrderItem, error) {
+// CheckUserSession mocks base method.
+func (m *MockStore) CheckUserSession(arg0 context.Context, arg1 db.CheckUserSessionParams) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrderItem", arg0, arg1)
-	ret0, _ := ret[0].(db.OrderItem)
+	ret := m.ctrl.Call(m, "CheckUserSession", arg0, arg1)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateOrderItem indicates an expected call of CreateOrderItem.
-func (mr *MockStoreMockRecorder) CreateOrderItem(arg0, arg1 interface{}) *gomock.Call {
+// CheckUserSession indicates an expected call of CheckUserSession.
+func (mr *MockStoreMockRecorder) CheckUserSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserSession", reflect.TypeOf((*MockStore)(nil).CheckUserSession), arg0, arg1)
 }
 
-// CreatePaymentDetail mocks base method.
-func (m *MockStore) CreatePaymentDetail(arg0 context.Context, arg1 db.CreatePaymentDetailParams) (db.PaymentDetail, error) {
+// ClaimGuestShoppingSession mocks base method.
+func (m *MockStore) ClaimGuestShoppingSession(arg0 context.Context, arg1 db.ClaimGuestShoppingSessionParams) (db.ShoppingSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePaymentDetail", arg0, arg1)
-	ret0, _ := ret[0].(db.PaymentDetail)
+	ret := m.ctrl.Call(m, "ClaimGuestShoppingSession", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreatePaymentDetail indicates an expected call of CreatePaymentDetail.
-func (mr *MockStoreMockRecorder) CreatePaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+// ClaimGuestShoppingSession indicates an expected call of ClaimGuestShoppingSession.
+func (mr *MockStoreMockRecorder) ClaimGuestShoppingSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentDetail", reflect.TypeOf((*MockStore)(nil).CreatePaymentDetail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimGuestShoppingSession", reflect.TypeOf((*MockStore)(nil).ClaimGuestShoppingSession), arg0, arg1)
 }
 
-// CreateProduct mocks base method.
-func (m *MockStore) CreateProduct(arg0 context.Context, arg1 db.CreateProductParams) (db.Product, error) {
+// ConfirmAdminTOTP mocks base method.
+func (m *MockStore) ConfirmAdminTOTP(arg0 context.Context, arg1 int64) (db.AdminTotp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "ConfirmAdminTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTotp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 interface{}) *gomock.Call {
+// ConfirmAdminTOTP indicates an expected call of ConfirmAdminTOTP.
+func (mr *MockStoreMockRecorder) ConfirmAdminTOTP(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAdminTOTP", reflect.TypeOf((*MockStore)(nil).ConfirmAdminTOTP), arg0, arg1)
 }
 
-// CreateProductCategory mocks base method.
-func (m *MockStore) CreateProductCategory(arg0 context.Context, arg1 db.CreateProductCategoryParams) (db.ProductCategory, error) {
+// ConfirmEmailChangeTx mocks base method.
+func (m *MockStore) ConfirmEmailChangeTx(arg0 context.Context, arg1 db.ConfirmEmailChangeTxParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProductCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductCategory)
+	ret := m.ctrl.Call(m, "ConfirmEmailChangeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProductCategory indicates an expected call of CreateProductCategory.
-func (mr *MockStoreMockRecorder) CreateProductCategory(arg0, arg1 interface{}) *gomock.Call {
+// ConfirmEmailChangeTx indicates an expected call of ConfirmEmailChangeTx.
+func (mr *MockStoreMockRecorder) ConfirmEmailChangeTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductCategory", reflect.TypeOf((*MockStore)(nil).CreateProductCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChangeTx", reflect.TypeOf((*MockStore)(nil).ConfirmEmailChangeTx), arg0, arg1)
 }
 
-// CreateProductInventory mocks base method.
-func (m *MockStore) CreateProductInventory(arg0 context.Context, arg1 int32) (db.ProductInventory, error) {
+// ConfirmUserTOTP mocks base method.
+func (m *MockStore) ConfirmUserTOTP(arg0 context.Context, arg1 int64) (db.UserTotp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProductInventory", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductInventory)
+	ret := m.ctrl.Call(m, "ConfirmUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProductInventory indicates an expected call of CreateProductInventory.
-func (mr *MockStoreMockRecorder) CreateProductInventory(arg0, arg1 interface{}) *gomock.Call {
+// ConfirmUserTOTP indicates an expected call of ConfirmUserTOTP.
+func (mr *MockStoreMockRecorder) ConfirmUserTOTP(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductInventory", reflect.TypeOf((*MockStore)(nil).CreateProductInventory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUserTOTP", reflect.TypeOf((*MockStore)(nil).ConfirmUserTOTP), arg0, arg1)
 }
 
-// CreateShoppingSession mocks base method.
-func (m *MockStore) CreateShoppingSession(arg0 context.Context, arg1 db.CreateShoppingSessionParams) (db.ShoppingSession, error) {
+// CountAPIKeys mocks base method.
+func (m *MockStore) CountAPIKeys(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateShoppingSession", arg0, arg1)
-	ret0, _ := ret[0].(db.ShoppingSession)
+	ret := m.ctrl.Call(m, "CountAPIKeys", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateShoppingSession indicates an expected call of CreateShoppingSession.
-func (mr *MockStoreMockRecorder) CreateShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+// CountAPIKeys indicates an expected call of CountAPIKeys.
+func (mr *MockStoreMockRecorder) CountAPIKeys(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShoppingSession", reflect.TypeOf((*MockStore)(nil).CreateShoppingSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAPIKeys", reflect.TypeOf((*MockStore)(nil).CountAPIKeys), arg0)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// CountAdminRecoveryCodes mocks base method.
+func (m *MockStore) CountAdminRecoveryCodes(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "CountAdminRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// CountAdminRecoveryCodes indicates an expected call of CountAdminRecoveryCodes.
+func (mr *MockStoreMockRecorder) CountAdminRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAdminRecoveryCodes", reflect.TypeOf((*MockStore)(nil).CountAdminRecoveryCodes), arg0, arg1)
 }
 
-// CreateUserAddress mocks base method.
-func (m *MockStore) CreateUserAddress(arg0 context.Context, arg1 db.CreateUserAddressParams) (db.UserAddress, error) {
+// CountAdminTypes mocks base method.
+func (m *MockStore) CountAdminTypes(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserAddress", arg0, arg1)
-	ret0, _ := ret[0].(db.UserAddress)
+	ret := m.ctrl.Call(m, "CountAdminTypes", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserAddress indicates an expected call of CreateUserAddress.
-func (mr *MockStoreMockRecorder) CreateUserAddress(arg0, arg1 interface{}) *gomock.Call {
+// CountAdminTypes indicates an expected call of CountAdminTypes.
+func (mr *MockStoreMockRecorder) CountAdminTypes(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAddress", reflect.TypeOf((*MockStore)(nil).CreateUserAddress), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAdminTypes", reflect.TypeOf((*MockStore)(nil).CountAdminTypes), arg0)
 }
 
-// CreateUserPayment mocks base method.
-func (m *MockStore) CreateUserPayment(arg0 context.Context, arg1 db.CreateUserPaymentParams) (db.UserPayment, error) {
+// CountAdmins mocks base method.
+func (m *MockStore) CountAdmins(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserPayment", arg0, arg1)
-	ret0, _ := ret[0].(db.UserPayment)
+	ret := m.ctrl.Call(m, "CountAdmins", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserPayment indicates an expected call of CreateUserPayment.
-func (mr *MockStoreMockRecorder) CreateUserPayment(arg0, arg1 interface{}) *gomock.Call {
+// CountAdmins indicates an expected call of CountAdmins.
+func (mr *MockStoreMockRecorder) CountAdmins(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserPayment", reflect.TypeOf((*MockStore)(nil).CreateUserPayment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAdmins", reflect.TypeOf((*MockStore)(nil).CountAdmins), arg0)
 }
 
-// CreateUserSession mocks base method.
-func (m *MockStore) CreateUserSession(arg0 context.Context, arg1 db.CreateUserSessionParams) (db.UserSession, error) {
+// CountDiscounts mocks base method.
+func (m *MockStore) CountDiscounts(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserSession", arg0, arg1)
-	ret0, _ := ret[0].(db.UserSession)
+	ret := m.ctrl.Call(m, "CountDiscounts", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserSession indicates an expected call of CreateUserSession.
-func (mr *MockStoreMockRecorder) CreateUserSession(arg0, arg1 interface{}) *gomock.Call {
+// CountDiscounts indicates an expected call of CountDiscounts.
+func (mr *MockStoreMockRecorder) CountDiscounts(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSession", reflect.TypeOf((*MockStore)(nil).CreateUserSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDiscounts", reflect.TypeOf((*MockStore)(nil).CountDiscounts), arg0)
 }
 
-// DeleteAdmin mocks base method.
-func (m *MockStore) DeleteAdmin(arg0 context.Context, arg1 int64) error {
+// CountFilteredProducts mocks base method.
+func (m *MockStore) CountFilteredProducts(arg0 context.Context, arg1 db.FilterProductsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAdmin", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountFilteredProducts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAdmin indicates an expected call of DeleteAdmin.
-func (mr *MockStoreMockRecorder) DeleteAdmin(arg0, arg1 interface{}) *gomock.Call {
+// CountFilteredProducts indicates an expected call of CountFilteredProducts.
+func (mr *MockStoreMockRecorder) CountFilteredProducts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdmin", reflect.TypeOf((*MockStore)(nil).DeleteAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFilteredProducts", reflect.TypeOf((*MockStore)(nil).CountFilteredProducts), arg0, arg1)
 }
 
-// DeleteAdminTypeByID mocks base method.
-func (m *MockStore) DeleteAdminTypeByID(arg0 context.Context, arg1 int64) error {
+// CountLockedUsers mocks base method.
+func (m *MockStore) CountLockedUsers(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAdminTypeByID", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountLockedUsers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAdminTypeByID indicates an expected call of DeleteAdminTypeByID.
-func (mr *MockStoreMockRecorder) DeleteAdminTypeByID(arg0, arg1 interface{}) *gomock.Call {
+// CountLockedUsers indicates an expected call of CountLockedUsers.
+func (mr *MockStoreMockRecorder) CountLockedUsers(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminTypeByID", reflect.TypeOf((*MockStore)(nil).DeleteAdminTypeByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLockedUsers", reflect.TypeOf((*MockStore)(nil).CountLockedUsers), arg0)
 }
 
-// DeleteAdminTypeByType mocks base method.
-func (m *MockStore) DeleteAdminTypeByType(arg0 context.Context, arg1 string) error {
+// CountOrderDetails mocks base method.
+func (m *MockStore) CountOrderDetails(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAdminTypeByType", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountOrderDetails", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAdminTypeByType indicates an expected call of DeleteAdminTypeByType.
-func (mr *MockStoreMockRecorder) DeleteAdminTypeByType(arg0, arg1 interface{}) *gomock.Call {
+// CountOrderDetails indicates an expected call of CountOrderDetails.
+func (mr *MockStoreMockRecorder) CountOrderDetails(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminTypeByType", reflect.TypeOf((*MockStore)(nil).DeleteAdminTypeByType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrderDetails", reflect.TypeOf((*MockStore)(nil).CountOrderDetails), arg0, arg1)
 }
 
-// DeleteCartItem mocks base method.
-func (m *MockStore) DeleteCartItem(arg0 context.Context, arg1 int64) error {
+// CountOrderDetailsOfAllUsers mocks base method.
+func (m *MockStore) CountOrderDetailsOfAllUsers(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCartItem", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountOrderDetailsOfAllUsers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCartItem indicates an expected call of DeleteCartItem.
-func (mr *MockStoreMockRecorder) DeleteCartItem(arg0, arg1 interface{}) *gomock.Call {
+// CountOrderDetailsOfAllUsers indicates an expected call of CountOrderDetailsOfAllUsers.
+func (mr *MockStoreMockRecorder) CountOrderDetailsOfAllUsers(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartItem", reflect.TypeOf((*MockStore)(nil).DeleteCartItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrderDetailsOfAllUsers", reflect.TypeOf((*MockStore)(nil).CountOrderDetailsOfAllUsers), arg0)
 }
 
-// DeleteDiscount mocks base method.
-func (m *MockStore) DeleteDiscount(arg0 context.Context, arg1 int64) error {
+// CountOrderItems mocks base method.
+func (m *MockStore) CountOrderItems(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDiscount", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountOrderItems", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteDiscount indicates an expected call of DeleteDiscount.
-func (mr *MockStoreMockRecorder) DeleteDiscount(arg0, arg1 interface{}) *gomock.Call {
+// CountOrderItems indicates an expected call of CountOrderItems.
+func (mr *MockStoreMockRecorder) CountOrderItems(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDiscount", reflect.TypeOf((*MockStore)(nil).DeleteDiscount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrderItems", reflect.TypeOf((*MockStore)(nil).CountOrderItems), arg0, arg1)
 }
 
-// DeleteOrderDetail mocks base method.
-func (m *MockStore) DeleteOrderDetail(arg0 context.Context, arg1 int64) error {
+// CountPaymentDetails mocks base method.
+func (m *MockStore) CountPaymentDetails(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrderDetail", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountPaymentDetails", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteOrderDetail indicates an expected call of DeleteOrderDetail.
-func (mr *MockStoreMockRecorder) DeleteOrderDetail(arg0, arg1 interface{}) *gomock.Call {
+// CountPaymentDetails indicates an expected call of CountPaymentDetails.
+func (mr *MockStoreMockRecorder) CountPaymentDetails(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderDetail", reflect.TypeOf((*MockStore)(nil).DeleteOrderDetail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPaymentDetails", reflect.TypeOf((*MockStore)(nil).CountPaymentDetails), arg0, arg1)
 }
 
-// DeleteOrderItem mocks base method.
-func (m *MockStore) DeleteOrderItem(arg0 context.Context, arg1 int64) error {
+// CountProductCategories mocks base method.
+func (m *MockStore) CountProductCategories(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrderItem", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountProductCategories", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteOrderItem indicates an expected call of DeleteOrderItem.
-func (mr *MockStoreMockRecorder) DeleteOrderItem(arg0, arg1 interface{}) *gomock.Call {
+// CountProductCategories indicates an expected call of CountProductCategories.
+func (mr *MockStoreMockRecorder) CountProductCategories(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderItem", reflect.TypeOf((*MockStore)(nil).DeleteOrderItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductCategories", reflect.TypeOf((*MockStore)(nil).CountProductCategories), arg0)
 }
 
-// DeletePaymentDetail mocks base method.
-func (m *MockStore) DeletePaymentDetail(arg0 context.Context, arg1 int64) error {
+// CountProductInventories mocks base method.
+func (m *MockStore) CountProductInventories(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePaymentDetail", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountProductInventories", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeletePaymentDetail indicates an expected call of DeletePaymentDetail.
-func (mr *MockStoreMockRecorder) DeletePaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+// CountProductInventories indicates an expected call of CountProductInventories.
+func (mr *MockStoreMockRecorder) CountProductInventories(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentDetail", reflect.TypeOf((*MockStore)(nil).DeletePaymentDetail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductInventories", reflect.TypeOf((*MockStore)(nil).CountProductInventories), arg0)
 }
 
-// DeleteProduct mocks base method.
-func (m *MockStore) DeleteProduct(arg0 context.Context, arg1 int64) error {
+// CountSearchAdmins mocks base method.
+func (m *MockStore) CountSearchAdmins(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountSearchAdmins", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockStoreMockRecorder) DeleteProduct(arg0, arg1 interface{}) *gomock.Call {
+// CountSearchAdmins indicates an expected call of CountSearchAdmins.
+func (mr *MockStoreMockRecorder) CountSearchAdmins(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearchAdmins", reflect.TypeOf((*MockStore)(nil).CountSearchAdmins), arg0, arg1)
 }
 
-// DeleteProductCategory mocks base method.
-func (m *MockStore) DeleteProductCategory(arg0 context.Context, arg1 int64) error {
+// CountSearchProducts mocks base method.
+func (m *MockStore) CountSearchProducts(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProductCategory", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountSearchProducts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteProductCategory indicates an expected call of DeleteProductCategory.
-func (mr *MockStoreMockRecorder) DeleteProductCategory(arg0, arg1 interface{}) *gomock.Call {
+// CountSearchProducts indicates an expected call of CountSearchProducts.
+func (mr *MockStoreMockRecorder) CountSearchProducts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductCategory", reflect.TypeOf((*MockStore)(nil).DeleteProductCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearchProducts", reflect.TypeOf((*MockStore)(nil).CountSearchProducts), arg0, arg1)
 }
 
-// DeleteProductInventory mocks base method.
-func (m *MockStore) DeleteProductInventory(arg0 context.Context, arg1 int64) error {
+// CountUserAddresses mocks base method.
+func (m *MockStore) CountUserAddresses(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProductInventory", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountUserAddresses", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteProductInventory indicates an expected call of DeleteProductInventory.
-func (mr *MockStoreMockRecorder) DeleteProductInventory(arg0, arg1 interface{}) *gomock.Call {
+// CountUserAddresses indicates an expected call of CountUserAddresses.
+func (mr *MockStoreMockRecorder) CountUserAddresses(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductInventory", reflect.TypeOf((*MockStore)(nil).DeleteProductInventory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserAddresses", reflect.TypeOf((*MockStore)(nil).CountUserAddresses), arg0, arg1)
 }
 
-// DeleteShoppingSession mocks base method.
-func (m *MockStore) DeleteShoppingSession(arg0 context.Context, arg1 int64) error {
+// CountUserPayments mocks base method.
+func (m *MockStore) CountUserPayments(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteShoppingSession", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountUserPayments", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteShoppingSession indicates an expected call of DeleteShoppingSession.
-func (mr *MockStoreMockRecorder) DeleteShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+// CountUserPayments indicates an expected call of CountUserPayments.
+func (mr *MockStoreMockRecorder) CountUserPayments(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShoppingSession", reflect.TypeOf((*MockStore)(nil).DeleteShoppingSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPayments", reflect.TypeOf((*MockStore)(nil).CountUserPayments), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int64) error {
+// CountUserRecoveryCodes mocks base method.
+func (m *MockStore) CountUserRecoveryCodes(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "CountUserRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserRecoveryCodes indicates an expected call of CountUserRecoveryCodes.
+func (mr *MockStoreMockRecorder) CountUserRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserRecoveryCodes", reflect.TypeOf((*MockStore)(nil).CountUserRecoveryCodes), arg0, arg1)
+}
+
+// CountUsers mocks base method.
+func (m *MockStore) CountUsers(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockStoreMockRecorder) CountUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), arg0)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockStore) CreateAPIKey(arg0 context.Context, arg1 db.CreateAPIKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockStoreMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockStore)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// CreateAPIKeyPermission mocks base method.
+func (m *MockStore) CreateAPIKeyPermission(arg0 context.Context, arg1 db.CreateAPIKeyPermissionParams) (db.ApiKeyPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKeyPermission", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKeyPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKeyPermission indicates an expected call of CreateAPIKeyPermission.
+func (mr *MockStoreMockRecorder) CreateAPIKeyPermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKeyPermission", reflect.TypeOf((*MockStore)(nil).CreateAPIKeyPermission), arg0, arg1)
+}
+
+// CreateAPIKeyTx mocks base method.
+func (m *MockStore) CreateAPIKeyTx(arg0 context.Context, arg1 db.CreateAPIKeyTxParams) (db.APIKeyTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKeyTx", arg0, arg1)
+	ret0, _ := ret[0].(db.APIKeyTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKeyTx indicates an expected call of CreateAPIKeyTx.
+func (mr *MockStoreMockRecorder) CreateAPIKeyTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKeyTx", reflect.TypeOf((*MockStore)(nil).CreateAPIKeyTx), arg0, arg1)
+}
+
+// CreateAdmin mocks base method.
+func (m *MockStore) CreateAdmin(arg0 context.Context, arg1 db.CreateAdminParams) (db.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdmin", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdmin indicates an expected call of CreateAdmin.
+func (mr *MockStoreMockRecorder) CreateAdmin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdmin", reflect.TypeOf((*MockStore)(nil).CreateAdmin), arg0, arg1)
+}
+
+// CreateAdminRecoveryCode mocks base method.
+func (m *MockStore) CreateAdminRecoveryCode(arg0 context.Context, arg1 db.CreateAdminRecoveryCodeParams) (db.AdminRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminRecoveryCode indicates an expected call of CreateAdminRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateAdminRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateAdminRecoveryCode), arg0, arg1)
+}
+
+// CreateAdminSession mocks base method.
+func (m *MockStore) CreateAdminSession(arg0 context.Context, arg1 db.CreateAdminSessionParams) (db.AdminSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminSession", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminSession indicates an expected call of CreateAdminSession.
+func (mr *MockStoreMockRecorder) CreateAdminSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminSession", reflect.TypeOf((*MockStore)(nil).CreateAdminSession), arg0, arg1)
+}
+
+// CreateAdminTOTP mocks base method.
+func (m *MockStore) CreateAdminTOTP(arg0 context.Context, arg1 db.CreateAdminTOTPParams) (db.AdminTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminTOTP indicates an expected call of CreateAdminTOTP.
+func (mr *MockStoreMockRecorder) CreateAdminTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminTOTP", reflect.TypeOf((*MockStore)(nil).CreateAdminTOTP), arg0, arg1)
+}
+
+// CreateAdminType mocks base method.
+func (m *MockStore) CreateAdminType(arg0 context.Context, arg1 string) (db.AdminType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminType", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminType indicates an expected call of CreateAdminType.
+func (mr *MockStoreMockRecorder) CreateAdminType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminType", reflect.TypeOf((*MockStore)(nil).CreateAdminType), arg0, arg1)
+}
+
+// CreateAdminTypePermission mocks base method.
+func (m *MockStore) CreateAdminTypePermission(arg0 context.Context, arg1 db.CreateAdminTypePermissionParams) (db.AdminTypePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminTypePermission", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTypePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminTypePermission indicates an expected call of CreateAdminTypePermission.
+func (mr *MockStoreMockRecorder) CreateAdminTypePermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminTypePermission", reflect.TypeOf((*MockStore)(nil).CreateAdminTypePermission), arg0, arg1)
+}
+
+// CreateAdminTypeTx mocks base method.
+func (m *MockStore) CreateAdminTypeTx(arg0 context.Context, arg1 db.CreateAdminTypeTxParams) (db.AdminTypeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminTypeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTypeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminTypeTx indicates an expected call of CreateAdminTypeTx.
+func (mr *MockStoreMockRecorder) CreateAdminTypeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminTypeTx", reflect.TypeOf((*MockStore)(nil).CreateAdminTypeTx), arg0, arg1)
+}
+
+// CreateCartItem mocks base method.
+func (m *MockStore) CreateCartItem(arg0 context.Context, arg1 db.CreateCartItemParams) (db.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCartItem", arg0, arg1)
+	ret0, _ := ret[0].(db.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCartItem indicates an expected call of CreateCartItem.
+func (mr *MockStoreMockRecorder) CreateCartItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCartItem", reflect.TypeOf((*MockStore)(nil).CreateCartItem), arg0, arg1)
+}
+
+// CreateCartItemTx mocks base method.
+func (m *MockStore) CreateCartItemTx(arg0 context.Context, arg1 db.CreateCartItemParams) (db.CartItemTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCartItemTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CartItemTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCartItemTx indicates an expected call of CreateCartItemTx.
+func (mr *MockStoreMockRecorder) CreateCartItemTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCartItemTx", reflect.TypeOf((*MockStore)(nil).CreateCartItemTx), arg0, arg1)
+}
+
+// CreateDiscount mocks base method.
+func (m *MockStore) CreateDiscount(arg0 context.Context, arg1 db.CreateDiscountParams) (db.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDiscount", arg0, arg1)
+	ret0, _ := ret[0].(db.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDiscount indicates an expected call of CreateDiscount.
+func (mr *MockStoreMockRecorder) CreateDiscount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiscount", reflect.TypeOf((*MockStore)(nil).CreateDiscount), arg0, arg1)
+}
+
+// CreateEmailChangeToken mocks base method.
+func (m *MockStore) CreateEmailChangeToken(arg0 context.Context, arg1 db.CreateEmailChangeTokenParams) (db.EmailChangeToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailChangeToken", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailChangeToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailChangeToken indicates an expected call of CreateEmailChangeToken.
+func (mr *MockStoreMockRecorder) CreateEmailChangeToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailChangeToken", reflect.TypeOf((*MockStore)(nil).CreateEmailChangeToken), arg0, arg1)
+}
+
+// CreateEmailVerificationToken mocks base method.
+func (m *MockStore) CreateEmailVerificationToken(arg0 context.Context, arg1 db.CreateEmailVerificationTokenParams) (db.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockStoreMockRecorder) CreateEmailVerificationToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockStore)(nil).CreateEmailVerificationToken), arg0, arg1)
+}
+
+// CreateGuestShoppingSession mocks base method.
+func (m *MockStore) CreateGuestShoppingSession(arg0 context.Context, arg1 db.CreateGuestShoppingSessionParams) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestShoppingSession", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestShoppingSession indicates an expected call of CreateGuestShoppingSession.
+func (mr *MockStoreMockRecorder) CreateGuestShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestShoppingSession", reflect.TypeOf((*MockStore)(nil).CreateGuestShoppingSession), arg0, arg1)
+}
+
+// CreateOrderDetailAndPaymentDetail mocks base method.
+func (m *MockStore) CreateOrderDetailAndPaymentDetail(arg0 context.Context, arg1 db.CreateOrderDetailAndPaymentDetailParams) (db.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderDetailAndPaymentDetail", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderDetailAndPaymentDetail indicates an expected call of CreateOrderDetailAndPaymentDetail.
+func (mr *MockStoreMockRecorder) CreateOrderDetailAndPaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderDetailAndPaymentDetail", reflect.TypeOf((*MockStore)(nil).CreateOrderDetailAndPaymentDetail), arg0, arg1)
+}
+
+// CreateOrderItem mocks base method.
+func (m *MockStore) CreateOrderItem(arg0 context.Context, arg1 db.CreateOrderItemParams) (db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItem indicates an expected call of CreateOrderItem.
+func (mr *MockStoreMockRecorder) CreateOrderItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), arg0, arg1)
+}
+
+// CreateOrderItemTx mocks base method.
+func (m *MockStore) CreateOrderItemTx(arg0 context.Context, arg1 db.CreateOrderItemTxParams) (db.OrderItemTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItemTx", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderItemTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItemTx indicates an expected call of CreateOrderItemTx.
+func (mr *MockStoreMockRecorder) CreateOrderItemTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItemTx", reflect.TypeOf((*MockStore)(nil).CreateOrderItemTx), arg0, arg1)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockStore) CreatePasswordResetToken(arg0 context.Context, arg1 db.CreatePasswordResetTokenParams) (db.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockStoreMockRecorder) CreatePasswordResetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockStore)(nil).CreatePasswordResetToken), arg0, arg1)
+}
+
+// CreatePaymentDetail mocks base method.
+func (m *MockStore) CreatePaymentDetail(arg0 context.Context, arg1 db.CreatePaymentDetailParams) (db.PaymentDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentDetail", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentDetail indicates an expected call of CreatePaymentDetail.
+func (mr *MockStoreMockRecorder) CreatePaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentDetail", reflect.TypeOf((*MockStore)(nil).CreatePaymentDetail), arg0, arg1)
+}
+
+// CreateProduct mocks base method.
+func (m *MockStore) CreateProduct(arg0 context.Context, arg1 db.CreateProductParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
+}
+
+// CreateProductCategory mocks base method.
+func (m *MockStore) CreateProductCategory(arg0 context.Context, arg1 db.CreateProductCategoryParams) (db.ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductCategory indicates an expected call of CreateProductCategory.
+func (mr *MockStoreMockRecorder) CreateProductCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductCategory", reflect.TypeOf((*MockStore)(nil).CreateProductCategory), arg0, arg1)
+}
+
+// CreateProductInventory mocks base method.
+func (m *MockStore) CreateProductInventory(arg0 context.Context, arg1 int32) (db.ProductInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductInventory", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductInventory indicates an expected call of CreateProductInventory.
+func (mr *MockStoreMockRecorder) CreateProductInventory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductInventory", reflect.TypeOf((*MockStore)(nil).CreateProductInventory), arg0, arg1)
+}
+
+// CreateProductOption mocks base method.
+func (m *MockStore) CreateProductOption(arg0 context.Context, arg1 db.CreateProductOptionParams) (db.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductOption", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductOption indicates an expected call of CreateProductOption.
+func (mr *MockStoreMockRecorder) CreateProductOption(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductOption", reflect.TypeOf((*MockStore)(nil).CreateProductOption), arg0, arg1)
+}
+
+// CreateProductOptionTx mocks base method.
+func (m *MockStore) CreateProductOptionTx(arg0 context.Context, arg1 db.CreateProductOptionTxParams) (db.ProductOptionValues, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductOptionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductOptionValues)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductOptionTx indicates an expected call of CreateProductOptionTx.
+func (mr *MockStoreMockRecorder) CreateProductOptionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductOptionTx", reflect.TypeOf((*MockStore)(nil).CreateProductOptionTx), arg0, arg1)
+}
+
+// CreateProductOptionValue mocks base method.
+func (m *MockStore) CreateProductOptionValue(arg0 context.Context, arg1 db.CreateProductOptionValueParams) (db.ProductOptionValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductOptionValue", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductOptionValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductOptionValue indicates an expected call of CreateProductOptionValue.
+func (mr *MockStoreMockRecorder) CreateProductOptionValue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductOptionValue", reflect.TypeOf((*MockStore)(nil).CreateProductOptionValue), arg0, arg1)
+}
+
+// CreateProductVariant mocks base method.
+func (m *MockStore) CreateProductVariant(arg0 context.Context, arg1 db.CreateProductVariantParams) (db.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductVariant", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductVariant indicates an expected call of CreateProductVariant.
+func (mr *MockStoreMockRecorder) CreateProductVariant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductVariant", reflect.TypeOf((*MockStore)(nil).CreateProductVariant), arg0, arg1)
+}
+
+// CreateProductVariantOptionValue mocks base method.
+func (m *MockStore) CreateProductVariantOptionValue(arg0 context.Context, arg1 db.CreateProductVariantOptionValueParams) (db.ProductVariantOptionValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductVariantOptionValue", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductVariantOptionValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductVariantOptionValue indicates an expected call of CreateProductVariantOptionValue.
+func (mr *MockStoreMockRecorder) CreateProductVariantOptionValue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductVariantOptionValue", reflect.TypeOf((*MockStore)(nil).CreateProductVariantOptionValue), arg0, arg1)
+}
+
+// CreateProductVariantTx mocks base method.
+func (m *MockStore) CreateProductVariantTx(arg0 context.Context, arg1 db.CreateProductVariantTxParams) (db.ProductVariantTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductVariantTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductVariantTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductVariantTx indicates an expected call of CreateProductVariantTx.
+func (mr *MockStoreMockRecorder) CreateProductVariantTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductVariantTx", reflect.TypeOf((*MockStore)(nil).CreateProductVariantTx), arg0, arg1)
+}
+
+// CreateShoppingSession mocks base method.
+func (m *MockStore) CreateShoppingSession(arg0 context.Context, arg1 db.CreateShoppingSessionParams) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShoppingSession", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShoppingSession indicates an expected call of CreateShoppingSession.
+func (mr *MockStoreMockRecorder) CreateShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShoppingSession", reflect.TypeOf((*MockStore)(nil).CreateShoppingSession), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateUserAddress mocks base method.
+func (m *MockStore) CreateUserAddress(arg0 context.Context, arg1 db.CreateUserAddressParams) (db.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserAddress", arg0, arg1)
+	ret0, _ := ret[0].(db.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserAddress indicates an expected call of CreateUserAddress.
+func (mr *MockStoreMockRecorder) CreateUserAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAddress", reflect.TypeOf((*MockStore)(nil).CreateUserAddress), arg0, arg1)
+}
+
+// CreateUserPayment mocks base method.
+func (m *MockStore) CreateUserPayment(arg0 context.Context, arg1 db.CreateUserPaymentParams) (db.UserPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserPayment", arg0, arg1)
+	ret0, _ := ret[0].(db.UserPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserPayment indicates an expected call of CreateUserPayment.
+func (mr *MockStoreMockRecorder) CreateUserPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserPayment", reflect.TypeOf((*MockStore)(nil).CreateUserPayment), arg0, arg1)
+}
+
+// CreateUserRecoveryCode mocks base method.
+func (m *MockStore) CreateUserRecoveryCode(arg0 context.Context, arg1 db.CreateUserRecoveryCodeParams) (db.UserRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(db.UserRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserRecoveryCode indicates an expected call of CreateUserRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateUserRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateUserRecoveryCode), arg0, arg1)
+}
+
+// CreateUserSession mocks base method.
+func (m *MockStore) CreateUserSession(arg0 context.Context, arg1 db.CreateUserSessionParams) (db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserSession", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserSession indicates an expected call of CreateUserSession.
+func (mr *MockStoreMockRecorder) CreateUserSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSession", reflect.TypeOf((*MockStore)(nil).CreateUserSession), arg0, arg1)
+}
+
+// CreateUserTOTP mocks base method.
+func (m *MockStore) CreateUserTOTP(arg0 context.Context, arg1 db.CreateUserTOTPParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTOTP indicates an expected call of CreateUserTOTP.
+func (mr *MockStoreMockRecorder) CreateUserTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTOTP", reflect.TypeOf((*MockStore)(nil).CreateUserTOTP), arg0, arg1)
+}
+
+// DeactivateDefaultProductVariant mocks base method.
+func (m *MockStore) DeactivateDefaultProductVariant(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateDefaultProductVariant", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateDefaultProductVariant indicates an expected call of DeactivateDefaultProductVariant.
+func (mr *MockStoreMockRecorder) DeactivateDefaultProductVariant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateDefaultProductVariant", reflect.TypeOf((*MockStore)(nil).DeactivateDefaultProductVariant), arg0, arg1)
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockStore) DeleteAPIKey(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockStoreMockRecorder) DeleteAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockStore)(nil).DeleteAPIKey), arg0, arg1)
+}
+
+// DeleteAdmin mocks base method.
+func (m *MockStore) DeleteAdmin(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdmin", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdmin indicates an expected call of DeleteAdmin.
+func (mr *MockStoreMockRecorder) DeleteAdmin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdmin", reflect.TypeOf((*MockStore)(nil).DeleteAdmin), arg0, arg1)
+}
+
+// DeleteAdminRecoveryCodes mocks base method.
+func (m *MockStore) DeleteAdminRecoveryCodes(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminRecoveryCodes indicates an expected call of DeleteAdminRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteAdminRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteAdminRecoveryCodes), arg0, arg1)
+}
+
+// DeleteAdminTOTP mocks base method.
+func (m *MockStore) DeleteAdminTOTP(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminTOTP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminTOTP indicates an expected call of DeleteAdminTOTP.
+func (mr *MockStoreMockRecorder) DeleteAdminTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminTOTP", reflect.TypeOf((*MockStore)(nil).DeleteAdminTOTP), arg0, arg1)
+}
+
+// DeleteAdminTypeByID mocks base method.
+func (m *MockStore) DeleteAdminTypeByID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminTypeByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminTypeByID indicates an expected call of DeleteAdminTypeByID.
+func (mr *MockStoreMockRecorder) DeleteAdminTypeByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminTypeByID", reflect.TypeOf((*MockStore)(nil).DeleteAdminTypeByID), arg0, arg1)
+}
+
+// DeleteAdminTypeByType mocks base method.
+func (m *MockStore) DeleteAdminTypeByType(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminTypeByType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminTypeByType indicates an expected call of DeleteAdminTypeByType.
+func (mr *MockStoreMockRecorder) DeleteAdminTypeByType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminTypeByType", reflect.TypeOf((*MockStore)(nil).DeleteAdminTypeByType), arg0, arg1)
+}
+
+// DeleteAdminTypePermissions mocks base method.
+func (m *MockStore) DeleteAdminTypePermissions(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminTypePermissions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminTypePermissions indicates an expected call of DeleteAdminTypePermissions.
+func (mr *MockStoreMockRecorder) DeleteAdminTypePermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminTypePermissions", reflect.TypeOf((*MockStore)(nil).DeleteAdminTypePermissions), arg0, arg1)
+}
+
+// DeleteCartItem mocks base method.
+func (m *MockStore) DeleteCartItem(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCartItem indicates an expected call of DeleteCartItem.
+func (mr *MockStoreMockRecorder) DeleteCartItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartItem", reflect.TypeOf((*MockStore)(nil).DeleteCartItem), arg0, arg1)
+}
+
+// DeleteCartItemTx mocks base method.
+func (m *MockStore) DeleteCartItemTx(arg0 context.Context, arg1 db.DeleteCartItemTxParams) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartItemTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCartItemTx indicates an expected call of DeleteCartItemTx.
+func (mr *MockStoreMockRecorder) DeleteCartItemTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartItemTx", reflect.TypeOf((*MockStore)(nil).DeleteCartItemTx), arg0, arg1)
+}
+
+// DeleteCartItemsByUserID mocks base method.
+func (m *MockStore) DeleteCartItemsByUserID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartItemsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCartItemsByUserID indicates an expected call of DeleteCartItemsByUserID.
+func (mr *MockStoreMockRecorder) DeleteCartItemsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartItemsByUserID", reflect.TypeOf((*MockStore)(nil).DeleteCartItemsByUserID), arg0, arg1)
+}
+
+// DeleteDiscount mocks base method.
+func (m *MockStore) DeleteDiscount(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDiscount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDiscount indicates an expected call of DeleteDiscount.
+func (mr *MockStoreMockRecorder) DeleteDiscount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDiscount", reflect.TypeOf((*MockStore)(nil).DeleteDiscount), arg0, arg1)
+}
+
+// DeleteEmailChangeTokens mocks base method.
+func (m *MockStore) DeleteEmailChangeTokens(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailChangeTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailChangeTokens indicates an expected call of DeleteEmailChangeTokens.
+func (mr *MockStoreMockRecorder) DeleteEmailChangeTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailChangeTokens", reflect.TypeOf((*MockStore)(nil).DeleteEmailChangeTokens), arg0, arg1)
+}
+
+// DeleteEmailVerificationTokens mocks base method.
+func (m *MockStore) DeleteEmailVerificationTokens(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailVerificationTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailVerificationTokens indicates an expected call of DeleteEmailVerificationTokens.
+func (mr *MockStoreMockRecorder) DeleteEmailVerificationTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailVerificationTokens", reflect.TypeOf((*MockStore)(nil).DeleteEmailVerificationTokens), arg0, arg1)
+}
+
+// DeleteExpiredGuestShoppingSessions mocks base method.
+func (m *MockStore) DeleteExpiredGuestShoppingSessions(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredGuestShoppingSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredGuestShoppingSessions indicates an expected call of DeleteExpiredGuestShoppingSessions.
+func (mr *MockStoreMockRecorder) DeleteExpiredGuestShoppingSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredGuestShoppingSessions", reflect.TypeOf((*MockStore)(nil).DeleteExpiredGuestShoppingSessions), arg0, arg1)
+}
+
+// DeleteExpiredStockReservations mocks base method.
+func (m *MockStore) DeleteExpiredStockReservations(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredStockReservations", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredStockReservations indicates an expected call of DeleteExpiredStockReservations.
+func (mr *MockStoreMockRecorder) DeleteExpiredStockReservations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredStockReservations", reflect.TypeOf((*MockStore)(nil).DeleteExpiredStockReservations), arg0)
+}
+
+// DeleteOrderDetail mocks base method.
+func (m *MockStore) DeleteOrderDetail(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderDetail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderDetail indicates an expected call of DeleteOrderDetail.
+func (mr *MockStoreMockRecorder) DeleteOrderDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderDetail", reflect.TypeOf((*MockStore)(nil).DeleteOrderDetail), arg0, arg1)
+}
+
+// DeleteOrderItem mocks base method.
+func (m *MockStore) DeleteOrderItem(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderItem indicates an expected call of DeleteOrderItem.
+func (mr *MockStoreMockRecorder) DeleteOrderItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderItem", reflect.TypeOf((*MockStore)(nil).DeleteOrderItem), arg0, arg1)
+}
+
+// DeletePasswordResetTokens mocks base method.
+func (m *MockStore) DeletePasswordResetTokens(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordResetTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordResetTokens indicates an expected call of DeletePasswordResetTokens.
+func (mr *MockStoreMockRecorder) DeletePasswordResetTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordResetTokens", reflect.TypeOf((*MockStore)(nil).DeletePasswordResetTokens), arg0, arg1)
+}
+
+// DeletePaymentDetail mocks base method.
+func (m *MockStore) DeletePaymentDetail(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymentDetail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePaymentDetail indicates an expected call of DeletePaymentDetail.
+func (mr *MockStoreMockRecorder) DeletePaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentDetail", reflect.TypeOf((*MockStore)(nil).DeletePaymentDetail), arg0, arg1)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockStore) DeleteProduct(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockStoreMockRecorder) DeleteProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), arg0, arg1)
+}
+
+// DeleteProductCategory mocks base method.
+func (m *MockStore) DeleteProductCategory(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductCategory indicates an expected call of DeleteProductCategory.
+func (mr *MockStoreMockRecorder) DeleteProductCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductCategory", reflect.TypeOf((*MockStore)(nil).DeleteProductCategory), arg0, arg1)
+}
+
+// DeleteProductInventory mocks base method.
+func (m *MockStore) DeleteProductInventory(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductInventory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductInventory indicates an expected call of DeleteProductInventory.
+func (mr *MockStoreMockRecorder) DeleteProductInventory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductInventory", reflect.TypeOf((*MockStore)(nil).DeleteProductInventory), arg0, arg1)
+}
+
+// DeleteShoppingSession mocks base method.
+func (m *MockStore) DeleteShoppingSession(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShoppingSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShoppingSession indicates an expected call of DeleteShoppingSession.
+func (mr *MockStoreMockRecorder) DeleteShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShoppingSession", reflect.TypeOf((*MockStore)(nil).DeleteShoppingSession), arg0, arg1)
+}
+
+// DeleteShoppingSessionsByUserID mocks base method.
+func (m *MockStore) DeleteShoppingSessionsByUserID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShoppingSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShoppingSessionsByUserID indicates an expected call of DeleteShoppingSessionsByUserID.
+func (mr *MockStoreMockRecorder) DeleteShoppingSessionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShoppingSessionsByUserID", reflect.TypeOf((*MockStore)(nil).DeleteShoppingSessionsByUserID), arg0, arg1)
+}
+
+// DeleteStockReservationsBySessionID mocks base method.
+func (m *MockStore) DeleteStockReservationsBySessionID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStockReservationsBySessionID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStockReservationsBySessionID indicates an expected call of DeleteStockReservationsBySessionID.
+func (mr *MockStoreMockRecorder) DeleteStockReservationsBySessionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStockReservationsBySessionID", reflect.TypeOf((*MockStore)(nil).DeleteStockReservationsBySessionID), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+}
+
+// DeleteUserAddress mocks base method.
+func (m *MockStore) DeleteUserAddress(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserAddress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserAddress indicates an expected call of DeleteUserAddress.
+func (mr *MockStoreMockRecorder) DeleteUserAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAddress", reflect.TypeOf((*MockStore)(nil).DeleteUserAddress), arg0, arg1)
+}
+
+// DeleteUserAddresses mocks base method.
+func (m *MockStore) DeleteUserAddresses(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserAddresses", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserAddresses indicates an expected call of DeleteUserAddresses.
+func (mr *MockStoreMockRecorder) DeleteUserAddresses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAddresses", reflect.TypeOf((*MockStore)(nil).DeleteUserAddresses), arg0, arg1)
+}
+
+// DeleteUserPayment mocks base method.
+func (m *MockStore) DeleteUserPayment(arg0 context.Context, arg1 db.DeleteUserPaymentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserPayment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserPayment indicates an expected call of DeleteUserPayment.
+func (mr *MockStoreMockRecorder) DeleteUserPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserPayment", reflect.TypeOf((*MockStore)(nil).DeleteUserPayment), arg0, arg1)
+}
+
+// DeleteUserPayments mocks base method.
+func (m *MockStore) DeleteUserPayments(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserPayments", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserPayments indicates an expected call of DeleteUserPayments.
+func (mr *MockStoreMockRecorder) DeleteUserPayments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserPayments", reflect.TypeOf((*MockStore)(nil).DeleteUserPayments), arg0, arg1)
+}
+
+// DeleteUserRecoveryCodes mocks base method.
+func (m *MockStore) DeleteUserRecoveryCodes(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRecoveryCodes indicates an expected call of DeleteUserRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteUserRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteUserRecoveryCodes), arg0, arg1)
+}
+
+// DeleteUserSessions mocks base method.
+func (m *MockStore) DeleteUserSessions(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockStoreMockRecorder) DeleteUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockStore)(nil).DeleteUserSessions), arg0, arg1)
+}
+
+// DeleteUserTOTP mocks base method.
+func (m *MockStore) DeleteUserTOTP(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTOTP indicates an expected call of DeleteUserTOTP.
+func (mr *MockStoreMockRecorder) DeleteUserTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTOTP", reflect.TypeOf((*MockStore)(nil).DeleteUserTOTP), arg0, arg1)
+}
+
+// DisableAdminTOTPTx mocks base method.
+func (m *MockStore) DisableAdminTOTPTx(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableAdminTOTPTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableAdminTOTPTx indicates an expected call of DisableAdminTOTPTx.
+func (mr *MockStoreMockRecorder) DisableAdminTOTPTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAdminTOTPTx", reflect.TypeOf((*MockStore)(nil).DisableAdminTOTPTx), arg0, arg1)
+}
+
+// DisableUserTOTPTx mocks base method.
+func (m *MockStore) DisableUserTOTPTx(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUserTOTPTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUserTOTPTx indicates an expected call of DisableUserTOTPTx.
+func (mr *MockStoreMockRecorder) DisableUserTOTPTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUserTOTPTx", reflect.TypeOf((*MockStore)(nil).DisableUserTOTPTx), arg0, arg1)
+}
+
+// EnableAdminTOTPTx mocks base method.
+func (m *MockStore) EnableAdminTOTPTx(arg0 context.Context, arg1 db.EnableAdminTOTPTxParams) (db.AdminTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAdminTOTPTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableAdminTOTPTx indicates an expected call of EnableAdminTOTPTx.
+func (mr *MockStoreMockRecorder) EnableAdminTOTPTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAdminTOTPTx", reflect.TypeOf((*MockStore)(nil).EnableAdminTOTPTx), arg0, arg1)
+}
+
+// EnableUserTOTPTx mocks base method.
+func (m *MockStore) EnableUserTOTPTx(arg0 context.Context, arg1 db.EnableUserTOTPTxParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserTOTPTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUserTOTPTx indicates an expected call of EnableUserTOTPTx.
+func (mr *MockStoreMockRecorder) EnableUserTOTPTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserTOTPTx", reflect.TypeOf((*MockStore)(nil).EnableUserTOTPTx), arg0, arg1)
+}
+
+// EraseUser mocks base method.
+func (m *MockStore) EraseUser(arg0 context.Context, arg1 int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockStoreMockRecorder) EraseUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockStore)(nil).EraseUser), arg0, arg1)
+}
+
+// EraseUserTx mocks base method.
+func (m *MockStore) EraseUserTx(arg0 context.Context, arg1 int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUserTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUserTx indicates an expected call of EraseUserTx.
+func (mr *MockStoreMockRecorder) EraseUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUserTx", reflect.TypeOf((*MockStore)(nil).EraseUserTx), arg0, arg1)
+}
+
+// ExportUserDataTx mocks base method.
+func (m *MockStore) ExportUserDataTx(arg0 context.Context, arg1 int64) (db.ExportUserDataTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserDataTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ExportUserDataTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserDataTx indicates an expected call of ExportUserDataTx.
+func (mr *MockStoreMockRecorder) ExportUserDataTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserDataTx", reflect.TypeOf((*MockStore)(nil).ExportUserDataTx), arg0, arg1)
+}
+
+// FilterProducts mocks base method.
+func (m *MockStore) FilterProducts(arg0 context.Context, arg1 db.FilterProductsParams) ([]db.FilterProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.FilterProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterProducts indicates an expected call of FilterProducts.
+func (mr *MockStoreMockRecorder) FilterProducts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterProducts", reflect.TypeOf((*MockStore)(nil).FilterProducts), arg0, arg1)
+}
+
+// FinishedPurchaseTx mocks base method.
+func (m *MockStore) FinishedPurchaseTx(arg0 context.Context, arg1 db.FinishedPurchaseTxParams) (db.FinishedPurchaseTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishedPurchaseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.FinishedPurchaseTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishedPurchaseTx indicates an expected call of FinishedPurchaseTx.
+func (mr *MockStoreMockRecorder) FinishedPurchaseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishedPurchaseTx", reflect.TypeOf((*MockStore)(nil).FinishedPurchaseTx), arg0, arg1)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockStore) GetAPIKey(arg0 context.Context, arg1 int64) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockStoreMockRecorder) GetAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockStore)(nil).GetAPIKey), arg0, arg1)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockStore) GetAPIKeyByHash(arg0 context.Context, arg1 string) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockStoreMockRecorder) GetAPIKeyByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockStore)(nil).GetAPIKeyByHash), arg0, arg1)
+}
+
+// GetAdmin mocks base method.
+func (m *MockStore) GetAdmin(arg0 context.Context, arg1 int64) (db.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdmin", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdmin indicates an expected call of GetAdmin.
+func (mr *MockStoreMockRecorder) GetAdmin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdmin", reflect.TypeOf((*MockStore)(nil).GetAdmin), arg0, arg1)
+}
+
+// GetAdminByEmail mocks base method.
+func (m *MockStore) GetAdminByEmail(arg0 context.Context, arg1 string) (db.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminByEmail indicates an expected call of GetAdminByEmail.
+func (mr *MockStoreMockRecorder) GetAdminByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminByEmail", reflect.TypeOf((*MockStore)(nil).GetAdminByEmail), arg0, arg1)
+}
+
+// GetAdminSession mocks base method.
+func (m *MockStore) GetAdminSession(arg0 context.Context, arg1 uuid.UUID) (db.AdminSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminSession", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminSession indicates an expected call of GetAdminSession.
+func (mr *MockStoreMockRecorder) GetAdminSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminSession", reflect.TypeOf((*MockStore)(nil).GetAdminSession), arg0, arg1)
+}
+
+// GetAdminTOTP mocks base method.
+func (m *MockStore) GetAdminTOTP(arg0 context.Context, arg1 int64) (db.AdminTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminTOTP indicates an expected call of GetAdminTOTP.
+func (mr *MockStoreMockRecorder) GetAdminTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminTOTP", reflect.TypeOf((*MockStore)(nil).GetAdminTOTP), arg0, arg1)
+}
+
+// GetAdminType mocks base method.
+func (m *MockStore) GetAdminType(arg0 context.Context, arg1 int64) (db.AdminType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminType", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminType indicates an expected call of GetAdminType.
+func (mr *MockStoreMockRecorder) GetAdminType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminType", reflect.TypeOf((*MockStore)(nil).GetAdminType), arg0, arg1)
+}
+
+// GetCartItemByID mocks base method.
+func (m *MockStore) GetCartItemByID(arg0 context.Context, arg1 int64) (db.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartItemByID", arg0, arg1)
+	ret0, _ := ret[0].(db.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartItemByID indicates an expected call of GetCartItemByID.
+func (mr *MockStoreMockRecorder) GetCartItemByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemByID", reflect.TypeOf((*MockStore)(nil).GetCartItemByID), arg0, arg1)
+}
+
+// GetCartItemBySessionID mocks base method.
+func (m *MockStore) GetCartItemBySessionID(arg0 context.Context, arg1 int64) (db.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartItemBySessionID", arg0, arg1)
+	ret0, _ := ret[0].(db.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartItemBySessionID indicates an expected call of GetCartItemBySessionID.
+func (mr *MockStoreMockRecorder) GetCartItemBySessionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemBySessionID", reflect.TypeOf((*MockStore)(nil).GetCartItemBySessionID), arg0, arg1)
+}
+
+// GetDiscount mocks base method.
+func (m *MockStore) GetDiscount(arg0 context.Context, arg1 int64) (db.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscount", arg0, arg1)
+	ret0, _ := ret[0].(db.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscount indicates an expected call of GetDiscount.
+func (mr *MockStoreMockRecorder) GetDiscount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscount", reflect.TypeOf((*MockStore)(nil).GetDiscount), arg0, arg1)
+}
+
+// GetLastEmailVerificationToken mocks base method.
+func (m *MockStore) GetLastEmailVerificationToken(arg0 context.Context, arg1 int64) (db.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastEmailVerificationToken", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastEmailVerificationToken indicates an expected call of GetLastEmailVerificationToken.
+func (mr *MockStoreMockRecorder) GetLastEmailVerificationToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastEmailVerificationToken", reflect.TypeOf((*MockStore)(nil).GetLastEmailVerificationToken), arg0, arg1)
+}
+
+// GetOrderDetail mocks base method.
+func (m *MockStore) GetOrderDetail(arg0 context.Context, arg1 int64) (db.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetail", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetail indicates an expected call of GetOrderDetail.
+func (mr *MockStoreMockRecorder) GetOrderDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetail", reflect.TypeOf((*MockStore)(nil).GetOrderDetail), arg0, arg1)
+}
+
+// GetOrderDetailForUpdate mocks base method.
+func (m *MockStore) GetOrderDetailForUpdate(arg0 context.Context, arg1 int64) (db.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetailForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetailForUpdate indicates an expected call of GetOrderDetailForUpdate.
+func (mr *MockStoreMockRecorder) GetOrderDetailForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetailForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderDetailForUpdate), arg0, arg1)
+}
+
+// GetOrderItem mocks base method.
+func (m *MockStore) GetOrderItem(arg0 context.Context, arg1 db.GetOrderItemParams) (db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItem indicates an expected call of GetOrderItem.
+func (mr *MockStoreMockRecorder) GetOrderItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItem", reflect.TypeOf((*MockStore)(nil).GetOrderItem), arg0, arg1)
+}
+
+// GetPaymentDetail mocks base method.
+func (m *MockStore) GetPaymentDetail(arg0 context.Context, arg1 db.GetPaymentDetailParams) (db.PaymentDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentDetail", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentDetail indicates an expected call of GetPaymentDetail.
+func (mr *MockStoreMockRecorder) GetPaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentDetail", reflect.TypeOf((*MockStore)(nil).GetPaymentDetail), arg0, arg1)
+}
+
+// GetPaymentDetailStatus mocks base method.
+func (m *MockStore) GetPaymentDetailStatus(arg0 context.Context, arg1 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentDetailStatus", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentDetailStatus indicates an expected call of GetPaymentDetailStatus.
+func (mr *MockStoreMockRecorder) GetPaymentDetailStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentDetailStatus", reflect.TypeOf((*MockStore)(nil).GetPaymentDetailStatus), arg0, arg1)
+}
+
+// GetProduct mocks base method.
+func (m *MockStore) GetProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+}
+
+// GetProductCategory mocks base method.
+func (m *MockStore) GetProductCategory(arg0 context.Context, arg1 int64) (db.ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCategory indicates an expected call of GetProductCategory.
+func (mr *MockStoreMockRecorder) GetProductCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategory", reflect.TypeOf((*MockStore)(nil).GetProductCategory), arg0, arg1)
+}
+
+// GetProductForUpdate mocks base method.
+func (m *MockStore) GetProductForUpdate(arg0 context.Context, arg1 int64) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductForUpdate indicates an expected call of GetProductForUpdate.
+func (mr *MockStoreMockRecorder) GetProductForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductForUpdate), arg0, arg1)
+}
+
+// GetProductInventory mocks base method.
+func (m *MockStore) GetProductInventory(arg0 context.Context, arg1 int64) (db.ProductInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductInventory", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductInventory indicates an expected call of GetProductInventory.
+func (mr *MockStoreMockRecorder) GetProductInventory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductInventory", reflect.TypeOf((*MockStore)(nil).GetProductInventory), arg0, arg1)
+}
+
+// GetProductInventoryForUpdate mocks base method.
+func (m *MockStore) GetProductInventoryForUpdate(arg0 context.Context, arg1 int64) (db.ProductInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductInventoryForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductInventoryForUpdate indicates an expected call of GetProductInventoryForUpdate.
+func (mr *MockStoreMockRecorder) GetProductInventoryForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductInventoryForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductInventoryForUpdate), arg0, arg1)
+}
+
+// GetProductVariant mocks base method.
+func (m *MockStore) GetProductVariant(arg0 context.Context, arg1 int64) (db.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductVariant", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductVariant indicates an expected call of GetProductVariant.
+func (mr *MockStoreMockRecorder) GetProductVariant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVariant", reflect.TypeOf((*MockStore)(nil).GetProductVariant), arg0, arg1)
+}
+
+// GetProductVariantMatrixTx mocks base method.
+func (m *MockStore) GetProductVariantMatrixTx(arg0 context.Context, arg1 int64) (db.ProductVariantMatrix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductVariantMatrixTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductVariantMatrix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductVariantMatrixTx indicates an expected call of GetProductVariantMatrixTx.
+func (mr *MockStoreMockRecorder) GetProductVariantMatrixTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVariantMatrixTx", reflect.TypeOf((*MockStore)(nil).GetProductVariantMatrixTx), arg0, arg1)
+}
+
+// GetProductVariantName mocks base method.
+func (m *MockStore) GetProductVariantName(arg0 context.Context, arg1 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductVariantName", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductVariantName indicates an expected call of GetProductVariantName.
+func (mr *MockStoreMockRecorder) GetProductVariantName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVariantName", reflect.TypeOf((*MockStore)(nil).GetProductVariantName), arg0, arg1)
+}
+
+// GetReservedQuantity mocks base method.
+func (m *MockStore) GetReservedQuantity(arg0 context.Context, arg1 db.GetReservedQuantityParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedQuantity", arg0, arg1)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedQuantity indicates an expected call of GetReservedQuantity.
+func (mr *MockStoreMockRecorder) GetReservedQuantity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedQuantity", reflect.TypeOf((*MockStore)(nil).GetReservedQuantity), arg0, arg1)
+}
+
+// GetShoppingSession mocks base method.
+func (m *MockStore) GetShoppingSession(arg0 context.Context, arg1 int64) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingSession", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingSession indicates an expected call of GetShoppingSession.
+func (mr *MockStoreMockRecorder) GetShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingSession", reflect.TypeOf((*MockStore)(nil).GetShoppingSession), arg0, arg1)
+}
+
+// GetShoppingSessionByGuestTokenHash mocks base method.
+func (m *MockStore) GetShoppingSessionByGuestTokenHash(arg0 context.Context, arg1 string) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingSessionByGuestTokenHash", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingSessionByGuestTokenHash indicates an expected call of GetShoppingSessionByGuestTokenHash.
+func (mr *MockStoreMockRecorder) GetShoppingSessionByGuestTokenHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingSessionByGuestTokenHash", reflect.TypeOf((*MockStore)(nil).GetShoppingSessionByGuestTokenHash), arg0, arg1)
+}
+
+// GetShoppingSessionByUserID mocks base method.
+func (m *MockStore) GetShoppingSessionByUserID(arg0 context.Context, arg1 int64) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingSessionByUserID", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingSessionByUserID indicates an expected call of GetShoppingSessionByUserID.
+func (mr *MockStoreMockRecorder) GetShoppingSessionByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingSessionByUserID", reflect.TypeOf((*MockStore)(nil).GetShoppingSessionByUserID), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserAddress mocks base method.
+func (m *MockStore) GetUserAddress(arg0 context.Context, arg1 db.GetUserAddressParams) (db.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAddress", arg0, arg1)
+	ret0, _ := ret[0].(db.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAddress indicates an expected call of GetUserAddress.
+func (mr *MockStoreMockRecorder) GetUserAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddress", reflect.TypeOf((*MockStore)(nil).GetUserAddress), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
+}
+
+// GetUserPayment mocks base method.
+func (m *MockStore) GetUserPayment(arg0 context.Context, arg1 db.GetUserPaymentParams) (db.UserPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPayment", arg0, arg1)
+	ret0, _ := ret[0].(db.UserPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPayment indicates an expected call of GetUserPayment.
+func (mr *MockStoreMockRecorder) GetUserPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPayment", reflect.TypeOf((*MockStore)(nil).GetUserPayment), arg0, arg1)
+}
+
+// GetUserSession mocks base method.
+func (m *MockStore) GetUserSession(arg0 context.Context, arg1 uuid.UUID) (db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSession", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSession indicates an expected call of GetUserSession.
+func (mr *MockStoreMockRecorder) GetUserSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSession", reflect.TypeOf((*MockStore)(nil).GetUserSession), arg0, arg1)
+}
+
+// GetUserTOTP mocks base method.
+func (m *MockStore) GetUserTOTP(arg0 context.Context, arg1 int64) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockStoreMockRecorder) GetUserTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*MockStore)(nil).GetUserTOTP), arg0, arg1)
+}
+
+// InvalidateEmailChangeTokens mocks base method.
+func (m *MockStore) InvalidateEmailChangeTokens(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateEmailChangeTokens", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// InvalidateEmailChangeTokens indicates an expected call of InvalidateEmailChangeTokens.
+func (mr *MockStoreMockRecorder) InvalidateEmailChangeTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateEmailChangeTokens", reflect.TypeOf((*MockStore)(nil).InvalidateEmailChangeTokens), arg0, arg1)
+}
+
+// InvalidateEmailVerificationTokens mocks base method.
+func (m *MockStore) InvalidateEmailVerificationTokens(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateEmailVerificationTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateEmailVerificationTokens indicates an expected call of InvalidateEmailVerificationTokens.
+func (mr *MockStoreMockRecorder) InvalidateEmailVerificationTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateEmailVerificationTokens", reflect.TypeOf((*MockStore)(nil).InvalidateEmailVerificationTokens), arg0, arg1)
+}
+
+// InvalidatePasswordResetTokens mocks base method.
+func (m *MockStore) InvalidatePasswordResetTokens(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResetTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResetTokens indicates an expected call of InvalidatePasswordResetTokens.
+func (mr *MockStoreMockRecorder) InvalidatePasswordResetTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResetTokens", reflect.TypeOf((*MockStore)(nil).InvalidatePasswordResetTokens), arg0, arg1)
+}
+
+// ListAPIKeyPermissions mocks base method.
+func (m *MockStore) ListAPIKeyPermissions(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeyPermissions", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeyPermissions indicates an expected call of ListAPIKeyPermissions.
+func (mr *MockStoreMockRecorder) ListAPIKeyPermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeyPermissions", reflect.TypeOf((*MockStore)(nil).ListAPIKeyPermissions), arg0, arg1)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockStore) ListAPIKeys(arg0 context.Context, arg1 db.ListAPIKeysParams) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockStoreMockRecorder) ListAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockStore)(nil).ListAPIKeys), arg0, arg1)
+}
+
+// ListActiveUserSessions mocks base method.
+func (m *MockStore) ListActiveUserSessions(arg0 context.Context, arg1 int64) ([]db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveUserSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveUserSessions indicates an expected call of ListActiveUserSessions.
+func (mr *MockStoreMockRecorder) ListActiveUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveUserSessions", reflect.TypeOf((*MockStore)(nil).ListActiveUserSessions), arg0, arg1)
+}
+
+// ListAdminTypePermissions mocks base method.
+func (m *MockStore) ListAdminTypePermissions(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdminTypePermissions", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdminTypePermissions indicates an expected call of ListAdminTypePermissions.
+func (mr *MockStoreMockRecorder) ListAdminTypePermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdminTypePermissions", reflect.TypeOf((*MockStore)(nil).ListAdminTypePermissions), arg0, arg1)
+}
+
+// ListAdminTypes mocks base method.
+func (m *MockStore) ListAdminTypes(arg0 context.Context, arg1 db.ListAdminTypesParams) ([]db.AdminType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdminTypes", arg0, arg1)
+	ret0, _ := ret[0].([]db.AdminType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdminTypes indicates an expected call of ListAdminTypes.
+func (mr *MockStoreMockRecorder) ListAdminTypes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdminTypes", reflect.TypeOf((*MockStore)(nil).ListAdminTypes), arg0, arg1)
+}
+
+// ListAdmins mocks base method.
+func (m *MockStore) ListAdmins(arg0 context.Context, arg1 db.ListAdminsParams) ([]db.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdmins", arg0, arg1)
+	ret0, _ := ret[0].([]db.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdmins indicates an expected call of ListAdmins.
+func (mr *MockStoreMockRecorder) ListAdmins(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdmins", reflect.TypeOf((*MockStore)(nil).ListAdmins), arg0, arg1)
+}
+
+// ListAllOrderDetails mocks base method.
+func (m *MockStore) ListAllOrderDetails(arg0 context.Context, arg1 int64) ([]db.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllOrderDetails", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllOrderDetails indicates an expected call of ListAllOrderDetails.
+func (mr *MockStoreMockRecorder) ListAllOrderDetails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllOrderDetails", reflect.TypeOf((*MockStore)(nil).ListAllOrderDetails), arg0, arg1)
+}
+
+// ListAllUserAddresses mocks base method.
+func (m *MockStore) ListAllUserAddresses(arg0 context.Context, arg1 int64) ([]db.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllUserAddresses", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllUserAddresses indicates an expected call of ListAllUserAddresses.
+func (mr *MockStoreMockRecorder) ListAllUserAddresses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllUserAddresses", reflect.TypeOf((*MockStore)(nil).ListAllUserAddresses), arg0, arg1)
+}
+
+// ListAllUserPayments mocks base method.
+func (m *MockStore) ListAllUserPayments(arg0 context.Context, arg1 int64) ([]db.UserPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllUserPayments", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllUserPayments indicates an expected call of ListAllUserPayments.
+func (mr *MockStoreMockRecorder) ListAllUserPayments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllUserPayments", reflect.TypeOf((*MockStore)(nil).ListAllUserPayments), arg0, arg1)
+}
+
+// ListAllUserSessions mocks base method.
+func (m *MockStore) ListAllUserSessions(arg0 context.Context, arg1 int64) ([]db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllUserSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllUserSessions indicates an expected call of ListAllUserSessions.
+func (mr *MockStoreMockRecorder) ListAllUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllUserSessions", reflect.TypeOf((*MockStore)(nil).ListAllUserSessions), arg0, arg1)
+}
+
+// ListCartItem mocks base method.
+func (m *MockStore) ListCartItem(arg0 context.Context, arg1 db.ListCartItemParams) ([]db.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCartItem", arg0, arg1)
+	ret0, _ := ret[0].([]db.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCartItem indicates an expected call of ListCartItem.
+func (mr *MockStoreMockRecorder) ListCartItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCartItem", reflect.TypeOf((*MockStore)(nil).ListCartItem), arg0, arg1)
+}
+
+// ListCartItemsBySessionID mocks base method.
+func (m *MockStore) ListCartItemsBySessionID(arg0 context.Context, arg1 int64) ([]db.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCartItemsBySessionID", arg0, arg1)
+	ret0, _ := ret[0].([]db.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCartItemsBySessionID indicates an expected call of ListCartItemsBySessionID.
+func (mr *MockStoreMockRecorder) ListCartItemsBySessionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCartItemsBySessionID", reflect.TypeOf((*MockStore)(nil).ListCartItemsBySessionID), arg0, arg1)
+}
+
+// ListDiscounts mocks base method.
+func (m *MockStore) ListDiscounts(arg0 context.Context, arg1 db.ListDiscountsParams) ([]db.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDiscounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDiscounts indicates an expected call of ListDiscounts.
+func (mr *MockStoreMockRecorder) ListDiscounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiscounts", reflect.TypeOf((*MockStore)(nil).ListDiscounts), arg0, arg1)
+}
+
+// ListLockedUsers mocks base method.
+func (m *MockStore) ListLockedUsers(arg0 context.Context, arg1 db.ListLockedUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLockedUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLockedUsers indicates an expected call of ListLockedUsers.
+func (mr *MockStoreMockRecorder) ListLockedUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLockedUsers", reflect.TypeOf((*MockStore)(nil).ListLockedUsers), arg0, arg1)
 }
 
-// DeleteUserAddress mocks base method.
-func (m *MockStore) DeleteUserAddress(arg0 context.Context, arg1 int64) error {
+// ListOrderDetails mocks base method.
+func (m *MockStore) ListOrderDetails(arg0 context.Context, arg1 db.ListOrderDetailsParams) ([]db.OrderDetail, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserAddress", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListOrderDetails", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUserAddress indicates an expected call of DeleteUserAddress.
-func (mr *MockStoreMockRecorder) DeleteUserAddress(arg0, arg1 interface{}) *gomock.Call {
+// ListOrderDetails indicates an expected call of ListOrderDetails.
+func (mr *MockStoreMockRecorder) ListOrderDetails(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAddress", reflect.TypeOf((*MockStore)(nil).DeleteUserAddress), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderDetails", reflect.TypeOf((*MockStore)(nil).ListOrderDetails), arg0, arg1)
 }
 
-// DeleteUserPayment mocks base method.
-func (m *MockStore) DeleteUserPayment(arg0 context.Context, arg1 db.DeleteUserPaymentParams) error {
+// ListOrderDetailsOfAllUsers mocks base method.
+func (m *MockStore) ListOrderDetailsOfAllUsers(arg0 context.Context, arg1 db.ListOrderDetailsOfAllUsersParams) ([]db.OrderDetail, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserPayment", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListOrderDetailsOfAllUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUserPayment indicates an expected call of DeleteUserPayment.
-func (mr *MockStoreMockRecorder) DeleteUserPayment(arg0, arg1 interface{}) *gomock.Call {
+// ListOrderDetailsOfAllUsers indicates an expected call of ListOrderDetailsOfAllUsers.
+func (mr *MockStoreMockRecorder) ListOrderDetailsOfAllUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserPayment", reflect.TypeOf((*MockStore)(nil).DeleteUserPayment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderDetailsOfAllUsers", reflect.TypeOf((*MockStore)(nil).ListOrderDetailsOfAllUsers), arg0, arg1)
 }
 
-// FinishedPurchaseTx mocks base method.
-func (m *MockStore) FinishedPurchaseTx(arg0 context.Context, arg1 db.FinishedPurchaseTxParams) (db.FinishedPurchaseTxResult, error) {
+// ListOrderItems mocks base method.
+func (m *MockStore) ListOrderItems(arg0 context.Context, arg1 db.ListOrderItemsParams) ([]db.OrderItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FinishedPurchaseTx", arg0, arg1)
-	ret0, _ := ret[0].(db.FinishedPurchaseTxResult)
+	ret := m.ctrl.Call(m, "ListOrderItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FinishedPurchaseTx indicates an expected call of FinishedPurchaseTx.
-func (mr *MockStoreMockRecorder) FinishedPurchaseTx(arg0, arg1 interface{}) *gomock.Call {
+// ListOrderItems indicates an expected call of ListOrderItems.
+func (mr *MockStoreMockRecorder) ListOrderItems(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishedPurchaseTx", reflect.TypeOf((*MockStore)(nil).FinishedPurchaseTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItems", reflect.TypeOf((*MockStore)(nil).ListOrderItems), arg0, arg1)
 }
 
-// GetAdmin mocks base method.
-func (m *MockStore) GetAdmin(arg0 context.Context, arg1 int64) (db.Admin, error) {
+// ListOrderItemsByOrderID mocks base method.
+func (m *MockStore) ListOrderItemsByOrderID(arg0 context.Context, arg1 int64) ([]db.OrderItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdmin", arg0, arg1)
-	ret0, _ := ret[0].(db.Admin)
+	ret := m.ctrl.Call(m, "ListOrderItemsByOrderID", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAdmin indicates an expected call of GetAdmin.
-func (mr *MockStoreMockRecorder) GetAdmin(arg0, arg1 interface{}) *gomock.Call {
+// ListOrderItemsByOrderID indicates an expected call of ListOrderItemsByOrderID.
+func (mr *MockStoreMockRecorder) ListOrderItemsByOrderID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdmin", reflect.TypeOf((*MockStore)(nil).GetAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItemsByOrderID", reflect.TypeOf((*MockStore)(nil).ListOrderItemsByOrderID), arg0, arg1)
 }
 
-// GetAdminByEmail mocks base method.
-func (m *MockStore) GetAdminByEmail(arg0 context.Context, arg1 string) (db.Admin, error) {
+// ListPaymentDetails mocks base method.
+func (m *MockStore) ListPaymentDetails(arg0 context.Context, arg1 db.ListPaymentDetailsParams) ([]db.ListPaymentDetailsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdminByEmail", arg0, arg1)
-	ret0, _ := ret[0].(db.Admin)
+	ret := m.ctrl.Call(m, "ListPaymentDetails", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPaymentDetailsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAdminByEmail indicates an expected call of GetAdminByEmail.
-func (mr *MockStoreMockRecorder) GetAdminByEmail(arg0, arg1 interface{}) *gomock.Call {
+// ListPaymentDetails indicates an expected call of ListPaymentDetails.
+func (mr *MockStoreMockRecorder) ListPaymentDetails(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminByEmail", reflect.TypeOf((*MockStore)(nil).GetAdminByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentDetails", reflect.TypeOf((*MockStore)(nil).ListPaymentDetails), arg0, arg1)
 }
 
-// GetAdminType mocks base method.
-func (m *MockStore) GetAdminType(arg0 context.Context, arg1 int64) (db.AdminType, error) {
+// ListProductCategories mocks base method.
+func (m *MockStore) ListProductCategories(arg0 context.Context, arg1 db.ListProductCategoriesParams) ([]db.ProductCategory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdminType", arg0, arg1)
-	ret0, _ := ret[0].(db.AdminType)
+	ret := m.ctrl.Call(m, "ListProductCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAdminType indicates an expected call of GetAdminType.
-func (mr *MockStoreMockRecorder) GetAdminType(arg0, arg1 interface{}) *gomock.Call {
+// ListProductCategories indicates an expected call of ListProductCategories.
+func (mr *MockStoreMockRecorder) ListProductCategories(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminType", reflect.TypeOf((*MockStore)(nil).GetAdminType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductCategories", reflect.TypeOf((*MockStore)(nil).ListProductCategories), arg0, arg1)
 }
 
-// GetCartItemByID mocks base method.
-func (m *MockStore) GetCartItemByID(arg0 context.Context, arg1 int64) (db.CartItem, error) {
+// ListProductInventories mocks base method.
+func (m *MockStore) ListProductInventories(arg0 context.Context, arg1 db.ListProductInventoriesParams) ([]db.ProductInventory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartItemByID", arg0, arg1)
-	ret0, _ := ret[0].(db.CartItem)
+	ret := m.ctrl.Call(m, "ListProductInventories", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductInventory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartItemByID indicates an expected call of GetCartItemByID.
-func (mr *MockStoreMockRecorder) GetCartItemByID(arg0, arg1 interface{}) *gomock.Call {
+// ListProductInventories indicates an expected call of ListProductInventories.
+func (mr *MockStoreMockRecorder) ListProductInventories(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemByID", reflect.TypeOf((*MockStore)(nil).GetCartItemByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductInventories", reflect.TypeOf((*MockStore)(nil).ListProductInventories), arg0, arg1)
 }
 
-// GetCartItemBySessionID mocks base method.
-func (m *MockStore) GetCartItemBySessionID(arg0 context.Context, arg1 int64) (db.CartItem, error) {
+// ListProductOptionValuesByProductID mocks base method.
+func (m *MockStore) ListProductOptionValuesByProductID(arg0 context.Context, arg1 int64) ([]db.ProductOptionValue, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartItemBySessionID", arg0, arg1)
-	ret0, _ := ret[0].(db.CartItem)
+	ret := m.ctrl.Call(m, "ListProductOptionValuesByProductID", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductOptionValue)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartItemBySessionID indicates an expected call of GetCartItemBySessionID.
-func (mr *MockStoreMockRecorder) GetCartItemBySessionID(arg0, arg1 interface{}) *gomock.Call {
+// ListProductOptionValuesByProductID indicates an expected call of ListProductOptionValuesByProductID.
+func (mr *MockStoreMockRecorder) ListProductOptionValuesByProductID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemBySessionID", reflect.TypeOf((*MockStore)(nil).GetCartItemBySessionID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductOptionValuesByProductID", reflect.TypeOf((*MockStore)(nil).ListProductOptionValuesByProductID), arg0, arg1)
 }
 
-// GetDiscount mocks base method.
-func (m *MockStore) GetDiscount(arg0 context.Context, arg1 int64) (db.Discount, error) {
+// ListProductOptionsByProductID mocks base method.
+func (m *MockStore) ListProductOptionsByProductID(arg0 context.Context, arg1 int64) ([]db.ProductOption, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDiscount", arg0, arg1)
-	ret0, _ := ret[0].(db.Discount)
+	ret := m.ctrl.Call(m, "ListProductOptionsByProductID", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductOption)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDiscount indicates an expected call of GetDiscount.
-func (mr *MockStoreMockRecorder) GetDiscount(arg0, arg1 interface{}) *gomock.Call {
+// ListProductOptionsByProductID indicates an expected call of ListProductOptionsByProductID.
+func (mr *MockStoreMockRecorder) ListProductOptionsByProductID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscount", reflect.TypeOf((*MockStore)(nil).GetDiscount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductOptionsByProductID", reflect.TypeOf((*MockStore)(nil).ListProductOptionsByProductID), arg0, arg1)
 }
 
-// GetOrderDetail mocks base method.
-func (m *MockStore) GetOrderDetail(arg0 context.Context, arg1 int64) (db.OrderDetail, error) {
+// ListProductVariantOptionValuesByProductID mocks base method.
+func (m *MockStore) ListProductVariantOptionValuesByProductID(arg0 context.Context, arg1 int64) ([]db.ProductVariantOptionValue, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderDetail", arg0, arg1)
-	ret0, _ := ret[0].(db.OrderDetail)
+	ret := m.ctrl.Call(m, "ListProductVariantOptionValuesByProductID", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductVariantOptionValue)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrderDetail indicates an expected call of GetOrderDetail.
-func (mr *MockStoreMockRecorder) GetOrderDetail(arg0, arg1 interface{}) *gomock.Call {
+// ListProductVariantOptionValuesByProductID indicates an expected call of ListProductVariantOptionValuesByProductID.
+func (mr *MockStoreMockRecorder) ListProductVariantOptionValuesByProductID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetail", reflect.TypeOf((*MockStore)(nil).GetOrderDetail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductVariantOptionValuesByProductID", reflect.TypeOf((*MockStore)(nil).ListProductVariantOptionValuesByProductID), arg0, arg1)
 }
 
-// GetOrderItem mocks base method.
-func (m *MockStore) GetOrderItem(arg0 context.Context, arg1 db.GetOrderItemParams) (db.OrderItem, error) {
+// ListProductVariantsByProductID mocks base method.
+func (m *MockStore) ListProductVariantsByProductID(arg0 context.Context, arg1 int64) ([]db.ListProductVariantsByProductIDRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderItem", arg0, arg1)
-	ret0, _ := ret[0].(db.OrderItem)
+	ret := m.ctrl.Call(m, "ListProductVariantsByProductID", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListProductVariantsByProductIDRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrderItem indicates an expected call of GetOrderItem.
-func (mr *MockStoreMockRecorder) GetOrderItem(arg0, arg1 interface{}) *gomock.Call {
+// ListProductVariantsByProductID indicates an expected call of ListProductVariantsByProductID.
+func (mr *MockStoreMockRecorder) ListProductVariantsByProductID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItem", reflect.TypeOf((*MockStore)(nil).GetOrderItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductVariantsByProductID", reflect.TypeOf((*MockStore)(nil).ListProductVariantsByProductID), arg0, arg1)
 }
 
-// GetPaymentDetail mocks base method.
-func (m *MockStore) GetPaymentDetail(arg0 context.Context, arg1 db.GetPaymentDetailParams) (db.PaymentDetail, error) {
+// ListProducts mocks base method.
+func (m *MockStore) ListProducts(arg0 context.Context, arg1 db.ListProductsParams) ([]db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaymentDetail", arg0, arg1)
-	ret0, _ := ret[0].(db.PaymentDetail)
+	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPaymentDetail indicates an expected call of GetPaymentDetail.
-func (mr *MockStoreMockRecorder) GetPaymentDetail(arg0, arg1 interface{}) *gomock.Call {
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockStoreMockRecorder) ListProducts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentDetail", reflect.TypeOf((*MockStore)(nil).GetPaymentDetail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), arg0, arg1)
 }
 
-// GetProduct mocks base method.
-func (m *MockStore) GetProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
+// ListShoppingSessions mocks base method.
+func (m *MockStore) ListShoppingSessions(arg0 context.Context, arg1 db.ListShoppingSessionsParams) ([]db.ShoppingSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "ListShoppingSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ShoppingSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 interface{}) *gomock.Call {
+// ListShoppingSessions indicates an expected call of ListShoppingSessions.
+func (mr *MockStoreMockRecorder) ListShoppingSessions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShoppingSessions", reflect.TypeOf((*MockStore)(nil).ListShoppingSessions), arg0, arg1)
 }
 
-// GetProductCategory mocks base method.
-func (m *MockStore) GetProductCategory(arg0 context.Context, arg1 int64) (db.ProductCategory, error) {
+// ListStockReservationsBySessionID mocks base method.
+func (m *MockStore) ListStockReservationsBySessionID(arg0 context.Context, arg1 int64) ([]db.StockReservation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductCategory)
+	ret := m.ctrl.Call(m, "ListStockReservationsBySessionID", arg0, arg1)
+	ret0, _ := ret[0].([]db.StockReservation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductCategory indicates an expected call of GetProductCategory.
-func (mr *MockStoreMockRecorder) GetProductCategory(arg0, arg1 interface{}) *gomock.Call {
+// ListStockReservationsBySessionID indicates an expected call of ListStockReservationsBySessionID.
+func (mr *MockStoreMockRecorder) ListStockReservationsBySessionID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategory", reflect.TypeOf((*MockStore)(nil).GetProductCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockReservationsBySessionID", reflect.TypeOf((*MockStore)(nil).ListStockReservationsBySessionID), arg0, arg1)
 }
 
-// GetProductInventory mocks base method.
-func (m *MockStore) GetProductInventory(arg0 context.Context, arg1 int64) (db.ProductInventory, error) {
+// ListUserAddresses mocks base method.
+func (m *MockStore) ListUserAddresses(arg0 context.Context, arg1 db.ListUserAddressesParams) ([]db.UserAddress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductInventory", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductInventory)
+	ret := m.ctrl.Call(m, "ListUserAddresses", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserAddress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductInventory indicates an expected call of GetProductInventory.
-func (mr *MockStoreMockRecorder) GetProductInventory(arg0, arg1 interface{}) *gomock.Call {
+// ListUserAddresses indicates an expected call of ListUserAddresses.
+func (mr *MockStoreMockRecorder) ListUserAddresses(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductInventory", reflect.TypeOf((*MockStore)(nil).GetProductInventory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAddresses", reflect.TypeOf((*MockStore)(nil).ListUserAddresses), arg0, arg1)
 }
 
-// GetProductInventoryForUpdate mocks base method.
-func (m *MockStore) GetProductInventoryForUpdate(arg0 context.Context, arg1 int64) (db.ProductInventory, error) {
+// ListUserPayments mocks base method.
+func (m *MockStore) ListUserPayments(arg0 context.Context, arg1 db.ListUserPaymentsParams) ([]db.UserPayment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductInventoryForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductInventory)
+	ret := m.ctrl.Call(m, "ListUserPayments", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserPayment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductInventoryForUpdate indicates an expected call of GetProductInventoryForUpdate.
-func (mr *MockStoreMockRecorder) GetProductInventoryForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// ListUserPayments indicates an expected call of ListUserPayments.
+func (mr *MockStoreMockRecorder) ListUserPayments(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductInventoryForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductInventoryForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPayments", reflect.TypeOf((*MockStore)(nil).ListUserPayments), arg0, arg1)
 }
 
-// GetShoppingSession mocks base method.
-func (m *MockStore) GetShoppingSession(arg0 context.Context, arg1 int64) (db.ShoppingSession, error) {
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetShoppingSession", arg0, arg1)
-	ret0, _ := ret[0].(db.ShoppingSession)
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetShoppingSession indicates an expected call of GetShoppingSession.
-func (mr *MockStoreMockRecorder) GetShoppingSession(arg0, arg1 interface{}) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingSession", reflect.TypeOf((*MockStore)(nil).GetShoppingSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 int64) (db.User, error) {
+// LockAdmin mocks base method.
+func (m *MockStore) LockAdmin(arg0 context.Context, arg1 db.LockAdminParams) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "LockAdmin", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// LockAdmin indicates an expected call of LockAdmin.
+func (mr *MockStoreMockRecorder) LockAdmin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAdmin", reflect.TypeOf((*MockStore)(nil).LockAdmin), arg0, arg1)
 }
 
-// GetUserAddress mocks base method.
-func (m *MockStore) GetUserAddress(arg0 context.Context, arg1 db.GetUserAddressParams) (db.UserAddress, error) {
+// LockShoppingSessionByGuestTokenHash mocks base method.
+func (m *MockStore) LockShoppingSessionByGuestTokenHash(arg0 context.Context, arg1 string) (db.ShoppingSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserAddress", arg0, arg1)
-	ret0, _ := ret[0].(db.UserAddress)
+	ret := m.ctrl.Call(m, "LockShoppingSessionByGuestTokenHash", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserAddress indicates an expected call of GetUserAddress.
-func (mr *MockStoreMockRecorder) GetUserAddress(arg0, arg1 interface{}) *gomock.Call {
+// LockShoppingSessionByGuestTokenHash indicates an expected call of LockShoppingSessionByGuestTokenHash.
+func (mr *MockStoreMockRecorder) LockShoppingSessionByGuestTokenHash(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddress", reflect.TypeOf((*MockStore)(nil).GetUserAddress), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockShoppingSessionByGuestTokenHash", reflect.TypeOf((*MockStore)(nil).LockShoppingSessionByGuestTokenHash), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+// LockUser mocks base method.
+func (m *MockStore) LockUser(arg0 context.Context, arg1 db.LockUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret := m.ctrl.Call(m, "LockUser", arg0, arg1)
 	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// LockUser indicates an expected call of LockUser.
+func (mr *MockStoreMockRecorder) LockUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockStore)(nil).LockUser), arg0, arg1)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+// MergeGuestCartTx mocks base method.
+func (m *MockStore) MergeGuestCartTx(arg0 context.Context, arg1 db.MergeGuestCartTxParams) (db.ShoppingSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "MergeGuestCartTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+// MergeGuestCartTx indicates an expected call of MergeGuestCartTx.
+func (mr *MockStoreMockRecorder) MergeGuestCartTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCartTx", reflect.TypeOf((*MockStore)(nil).MergeGuestCartTx), arg0, arg1)
 }
 
-// GetUserPayment mocks base method.
-func (m *MockStore) GetUserPayment(arg0 context.Context, arg1 db.GetUserPaymentParams) (db.UserPayment, error) {
+// RecordFailedAdminLogin mocks base method.
+func (m *MockStore) RecordFailedAdminLogin(arg0 context.Context, arg1 int64) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserPayment", arg0, arg1)
-	ret0, _ := ret[0].(db.UserPayment)
+	ret := m.ctrl.Call(m, "RecordFailedAdminLogin", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserPayment indicates an expected call of GetUserPayment.
-func (mr *MockStoreMockRecorder) GetUserPayment(arg0, arg1 interface{}) *gomock.Call {
+// RecordFailedAdminLogin indicates an expected call of RecordFailedAdminLogin.
+func (mr *MockStoreMockRecorder) RecordFailedAdminLogin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPayment", reflect.TypeOf((*MockStore)(nil).GetUserPayment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedAdminLogin", reflect.TypeOf((*MockStore)(nil).RecordFailedAdminLogin), arg0, arg1)
 }
 
-// GetUserSession mocks base method.
-func (m *MockStore) GetUserSession(arg0 context.Context, arg1 uuid.UUID) (db.UserSession, error) {
+// RecordFailedUserLogin mocks base method.
+func (m *MockStore) RecordFailedUserLogin(arg0 context.Context, arg1 int64) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserSession", arg0, arg1)
-	ret0, _ := ret[0].(db.UserSession)
+	ret := m.ctrl.Call(m, "RecordFailedUserLogin", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserSession indicates an expected call of GetUserSession.
-func (mr *MockStoreMockRecorder) GetUserSession(arg0, arg1 interface{}) *gomock.Call {
+// RecordFailedUserLogin indicates an expected call of RecordFailedUserLogin.
+func (mr *MockStoreMockRecorder) RecordFailedUserLogin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSession", reflect.TypeOf((*MockStore)(nil).GetUserSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedUserLogin", reflect.TypeOf((*MockStore)(nil).RecordFailedUserLogin), arg0, arg1)
 }
 
-// ListAdminTypes mocks base method.
-func (m *MockStore) ListAdminTypes(arg0 context.Context, arg1 db.ListAdminTypesParams) ([]db.AdminType, error) {
+// ReserveStockTx mocks base method.
+func (m *MockStore) ReserveStockTx(arg0 context.Context, arg1 db.ReserveStockTxParams) (db.ReserveStockTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAdminTypes", arg0, arg1)
-	ret0, _ := ret[0].([]db.AdminType)
+	ret := m.ctrl.Call(m, "ReserveStockTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReserveStockTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAdminTypes indicates an expected call of ListAdminTypes.
-func (mr *MockStoreMockRecorder) ListAdminTypes(arg0, arg1 interface{}) *gomock.Call {
+// ReserveStockTx indicates an expected call of ReserveStockTx.
+func (mr *MockStoreMockRecorder) ReserveStockTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdminTypes", reflect.TypeOf((*MockStore)(nil).ListAdminTypes), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStockTx", reflect.TypeOf((*MockStore)(nil).ReserveStockTx), arg0, arg1)
 }
 
-// ListAdmins mocks base method.
-func (m *MockStore) ListAdmins(arg0 context.Context, arg1 db.ListAdminsParams) ([]db.Admin, error) {
+// ResetFailedAdminLogins mocks base method.
+func (m *MockStore) ResetFailedAdminLogins(arg0 context.Context, arg1 int64) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAdmins", arg0, arg1)
-	ret0, _ := ret[0].([]db.Admin)
+	ret := m.ctrl.Call(m, "ResetFailedAdminLogins", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAdmins indicates an expected call of ListAdmins.
-func (mr *MockStoreMockRecorder) ListAdmins(arg0, arg1 interface{}) *gomock.Call {
+// ResetFailedAdminLogins indicates an expected call of ResetFailedAdminLogins.
+func (mr *MockStoreMockRecorder) ResetFailedAdminLogins(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdmins", reflect.TypeOf((*MockStore)(nil).ListAdmins), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedAdminLogins", reflect.TypeOf((*MockStore)(nil).ResetFailedAdminLogins), arg0, arg1)
 }
 
-// ListCartItem mocks base method.
-func (m *MockStore) ListCartItem(arg0 context.Context, arg1 db.ListCartItemParams) ([]db.CartItem, error) {
+// ResetFailedUserLogins mocks base method.
+func (m *MockStore) ResetFailedUserLogins(arg0 context.Context, arg1 int64) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCartItem", arg0, arg1)
-	ret0, _ := ret[0].([]db.CartItem)
+	ret := m.ctrl.Call(m, "ResetFailedUserLogins", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCartItem indicates an expected call of ListCartItem.
-func (mr *MockStoreMockRecorder) ListCartItem(arg0, arg1 interface{}) *gomock.Call {
+// ResetFailedUserLogins indicates an expected call of ResetFailedUserLogins.
+func (mr *MockStoreMockRecorder) ResetFailedUserLogins(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCartItem", reflect.TypeOf((*MockStore)(nil).ListCartItem), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedUserLogins", reflect.TypeOf((*MockStore)(nil).ResetFailedUserLogins), arg0, arg1)
 }
 
-// ListDiscounts mocks base method.
-func (m *MockStore) ListDiscounts(arg0 context.Context, arg1 db.ListDiscountsParams) ([]db.Discount, error) {
+// ResetPasswordTx mocks base method.
+func (m *MockStore) ResetPasswordTx(arg0 context.Context, arg1 db.ResetPasswordTxParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDiscounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Discount)
+	ret := m.ctrl.Call(m, "ResetPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListDiscounts indicates an expected call of ListDiscounts.
-func (mr *MockStoreMockRecorder) ListDiscounts(arg0, arg1 interface{}) *gomock.Call {
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockStoreMockRecorder) ResetPasswordTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiscounts", reflect.TypeOf((*MockStore)(nil).ListDiscounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetPasswordTx), arg0, arg1)
 }
 
-// ListOrderDetails mocks base method.
-func (m *MockStore) ListOrderDetails(arg0 context.Context, arg1 db.ListOrderDetailsParams) ([]db.OrderDetail, error) {
+// RotateUserSession mocks base method.
+func (m *MockStore) RotateUserSession(arg0 context.Context, arg1 uuid.UUID) (db.UserSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrderDetails", arg0, arg1)
-	ret0, _ := ret[0].([]db.OrderDetail)
+	ret := m.ctrl.Call(m, "RotateUserSession", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrderDetails indicates an expected call of ListOrderDetails.
-func (mr *MockStoreMockRecorder) ListOrderDetails(arg0, arg1 interface{}) *gomock.Call {
+// RotateUserSession indicates an expected call of RotateUserSession.
+func (mr *MockStoreMockRecorder) RotateUserSession(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderDetails", reflect.TypeOf((*MockStore)(nil).ListOrderDetails), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateUserSession", reflect.TypeOf((*MockStore)(nil).RotateUserSession), arg0, arg1)
 }
 
-// ListOrderItems mocks base method.
-func (m *MockStore) ListOrderItems(arg0 context.Context, arg1 db.ListOrderItemsParams) ([]db.OrderItem, error) {
+// RotateUserSessionTx mocks base method.
+func (m *MockStore) RotateUserSessionTx(arg0 context.Context, arg1 db.RotateUserSessionTxParams) (db.UserSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrderItems", arg0, arg1)
-	ret0, _ := ret[0].([]db.OrderItem)
+	ret := m.ctrl.Call(m, "RotateUserSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrderItems indicates an expected call of ListOrderItems.
-func (mr *MockStoreMockRecorder) ListOrderItems(arg0, arg1 interface{}) *gomock.Call {
+// RotateUserSessionTx indicates an expected call of RotateUserSessionTx.
+func (mr *MockStoreMockRecorder) RotateUserSessionTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItems", reflect.TypeOf((*MockStore)(nil).ListOrderItems), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateUserSessionTx", reflect.TypeOf((*MockStore)(nil).RotateUserSessionTx), arg0, arg1)
 }
 
-// ListPaymentDetails mocks base method.
-func (m *MockStore) ListPaymentDetails(arg0 context.Context, arg1 db.ListPaymentDetailsParams) ([]db.ListPaymentDetailsRow, error) {
+// SearchAdmins mocks base method.
+func (m *MockStore) SearchAdmins(arg0 context.Context, arg1 db.SearchAdminsParams) ([]db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaymentDetails", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListPaymentDetailsRow)
+	ret := m.ctrl.Call(m, "SearchAdmins", arg0, arg1)
+	ret0, _ := ret[0].([]db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPaymentDetails indicates an expected call of ListPaymentDetails.
-func (mr *MockStoreMockRecorder) ListPaymentDetails(arg0, arg1 interface{}) *gomock.Call {
+// SearchAdmins indicates an expected call of SearchAdmins.
+func (mr *MockStoreMockRecorder) SearchAdmins(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentDetails", reflect.TypeOf((*MockStore)(nil).ListPaymentDetails), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAdmins", reflect.TypeOf((*MockStore)(nil).SearchAdmins), arg0, arg1)
 }
 
-// ListProductCategories mocks base method.
-func (m *MockStore) ListProductCategories(arg0 context.Context, arg1 db.ListProductCategoriesParams) ([]db.ProductCategory, error) {
+// SearchProducts mocks base method.
+func (m *MockStore) SearchProducts(arg0 context.Context, arg1 db.SearchProductsParams) ([]db.SearchProductsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductCategories", arg0, arg1)
-	ret0, _ := ret[0].([]db.ProductCategory)
+	ret := m.ctrl.Call(m, "SearchProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchProductsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductCategories indicates an expected call of ListProductCategories.
-func (mr *MockStoreMockRecorder) ListProductCategories(arg0, arg1 interface{}) *gomock.Call {
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockStoreMockRecorder) SearchProducts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductCategories", reflect.TypeOf((*MockStore)(nil).ListProductCategories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockStore)(nil).SearchProducts), arg0, arg1)
 }
 
-// ListProductInventories mocks base method.
-func (m *MockStore) ListProductInventories(arg0 context.Context, arg1 db.ListProductInventoriesParams) ([]db.ProductInventory, error) {
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockStore) UpdateAPIKeyLastUsed(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductInventories", arg0, arg1)
-	ret0, _ := ret[0].([]db.ProductInventory)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListProductInventories indicates an expected call of ListProductInventories.
-func (mr *MockStoreMockRecorder) ListProductInventories(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockStoreMockRecorder) UpdateAPIKeyLastUsed(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductInventories", reflect.TypeOf((*MockStore)(nil).ListProductInventories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockStore)(nil).UpdateAPIKeyLastUsed), arg0, arg1)
 }
 
-// ListProducts mocks base method.
-func (m *MockStore) ListProducts(arg0 context.Context, arg1 db.ListProductsParams) ([]db.Product, error) {
+// UpdateAdmin mocks base method.
+func (m *MockStore) UpdateAdmin(arg0 context.Context, arg1 db.UpdateAdminParams) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Product)
+	ret := m.ctrl.Call(m, "UpdateAdmin", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProducts indicates an expected call of ListProducts.
-func (mr *MockStoreMockRecorder) ListProducts(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdmin indicates an expected call of UpdateAdmin.
+func (mr *MockStoreMockRecorder) UpdateAdmin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdmin", reflect.TypeOf((*MockStore)(nil).UpdateAdmin), arg0, arg1)
 }
 
-// ListShoppingSessions mocks base method.
-func (m *MockStore) ListShoppingSessions(arg0 context.Context, arg1 db.ListShoppingSessionsParams) ([]db.ShoppingSession, error) {
+// UpdateAdminLastLogin mocks base method.
+func (m *MockStore) UpdateAdminLastLogin(arg0 context.Context, arg1 int64) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListShoppingSessions", arg0, arg1)
-	ret0, _ := ret[0].([]db.ShoppingSession)
+	ret := m.ctrl.Call(m, "UpdateAdminLastLogin", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListShoppingSessions indicates an expected call of ListShoppingSessions.
-func (mr *MockStoreMockRecorder) ListShoppingSessions(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdminLastLogin indicates an expected call of UpdateAdminLastLogin.
+func (mr *MockStoreMockRecorder) UpdateAdminLastLogin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShoppingSessions", reflect.TypeOf((*MockStore)(nil).ListShoppingSessions), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminLastLogin", reflect.TypeOf((*MockStore)(nil).UpdateAdminLastLogin), arg0, arg1)
 }
 
-// ListUserAddresses mocks base method.
-func (m *MockStore) ListUserAddresses(arg0 context.Context, arg1 db.ListUserAddressesParams) ([]db.UserAddress, error) {
+// UpdateAdminTx mocks base method.
+func (m *MockStore) UpdateAdminTx(arg0 context.Context, arg1 db.UpdateAdminTxParams) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserAddresses", arg0, arg1)
-	ret0, _ := ret[0].([]db.UserAddress)
+	ret := m.ctrl.Call(m, "UpdateAdminTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserAddresses indicates an expected call of ListUserAddresses.
-func (mr *MockStoreMockRecorder) ListUserAddresses(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdminTx indicates an expected call of UpdateAdminTx.
+func (mr *MockStoreMockRecorder) UpdateAdminTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAddresses", reflect.TypeOf((*MockStore)(nil).ListUserAddresses), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminTx", reflect.TypeOf((*MockStore)(nil).UpdateAdminTx), arg0, arg1)
 }
 
-// ListUserPayments mocks base method.
-func (m *MockStore) ListUserPayments(arg0 context.Context, arg1 db.ListUserPaymentsParams) ([]db.UserPayment, error) {
+// UpdateAdminType mocks base method.
+func (m *MockStore) UpdateAdminType(arg0 context.Context, arg1 db.UpdateAdminTypeParams) (db.AdminType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserPayments", arg0, arg1)
-	ret0, _ := ret[0].([]db.UserPayment)
+	ret := m.ctrl.Call(m, "UpdateAdminType", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserPayments indicates an expected call of ListUserPayments.
-func (mr *MockStoreMockRecorder) ListUserPayments(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdminType indicates an expected call of UpdateAdminType.
+func (mr *MockStoreMockRecorder) UpdateAdminType(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPayments", reflect.TypeOf((*MockStore)(nil).ListUserPayments), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminType", reflect.TypeOf((*MockStore)(nil).UpdateAdminType), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+// UpdateAdminTypeID mocks base method.
+func (m *MockStore) UpdateAdminTypeID(arg0 context.Context, arg1 db.UpdateAdminTypeIDParams) (db.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "UpdateAdminTypeID", arg0, arg1)
+	ret0, _ := ret[0].(db.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdminTypeID indicates an expected call of UpdateAdminTypeID.
+func (mr *MockStoreMockRecorder) UpdateAdminTypeID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminTypeID", reflect.TypeOf((*MockStore)(nil).UpdateAdminTypeID), arg0, arg1)
 }
 
-// UpdateAdmin mocks base method.
-func (m *MockStore) UpdateAdmin(arg0 context.Context, arg1 db.UpdateAdminParams) (db.Admin, error) {
+// UpdateAdminTypeMFAPolicy mocks base method.
+func (m *MockStore) UpdateAdminTypeMFAPolicy(arg0 context.Context, arg1 db.UpdateAdminTypeMFAPolicyParams) (db.AdminType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAdmin", arg0, arg1)
-	ret0, _ := ret[0].(db.Admin)
+	ret := m.ctrl.Call(m, "UpdateAdminTypeMFAPolicy", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAdmin indicates an expected call of UpdateAdmin.
-func (mr *MockStoreMockRecorder) UpdateAdmin(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdminTypeMFAPolicy indicates an expected call of UpdateAdminTypeMFAPolicy.
+func (mr *MockStoreMockRecorder) UpdateAdminTypeMFAPolicy(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdmin", reflect.TypeOf((*MockStore)(nil).UpdateAdmin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminTypeMFAPolicy", reflect.TypeOf((*MockStore)(nil).UpdateAdminTypeMFAPolicy), arg0, arg1)
 }
 
-// UpdateAdminType mocks base method.
-func (m *MockStore) UpdateAdminType(arg0 context.Context, arg1 db.UpdateAdminTypeParams) (db.AdminType, error) {
+// UpdateAdminTypePermissionsTx mocks base method.
+func (m *MockStore) UpdateAdminTypePermissionsTx(arg0 context.Context, arg1 db.UpdateAdminTypePermissionsTxParams) (db.AdminTypeTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAdminType", arg0, arg1)
-	ret0, _ := ret[0].(db.AdminType)
+	ret := m.ctrl.Call(m, "UpdateAdminTypePermissionsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTypeTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAdminType indicates an expected call of UpdateAdminType.
-func (mr *MockStoreMockRecorder) UpdateAdminType(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAdminTypePermissionsTx indicates an expected call of UpdateAdminTypePermissionsTx.
+func (mr *MockStoreMockRecorder) UpdateAdminTypePermissionsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminType", reflect.TypeOf((*MockStore)(nil).UpdateAdminType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdminTypePermissionsTx", reflect.TypeOf((*MockStore)(nil).UpdateAdminTypePermissionsTx), arg0, arg1)
 }
 
 // UpdateCartItem mocks base method.
@@ -1041,6 +3039,21 @@ func (mr *MockStoreMockRecorder) UpdateCartItem(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartItem", reflect.TypeOf((*MockStore)(nil).UpdateCartItem), arg0, arg1)
 }
 
+// UpdateCartItemTx mocks base method.
+func (m *MockStore) UpdateCartItemTx(arg0 context.Context, arg1 db.UpdateCartItemTxParams) (db.CartItemTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCartItemTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CartItemTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCartItemTx indicates an expected call of UpdateCartItemTx.
+func (mr *MockStoreMockRecorder) UpdateCartItemTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartItemTx", reflect.TypeOf((*MockStore)(nil).UpdateCartItemTx), arg0, arg1)
+}
+
 // UpdateDiscount mocks base method.
 func (m *MockStore) UpdateDiscount(arg0 context.Context, arg1 db.UpdateDiscountParams) (db.Discount, error) {
 	m.ctrl.T.Helper()
@@ -1161,6 +3174,21 @@ func (mr *MockStoreMockRecorder) UpdateProductQuantity(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductQuantity", reflect.TypeOf((*MockStore)(nil).UpdateProductQuantity), arg0, arg1)
 }
 
+// UpdateProductVariant mocks base method.
+func (m *MockStore) UpdateProductVariant(arg0 context.Context, arg1 db.UpdateProductVariantParams) (db.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductVariant", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductVariant indicates an expected call of UpdateProductVariant.
+func (mr *MockStoreMockRecorder) UpdateProductVariant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductVariant", reflect.TypeOf((*MockStore)(nil).UpdateProductVariant), arg0, arg1)
+}
+
 // UpdateShoppingSession mocks base method.
 func (m *MockStore) UpdateShoppingSession(arg0 context.Context, arg1 db.UpdateShoppingSessionParams) (db.ShoppingSession, error) {
 	m.ctrl.T.Helper()
@@ -1176,6 +3204,21 @@ func (mr *MockStoreMockRecorder) UpdateShoppingSession(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShoppingSession", reflect.TypeOf((*MockStore)(nil).UpdateShoppingSession), arg0, arg1)
 }
 
+// UpdateShoppingSessionTotalTx mocks base method.
+func (m *MockStore) UpdateShoppingSessionTotalTx(arg0 context.Context, arg1 int64) (db.ShoppingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShoppingSessionTotalTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ShoppingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShoppingSessionTotalTx indicates an expected call of UpdateShoppingSessionTotalTx.
+func (mr *MockStoreMockRecorder) UpdateShoppingSessionTotalTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShoppingSessionTotalTx", reflect.TypeOf((*MockStore)(nil).UpdateShoppingSessionTotalTx), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1221,6 +3264,36 @@ func (mr *MockStoreMockRecorder) UpdateUserAddressByUserID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAddressByUserID", reflect.TypeOf((*MockStore)(nil).UpdateUserAddressByUserID), arg0, arg1)
 }
 
+// UpdateUserEmail mocks base method.
+func (m *MockStore) UpdateUserEmail(arg0 context.Context, arg1 db.UpdateUserEmailParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserEmail indicates an expected call of UpdateUserEmail.
+func (mr *MockStoreMockRecorder) UpdateUserEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEmail", reflect.TypeOf((*MockStore)(nil).UpdateUserEmail), arg0, arg1)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1 db.UpdateUserPasswordParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1)
+}
+
 // UpdateUserPayment mocks base method.
 func (m *MockStore) UpdateUserPayment(arg0 context.Context, arg1 db.UpdateUserPaymentParams) (db.UserPayment, error) {
 	m.ctrl.T.Helper()
@@ -1235,3 +3308,153 @@ func (mr *MockStoreMockRecorder) UpdateUserPayment(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPayment", reflect.TypeOf((*MockStore)(nil).UpdateUserPayment), arg0, arg1)
 }
+
+// UpsertStockReservation mocks base method.
+func (m *MockStore) UpsertStockReservation(arg0 context.Context, arg1 db.UpsertStockReservationParams) (db.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertStockReservation", arg0, arg1)
+	ret0, _ := ret[0].(db.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertStockReservation indicates an expected call of UpsertStockReservation.
+func (mr *MockStoreMockRecorder) UpsertStockReservation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertStockReservation", reflect.TypeOf((*MockStore)(nil).UpsertStockReservation), arg0, arg1)
+}
+
+// UseAdminRecoveryCode mocks base method.
+func (m *MockStore) UseAdminRecoveryCode(arg0 context.Context, arg1 db.UseAdminRecoveryCodeParams) (db.AdminRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAdminRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseAdminRecoveryCode indicates an expected call of UseAdminRecoveryCode.
+func (mr *MockStoreMockRecorder) UseAdminRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAdminRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseAdminRecoveryCode), arg0, arg1)
+}
+
+// UseAdminTOTPStep mocks base method.
+func (m *MockStore) UseAdminTOTPStep(arg0 context.Context, arg1 db.UseAdminTOTPStepParams) (db.AdminTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAdminTOTPStep", arg0, arg1)
+	ret0, _ := ret[0].(db.AdminTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseAdminTOTPStep indicates an expected call of UseAdminTOTPStep.
+func (mr *MockStoreMockRecorder) UseAdminTOTPStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAdminTOTPStep", reflect.TypeOf((*MockStore)(nil).UseAdminTOTPStep), arg0, arg1)
+}
+
+// UseEmailChangeToken mocks base method.
+func (m *MockStore) UseEmailChangeToken(arg0 context.Context, arg1 db.UseEmailChangeTokenParams) (db.EmailChangeToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseEmailChangeToken", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailChangeToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseEmailChangeToken indicates an expected call of UseEmailChangeToken.
+func (mr *MockStoreMockRecorder) UseEmailChangeToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailChangeToken", reflect.TypeOf((*MockStore)(nil).UseEmailChangeToken), arg0, arg1)
+}
+
+// UseEmailVerificationToken mocks base method.
+func (m *MockStore) UseEmailVerificationToken(arg0 context.Context, arg1 string) (db.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseEmailVerificationToken", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseEmailVerificationToken indicates an expected call of UseEmailVerificationToken.
+func (mr *MockStoreMockRecorder) UseEmailVerificationToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailVerificationToken", reflect.TypeOf((*MockStore)(nil).UseEmailVerificationToken), arg0, arg1)
+}
+
+// UsePasswordResetToken mocks base method.
+func (m *MockStore) UsePasswordResetToken(arg0 context.Context, arg1 string) (db.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordResetToken", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordResetToken indicates an expected call of UsePasswordResetToken.
+func (mr *MockStoreMockRecorder) UsePasswordResetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordResetToken", reflect.TypeOf((*MockStore)(nil).UsePasswordResetToken), arg0, arg1)
+}
+
+// UseUserRecoveryCode mocks base method.
+func (m *MockStore) UseUserRecoveryCode(arg0 context.Context, arg1 db.UseUserRecoveryCodeParams) (db.UserRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(db.UserRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseUserRecoveryCode indicates an expected call of UseUserRecoveryCode.
+func (mr *MockStoreMockRecorder) UseUserRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseUserRecoveryCode), arg0, arg1)
+}
+
+// UseUserTOTPStep mocks base method.
+func (m *MockStore) UseUserTOTPStep(arg0 context.Context, arg1 db.UseUserTOTPStepParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserTOTPStep", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseUserTOTPStep indicates an expected call of UseUserTOTPStep.
+func (mr *MockStoreMockRecorder) UseUserTOTPStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserTOTPStep", reflect.TypeOf((*MockStore)(nil).UseUserTOTPStep), arg0, arg1)
+}
+
+// VerifyEmailTx mocks base method.
+func (m *MockStore) VerifyEmailTx(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailTx indicates an expected call of VerifyEmailTx.
+func (mr *MockStoreMockRecorder) VerifyEmailTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailTx", reflect.TypeOf((*MockStore)(nil).VerifyEmailTx), arg0, arg1)
+}
+
+// VerifyUserEmail mocks base method.
+func (m *MockStore) VerifyUserEmail(arg0 context.Context, arg1 int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockStoreMockRecorder) VerifyUserEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockStore)(nil).VerifyUserEmail), arg0, arg1)
+}
@@ -19,9 +19,25 @@ WHERE email = $1 LIMIT 1;
 
 -- name: ListAdmins :many
 SELECT * FROM "admin"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: CountAdmins :one
+SELECT count(*) FROM "admin";
+
+-- name: SearchAdmins :many
+SELECT * FROM "admin"
+WHERE (username ILIKE '%' || sqlc.arg(search)::varchar || '%'
+OR email ILIKE '%' || sqlc.arg(search)::varchar || '%')
+AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: CountSearchAdmins :one
+SELECT count(*) FROM "admin"
+WHERE username ILIKE '%' || sqlc.arg(search)::varchar || '%'
+OR email ILIKE '%' || sqlc.arg(search)::varchar || '%';
 
 -- name: UpdateAdmin :one
 UPDATE "admin"
@@ -29,6 +45,37 @@ SET active = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateAdminTypeID :one
+UPDATE "admin"
+SET type_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateAdminLastLogin :one
+UPDATE "admin"
+SET last_login = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RecordFailedAdminLogin :one
+UPDATE "admin"
+SET failed_login_attempts = failed_login_attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: LockAdmin :one
+UPDATE "admin"
+SET locked_until = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ResetFailedAdminLogins :one
+UPDATE "admin"
+SET failed_login_attempts = 0,
+locked_until = NULL
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteAdmin :exec
 DELETE FROM "admin"
 WHERE id = $1;
\ No newline at end of file
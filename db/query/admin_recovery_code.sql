@@ -0,0 +1,25 @@
+-- name: CreateAdminRecoveryCode :one
+INSERT INTO "admin_recovery_code" (
+  admin_id,
+  code_hash
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: UseAdminRecoveryCode :one
+UPDATE "admin_recovery_code"
+SET used_at = now()
+WHERE admin_id = $1
+AND code_hash = $2
+AND used_at IS NULL
+RETURNING *;
+
+-- name: CountAdminRecoveryCodes :one
+SELECT count(*) FROM "admin_recovery_code"
+WHERE admin_id = $1
+AND used_at IS NULL;
+
+-- name: DeleteAdminRecoveryCodes :exec
+DELETE FROM "admin_recovery_code"
+WHERE admin_id = $1;
\ No newline at end of file
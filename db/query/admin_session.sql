@@ -0,0 +1,29 @@
+-- name: CreateAdminSession :one
+INSERT INTO "admin_session" (
+  id,
+  admin_id,
+  refresh_token,
+  user_agent,
+  client_ip,
+  is_blocked,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetAdminSession :one
+SELECT * FROM "admin_session"
+WHERE id = $1 LIMIT 1;
+
+-- name: CheckAdminSession :one
+SELECT s.is_blocked, a.active FROM "admin_session" AS s
+JOIN "admin" AS a ON a.id = s.admin_id
+WHERE s.id = $1
+AND s.admin_id = $2 LIMIT 1;
+
+-- name: BlockAdminSessions :exec
+UPDATE "admin_session"
+SET is_blocked = true
+WHERE admin_id = $1
+AND is_blocked = false;
\ No newline at end of file
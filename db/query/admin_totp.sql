@@ -0,0 +1,34 @@
+-- name: CreateAdminTOTP :one
+INSERT INTO "admin_totp" (
+  admin_id,
+  secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (admin_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+created_at = now()
+WHERE "admin_totp".confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetAdminTOTP :one
+SELECT * FROM "admin_totp"
+WHERE admin_id = $1 LIMIT 1;
+
+-- name: ConfirmAdminTOTP :one
+UPDATE "admin_totp"
+SET confirmed_at = now()
+WHERE admin_id = $1
+AND confirmed_at IS NULL
+RETURNING *;
+
+-- name: UseAdminTOTPStep :one
+UPDATE "admin_totp"
+SET last_used_step = $2
+WHERE admin_id = $1
+AND last_used_step < $2
+RETURNING *;
+
+-- name: DeleteAdminTOTP :exec
+DELETE FROM "admin_totp"
+WHERE admin_id = $1;
\ No newline at end of file
@@ -10,9 +10,12 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListAdminTypes :many
 SELECT * FROM "admin_type"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: CountAdminTypes :one
+SELECT count(*) FROM "admin_type";
 
 -- name: UpdateAdminType :one
 UPDATE "admin_type"
@@ -26,4 +29,10 @@ WHERE id = $1;
 
 -- name: DeleteAdminTypeByType :exec
 DELETE FROM "admin_type"
-WHERE admin_type = $1;
\ No newline at end of file
+WHERE admin_type = $1;
+
+-- name: UpdateAdminTypeMFAPolicy :one
+UPDATE "admin_type"
+SET require_mfa = $2
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- name: CreateAdminTypePermission :one
+INSERT INTO "admin_type_permission" (
+  admin_type_id,
+  permission
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: ListAdminTypePermissions :many
+SELECT permission FROM "admin_type_permission"
+WHERE admin_type_id = $1
+ORDER BY permission;
+
+-- name: DeleteAdminTypePermissions :exec
+DELETE FROM "admin_type_permission"
+WHERE admin_type_id = $1;
\ No newline at end of file
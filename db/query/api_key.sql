@@ -0,0 +1,40 @@
+-- name: CreateAPIKey :one
+INSERT INTO "api_key" (
+  name,
+  prefix,
+  key_hash,
+  admin_id,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetAPIKey :one
+SELECT * FROM "api_key"
+WHERE id = $1 LIMIT 1;
+
+-- name: GetAPIKeyByHash :one
+SELECT "api_key".* FROM "api_key"
+JOIN "admin" ON "admin".id = "api_key".admin_id
+WHERE "api_key".key_hash = $1
+AND "admin".active = true
+LIMIT 1;
+
+-- name: ListAPIKeys :many
+SELECT * FROM "api_key"
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: CountAPIKeys :one
+SELECT count(*) FROM "api_key";
+
+-- name: UpdateAPIKeyLastUsed :exec
+UPDATE "api_key"
+SET last_used_at = now()
+WHERE id = $1;
+
+-- name: DeleteAPIKey :exec
+DELETE FROM "api_key"
+WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- name: CreateAPIKeyPermission :one
+INSERT INTO "api_key_permission" (
+  api_key_id,
+  permission
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: ListAPIKeyPermissions :many
+SELECT permission FROM "api_key_permission"
+WHERE api_key_id = $1
+ORDER BY permission;
\ No newline at end of file
@@ -2,10 +2,13 @@
 INSERT INTO "cart_item" (
   session_id,
   product_id,
+  variant_id,
   quantity
-) VALUES (
-  $1, $2, $3
 )
+SELECT sqlc.arg(session_id)::bigint, "product_variant".product_id, "product_variant".id, sqlc.arg(quantity)::int
+FROM "product_variant"
+WHERE "product_variant".id = sqlc.arg(variant_id)
+AND "product_variant".active
 RETURNING *;
 
 -- name: GetCartItemByID :one
@@ -16,6 +19,11 @@ WHERE id = $1 LIMIT 1;
 SELECT * FROM "cart_item"
 WHERE session_id = $1 LIMIT 1;
 
+-- name: ListCartItemsBySessionID :many
+SELECT * FROM "cart_item"
+WHERE session_id = $1
+ORDER BY id;
+
 -- name: ListCartItem :many
 SELECT * FROM "cart_item"
 ORDER BY id
@@ -30,4 +38,11 @@ RETURNING *;
 
 -- name: DeleteCartItem :exec
 DELETE FROM "cart_item"
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: DeleteCartItemsByUserID :exec
+DELETE FROM "cart_item"
+WHERE session_id IN (
+  SELECT id FROM "shopping_session"
+  WHERE user_id = sqlc.arg(user_id)::bigint
+);
\ No newline at end of file
@@ -14,9 +14,12 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListDiscounts :many
 SELECT * FROM "discount"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: CountDiscounts :one
+SELECT count(*) FROM "discount";
 
 -- name: UpdateDiscount :one
 UPDATE "discount"
@@ -0,0 +1,29 @@
+-- name: CreateEmailChangeToken :one
+INSERT INTO "email_change_token" (
+  user_id,
+  new_email,
+  token_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UseEmailChangeToken :one
+UPDATE "email_change_token"
+SET used_at = now()
+WHERE token_hash = $1
+AND user_id = $2
+AND used_at IS NULL
+AND expires_at > now()
+RETURNING *;
+
+-- name: InvalidateEmailChangeTokens :exec
+UPDATE "email_change_token"
+SET used_at = now()
+WHERE user_id = $1
+AND used_at IS NULL;
+
+-- name: DeleteEmailChangeTokens :exec
+DELETE FROM "email_change_token"
+WHERE user_id = $1;
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- name: CreateEmailVerificationToken :one
+INSERT INTO "email_verification_token" (
+  user_id,
+  token_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetLastEmailVerificationToken :one
+SELECT * FROM "email_verification_token"
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UseEmailVerificationToken :one
+UPDATE "email_verification_token"
+SET used_at = now()
+WHERE token_hash = $1
+AND used_at IS NULL
+AND expires_at > now()
+RETURNING *;
+
+-- name: InvalidateEmailVerificationTokens :exec
+UPDATE "email_verification_token"
+SET used_at = now()
+WHERE user_id = $1
+AND used_at IS NULL;
+
+-- name: DeleteEmailVerificationTokens :exec
+DELETE FROM "email_verification_token"
+WHERE user_id = $1;
\ No newline at end of file
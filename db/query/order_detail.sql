@@ -29,12 +29,31 @@ SELECT * FROM "order_detail"
 WHERE id = $1 
 LIMIT 1;
 
+-- name: GetOrderDetailForUpdate :one
+SELECT * FROM "order_detail"
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListOrderDetails :many
 SELECT * FROM "order_detail"
-WHERE user_id = $1
+WHERE user_id = sqlc.arg(user_id)
+AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: CountOrderDetails :one
+SELECT count(*) FROM "order_detail"
+WHERE user_id = $1;
+
+-- name: ListOrderDetailsOfAllUsers :many
+SELECT * FROM "order_detail"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit');
+
+-- name: CountOrderDetailsOfAllUsers :one
+SELECT count(*) FROM "order_detail";
 
 -- name: UpdateOrderDetail :one
 UPDATE "order_detail"
@@ -44,4 +63,9 @@ RETURNING *;
 
 -- name: DeleteOrderDetail :exec
 DELETE FROM "order_detail"
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListAllOrderDetails :many
+SELECT * FROM "order_detail"
+WHERE user_id = $1
+ORDER BY id;
\ No newline at end of file
@@ -2,15 +2,22 @@
 INSERT INTO "order_item" (
   order_id,
   product_id,
-  quantity
+  quantity,
+  product_name,
+  product_sku,
+  price,
+  variant_id,
+  variant_name
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 
 -- name: GetOrderItem :one
 SELECT "order_item".id, "order_item".order_id, "order_item".product_id, 
-"order_item".quantity, "order_item".created_at, "order_item".updated_at 
+"order_item".quantity, "order_item".created_at, "order_item".updated_at,
+"order_item".product_name, "order_item".product_sku, "order_item".price,
+"order_item".variant_id, "order_item".variant_name
 FROM "order_item"
 LEFT JOIN "order_detail" ON "order_detail".id = "order_item".order_id
 WHERE "order_item".id = $1 
@@ -19,12 +26,25 @@ LIMIT 1;
 
 -- name: ListOrderItems :many
 SELECT "order_item".id, "order_item".order_id, "order_item".product_id, 
-"order_item".quantity, "order_item".created_at, "order_item".updated_at
+"order_item".quantity, "order_item".created_at, "order_item".updated_at,
+"order_item".product_name, "order_item".product_sku, "order_item".price,
+"order_item".variant_id, "order_item".variant_name
 FROM "order_item"
 LEFT JOIN "order_detail" ON "order_detail".id = "order_item".order_id
-WHERE "order_detail".user_id = $1
-LIMIT $2
-OFFSET $3;
+WHERE "order_detail".user_id = sqlc.arg(user_id)
+AND "order_item".id > sqlc.arg(after_id)
+ORDER BY "order_item".id
+LIMIT sqlc.arg('limit');
+
+-- name: CountOrderItems :one
+SELECT count(*) FROM "order_item"
+JOIN "order_detail" ON "order_detail".id = "order_item".order_id
+WHERE "order_detail".user_id = $1;
+
+-- name: ListOrderItemsByOrderID :many
+SELECT * FROM "order_item"
+WHERE order_id = $1
+ORDER BY id;
 
 -- name: UpdateOrderItem :one
 UPDATE "order_item"
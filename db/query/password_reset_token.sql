@@ -0,0 +1,27 @@
+-- name: CreatePasswordResetToken :one
+INSERT INTO "password_reset_token" (
+  user_id,
+  token_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UsePasswordResetToken :one
+UPDATE "password_reset_token"
+SET used_at = now()
+WHERE token_hash = $1
+AND used_at IS NULL
+AND expires_at > now()
+RETURNING *;
+
+-- name: InvalidatePasswordResetTokens :exec
+UPDATE "password_reset_token"
+SET used_at = now()
+WHERE user_id = $1
+AND used_at IS NULL;
+
+-- name: DeletePasswordResetTokens :exec
+DELETE FROM "password_reset_token"
+WHERE user_id = $1;
\ No newline at end of file
@@ -19,13 +19,23 @@ WHERE "payment_detail".id = $1
 AND "order_detail".user_id = $2 
 LIMIT 1;
 
+-- name: GetPaymentDetailStatus :one
+SELECT status FROM "payment_detail"
+WHERE id = $1
+LIMIT 1;
+
 -- name: ListPaymentDetails :many
 SELECT * FROM "payment_detail"
 LEFT JOIN "order_detail" ON "order_detail".id = "payment_detail".order_id
-WHERE "order_detail".user_id = $1
+WHERE "order_detail".user_id = sqlc.arg(user_id)
+AND "payment_detail".id > sqlc.arg(after_id)
 ORDER BY "payment_detail".id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit');
+
+-- name: CountPaymentDetails :one
+SELECT count(*) FROM "payment_detail"
+JOIN "order_detail" ON "order_detail".id = "payment_detail".order_id
+WHERE "order_detail".user_id = $1;
 
 -- name: UpdatePaymentDetail :one
 WITH t1 AS (
@@ -16,11 +16,37 @@ RETURNING *;
 SELECT * FROM "product"
 WHERE id = $1 LIMIT 1;
 
+-- name: GetProductForUpdate :one
+SELECT * FROM "product"
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListProducts :many
 SELECT * FROM "product"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: SearchProducts :many
+SELECT p.id, p.name, p.description, p.sku, p.category_id, p.inventory_id, p.price, p.active, p.discount_id, p.created_at, p.updated_at,
+ts_rank(p.search_vector, q.query)::real AS rank,
+ts_headline('simple', p.name, q.query)::varchar AS name_highlight,
+ts_headline('simple', p.description, q.query, 'MaxFragments=2, MaxWords=20, MinWords=5')::text AS description_snippet
+FROM "product" AS p
+CROSS JOIN to_tsquery('simple', sqlc.arg(query)::varchar) AS q(query)
+WHERE p.search_vector @@ q.query
+AND p.active
+AND (sqlc.narg(after_rank)::real IS NULL
+OR ts_rank(p.search_vector, q.query)::real < sqlc.narg(after_rank)::real
+OR (ts_rank(p.search_vector, q.query)::real = sqlc.narg(after_rank)::real AND p.id > sqlc.arg(after_id)::bigint))
+ORDER BY rank DESC, p.id
+LIMIT sqlc.arg('limit');
+
+-- name: CountSearchProducts :one
+SELECT count(*) FROM "product" AS p
+CROSS JOIN to_tsquery('simple', sqlc.arg(query)::varchar) AS q(query)
+WHERE p.search_vector @@ q.query
+AND p.active;
 
 -- name: UpdateProduct :one
 UPDATE "product"
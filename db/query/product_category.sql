@@ -13,9 +13,12 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListProductCategories :many
 SELECT * FROM "product_category"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: CountProductCategories :one
+SELECT count(*) FROM "product_category";
 
 -- name: UpdateProductCategory :one
 UPDATE "product_category"
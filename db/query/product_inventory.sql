@@ -17,9 +17,12 @@ FOR NO KEY UPDATE;
 
 -- name: ListProductInventories :many
 SELECT * FROM "product_inventory"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: CountProductInventories :one
+SELECT count(*) FROM "product_inventory";
 
 -- name: UpdateProductInventory :one
 UPDATE "product_inventory"
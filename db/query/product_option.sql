@@ -0,0 +1,32 @@
+-- name: CreateProductOption :one
+INSERT INTO "product_option" (
+  product_id,
+  name,
+  position
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListProductOptionsByProductID :many
+SELECT * FROM "product_option"
+WHERE product_id = $1
+ORDER BY position, id;
+
+-- name: CreateProductOptionValue :one
+INSERT INTO "product_option_value" (
+  option_id,
+  value,
+  position
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListProductOptionValuesByProductID :many
+SELECT "product_option_value".id, "product_option_value".option_id, "product_option_value".value,
+"product_option_value".position, "product_option_value".created_at
+FROM "product_option_value"
+JOIN "product_option" ON "product_option".id = "product_option_value".option_id
+WHERE "product_option".product_id = $1
+ORDER BY "product_option".position, "product_option".id, "product_option_value".position, "product_option_value".id;
\ No newline at end of file
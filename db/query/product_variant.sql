@@ -0,0 +1,65 @@
+-- name: CreateProductVariant :one
+INSERT INTO "product_variant" (
+  product_id,
+  sku,
+  price,
+  inventory_id
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetProductVariant :one
+SELECT * FROM "product_variant"
+WHERE id = $1 LIMIT 1;
+
+-- name: ListProductVariantsByProductID :many
+SELECT "product_variant".id, "product_variant".product_id, "product_variant".sku,
+"product_variant".price, "product_variant".inventory_id, "product_variant".active,
+"product_variant".created_at, "product_variant".updated_at,
+("product_inventory".active AND "product_inventory".quantity > 0)::boolean AS in_stock
+FROM "product_variant"
+JOIN "product_inventory" ON "product_inventory".id = "product_variant".inventory_id
+WHERE "product_variant".product_id = $1
+ORDER BY "product_variant".id;
+
+-- name: UpdateProductVariant :one
+UPDATE "product_variant"
+SET price = $3,
+active = $4
+WHERE id = $1
+AND product_id = $2
+RETURNING *;
+
+-- name: DeactivateDefaultProductVariant :exec
+UPDATE "product_variant"
+SET active = false
+FROM "product"
+WHERE "product".id = "product_variant".product_id
+AND "product_variant".inventory_id = "product".inventory_id
+AND "product_variant".product_id = $1;
+
+-- name: CreateProductVariantOptionValue :one
+INSERT INTO "product_variant_option_value" (
+  variant_id,
+  option_id,
+  option_value_id
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListProductVariantOptionValuesByProductID :many
+SELECT "product_variant_option_value".variant_id, "product_variant_option_value".option_id,
+"product_variant_option_value".option_value_id
+FROM "product_variant_option_value"
+JOIN "product_variant" ON "product_variant".id = "product_variant_option_value".variant_id
+WHERE "product_variant".product_id = $1
+ORDER BY "product_variant_option_value".variant_id, "product_variant_option_value".option_id;
+
+-- name: GetProductVariantName :one
+SELECT COALESCE(string_agg("product_option_value".value, ' / ' ORDER BY "product_option".position, "product_option".id), '')::varchar AS variant_name
+FROM "product_variant_option_value"
+JOIN "product_option_value" ON "product_option_value".id = "product_variant_option_value".option_value_id
+JOIN "product_option" ON "product_option".id = "product_variant_option_value".option_id
+WHERE "product_variant_option_value".variant_id = $1;
\ No newline at end of file
@@ -3,7 +3,16 @@ INSERT INTO "shopping_session" (
   user_id,
   total
 ) VALUES (
-  $1, $2
+  sqlc.arg(user_id)::bigint, sqlc.arg(total)
+)
+RETURNING *;
+
+-- name: CreateGuestShoppingSession :one
+INSERT INTO "shopping_session" (
+  guest_token_hash,
+  total
+) VALUES (
+  sqlc.arg(guest_token_hash)::varchar, sqlc.arg(total)
 )
 RETURNING *;
 
@@ -12,12 +21,28 @@ SELECT * FROM "shopping_session"
 WHERE id = $1
 LIMIT 1;
 
+-- name: GetShoppingSessionByUserID :one
+SELECT * FROM "shopping_session"
+WHERE user_id = sqlc.arg(user_id)::bigint
+LIMIT 1;
+
+-- name: GetShoppingSessionByGuestTokenHash :one
+SELECT * FROM "shopping_session"
+WHERE guest_token_hash = sqlc.arg(guest_token_hash)::varchar
+LIMIT 1;
+
+-- name: LockShoppingSessionByGuestTokenHash :one
+SELECT * FROM "shopping_session"
+WHERE guest_token_hash = sqlc.arg(guest_token_hash)::varchar
+LIMIT 1
+FOR UPDATE;
+
 -- name: ListShoppingSessions :many
 SELECT * FROM "shopping_session"
-WHERE user_id = $1
+WHERE user_id = sqlc.arg(user_id)::bigint
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateShoppingSession :one
 UPDATE "shopping_session"
@@ -25,6 +50,21 @@ SET total = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: ClaimGuestShoppingSession :one
+UPDATE "shopping_session"
+SET user_id = sqlc.arg(user_id)::bigint,
+guest_token_hash = NULL
+WHERE id = sqlc.arg(id) AND user_id IS NULL
+RETURNING *;
+
 -- name: DeleteShoppingSession :exec
 DELETE FROM "shopping_session"
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: DeleteShoppingSessionsByUserID :exec
+DELETE FROM "shopping_session"
+WHERE user_id = sqlc.arg(user_id)::bigint;
+
+-- name: DeleteExpiredGuestShoppingSessions :exec
+DELETE FROM "shopping_session"
+WHERE user_id IS NULL AND created_at < sqlc.arg(created_before);
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- name: UpsertStockReservation :one
+INSERT INTO "stock_reservation" (
+  session_id,
+  inventory_id,
+  quantity,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (session_id, inventory_id) DO UPDATE
+SET quantity = EXCLUDED.quantity,
+expires_at = EXCLUDED.expires_at
+RETURNING *;
+
+-- name: GetReservedQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::int AS reserved_quantity
+FROM "stock_reservation"
+WHERE inventory_id = $1
+AND session_id <> $2
+AND expires_at > now();
+
+-- name: ListStockReservationsBySessionID :many
+SELECT * FROM "stock_reservation"
+WHERE session_id = $1
+ORDER BY id;
+
+-- name: DeleteStockReservationsBySessionID :exec
+DELETE FROM "stock_reservation"
+WHERE session_id = $1;
+
+-- name: DeleteExpiredStockReservations :exec
+DELETE FROM "stock_reservation"
+WHERE expires_at <= now();
\ No newline at end of file
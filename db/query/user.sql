@@ -23,9 +23,12 @@ WHERE email = $1 LIMIT 1;
 
 -- name: ListUsers :many
 SELECT * FROM "user"
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: CountUsers :one
+SELECT count(*) FROM "user";
 
 -- name: UpdateUser :one
 UPDATE "user"
@@ -35,4 +38,68 @@ RETURNING *;
 
 -- name: DeleteUser :exec
 DELETE FROM "user"
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: UpdateUserPassword :one
+UPDATE "user"
+SET password = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: VerifyUserEmail :one
+UPDATE "user"
+SET verified_at = COALESCE(verified_at, now())
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateUserEmail :one
+UPDATE "user"
+SET email = $2,
+verified_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RecordFailedUserLogin :one
+UPDATE "user"
+SET failed_login_attempts = failed_login_attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: LockUser :one
+UPDATE "user"
+SET locked_until = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ResetFailedUserLogins :one
+UPDATE "user"
+SET failed_login_attempts = 0,
+locked_until = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: ListLockedUsers :many
+SELECT * FROM "user"
+WHERE locked_until > now()
+AND (sqlc.narg(after_locked_until)::timestamptz IS NULL
+OR (locked_until, id) < (sqlc.narg(after_locked_until)::timestamptz, sqlc.arg(after_id)::bigint))
+ORDER BY locked_until DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountLockedUsers :one
+SELECT count(*) FROM "user"
+WHERE locked_until > now();
+
+-- name: EraseUser :one
+UPDATE "user"
+SET username = 'erased-user-' || id,
+email = 'erased-user-' || id,
+password = '',
+telephone = '',
+verified_at = NULL,
+failed_login_attempts = 0,
+locked_until = NULL,
+erased_at = now()
+WHERE id = $1
+AND erased_at IS NULL
+RETURNING *;
\ No newline at end of file
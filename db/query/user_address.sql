@@ -17,10 +17,14 @@ LIMIT 1;
 
 -- name: ListUserAddresses :many
 SELECT * FROM "user_address"
-WHERE user_id = $1
+WHERE user_id = sqlc.arg(user_id)
+AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit');
+
+-- name: CountUserAddresses :one
+SELECT count(*) FROM "user_address"
+WHERE user_id = $1;
 
 -- name: UpdateUserAddress :one
 UPDATE "user_address"
@@ -41,4 +45,13 @@ RETURNING *;
 
 -- name: DeleteUserAddress :exec
 DELETE FROM "user_address"
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListAllUserAddresses :many
+SELECT * FROM "user_address"
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: DeleteUserAddresses :exec
+DELETE FROM "user_address"
+WHERE user_id = $1;
\ No newline at end of file
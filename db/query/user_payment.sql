@@ -18,10 +18,14 @@ LIMIT 1;
 
 -- name: ListUserPayments :many
 SELECT * FROM "user_payment"
-WHERE user_id = $1
+WHERE user_id = sqlc.arg(user_id)
+AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit');
+
+-- name: CountUserPayments :one
+SELECT count(*) FROM "user_payment"
+WHERE user_id = $1;
 
 -- name: UpdateUserPayment :one
 UPDATE "user_payment"
@@ -33,4 +37,13 @@ RETURNING *;
 -- name: DeleteUserPayment :exec
 DELETE FROM "user_payment"
 WHERE id = $1
-AND user_id = $2;
\ No newline at end of file
+AND user_id = $2;
+
+-- name: ListAllUserPayments :many
+SELECT * FROM "user_payment"
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: DeleteUserPayments :exec
+DELETE FROM "user_payment"
+WHERE user_id = $1;
\ No newline at end of file
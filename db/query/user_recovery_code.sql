@@ -0,0 +1,25 @@
+-- name: CreateUserRecoveryCode :one
+INSERT INTO "user_recovery_code" (
+  user_id,
+  code_hash
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: UseUserRecoveryCode :one
+UPDATE "user_recovery_code"
+SET used_at = now()
+WHERE user_id = $1
+AND code_hash = $2
+AND used_at IS NULL
+RETURNING *;
+
+-- name: CountUserRecoveryCodes :one
+SELECT count(*) FROM "user_recovery_code"
+WHERE user_id = $1
+AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM "user_recovery_code"
+WHERE user_id = $1;
\ No newline at end of file
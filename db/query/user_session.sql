@@ -6,12 +6,69 @@ INSERT INTO "user_session" (
   user_agent,
   client_ip,
   is_blocked,
-  expires_at
+  expires_at,
+  family_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 
 -- name: GetUserSession :one
 SELECT * FROM "user_session"
-WHERE id = $1 LIMIT 1;
\ No newline at end of file
+WHERE id = $1 LIMIT 1;
+
+-- name: ListActiveUserSessions :many
+SELECT * FROM "user_session"
+WHERE user_id = $1
+AND is_blocked = false
+AND rotated_at IS NULL
+AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockUserSession :one
+UPDATE "user_session"
+SET is_blocked = true
+WHERE id = $1
+AND user_id = $2
+RETURNING *;
+
+-- name: BlockAllUserSessions :exec
+UPDATE "user_session"
+SET is_blocked = true
+WHERE user_id = $1
+AND is_blocked = false;
+
+-- name: RotateUserSession :one
+UPDATE "user_session"
+SET rotated_at = now()
+WHERE id = $1
+AND rotated_at IS NULL
+AND is_blocked = false
+RETURNING *;
+
+-- name: BlockUserSessionFamily :exec
+UPDATE "user_session"
+SET is_blocked = true
+WHERE family_id = $1
+AND user_id = $2;
+
+-- name: CheckUserSession :one
+SELECT is_blocked FROM "user_session"
+WHERE id = $1
+AND user_id = $2 LIMIT 1;
+
+-- name: BlockOtherUserSessions :exec
+UPDATE "user_session"
+SET is_blocked = true
+WHERE user_id = $1
+AND id <> $2
+AND is_blocked = false;
+
+-- name: ListAllUserSessions :many
+SELECT * FROM "user_session"
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM "user_session"
+WHERE user_id = $1;
\ No newline at end of file
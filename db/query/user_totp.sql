@@ -0,0 +1,34 @@
+-- name: CreateUserTOTP :one
+INSERT INTO "user_totp" (
+  user_id,
+  secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+created_at = now()
+WHERE "user_totp".confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetUserTOTP :one
+SELECT * FROM "user_totp"
+WHERE user_id = $1 LIMIT 1;
+
+-- name: ConfirmUserTOTP :one
+UPDATE "user_totp"
+SET confirmed_at = now()
+WHERE user_id = $1
+AND confirmed_at IS NULL
+RETURNING *;
+
+-- name: UseUserTOTPStep :one
+UPDATE "user_totp"
+SET last_used_step = $2
+WHERE user_id = $1
+AND last_used_step < $2
+RETURNING *;
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM "user_totp"
+WHERE user_id = $1;
\ No newline at end of file
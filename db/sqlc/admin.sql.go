@@ -5,8 +5,33 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const countAdmins = `-- name: CountAdmins :one
+SELECT count(*) FROM "admin"
+`
+
+func (q *Queries) CountAdmins(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAdmins)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchAdmins = `-- name: CountSearchAdmins :one
+SELECT count(*) FROM "admin"
+WHERE username ILIKE '%' || $1::varchar || '%'
+OR email ILIKE '%' || $1::varchar || '%'
+`
+
+func (q *Queries) CountSearchAdmins(ctx context.Context, search string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchAdmins, search)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAdmin = `-- name: CreateAdmin :one
 INSERT INTO "admin" (
   username,
@@ -16,7 +41,7 @@ INSERT INTO "admin" (
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
 `
 
 type CreateAdminParams struct {
@@ -44,6 +69,8 @@ func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
 	)
 	return i, err
 }
@@ -59,7 +86,7 @@ func (q *Queries) DeleteAdmin(ctx context.Context, id int64) error {
 }
 
 const getAdmin = `-- name: GetAdmin :one
-SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login FROM "admin"
+SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until FROM "admin"
 WHERE id = $1 LIMIT 1
 `
 
@@ -76,12 +103,14 @@ func (q *Queries) GetAdmin(ctx context.Context, id int64) (Admin, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
 	)
 	return i, err
 }
 
 const getAdminByEmail = `-- name: GetAdminByEmail :one
-SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login FROM "admin"
+SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until FROM "admin"
 WHERE email = $1 LIMIT 1
 `
 
@@ -98,24 +127,160 @@ func (q *Queries) GetAdminByEmail(ctx context.Context, email string) (Admin, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
 	)
 	return i, err
 }
 
 const listAdmins = `-- name: ListAdmins :many
-SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login FROM "admin"
+SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until FROM "admin"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListAdminsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListAdmins(ctx context.Context, arg ListAdminsParams) ([]Admin, error) {
-	rows, err := q.db.QueryContext(ctx, listAdmins, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAdmins, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Admin{}
+	for rows.Next() {
+		var i Admin
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.Password,
+			&i.Active,
+			&i.TypeID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastLogin,
+			&i.FailedLoginAttempts,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAdmin = `-- name: LockAdmin :one
+UPDATE "admin"
+SET locked_until = $2
+WHERE id = $1
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
+`
+
+type LockAdminParams struct {
+	ID          int64        `json:"id"`
+	LockedUntil sql.NullTime `json:"locked_until"`
+}
+
+func (q *Queries) LockAdmin(ctx context.Context, arg LockAdminParams) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, lockAdmin, arg.ID, arg.LockedUntil)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Active,
+		&i.TypeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const recordFailedAdminLogin = `-- name: RecordFailedAdminLogin :one
+UPDATE "admin"
+SET failed_login_attempts = failed_login_attempts + 1
+WHERE id = $1
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
+`
+
+func (q *Queries) RecordFailedAdminLogin(ctx context.Context, id int64) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, recordFailedAdminLogin, id)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Active,
+		&i.TypeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const resetFailedAdminLogins = `-- name: ResetFailedAdminLogins :one
+UPDATE "admin"
+SET failed_login_attempts = 0,
+locked_until = NULL
+WHERE id = $1
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
+`
+
+func (q *Queries) ResetFailedAdminLogins(ctx context.Context, id int64) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, resetFailedAdminLogins, id)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Active,
+		&i.TypeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const searchAdmins = `-- name: SearchAdmins :many
+SELECT id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until FROM "admin"
+WHERE (username ILIKE '%' || $1::varchar || '%'
+OR email ILIKE '%' || $1::varchar || '%')
+AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type SearchAdminsParams struct {
+	Search  string `json:"search"`
+	AfterID int64  `json:"after_id"`
+	Limit   int32  `json:"limit"`
+}
+
+func (q *Queries) SearchAdmins(ctx context.Context, arg SearchAdminsParams) ([]Admin, error) {
+	rows, err := q.db.QueryContext(ctx, searchAdmins, arg.Search, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +298,8 @@ func (q *Queries) ListAdmins(ctx context.Context, arg ListAdminsParams) ([]Admin
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.FailedLoginAttempts,
+			&i.LockedUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -151,7 +318,7 @@ const updateAdmin = `-- name: UpdateAdmin :one
 UPDATE "admin"
 SET active = $2
 WHERE id = $1
-RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
 `
 
 type UpdateAdminParams struct {
@@ -172,6 +339,65 @@ func (q *Queries) UpdateAdmin(ctx context.Context, arg UpdateAdminParams) (Admin
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const updateAdminLastLogin = `-- name: UpdateAdminLastLogin :one
+UPDATE "admin"
+SET last_login = now()
+WHERE id = $1
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
+`
+
+func (q *Queries) UpdateAdminLastLogin(ctx context.Context, id int64) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, updateAdminLastLogin, id)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Active,
+		&i.TypeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const updateAdminTypeID = `-- name: UpdateAdminTypeID :one
+UPDATE "admin"
+SET type_id = $2
+WHERE id = $1
+RETURNING id, username, email, password, active, type_id, created_at, updated_at, last_login, failed_login_attempts, locked_until
+`
+
+type UpdateAdminTypeIDParams struct {
+	ID     int64 `json:"id"`
+	TypeID int64 `json:"type_id"`
+}
+
+func (q *Queries) UpdateAdminTypeID(ctx context.Context, arg UpdateAdminTypeIDParams) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, updateAdminTypeID, arg.ID, arg.TypeID)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Active,
+		&i.TypeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
 	)
 	return i, err
 }
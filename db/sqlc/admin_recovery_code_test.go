@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createAdminRecoveryCodeForAdmin(t *testing.T, admin Admin) (AdminRecoveryCode, string) {
+	code, err := util.RandomRecoveryCode()
+	require.NoError(t, err)
+
+	arg := CreateAdminRecoveryCodeParams{
+		AdminID:  admin.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	}
+
+	recoveryCode, err := testQueires.CreateAdminRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, recoveryCode)
+
+	require.Equal(t, arg.AdminID, recoveryCode.AdminID)
+	require.Equal(t, arg.CodeHash, recoveryCode.CodeHash)
+	require.False(t, recoveryCode.UsedAt.Valid)
+	require.NotZero(t, recoveryCode.CreatedAt)
+
+	return recoveryCode, code
+}
+
+func TestCreateAdminRecoveryCode(t *testing.T) {
+	admin := createRandomAdmin(t)
+	createAdminRecoveryCodeForAdmin(t, admin)
+}
+
+func TestUseAdminRecoveryCode(t *testing.T) {
+	admin := createRandomAdmin(t)
+	recoveryCode1, code := createAdminRecoveryCodeForAdmin(t, admin)
+
+	arg := UseAdminRecoveryCodeParams{
+		AdminID:  admin.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	}
+
+	recoveryCode2, err := testQueires.UseAdminRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, recoveryCode1.ID, recoveryCode2.ID)
+	require.True(t, recoveryCode2.UsedAt.Valid)
+
+	// a recovery code can only be used once
+	_, err = testQueires.UseAdminRecoveryCode(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseAdminRecoveryCodeOfAnotherAdmin(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+	admin2 := createRandomAdmin(t)
+	_, code := createAdminRecoveryCodeForAdmin(t, admin1)
+
+	_, err := testQueires.UseAdminRecoveryCode(context.Background(), UseAdminRecoveryCodeParams{
+		AdminID:  admin2.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCountAdminRecoveryCodes(t *testing.T) {
+	admin := createRandomAdmin(t)
+	_, code := createAdminRecoveryCodeForAdmin(t, admin)
+	createAdminRecoveryCodeForAdmin(t, admin)
+
+	count, err := testQueires.CountAdminRecoveryCodes(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	_, err = testQueires.UseAdminRecoveryCode(context.Background(), UseAdminRecoveryCodeParams{
+		AdminID:  admin.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	})
+	require.NoError(t, err)
+
+	// only the unused codes are counted
+	count, err = testQueires.CountAdminRecoveryCodes(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestDeleteAdminRecoveryCodes(t *testing.T) {
+	admin := createRandomAdmin(t)
+	createAdminRecoveryCodeForAdmin(t, admin)
+	createAdminRecoveryCodeForAdmin(t, admin)
+
+	err := testQueires.DeleteAdminRecoveryCodes(context.Background(), admin.ID)
+	require.NoError(t, err)
+
+	count, err := testQueires.CountAdminRecoveryCodes(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAdminSession(t *testing.T) AdminSession {
+	admin1 := createRandomAdmin(t)
+	arg := CreateAdminSessionParams{
+		ID:           uuid.New(),
+		AdminID:      admin1.ID,
+		RefreshToken: util.RandomString(100),
+		UserAgent:    util.RandomString(6),
+		ClientIp:     util.RandomString(10),
+		IsBlocked:    false,
+		ExpiresAt:    time.Now().Local().UTC(),
+	}
+
+	adminSession, err := testQueires.CreateAdminSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, adminSession)
+
+	require.Equal(t, arg.ID, adminSession.ID)
+	require.Equal(t, arg.AdminID, adminSession.AdminID)
+	require.Equal(t, arg.RefreshToken, adminSession.RefreshToken)
+	require.Equal(t, arg.UserAgent, adminSession.UserAgent)
+	require.Equal(t, arg.ClientIp, adminSession.ClientIp)
+	require.Equal(t, arg.IsBlocked, adminSession.IsBlocked)
+	require.WithinDuration(t, arg.ExpiresAt, adminSession.ExpiresAt, time.Second)
+
+	require.NotEmpty(t, adminSession.CreatedAt)
+
+	return adminSession
+}
+
+func TestCreateAdminSession(t *testing.T) {
+	createRandomAdminSession(t)
+}
+
+func TestGetAdminSession(t *testing.T) {
+	adminSession1 := createRandomAdminSession(t)
+
+	adminSession2, err := testQueires.GetAdminSession(context.Background(), adminSession1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, adminSession2)
+
+	require.Equal(t, adminSession1.ID, adminSession2.ID)
+	require.Equal(t, adminSession1.AdminID, adminSession2.AdminID)
+	require.Equal(t, adminSession1.RefreshToken, adminSession2.RefreshToken)
+	require.Equal(t, adminSession1.UserAgent, adminSession2.UserAgent)
+	require.Equal(t, adminSession1.ClientIp, adminSession2.ClientIp)
+	require.Equal(t, adminSession1.IsBlocked, adminSession2.IsBlocked)
+	require.Equal(t, adminSession1.CreatedAt, adminSession2.CreatedAt)
+	require.Equal(t, adminSession1.ExpiresAt, adminSession2.ExpiresAt)
+}
+
+func TestCheckAdminSession(t *testing.T) {
+	adminSession := createRandomAdminSession(t)
+
+	arg := CheckAdminSessionParams{
+		ID:      adminSession.ID,
+		AdminID: adminSession.AdminID,
+	}
+
+	row, err := testQueires.CheckAdminSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, row.IsBlocked)
+
+	_, err = testQueires.UpdateAdmin(context.Background(), UpdateAdminParams{
+		ID:     adminSession.AdminID,
+		Active: false,
+	})
+	require.NoError(t, err)
+
+	row, err = testQueires.CheckAdminSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, row.Active)
+
+	arg.AdminID = adminSession.AdminID + 1
+	row, err = testQueires.CheckAdminSession(context.Background(), arg)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, row)
+}
+
+func TestBlockAdminSessions(t *testing.T) {
+	adminSession1 := createRandomAdminSession(t)
+
+	err := testQueires.BlockAdminSessions(context.Background(), adminSession1.AdminID)
+	require.NoError(t, err)
+
+	adminSession2, err := testQueires.GetAdminSession(context.Background(), adminSession1.ID)
+	require.NoError(t, err)
+	require.True(t, adminSession2.IsBlocked)
+}
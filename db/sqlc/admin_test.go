@@ -81,6 +81,68 @@ func TestUpdateAdmin(t *testing.T) {
 	require.Equal(t, admin1.CreatedAt, admin2.CreatedAt, time.Second)
 	require.NotEqual(t, admin1.UpdatedAt, admin2.UpdatedAt, time.Second)
 }
+
+func TestUpdateAdminLastLogin(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+	require.True(t, admin1.LastLogin.IsZero())
+
+	admin2, err := testQueires.UpdateAdminLastLogin(context.Background(), admin1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, admin2)
+
+	require.Equal(t, admin1.ID, admin2.ID)
+	require.Equal(t, admin1.Username, admin2.Username)
+	require.Equal(t, admin1.Active, admin2.Active)
+	require.WithinDuration(t, time.Now(), admin2.LastLogin, time.Second)
+}
+
+func TestRecordFailedAdminLogin(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+	require.Zero(t, admin1.FailedLoginAttempts)
+
+	admin2, err := testQueires.RecordFailedAdminLogin(context.Background(), admin1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), admin2.FailedLoginAttempts)
+
+	admin3, err := testQueires.RecordFailedAdminLogin(context.Background(), admin1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), admin3.FailedLoginAttempts)
+}
+
+func TestLockAdmin(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+	require.False(t, admin1.LockedUntil.Valid)
+
+	arg := LockAdminParams{
+		ID:          admin1.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	admin2, err := testQueires.LockAdmin(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, admin2.LockedUntil.Valid)
+	require.WithinDuration(t, arg.LockedUntil.Time, admin2.LockedUntil.Time, time.Second)
+}
+
+func TestResetFailedAdminLogins(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+
+	_, err := testQueires.RecordFailedAdminLogin(context.Background(), admin1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.LockAdmin(context.Background(), LockAdminParams{
+		ID:          admin1.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	admin2, err := testQueires.ResetFailedAdminLogins(context.Background(), admin1.ID)
+	require.NoError(t, err)
+	require.Zero(t, admin2.FailedLoginAttempts)
+	require.False(t, admin2.LockedUntil.Valid)
+}
+
 func TestDeleteAdmin(t *testing.T) {
 	admin1 := createRandomAdmin(t)
 
@@ -96,12 +158,13 @@ func TestDeleteAdmin(t *testing.T) {
 }
 
 func TestListAdmins(t *testing.T) {
+	first := createRandomAdmin(t)
 	for i := 0; i < 10; i++ {
 		createRandomAdmin(t)
 	}
 	arg := ListAdminsParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	admins, err := testQueires.ListAdmins(context.Background(), arg)
@@ -110,6 +173,63 @@ func TestListAdmins(t *testing.T) {
 
 	for _, Admin := range admins {
 		require.NotEmpty(t, Admin)
+		require.Greater(t, Admin.ID, first.ID)
+
+	}
+
+	count, err := testQueires.CountAdmins(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
+}
+
+func TestUpdateAdminTypeID(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+	adminType := createRandomAdminType(t)
+
+	arg := UpdateAdminTypeIDParams{
+		ID:     admin1.ID,
+		TypeID: adminType.ID,
+	}
+
+	admin2, err := testQueires.UpdateAdminTypeID(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, admin2)
+
+	require.Equal(t, admin1.ID, admin2.ID)
+	require.Equal(t, admin1.Active, admin2.Active)
+	require.Equal(t, arg.TypeID, admin2.TypeID)
+}
+
+func TestSearchAdmins(t *testing.T) {
+	admin1 := createRandomAdmin(t)
+	createRandomAdmin(t)
+
+	arg := SearchAdminsParams{
+		Search: admin1.Username[1:5],
+		Limit:  5,
+	}
 
+	admins, err := testQueires.SearchAdmins(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, admins)
+
+	found := false
+	for _, admin := range admins {
+		require.Contains(t, admin.Username+admin.Email, arg.Search)
+		if admin.ID == admin1.ID {
+			found = true
+		}
 	}
+	require.True(t, found)
+}
+
+func TestDeleteAdminWithSessions(t *testing.T) {
+	adminSession := createRandomAdminSession(t)
+
+	err := testQueires.DeleteAdmin(context.Background(), adminSession.AdminID)
+	require.NoError(t, err)
+
+	_, err = testQueires.GetAdminSession(context.Background(), adminSession.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
 }
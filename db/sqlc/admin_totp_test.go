@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createAdminTOTPForAdmin(t *testing.T, admin Admin) AdminTotp {
+	secret, err := util.RandomTOTPSecret()
+	require.NoError(t, err)
+
+	arg := CreateAdminTOTPParams{
+		AdminID: admin.ID,
+		Secret:  secret,
+	}
+
+	totp, err := testQueires.CreateAdminTOTP(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, totp)
+
+	require.Equal(t, arg.AdminID, totp.AdminID)
+	require.Equal(t, arg.Secret, totp.Secret)
+	require.False(t, totp.ConfirmedAt.Valid)
+	require.Zero(t, totp.LastUsedStep)
+	require.NotZero(t, totp.CreatedAt)
+
+	return totp
+}
+
+func TestCreateAdminTOTP(t *testing.T) {
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+}
+
+func TestCreateAdminTOTPReplacesPendingSecret(t *testing.T) {
+	admin := createRandomAdmin(t)
+	totp1 := createAdminTOTPForAdmin(t, admin)
+	totp2 := createAdminTOTPForAdmin(t, admin)
+	require.NotEqual(t, totp1.Secret, totp2.Secret)
+
+	totp3, err := testQueires.GetAdminTOTP(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Equal(t, totp2.Secret, totp3.Secret)
+}
+
+func TestCreateAdminTOTPWhenConfirmed(t *testing.T) {
+	admin := createRandomAdmin(t)
+	totp := createAdminTOTPForAdmin(t, admin)
+
+	_, err := testQueires.ConfirmAdminTOTP(context.Background(), admin.ID)
+	require.NoError(t, err)
+
+	// an enabled secret can't be swapped for another one
+	_, err = testQueires.CreateAdminTOTP(context.Background(), CreateAdminTOTPParams{
+		AdminID: admin.ID,
+		Secret:  totp.Secret + "A",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestConfirmAdminTOTP(t *testing.T) {
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+
+	totp, err := testQueires.ConfirmAdminTOTP(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.True(t, totp.ConfirmedAt.Valid)
+
+	_, err = testQueires.ConfirmAdminTOTP(context.Background(), admin.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseAdminTOTPStep(t *testing.T) {
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+
+	arg := UseAdminTOTPStepParams{
+		AdminID:      admin.ID,
+		LastUsedStep: 100,
+	}
+
+	totp, err := testQueires.UseAdminTOTPStep(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.LastUsedStep, totp.LastUsedStep)
+
+	// neither the same step nor an earlier one can be used again
+	_, err = testQueires.UseAdminTOTPStep(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.LastUsedStep = 99
+	_, err = testQueires.UseAdminTOTPStep(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.LastUsedStep = 101
+	_, err = testQueires.UseAdminTOTPStep(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestDeleteAdminTOTP(t *testing.T) {
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+
+	err := testQueires.DeleteAdminTOTP(context.Background(), admin.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.GetAdminTOTP(context.Background(), admin.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
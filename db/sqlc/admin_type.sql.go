@@ -7,11 +7,22 @@ import (
 	"context"
 )
 
+const countAdminTypes = `-- name: CountAdminTypes :one
+SELECT count(*) FROM "admin_type"
+`
+
+func (q *Queries) CountAdminTypes(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAdminTypes)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAdminType = `-- name: CreateAdminType :one
 INSERT INTO "admin_type" (
   admin_type
 ) VALUES ( $1 )
-RETURNING id, admin_type, created_at, updated_at
+RETURNING id, admin_type, created_at, updated_at, require_mfa
 `
 
 func (q *Queries) CreateAdminType(ctx context.Context, adminType string) (AdminType, error) {
@@ -22,6 +33,7 @@ func (q *Queries) CreateAdminType(ctx context.Context, adminType string) (AdminT
 		&i.AdminType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RequireMfa,
 	)
 	return i, err
 }
@@ -47,7 +59,7 @@ func (q *Queries) DeleteAdminTypeByType(ctx context.Context, adminType string) e
 }
 
 const getAdminType = `-- name: GetAdminType :one
-SELECT id, admin_type, created_at, updated_at FROM "admin_type"
+SELECT id, admin_type, created_at, updated_at, require_mfa FROM "admin_type"
 WHERE id = $1 LIMIT 1
 `
 
@@ -59,24 +71,25 @@ func (q *Queries) GetAdminType(ctx context.Context, id int64) (AdminType, error)
 		&i.AdminType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RequireMfa,
 	)
 	return i, err
 }
 
 const listAdminTypes = `-- name: ListAdminTypes :many
-SELECT id, admin_type, created_at, updated_at FROM "admin_type"
+SELECT id, admin_type, created_at, updated_at, require_mfa FROM "admin_type"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListAdminTypesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListAdminTypes(ctx context.Context, arg ListAdminTypesParams) ([]AdminType, error) {
-	rows, err := q.db.QueryContext(ctx, listAdminTypes, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAdminTypes, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +102,7 @@ func (q *Queries) ListAdminTypes(ctx context.Context, arg ListAdminTypesParams)
 			&i.AdminType,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RequireMfa,
 		); err != nil {
 			return nil, err
 		}
@@ -107,7 +121,7 @@ const updateAdminType = `-- name: UpdateAdminType :one
 UPDATE "admin_type"
 SET admin_type = $2
 WHERE id = $1
-RETURNING id, admin_type, created_at, updated_at
+RETURNING id, admin_type, created_at, updated_at, require_mfa
 `
 
 type UpdateAdminTypeParams struct {
@@ -123,6 +137,32 @@ func (q *Queries) UpdateAdminType(ctx context.Context, arg UpdateAdminTypeParams
 		&i.AdminType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RequireMfa,
+	)
+	return i, err
+}
+
+const updateAdminTypeMFAPolicy = `-- name: UpdateAdminTypeMFAPolicy :one
+UPDATE "admin_type"
+SET require_mfa = $2
+WHERE id = $1
+RETURNING id, admin_type, created_at, updated_at, require_mfa
+`
+
+type UpdateAdminTypeMFAPolicyParams struct {
+	ID         int64 `json:"id"`
+	RequireMfa bool  `json:"require_mfa"`
+}
+
+func (q *Queries) UpdateAdminTypeMFAPolicy(ctx context.Context, arg UpdateAdminTypeMFAPolicyParams) (AdminType, error) {
+	row := q.db.QueryRowContext(ctx, updateAdminTypeMFAPolicy, arg.ID, arg.RequireMfa)
+	var i AdminType
+	err := row.Scan(
+		&i.ID,
+		&i.AdminType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RequireMfa,
 	)
 	return i, err
 }
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createPermissionForAdminType(t *testing.T, adminType AdminType, permission string) AdminTypePermission {
+	arg := CreateAdminTypePermissionParams{
+		AdminTypeID: adminType.ID,
+		Permission:  permission,
+	}
+
+	adminTypePermission, err := testQueires.CreateAdminTypePermission(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, adminTypePermission)
+
+	require.Equal(t, arg.AdminTypeID, adminTypePermission.AdminTypeID)
+	require.Equal(t, arg.Permission, adminTypePermission.Permission)
+	require.NotEmpty(t, adminTypePermission.CreatedAt)
+
+	return adminTypePermission
+}
+
+func TestCreateAdminTypePermission(t *testing.T) {
+	adminType := createRandomAdminType(t)
+	createPermissionForAdminType(t, adminType, "catalog.manage")
+}
+
+func TestCreateAdminTypePermissionDuplicate(t *testing.T) {
+	adminType := createRandomAdminType(t)
+	createPermissionForAdminType(t, adminType, "catalog.manage")
+
+	arg := CreateAdminTypePermissionParams{
+		AdminTypeID: adminType.ID,
+		Permission:  "catalog.manage",
+	}
+
+	_, err := testQueires.CreateAdminTypePermission(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestListAdminTypePermissions(t *testing.T) {
+	adminType := createRandomAdminType(t)
+	createPermissionForAdminType(t, adminType, "users.read")
+	createPermissionForAdminType(t, adminType, "catalog.manage")
+
+	permissions, err := testQueires.ListAdminTypePermissions(context.Background(), adminType.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"catalog.manage", "users.read"}, permissions)
+}
+
+func TestDeleteAdminTypePermissions(t *testing.T) {
+	adminType := createRandomAdminType(t)
+	createPermissionForAdminType(t, adminType, "users.read")
+
+	err := testQueires.DeleteAdminTypePermissions(context.Background(), adminType.ID)
+	require.NoError(t, err)
+
+	permissions, err := testQueires.ListAdminTypePermissions(context.Background(), adminType.ID)
+	require.NoError(t, err)
+	require.Empty(t, permissions)
+}
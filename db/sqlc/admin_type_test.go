@@ -61,6 +61,22 @@ func TestUpdateAdminType(t *testing.T) {
 	require.NotEqual(t, adminType1.UpdatedAt, adminType2.UpdatedAt, time.Second)
 }
 
+func TestUpdateAdminTypeMFAPolicy(t *testing.T) {
+	adminType1 := createRandomAdminType(t)
+	require.False(t, adminType1.RequireMfa)
+
+	arg := UpdateAdminTypeMFAPolicyParams{
+		ID:         adminType1.ID,
+		RequireMfa: true,
+	}
+
+	adminType2, err := testQueires.UpdateAdminTypeMFAPolicy(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, adminType1.ID, adminType2.ID)
+	require.Equal(t, adminType1.AdminType, adminType2.AdminType)
+	require.True(t, adminType2.RequireMfa)
+}
+
 func TestDeleteAdminTypeByID(t *testing.T) {
 	adminType1 := createRandomAdminType(t)
 	err := testQueires.DeleteAdminTypeByID(context.Background(), adminType1.ID)
@@ -90,12 +106,13 @@ func TestDeleteAdminTypeByType(t *testing.T) {
 }
 
 func TestListAdminTypes(t *testing.T) {
+	first := createRandomAdminType(t)
 	for i := 0; i < 10; i++ {
 		createRandomAdminType(t)
 	}
 	arg := ListAdminTypesParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	adminTypes, err := testQueires.ListAdminTypes(context.Background(), arg)
@@ -104,6 +121,11 @@ func TestListAdminTypes(t *testing.T) {
 
 	for _, adminType := range adminTypes {
 		require.NotEmpty(t, adminType)
+		require.Greater(t, adminType.ID, first.ID)
 
 	}
+
+	count, err := testQueires.CountAdminTypes(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
 }
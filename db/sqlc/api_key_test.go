@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAPIKey(t *testing.T, expiresAt sql.NullTime) ApiKey {
+	admin := createRandomAdmin(t)
+
+	secret, err := util.RandomSecret(32)
+	require.NoError(t, err)
+
+	arg := CreateAPIKeyParams{
+		Name:      util.RandomUser(),
+		Prefix:    "esk_" + util.RandomString(8),
+		KeyHash:   util.HashSecret(secret),
+		AdminID:   admin.ID,
+		ExpiresAt: expiresAt,
+	}
+
+	apiKey, err := testQueires.CreateAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, apiKey)
+
+	require.Equal(t, arg.Name, apiKey.Name)
+	require.Equal(t, arg.Prefix, apiKey.Prefix)
+	require.Equal(t, arg.KeyHash, apiKey.KeyHash)
+	require.Equal(t, arg.AdminID, apiKey.AdminID)
+	require.Equal(t, arg.ExpiresAt.Valid, apiKey.ExpiresAt.Valid)
+	require.False(t, apiKey.LastUsedAt.Valid)
+
+	require.NotZero(t, apiKey.ID)
+	require.NotZero(t, apiKey.CreatedAt)
+
+	return apiKey
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	createRandomAPIKey(t, sql.NullTime{})
+	createRandomAPIKey(t, sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true})
+}
+
+func TestGetAPIKey(t *testing.T) {
+	apiKey1 := createRandomAPIKey(t, sql.NullTime{})
+
+	apiKey2, err := testQueires.GetAPIKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+	require.Equal(t, apiKey1.ID, apiKey2.ID)
+	require.Equal(t, apiKey1.KeyHash, apiKey2.KeyHash)
+}
+
+func TestGetAPIKeyByHash(t *testing.T) {
+	apiKey1 := createRandomAPIKey(t, sql.NullTime{})
+
+	apiKey2, err := testQueires.GetAPIKeyByHash(context.Background(), apiKey1.KeyHash)
+	require.NoError(t, err)
+	require.Equal(t, apiKey1.ID, apiKey2.ID)
+
+	_, err = testQueires.GetAPIKeyByHash(context.Background(), util.HashSecret(util.RandomString(32)))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetAPIKeyByHashOfInactiveAdmin(t *testing.T) {
+	apiKey := createRandomAPIKey(t, sql.NullTime{})
+
+	_, err := testQueires.UpdateAdmin(context.Background(), UpdateAdminParams{
+		ID:     apiKey.AdminID,
+		Active: false,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueires.GetAPIKeyByHash(context.Background(), apiKey.KeyHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListAPIKeys(t *testing.T) {
+	first := createRandomAPIKey(t, sql.NullTime{})
+	for i := 0; i < 10; i++ {
+		createRandomAPIKey(t, sql.NullTime{})
+	}
+
+	arg := ListAPIKeysParams{
+		AfterID: first.ID,
+		Limit:   5,
+	}
+
+	apiKeys, err := testQueires.ListAPIKeys(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 5)
+
+	for _, apiKey := range apiKeys {
+		require.NotEmpty(t, apiKey)
+		require.Greater(t, apiKey.ID, first.ID)
+	}
+
+	count, err := testQueires.CountAPIKeys(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
+}
+
+func TestUpdateAPIKeyLastUsed(t *testing.T) {
+	apiKey1 := createRandomAPIKey(t, sql.NullTime{})
+
+	err := testQueires.UpdateAPIKeyLastUsed(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+
+	apiKey2, err := testQueires.GetAPIKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+	require.True(t, apiKey2.LastUsedAt.Valid)
+	require.WithinDuration(t, time.Now(), apiKey2.LastUsedAt.Time, time.Second)
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	apiKey1 := createRandomAPIKey(t, sql.NullTime{})
+
+	_, err := testQueires.CreateAPIKeyPermission(context.Background(), CreateAPIKeyPermissionParams{
+		ApiKeyID:   apiKey1.ID,
+		Permission: "catalog.manage",
+	})
+	require.NoError(t, err)
+
+	err = testQueires.DeleteAPIKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+
+	apiKey2, err := testQueires.GetAPIKey(context.Background(), apiKey1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, apiKey2)
+
+	permissions, err := testQueires.ListAPIKeyPermissions(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+	require.Empty(t, permissions)
+}
@@ -11,21 +11,24 @@ const createCartItem = `-- name: CreateCartItem :one
 INSERT INTO "cart_item" (
   session_id,
   product_id,
+  variant_id,
   quantity
-) VALUES (
-  $1, $2, $3
 )
-RETURNING id, session_id, product_id, quantity, created_at, updated_at
+SELECT $1::bigint, "product_variant".product_id, "product_variant".id, $2::int
+FROM "product_variant"
+WHERE "product_variant".id = $3
+AND "product_variant".active
+RETURNING id, session_id, product_id, quantity, created_at, updated_at, variant_id
 `
 
 type CreateCartItemParams struct {
 	SessionID int64 `json:"session_id"`
-	ProductID int64 `json:"product_id"`
 	Quantity  int32 `json:"quantity"`
+	VariantID int64 `json:"variant_id"`
 }
 
 func (q *Queries) CreateCartItem(ctx context.Context, arg CreateCartItemParams) (CartItem, error) {
-	row := q.db.QueryRowContext(ctx, createCartItem, arg.SessionID, arg.ProductID, arg.Quantity)
+	row := q.db.QueryRowContext(ctx, createCartItem, arg.SessionID, arg.Quantity, arg.VariantID)
 	var i CartItem
 	err := row.Scan(
 		&i.ID,
@@ -34,6 +37,7 @@ func (q *Queries) CreateCartItem(ctx context.Context, arg CreateCartItemParams)
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
@@ -48,8 +52,21 @@ func (q *Queries) DeleteCartItem(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteCartItemsByUserID = `-- name: DeleteCartItemsByUserID :exec
+DELETE FROM "cart_item"
+WHERE session_id IN (
+  SELECT id FROM "shopping_session"
+  WHERE user_id = $1::bigint
+)
+`
+
+func (q *Queries) DeleteCartItemsByUserID(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteCartItemsByUserID, userID)
+	return err
+}
+
 const getCartItemByID = `-- name: GetCartItemByID :one
-SELECT id, session_id, product_id, quantity, created_at, updated_at FROM "cart_item"
+SELECT id, session_id, product_id, quantity, created_at, updated_at, variant_id FROM "cart_item"
 WHERE id = $1 LIMIT 1
 `
 
@@ -63,12 +80,13 @@ func (q *Queries) GetCartItemByID(ctx context.Context, id int64) (CartItem, erro
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
 
 const getCartItemBySessionID = `-- name: GetCartItemBySessionID :one
-SELECT id, session_id, product_id, quantity, created_at, updated_at FROM "cart_item"
+SELECT id, session_id, product_id, quantity, created_at, updated_at, variant_id FROM "cart_item"
 WHERE session_id = $1 LIMIT 1
 `
 
@@ -82,12 +100,13 @@ func (q *Queries) GetCartItemBySessionID(ctx context.Context, sessionID int64) (
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
 
 const listCartItem = `-- name: ListCartItem :many
-SELECT id, session_id, product_id, quantity, created_at, updated_at FROM "cart_item"
+SELECT id, session_id, product_id, quantity, created_at, updated_at, variant_id FROM "cart_item"
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -114,6 +133,44 @@ func (q *Queries) ListCartItem(ctx context.Context, arg ListCartItemParams) ([]C
 			&i.Quantity,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VariantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCartItemsBySessionID = `-- name: ListCartItemsBySessionID :many
+SELECT id, session_id, product_id, quantity, created_at, updated_at, variant_id FROM "cart_item"
+WHERE session_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListCartItemsBySessionID(ctx context.Context, sessionID int64) ([]CartItem, error) {
+	rows, err := q.db.QueryContext(ctx, listCartItemsBySessionID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CartItem{}
+	for rows.Next() {
+		var i CartItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VariantID,
 		); err != nil {
 			return nil, err
 		}
@@ -132,7 +189,7 @@ const updateCartItem = `-- name: UpdateCartItem :one
 UPDATE "cart_item"
 SET quantity = $2
 WHERE id = $1
-RETURNING id, session_id, product_id, quantity, created_at, updated_at
+RETURNING id, session_id, product_id, quantity, created_at, updated_at, variant_id
 `
 
 type UpdateCartItemParams struct {
@@ -150,6 +207,7 @@ func (q *Queries) UpdateCartItem(ctx context.Context, arg UpdateCartItemParams)
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
@@ -15,8 +15,8 @@ func createRandomCartItem(t *testing.T) CartItem {
 	product := createRandomProduct(t)
 	arg := CreateCartItemParams{
 		SessionID: shoppingSession.ID,
-		ProductID: product.ID,
 		Quantity:  int32(util.RandomInt(1, 9)),
+		VariantID: defaultProductVariant(t, product).ID,
 	}
 
 	cartItem, err := testQueires.CreateCartItem(context.Background(), arg)
@@ -25,7 +25,8 @@ func createRandomCartItem(t *testing.T) CartItem {
 	require.NotEmpty(t, cartItem)
 
 	require.Equal(t, arg.SessionID, cartItem.SessionID)
-	require.Equal(t, arg.ProductID, cartItem.ProductID)
+	require.Equal(t, product.ID, cartItem.ProductID)
+	require.Equal(t, arg.VariantID, cartItem.VariantID)
 	require.Equal(t, arg.Quantity, cartItem.Quantity)
 
 	require.NotEmpty(t, cartItem.ID)
@@ -114,3 +115,96 @@ func TestListCartItem(t *testing.T) {
 	}
 
 }
+
+func TestListCartItemsBySessionID(t *testing.T) {
+	shoppingSession := createRandomShoppingSession(t)
+
+	n := 3
+	for i := 0; i < n; i++ {
+		product := createRandomProduct(t)
+		arg := CreateCartItemParams{
+			SessionID: shoppingSession.ID,
+			Quantity:  int32(util.RandomInt(1, 9)),
+			VariantID: defaultProductVariant(t, product).ID,
+		}
+		_, err := testQueires.CreateCartItem(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	cartItems, err := testQueires.ListCartItemsBySessionID(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Len(t, cartItems, n)
+
+	for _, cartItem := range cartItems {
+		require.NotEmpty(t, cartItem)
+		require.Equal(t, shoppingSession.ID, cartItem.SessionID)
+	}
+}
+
+func TestCreateDuplicateCartItem(t *testing.T) {
+	cartItem1 := createRandomCartItem(t)
+
+	arg := CreateCartItemParams{
+		SessionID: cartItem1.SessionID,
+		Quantity:  int32(util.RandomInt(1, 9)),
+		VariantID: cartItem1.VariantID,
+	}
+
+	cartItem2, err := testQueires.CreateCartItem(context.Background(), arg)
+	require.Error(t, err)
+	require.Empty(t, cartItem2)
+
+	// the same product can still sit in another user's cart
+	shoppingSession := createRandomShoppingSession(t)
+	arg.SessionID = shoppingSession.ID
+
+	cartItem3, err := testQueires.CreateCartItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, cartItem1.ProductID, cartItem3.ProductID)
+	require.Equal(t, cartItem1.VariantID, cartItem3.VariantID)
+}
+
+func TestCreateCartItemNegativeQuantity(t *testing.T) {
+	cartItem1 := createRandomCartItem(t)
+
+	cartItem2, err := testQueires.CreateCartItem(context.Background(), CreateCartItemParams{
+		SessionID: createRandomShoppingSession(t).ID,
+		Quantity:  -2,
+		VariantID: cartItem1.VariantID,
+	})
+	require.Error(t, err)
+	require.Empty(t, cartItem2)
+}
+
+func TestCreateCartItemOfVariant(t *testing.T) {
+	shoppingSession := createRandomShoppingSession(t)
+	product := createRandomProduct(t)
+	productVariant := createRandomProductVariant(t, product)
+
+	// two variants of the same product sit side by side in the cart
+	for _, variantID := range []int64{defaultProductVariant(t, product).ID, productVariant.ID} {
+		cartItem, err := testQueires.CreateCartItem(context.Background(), CreateCartItemParams{
+			SessionID: shoppingSession.ID,
+			Quantity:  1,
+			VariantID: variantID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, product.ID, cartItem.ProductID)
+		require.Equal(t, variantID, cartItem.VariantID)
+	}
+
+	// an inactive variant can't be added to a cart
+	_, err := testQueires.UpdateProductVariant(context.Background(), UpdateProductVariantParams{
+		ID:        productVariant.ID,
+		ProductID: product.ID,
+		Active:    false,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueires.CreateCartItem(context.Background(), CreateCartItemParams{
+		SessionID: createRandomShoppingSession(t).ID,
+		Quantity:  1,
+		VariantID: productVariant.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
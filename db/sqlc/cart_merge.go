@@ -0,0 +1,51 @@
+package db
+
+import (
+	"fmt"
+	"math"
+)
+
+// CartMergeRule tells which quantity a product gets when a guest cart is merged
+// into the shopping session of a user that already holds the same product
+type CartMergeRule string
+
+const (
+	// CartMergeRuleSum adds the quantity of the guest cart to the one of the user
+	CartMergeRuleSum CartMergeRule = "sum"
+	// CartMergeRuleMax keeps the larger of both quantities
+	CartMergeRuleMax CartMergeRule = "max"
+	// CartMergeRuleGuest replaces the quantity of the user by the one of the guest cart
+	CartMergeRuleGuest CartMergeRule = "guest"
+	// CartMergeRuleUser keeps the quantity of the user and drops the one of the guest cart
+	CartMergeRuleUser CartMergeRule = "user"
+)
+
+// ParseCartMergeRule returns the cart merge rule named by rule
+func ParseCartMergeRule(rule string) (CartMergeRule, error) {
+	switch r := CartMergeRule(rule); r {
+	case CartMergeRuleSum, CartMergeRuleMax, CartMergeRuleGuest, CartMergeRuleUser:
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown cart merge rule %q", rule)
+}
+
+// mergeQuantity reconciles the quantities of a product found in both carts
+func (rule CartMergeRule) mergeQuantity(userQuantity, guestQuantity int32) int32 {
+	switch rule {
+	case CartMergeRuleMax:
+		if guestQuantity > userQuantity {
+			return guestQuantity
+		}
+		return userQuantity
+	case CartMergeRuleGuest:
+		return guestQuantity
+	case CartMergeRuleUser:
+		return userQuantity
+	default:
+		sum := int64(userQuantity) + int64(guestQuantity)
+		if sum > math.MaxInt32 {
+			return math.MaxInt32
+		}
+		return int32(sum)
+	}
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCartMergeRule(t *testing.T) {
+	for _, rule := range []CartMergeRule{CartMergeRuleSum, CartMergeRuleMax, CartMergeRuleGuest, CartMergeRuleUser} {
+		parsed, err := ParseCartMergeRule(string(rule))
+		require.NoError(t, err)
+		require.Equal(t, rule, parsed)
+	}
+
+	_, err := ParseCartMergeRule("min")
+	require.Error(t, err)
+}
+
+func TestMergeQuantity(t *testing.T) {
+	require.Equal(t, int32(5), CartMergeRuleSum.mergeQuantity(2, 3))
+	require.Equal(t, int32(math.MaxInt32), CartMergeRuleSum.mergeQuantity(math.MaxInt32, 1))
+	require.Equal(t, int32(3), CartMergeRuleMax.mergeQuantity(2, 3))
+	require.Equal(t, int32(3), CartMergeRuleMax.mergeQuantity(3, 2))
+	require.Equal(t, int32(3), CartMergeRuleGuest.mergeQuantity(2, 3))
+	require.Equal(t, int32(2), CartMergeRuleUser.mergeQuantity(2, 3))
+}
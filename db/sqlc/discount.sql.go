@@ -7,6 +7,17 @@ import (
 	"context"
 )
 
+const countDiscounts = `-- name: CountDiscounts :one
+SELECT count(*) FROM "discount"
+`
+
+func (q *Queries) CountDiscounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDiscounts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createDiscount = `-- name: CreateDiscount :one
 INSERT INTO "discount" (
   name,
@@ -71,18 +82,18 @@ func (q *Queries) GetDiscount(ctx context.Context, id int64) (Discount, error) {
 
 const listDiscounts = `-- name: ListDiscounts :many
 SELECT id, name, description, discount_percent, active, created_at, updated_at FROM "discount"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListDiscountsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListDiscounts(ctx context.Context, arg ListDiscountsParams) ([]Discount, error) {
-	rows, err := q.db.QueryContext(ctx, listDiscounts, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listDiscounts, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
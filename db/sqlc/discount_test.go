@@ -92,12 +92,13 @@ func TestDeleteDiscount(t *testing.T) {
 }
 
 func TestListDiscounts(t *testing.T) {
+	first := createRandomDiscount(t)
 	for i := 0; i < 10; i++ {
 		createRandomDiscount(t)
 	}
 	arg := ListDiscountsParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	Discounts, err := testQueires.ListDiscounts(context.Background(), arg)
@@ -106,6 +107,11 @@ func TestListDiscounts(t *testing.T) {
 
 	for _, discount := range Discounts {
 		require.NotEmpty(t, discount)
+		require.Greater(t, discount.ID, first.ID)
 
 	}
+
+	count, err := testQueires.CountDiscounts(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
 }
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createEmailChangeTokenForUser(t *testing.T, user User, expiresAt time.Time) EmailChangeToken {
+	arg := CreateEmailChangeTokenParams{
+		UserID:    user.ID,
+		NewEmail:  util.RandomEmail(),
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		ExpiresAt: expiresAt,
+	}
+
+	changeToken, err := testQueires.CreateEmailChangeToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, changeToken)
+
+	require.Equal(t, arg.UserID, changeToken.UserID)
+	require.Equal(t, arg.NewEmail, changeToken.NewEmail)
+	require.Equal(t, arg.TokenHash, changeToken.TokenHash)
+	require.WithinDuration(t, arg.ExpiresAt, changeToken.ExpiresAt, time.Second)
+	require.False(t, changeToken.UsedAt.Valid)
+	require.NotZero(t, changeToken.CreatedAt)
+
+	return changeToken
+}
+
+func TestCreateEmailChangeToken(t *testing.T) {
+	user := createRandomUser(t)
+	createEmailChangeTokenForUser(t, user, time.Now().Add(time.Hour))
+}
+
+func TestUseEmailChangeToken(t *testing.T) {
+	user := createRandomUser(t)
+	changeToken1 := createEmailChangeTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	arg := UseEmailChangeTokenParams{
+		TokenHash: changeToken1.TokenHash,
+		UserID:    user.ID,
+	}
+
+	changeToken2, err := testQueires.UseEmailChangeToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, changeToken1.ID, changeToken2.ID)
+	require.Equal(t, changeToken1.NewEmail, changeToken2.NewEmail)
+	require.True(t, changeToken2.UsedAt.Valid)
+
+	// an email change token can only be used once
+	_, err = testQueires.UseEmailChangeToken(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseEmailChangeTokenOfAnotherUser(t *testing.T) {
+	user1 := createRandomUser(t)
+	user2 := createRandomUser(t)
+	changeToken := createEmailChangeTokenForUser(t, user1, time.Now().Add(time.Hour))
+
+	_, err := testQueires.UseEmailChangeToken(context.Background(), UseEmailChangeTokenParams{
+		TokenHash: changeToken.TokenHash,
+		UserID:    user2.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseExpiredEmailChangeToken(t *testing.T) {
+	user := createRandomUser(t)
+	changeToken := createEmailChangeTokenForUser(t, user, time.Now().Add(-time.Minute))
+
+	_, err := testQueires.UseEmailChangeToken(context.Background(), UseEmailChangeTokenParams{
+		TokenHash: changeToken.TokenHash,
+		UserID:    user.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestInvalidateEmailChangeTokens(t *testing.T) {
+	user := createRandomUser(t)
+	changeToken := createEmailChangeTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	err := testQueires.InvalidateEmailChangeTokens(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.UseEmailChangeToken(context.Background(), UseEmailChangeTokenParams{
+		TokenHash: changeToken.TokenHash,
+		UserID:    user.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
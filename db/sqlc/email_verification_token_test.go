@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createEmailVerificationTokenForUser(t *testing.T, user User, expiresAt time.Time) EmailVerificationToken {
+	arg := CreateEmailVerificationTokenParams{
+		UserID:    user.ID,
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		ExpiresAt: expiresAt,
+	}
+
+	verificationToken, err := testQueires.CreateEmailVerificationToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, verificationToken)
+
+	require.Equal(t, arg.UserID, verificationToken.UserID)
+	require.Equal(t, arg.TokenHash, verificationToken.TokenHash)
+	require.WithinDuration(t, arg.ExpiresAt, verificationToken.ExpiresAt, time.Second)
+	require.False(t, verificationToken.UsedAt.Valid)
+	require.NotZero(t, verificationToken.CreatedAt)
+
+	return verificationToken
+}
+
+func TestCreateEmailVerificationToken(t *testing.T) {
+	user := createRandomUser(t)
+	createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+}
+
+func TestGetLastEmailVerificationToken(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testQueires.GetLastEmailVerificationToken(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+	verificationToken1 := createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	verificationToken2, err := testQueires.GetLastEmailVerificationToken(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, verificationToken1.ID, verificationToken2.ID)
+}
+
+func TestUseEmailVerificationToken(t *testing.T) {
+	user := createRandomUser(t)
+	verificationToken1 := createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	verificationToken2, err := testQueires.UseEmailVerificationToken(context.Background(), verificationToken1.TokenHash)
+	require.NoError(t, err)
+	require.Equal(t, verificationToken1.ID, verificationToken2.ID)
+	require.True(t, verificationToken2.UsedAt.Valid)
+
+	// a verification token can only be used once
+	_, err = testQueires.UseEmailVerificationToken(context.Background(), verificationToken1.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseExpiredEmailVerificationToken(t *testing.T) {
+	user := createRandomUser(t)
+	verificationToken := createEmailVerificationTokenForUser(t, user, time.Now().Add(-time.Minute))
+
+	_, err := testQueires.UseEmailVerificationToken(context.Background(), verificationToken.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestInvalidateEmailVerificationTokens(t *testing.T) {
+	user := createRandomUser(t)
+	verificationToken := createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	err := testQueires.InvalidateEmailVerificationTokens(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.UseEmailVerificationToken(context.Background(), verificationToken.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -19,6 +19,40 @@ type Admin struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	LastLogin time.Time `json:"last_login"`
+	// consecutive failed logins, reset by a successful one
+	FailedLoginAttempts int32 `json:"failed_login_attempts"`
+	// logins are refused until then
+	LockedUntil sql.NullTime `json:"locked_until"`
+}
+
+type AdminRecoveryCode struct {
+	ID      int64 `json:"id"`
+	AdminID int64 `json:"admin_id"`
+	// SHA-256 of the normalized recovery code
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type AdminSession struct {
+	ID           uuid.UUID `json:"id"`
+	AdminID      int64     `json:"admin_id"`
+	RefreshToken string    `json:"refresh_token"`
+	UserAgent    string    `json:"user_agent"`
+	ClientIp     string    `json:"client_ip"`
+	IsBlocked    bool      `json:"is_blocked"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type AdminTotp struct {
+	AdminID int64  `json:"admin_id"`
+	Secret  string `json:"secret"`
+	// set once a first code was checked, the second factor is enforced from then on
+	ConfirmedAt sql.NullTime `json:"confirmed_at"`
+	// time step of the last accepted code, so that no code is accepted twice
+	LastUsedStep int64     `json:"last_used_step"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 type AdminType struct {
@@ -26,6 +60,37 @@ type AdminType struct {
 	AdminType string    `json:"admin_type"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// admins of this type must log in with a second factor
+	RequireMfa bool `json:"require_mfa"`
+}
+
+type AdminTypePermission struct {
+	AdminTypeID int64 `json:"admin_type_id"`
+	// one of the permissions known by the api package
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// public part of the key, shown to tell the keys apart
+	Prefix string `json:"prefix"`
+	// SHA-256 of the whole key
+	KeyHash string `json:"key_hash"`
+	// admin who created the key
+	AdminID int64 `json:"admin_id"`
+	// the key never expires when null
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type ApiKeyPermission struct {
+	ApiKeyID int64 `json:"api_key_id"`
+	// one of the permissions known by the api package
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type CartItem struct {
@@ -36,6 +101,7 @@ type CartItem struct {
 	Quantity  int32     `json:"quantity"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	VariantID int64     `json:"variant_id"`
 }
 
 type Discount struct {
@@ -50,6 +116,28 @@ type Discount struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type EmailChangeToken struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// the address replacing the email of the user once confirmed
+	NewEmail string `json:"new_email"`
+	// SHA-256 of the token sent by email
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type EmailVerificationToken struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// SHA-256 of the token sent by email
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
 type OrderDetail struct {
 	ID     int64 `json:"id"`
 	UserID int64 `json:"user_id"`
@@ -68,6 +156,25 @@ type OrderItem struct {
 	Quantity  int32     `json:"quantity"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// snapshot at purchase time
+	ProductName string `json:"product_name"`
+	// snapshot at purchase time, the sku of the variant
+	ProductSku string `json:"product_sku"`
+	// unit price at purchase time
+	Price     string `json:"price"`
+	VariantID int64  `json:"variant_id"`
+	// snapshot at purchase time, the option values of the variant such as M / Red
+	VariantName string `json:"variant_name"`
+}
+
+type PasswordResetToken struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// SHA-256 of the token sent by email
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
 }
 
 type PaymentDetail struct {
@@ -96,6 +203,8 @@ type Product struct {
 	DiscountID int64     `json:"discount_id"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+	// generated from the name, description and sku of the product
+	SearchVector string `json:"-"`
 }
 
 type ProductCategory struct {
@@ -118,23 +227,81 @@ type ProductInventory struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type ProductOption struct {
+	ID        int64 `json:"id"`
+	ProductID int64 `json:"product_id"`
+	// such as size or colour
+	Name      string    `json:"name"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ProductOptionValue struct {
+	ID        int64     `json:"id"`
+	OptionID  int64     `json:"option_id"`
+	Value     string    `json:"value"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ProductVariant struct {
+	ID        int64  `json:"id"`
+	ProductID int64  `json:"product_id"`
+	Sku       string `json:"sku"`
+	// NULL when the variant is sold at the price of its product
+	Price       sql.NullString `json:"price"`
+	InventoryID int64          `json:"inventory_id"`
+	Active      bool           `json:"active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type ProductVariantOptionValue struct {
+	VariantID int64 `json:"variant_id"`
+	// a variant takes a single value of every option of its product
+	OptionID      int64 `json:"option_id"`
+	OptionValueID int64 `json:"option_value_id"`
+}
+
 type ShoppingSession struct {
-	ID     int64 `json:"id"`
-	UserID int64 `json:"user_id"`
+	ID int64 `json:"id"`
+	// NULL while the shopping session is the cart of a guest
+	UserID sql.NullInt64 `json:"user_id"`
 	// must be positive
 	Total     string    `json:"total"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// SHA-256 of the cart token of a guest, NULL once the shopping session belongs to a user
+	GuestTokenHash sql.NullString `json:"guest_token_hash"`
+}
+
+type StockReservation struct {
+	ID          int64 `json:"id"`
+	SessionID   int64 `json:"session_id"`
+	InventoryID int64 `json:"inventory_id"`
+	// must be positive
+	Quantity  int32     `json:"quantity"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type User struct {
-	ID        int64     `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Password  string    `json:"password"`
-	Telephone int32     `json:"telephone"`
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	// E.164 format, empty once the user is erased
+	Telephone string    `json:"telephone"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// set once the user followed the link of the verification email
+	VerifiedAt sql.NullTime `json:"verified_at"`
+	// consecutive failed logins, reset by a successful one
+	FailedLoginAttempts int32 `json:"failed_login_attempts"`
+	// logins are refused until then
+	LockedUntil sql.NullTime `json:"locked_until"`
+	// set once the personal data of the user was anonymized, the account can't be used anymore
+	ErasedAt sql.NullTime `json:"erased_at"`
 }
 
 type UserAddress struct {
@@ -142,7 +309,8 @@ type UserAddress struct {
 	UserID      int64  `json:"user_id"`
 	AddressLine string `json:"address_line"`
 	City        string `json:"city"`
-	Telephone   int32  `json:"telephone"`
+	// E.164 format
+	Telephone string `json:"telephone"`
 }
 
 type UserPayment struct {
@@ -154,6 +322,15 @@ type UserPayment struct {
 	Expiry      time.Time `json:"expiry"`
 }
 
+type UserRecoveryCode struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// SHA-256 of the normalized recovery code
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
 type UserSession struct {
 	ID           uuid.UUID `json:"id"`
 	UserID       int64     `json:"user_id"`
@@ -163,4 +340,18 @@ type UserSession struct {
 	IsBlocked    bool      `json:"is_blocked"`
 	CreatedAt    time.Time `json:"created_at"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	// id of the session created at login, shared by every rotated session
+	FamilyID uuid.UUID `json:"family_id"`
+	// set once the refresh token has been exchanged for a new one
+	RotatedAt sql.NullTime `json:"rotated_at"`
+}
+
+type UserTotp struct {
+	UserID int64  `json:"user_id"`
+	Secret string `json:"secret"`
+	// set once a first code was checked, the second factor is enforced from then on
+	ConfirmedAt sql.NullTime `json:"confirmed_at"`
+	// time step of the last accepted code, so that no code is accepted twice
+	LastUsedStep int64     `json:"last_used_step"`
+	CreatedAt    time.Time `json:"created_at"`
 }
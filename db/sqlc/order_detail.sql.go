@@ -7,6 +7,29 @@ import (
 	"context"
 )
 
+const countOrderDetails = `-- name: CountOrderDetails :one
+SELECT count(*) FROM "order_detail"
+WHERE user_id = $1
+`
+
+func (q *Queries) CountOrderDetails(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrderDetails, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOrderDetailsOfAllUsers = `-- name: CountOrderDetailsOfAllUsers :one
+SELECT count(*) FROM "order_detail"
+`
+
+func (q *Queries) CountOrderDetailsOfAllUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrderDetailsOfAllUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createOrderDetailAndPaymentDetail = `-- name: CreateOrderDetailAndPaymentDetail :one
 WITH "payment_ins" AS (
 INSERT INTO "payment_detail" (
@@ -81,22 +104,121 @@ func (q *Queries) GetOrderDetail(ctx context.Context, id int64) (OrderDetail, er
 	return i, err
 }
 
+const getOrderDetailForUpdate = `-- name: GetOrderDetailForUpdate :one
+SELECT id, user_id, total, payment_id, created_at, updated_at FROM "order_detail"
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetOrderDetailForUpdate(ctx context.Context, id int64) (OrderDetail, error) {
+	row := q.db.QueryRowContext(ctx, getOrderDetailForUpdate, id)
+	var i OrderDetail
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Total,
+		&i.PaymentID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAllOrderDetails = `-- name: ListAllOrderDetails :many
+SELECT id, user_id, total, payment_id, created_at, updated_at FROM "order_detail"
+WHERE user_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAllOrderDetails(ctx context.Context, userID int64) ([]OrderDetail, error) {
+	rows, err := q.db.QueryContext(ctx, listAllOrderDetails, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrderDetail{}
+	for rows.Next() {
+		var i OrderDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Total,
+			&i.PaymentID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOrderDetails = `-- name: ListOrderDetails :many
 SELECT id, user_id, total, payment_id, created_at, updated_at FROM "order_detail"
 WHERE user_id = $1
+AND id > $2
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $3
 `
 
 type ListOrderDetailsParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID  int64 `json:"user_id"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListOrderDetails(ctx context.Context, arg ListOrderDetailsParams) ([]OrderDetail, error) {
-	rows, err := q.db.QueryContext(ctx, listOrderDetails, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listOrderDetails, arg.UserID, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrderDetail{}
+	for rows.Next() {
+		var i OrderDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Total,
+			&i.PaymentID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderDetailsOfAllUsers = `-- name: ListOrderDetailsOfAllUsers :many
+SELECT id, user_id, total, payment_id, created_at, updated_at FROM "order_detail"
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListOrderDetailsOfAllUsersParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
+}
+
+func (q *Queries) ListOrderDetailsOfAllUsers(ctx context.Context, arg ListOrderDetailsOfAllUsersParams) ([]OrderDetail, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderDetailsOfAllUsers, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +125,6 @@ func TestListOrderDetails(t *testing.T) {
 	arg := ListOrderDetailsParams{
 		UserID: lastOrderDetail.UserID,
 		Limit:  5,
-		Offset: 0,
 	}
 	orderDetails, err := testQueires.ListOrderDetails(context.Background(), arg)
 
@@ -139,4 +138,35 @@ func TestListOrderDetails(t *testing.T) {
 		require.Equal(t, lastOrderDetail.PaymentID.Int64, orderDetail.PaymentID.Int64)
 		require.Equal(t, lastOrderDetail.Total, orderDetail.Total)
 	}
+
+	count, err := testQueires.CountOrderDetails(context.Background(), arg.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(orderDetails)), count)
+}
+
+func TestListOrderDetailsOfAllUsers(t *testing.T) {
+	firstOrderDetail, _ := createRandomOrderDetailAndPaymentDetail(t)
+	for i := 0; i < 5; i++ {
+		createRandomOrderDetailAndPaymentDetail(t)
+	}
+
+	arg := ListOrderDetailsOfAllUsersParams{
+		AfterID: firstOrderDetail.ID - 1,
+		Limit:   5,
+	}
+	orderDetails, err := testQueires.ListOrderDetailsOfAllUsers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, orderDetails, 5)
+	require.Equal(t, firstOrderDetail.ID, orderDetails[0].ID)
+
+	// the orders of every user are listed
+	userIDs := make(map[int64]bool)
+	for _, orderDetail := range orderDetails {
+		userIDs[orderDetail.UserID] = true
+	}
+	require.Greater(t, len(userIDs), 1)
+
+	count, err := testQueires.CountOrderDetailsOfAllUsers(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(6))
 }
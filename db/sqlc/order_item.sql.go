@@ -7,25 +7,57 @@ import (
 	"context"
 )
 
+const countOrderItems = `-- name: CountOrderItems :one
+SELECT count(*) FROM "order_item"
+JOIN "order_detail" ON "order_detail".id = "order_item".order_id
+WHERE "order_detail".user_id = $1
+`
+
+func (q *Queries) CountOrderItems(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrderItems, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createOrderItem = `-- name: CreateOrderItem :one
 INSERT INTO "order_item" (
   order_id,
   product_id,
-  quantity
+  quantity,
+  product_name,
+  product_sku,
+  price,
+  variant_id,
+  variant_name
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
-RETURNING id, order_id, product_id, quantity, created_at, updated_at
+RETURNING id, order_id, product_id, quantity, created_at, updated_at, product_name, product_sku, price, variant_id, variant_name
 `
 
 type CreateOrderItemParams struct {
-	OrderID   int64 `json:"order_id"`
-	ProductID int64 `json:"product_id"`
-	Quantity  int32 `json:"quantity"`
+	OrderID     int64  `json:"order_id"`
+	ProductID   int64  `json:"product_id"`
+	Quantity    int32  `json:"quantity"`
+	ProductName string `json:"product_name"`
+	ProductSku  string `json:"product_sku"`
+	Price       string `json:"price"`
+	VariantID   int64  `json:"variant_id"`
+	VariantName string `json:"variant_name"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error) {
-	row := q.db.QueryRowContext(ctx, createOrderItem, arg.OrderID, arg.ProductID, arg.Quantity)
+	row := q.db.QueryRowContext(ctx, createOrderItem,
+		arg.OrderID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.ProductName,
+		arg.ProductSku,
+		arg.Price,
+		arg.VariantID,
+		arg.VariantName,
+	)
 	var i OrderItem
 	err := row.Scan(
 		&i.ID,
@@ -34,6 +66,11 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProductName,
+		&i.ProductSku,
+		&i.Price,
+		&i.VariantID,
+		&i.VariantName,
 	)
 	return i, err
 }
@@ -50,7 +87,9 @@ func (q *Queries) DeleteOrderItem(ctx context.Context, id int64) error {
 
 const getOrderItem = `-- name: GetOrderItem :one
 SELECT "order_item".id, "order_item".order_id, "order_item".product_id, 
-"order_item".quantity, "order_item".created_at, "order_item".updated_at 
+"order_item".quantity, "order_item".created_at, "order_item".updated_at,
+"order_item".product_name, "order_item".product_sku, "order_item".price,
+"order_item".variant_id, "order_item".variant_name
 FROM "order_item"
 LEFT JOIN "order_detail" ON "order_detail".id = "order_item".order_id
 WHERE "order_item".id = $1 
@@ -73,28 +112,77 @@ func (q *Queries) GetOrderItem(ctx context.Context, arg GetOrderItemParams) (Ord
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProductName,
+		&i.ProductSku,
+		&i.Price,
+		&i.VariantID,
+		&i.VariantName,
 	)
 	return i, err
 }
 
 const listOrderItems = `-- name: ListOrderItems :many
 SELECT "order_item".id, "order_item".order_id, "order_item".product_id, 
-"order_item".quantity, "order_item".created_at, "order_item".updated_at
+"order_item".quantity, "order_item".created_at, "order_item".updated_at,
+"order_item".product_name, "order_item".product_sku, "order_item".price,
+"order_item".variant_id, "order_item".variant_name
 FROM "order_item"
 LEFT JOIN "order_detail" ON "order_detail".id = "order_item".order_id
 WHERE "order_detail".user_id = $1
-LIMIT $2
-OFFSET $3
+AND "order_item".id > $2
+ORDER BY "order_item".id
+LIMIT $3
 `
 
 type ListOrderItemsParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID  int64 `json:"user_id"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListOrderItems(ctx context.Context, arg ListOrderItemsParams) ([]OrderItem, error) {
-	rows, err := q.db.QueryContext(ctx, listOrderItems, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listOrderItems, arg.UserID, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrderItem{}
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProductName,
+			&i.ProductSku,
+			&i.Price,
+			&i.VariantID,
+			&i.VariantName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderItemsByOrderID = `-- name: ListOrderItemsByOrderID :many
+SELECT id, order_id, product_id, quantity, created_at, updated_at, product_name, product_sku, price FROM "order_item"
+WHERE order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListOrderItemsByOrderID(ctx context.Context, orderID int64) ([]OrderItem, error) {
+	rows, err := q.db.QueryContext(ctx, listOrderItemsByOrderID, orderID)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +197,11 @@ func (q *Queries) ListOrderItems(ctx context.Context, arg ListOrderItemsParams)
 			&i.Quantity,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProductName,
+			&i.ProductSku,
+			&i.Price,
+			&i.VariantID,
+			&i.VariantName,
 		); err != nil {
 			return nil, err
 		}
@@ -127,7 +220,7 @@ const updateOrderItem = `-- name: UpdateOrderItem :one
 UPDATE "order_item"
 SET quantity = $2
 WHERE id = $1
-RETURNING id, order_id, product_id, quantity, created_at, updated_at
+RETURNING id, order_id, product_id, quantity, created_at, updated_at, product_name, product_sku, price, variant_id, variant_name
 `
 
 type UpdateOrderItemParams struct {
@@ -145,6 +238,11 @@ func (q *Queries) UpdateOrderItem(ctx context.Context, arg UpdateOrderItemParams
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProductName,
+		&i.ProductSku,
+		&i.Price,
+		&i.VariantID,
+		&i.VariantName,
 	)
 	return i, err
 }
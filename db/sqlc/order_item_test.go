@@ -11,11 +11,21 @@ import (
 
 func createRandomOrderItem(t *testing.T) OrderItem {
 	orderDetail, _ := createRandomOrderDetailAndPaymentDetail(t)
+	return createRandomOrderItemForOrder(t, orderDetail)
+}
+
+func createRandomOrderItemForOrder(t *testing.T, orderDetail OrderDetail) OrderItem {
 	product := createRandomProduct(t)
+	variant := defaultProductVariant(t, product)
 	arg := CreateOrderItemParams{
-		OrderID:   orderDetail.ID,
-		ProductID: product.ID,
-		Quantity:  int32(util.RandomMoney()),
+		OrderID:     orderDetail.ID,
+		ProductID:   product.ID,
+		Quantity:    int32(util.RandomMoney()),
+		ProductName: product.Name,
+		ProductSku:  variant.Sku,
+		Price:       product.Price,
+		VariantID:   variant.ID,
+		VariantName: util.RandomString(2),
 	}
 
 	orderItem, err := testQueires.CreateOrderItem(context.Background(), arg)
@@ -26,6 +36,11 @@ func createRandomOrderItem(t *testing.T) OrderItem {
 	require.Equal(t, arg.OrderID, orderItem.OrderID)
 	require.Equal(t, arg.ProductID, orderItem.ProductID)
 	require.Equal(t, arg.Quantity, orderItem.Quantity)
+	require.Equal(t, arg.ProductName, orderItem.ProductName)
+	require.Equal(t, arg.ProductSku, orderItem.ProductSku)
+	require.Equal(t, arg.Price, orderItem.Price)
+	require.Equal(t, arg.VariantID, orderItem.VariantID)
+	require.Equal(t, arg.VariantName, orderItem.VariantName)
 
 	require.NotEmpty(t, orderItem.ID)
 	require.NotEmpty(t, orderItem.CreatedAt)
@@ -80,6 +95,29 @@ func TestGetOrderItem(t *testing.T) {
 
 // }
 
+func TestListOrderItemsByOrderID(t *testing.T) {
+	orderDetail, _ := createRandomOrderDetailAndPaymentDetail(t)
+
+	var orderItems1 []OrderItem
+	for i := 0; i < 3; i++ {
+		orderItems1 = append(orderItems1, createRandomOrderItemForOrder(t, orderDetail))
+	}
+
+	orderItems2, err := testQueires.ListOrderItemsByOrderID(context.Background(), orderDetail.ID)
+
+	require.NoError(t, err)
+	require.Len(t, orderItems2, len(orderItems1))
+
+	for i, orderItem := range orderItems2 {
+		require.Equal(t, orderItems1[i].ID, orderItem.ID)
+		require.Equal(t, orderDetail.ID, orderItem.OrderID)
+		require.Equal(t, orderItems1[i].ProductID, orderItem.ProductID)
+		require.Equal(t, orderItems1[i].ProductName, orderItem.ProductName)
+		require.Equal(t, orderItems1[i].ProductSku, orderItem.ProductSku)
+		require.Equal(t, orderItems1[i].Price, orderItem.Price)
+	}
+}
+
 func TestUpdateOrderItem(t *testing.T) {
 	orderItem1 := createRandomOrderItem(t)
 	arg := UpdateOrderItemParams{
@@ -132,7 +170,6 @@ func TestListOrderItems(t *testing.T) {
 	arg := ListOrderItemsParams{
 		UserID: orderDetail1.UserID,
 		Limit:  5,
-		Offset: 0,
 	}
 
 	orderItems, err := testQueires.ListOrderItems(context.Background(), arg)
@@ -148,4 +185,7 @@ func TestListOrderItems(t *testing.T) {
 
 	}
 
+	count, err := testQueires.CountOrderItems(context.Background(), arg.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(orderItems)), count)
 }
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createPasswordResetTokenForUser(t *testing.T, user User, expiresAt time.Time) PasswordResetToken {
+	arg := CreatePasswordResetTokenParams{
+		UserID:    user.ID,
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		ExpiresAt: expiresAt,
+	}
+
+	resetToken, err := testQueires.CreatePasswordResetToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, resetToken)
+
+	require.Equal(t, arg.UserID, resetToken.UserID)
+	require.Equal(t, arg.TokenHash, resetToken.TokenHash)
+	require.WithinDuration(t, arg.ExpiresAt, resetToken.ExpiresAt, time.Second)
+	require.False(t, resetToken.UsedAt.Valid)
+	require.NotZero(t, resetToken.CreatedAt)
+
+	return resetToken
+}
+
+func TestCreatePasswordResetToken(t *testing.T) {
+	user := createRandomUser(t)
+	createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+}
+
+func TestUsePasswordResetToken(t *testing.T) {
+	user := createRandomUser(t)
+	resetToken1 := createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	resetToken2, err := testQueires.UsePasswordResetToken(context.Background(), resetToken1.TokenHash)
+	require.NoError(t, err)
+	require.Equal(t, resetToken1.ID, resetToken2.ID)
+	require.True(t, resetToken2.UsedAt.Valid)
+
+	// a reset token can only be used once
+	_, err = testQueires.UsePasswordResetToken(context.Background(), resetToken1.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseExpiredPasswordResetToken(t *testing.T) {
+	user := createRandomUser(t)
+	resetToken := createPasswordResetTokenForUser(t, user, time.Now().Add(-time.Minute))
+
+	_, err := testQueires.UsePasswordResetToken(context.Background(), resetToken.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestInvalidatePasswordResetTokens(t *testing.T) {
+	user := createRandomUser(t)
+	resetToken := createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	err := testQueires.InvalidatePasswordResetTokens(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.UsePasswordResetToken(context.Background(), resetToken.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -9,6 +9,19 @@ import (
 	"time"
 )
 
+const countPaymentDetails = `-- name: CountPaymentDetails :one
+SELECT count(*) FROM "payment_detail"
+JOIN "order_detail" ON "order_detail".id = "payment_detail".order_id
+WHERE "order_detail".user_id = $1
+`
+
+func (q *Queries) CountPaymentDetails(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPaymentDetails, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPaymentDetail = `-- name: CreatePaymentDetail :one
 INSERT INTO "payment_detail" (
   order_id,
@@ -89,19 +102,32 @@ func (q *Queries) GetPaymentDetail(ctx context.Context, arg GetPaymentDetailPara
 	return i, err
 }
 
+const getPaymentDetailStatus = `-- name: GetPaymentDetailStatus :one
+SELECT status FROM "payment_detail"
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetPaymentDetailStatus(ctx context.Context, id int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentDetailStatus, id)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
 const listPaymentDetails = `-- name: ListPaymentDetails :many
 SELECT payment_detail.id, order_id, amount, provider, status, payment_detail.created_at, payment_detail.updated_at, order_detail.id, user_id, total, payment_id, order_detail.created_at, order_detail.updated_at FROM "payment_detail"
 LEFT JOIN "order_detail" ON "order_detail".id = "payment_detail".order_id
 WHERE "order_detail".user_id = $1
+AND "payment_detail".id > $2
 ORDER BY "payment_detail".id
-LIMIT $2
-OFFSET $3
+LIMIT $3
 `
 
 type ListPaymentDetailsParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID  int64 `json:"user_id"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 type ListPaymentDetailsRow struct {
@@ -121,7 +147,7 @@ type ListPaymentDetailsRow struct {
 }
 
 func (q *Queries) ListPaymentDetails(ctx context.Context, arg ListPaymentDetailsParams) ([]ListPaymentDetailsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listPaymentDetails, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listPaymentDetails, arg.UserID, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +123,6 @@ func TestListPaymentDetails(t *testing.T) {
 	arg := ListPaymentDetailsParams{
 		UserID: lastOrderDetail.UserID,
 		Limit:  5,
-		Offset: 0,
 	}
 
 	paymentDetails, err := testQueires.ListPaymentDetails(context.Background(), arg)
@@ -135,4 +134,7 @@ func TestListPaymentDetails(t *testing.T) {
 		require.NotEmpty(t, paymentDetail)
 	}
 
+	count, err := testQueires.CountPaymentDetails(context.Background(), arg.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(paymentDetails)), count)
 }
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// VariantPrice returns the price of the variant before any discount, which is
+// the price of its product unless the variant overrides it.
+func VariantPrice(product Product, variant ProductVariant) string {
+	if variant.Price.Valid {
+		return variant.Price.String
+	}
+	return product.Price
+}
+
+// UnitPrice returns the price of one unit of the variant of the product,
+// with the discount applied only while it is active.
+func UnitPrice(product Product, variant ProductVariant, discount Discount) (decimal.Decimal, error) {
+	price, err := decimal.NewFromString(VariantPrice(product, variant))
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if discount.Active {
+		percent, err := decimal.NewFromString(discount.DiscountPercent)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		price = price.Sub(price.Mul(percent).Div(decimal.NewFromInt(100)))
+	}
+
+	return price, nil
+}
+
+// LineTotal returns the price of quantity units of the variant of the product.
+func LineTotal(product Product, variant ProductVariant, discount Discount, quantity int32) (decimal.Decimal, error) {
+	price, err := UnitPrice(product, variant, discount)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return price.Mul(decimal.NewFromInt32(quantity)), nil
+}
+
+// OrderItemsTotal returns the sum of the line totals of the order items,
+// priced at the unit prices captured when they were added to the order.
+func OrderItemsTotal(orderItems []OrderItem) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, orderItem := range orderItems {
+		price, err := decimal.NewFromString(orderItem.Price)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		total = total.Add(price.Mul(decimal.NewFromInt32(orderItem.Quantity)))
+	}
+	return total, nil
+}
+
+// FormatTotal formats an amount the way totals are stored,
+// rounded to cents with banker's rounding.
+func FormatTotal(amount decimal.Decimal) string {
+	return amount.StringFixedBank(2)
+}
+
+// pricedLine is a cart item priced against the current variant, product and discount
+type pricedLine struct {
+	CartItem  CartItem
+	Product   Product
+	Variant   ProductVariant
+	UnitPrice decimal.Decimal
+}
+
+// priceCartItems looks up the variant, product and discount of every cart item and
+// returns the priced lines together with the sum of their line totals.
+// ErrInvalidQuantity is returned when a cart item doesn't ask for a positive quantity.
+func (q *Queries) priceCartItems(ctx context.Context, cartItems []CartItem) ([]pricedLine, decimal.Decimal, error) {
+	lines := make([]pricedLine, 0, len(cartItems))
+	total := decimal.Zero
+
+	for _, cartItem := range cartItems {
+		if cartItem.Quantity <= 0 {
+			return nil, decimal.Zero, ErrInvalidQuantity
+		}
+
+		variant, err := q.GetProductVariant(ctx, cartItem.VariantID)
+		if err != nil {
+			return nil, decimal.Zero, err
+		}
+
+		product, err := q.GetProduct(ctx, variant.ProductID)
+		if err != nil {
+			return nil, decimal.Zero, err
+		}
+
+		discount, err := q.GetDiscount(ctx, product.DiscountID)
+		if err != nil {
+			return nil, decimal.Zero, err
+		}
+
+		unitPrice, err := UnitPrice(product, variant, discount)
+		if err != nil {
+			return nil, decimal.Zero, err
+		}
+
+		lines = append(lines, pricedLine{
+			CartItem:  cartItem,
+			Product:   product,
+			Variant:   variant,
+			UnitPrice: unitPrice,
+		})
+		total = total.Add(unitPrice.Mul(decimal.NewFromInt32(cartItem.Quantity)))
+	}
+
+	return lines, total, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineTotal(t *testing.T) {
+	product := Product{Price: "19.99"}
+	variant := ProductVariant{}
+
+	discount := Discount{DiscountPercent: "25", Active: true}
+	total, err := LineTotal(product, variant, discount, 4)
+	require.NoError(t, err)
+	require.Equal(t, "59.97", FormatTotal(total))
+
+	discount.Active = false
+	total, err = LineTotal(product, variant, discount, 4)
+	require.NoError(t, err)
+	require.Equal(t, "79.96", FormatTotal(total))
+
+	// the variant overrides the price of its product
+	variant.Price = sql.NullString{String: "24.99", Valid: true}
+	total, err = LineTotal(product, variant, discount, 4)
+	require.NoError(t, err)
+	require.Equal(t, "99.96", FormatTotal(total))
+}
+
+func TestVariantPrice(t *testing.T) {
+	product := Product{Price: "19.99"}
+
+	require.Equal(t, "19.99", VariantPrice(product, ProductVariant{}))
+	require.Equal(t, "24.99", VariantPrice(product, ProductVariant{Price: sql.NullString{String: "24.99", Valid: true}}))
+}
+
+func TestUnitPriceInvalidPrice(t *testing.T) {
+	_, err := UnitPrice(Product{Price: "free"}, ProductVariant{}, Discount{})
+	require.Error(t, err)
+
+	_, err = UnitPrice(Product{Price: "10"}, ProductVariant{Price: sql.NullString{String: "free", Valid: true}}, Discount{})
+	require.Error(t, err)
+
+	_, err = UnitPrice(Product{Price: "10"}, ProductVariant{}, Discount{DiscountPercent: "half", Active: true})
+	require.Error(t, err)
+}
+
+func TestPriceCartItemsInvalidQuantity(t *testing.T) {
+	// the quantity is checked before anything is looked up
+	q := &Queries{}
+
+	for _, quantity := range []int32{0, -3} {
+		lines, total, err := q.priceCartItems(context.Background(), []CartItem{{ID: 1, Quantity: quantity}})
+		require.ErrorIs(t, err, ErrInvalidQuantity)
+		require.Empty(t, lines)
+		require.True(t, total.IsZero())
+	}
+}
+
+func TestOrderItemsTotal(t *testing.T) {
+	total, err := OrderItemsTotal([]OrderItem{
+		{Price: "14.9925", Quantity: 2},
+		{Price: "5", Quantity: 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "44.98", FormatTotal(total))
+
+	total, err = OrderItemsTotal(nil)
+	require.NoError(t, err)
+	require.True(t, total.IsZero())
+
+	_, err = OrderItemsTotal([]OrderItem{{Price: "free", Quantity: 1}})
+	require.Error(t, err)
+}
+
+func TestFormatTotal(t *testing.T) {
+	require.Equal(t, "0.00", FormatTotal(decimal.Zero))
+	require.Equal(t, "21.76", FormatTotal(decimal.RequireFromString("21.765")))
+	require.Equal(t, "21.78", FormatTotal(decimal.RequireFromString("21.775")))
+}
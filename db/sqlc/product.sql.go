@@ -5,8 +5,24 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const countSearchProducts = `-- name: CountSearchProducts :one
+SELECT count(*) FROM "product" AS p
+CROSS JOIN to_tsquery('simple', $1::varchar) AS q(query)
+WHERE p.search_vector @@ q.query
+AND p.active
+`
+
+func (q *Queries) CountSearchProducts(ctx context.Context, query string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchProducts, query)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO "product" (
   name,
@@ -19,7 +35,7 @@ INSERT INTO "product" (
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at
+RETURNING id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at, search_vector
 `
 
 type CreateProductParams struct {
@@ -55,6 +71,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.DiscountID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -70,7 +87,7 @@ func (q *Queries) DeleteProduct(ctx context.Context, id int64) error {
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at FROM "product"
+SELECT id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at, search_vector FROM "product"
 WHERE id = $1 LIMIT 1
 `
 
@@ -89,24 +106,51 @@ func (q *Queries) GetProduct(ctx context.Context, id int64) (Product, error) {
 		&i.DiscountID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const getProductForUpdate = `-- name: GetProductForUpdate :one
+SELECT id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at, search_vector FROM "product"
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetProductForUpdate(ctx context.Context, id int64) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductForUpdate, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Sku,
+		&i.CategoryID,
+		&i.InventoryID,
+		&i.Price,
+		&i.Active,
+		&i.DiscountID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at FROM "product"
+SELECT id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at, search_vector FROM "product"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListProductsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, listProducts, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listProducts, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +170,85 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.DiscountID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProducts = `-- name: SearchProducts :many
+SELECT p.id, p.name, p.description, p.sku, p.category_id, p.inventory_id, p.price, p.active, p.discount_id, p.created_at, p.updated_at,
+ts_rank(p.search_vector, q.query)::real AS rank,
+ts_headline('simple', p.name, q.query)::varchar AS name_highlight,
+ts_headline('simple', p.description, q.query, 'MaxFragments=2, MaxWords=20, MinWords=5')::text AS description_snippet
+FROM "product" AS p
+CROSS JOIN to_tsquery('simple', $1::varchar) AS q(query)
+WHERE p.search_vector @@ q.query
+AND p.active
+AND ($2::real IS NULL
+OR ts_rank(p.search_vector, q.query)::real < $2::real
+OR (ts_rank(p.search_vector, q.query)::real = $2::real AND p.id > $3::bigint))
+ORDER BY rank DESC, p.id
+LIMIT $4
+`
+
+type SearchProductsParams struct {
+	Query     string          `json:"query"`
+	AfterRank sql.NullFloat64 `json:"after_rank"`
+	AfterID   int64           `json:"after_id"`
+	Limit     int32           `json:"limit"`
+}
+
+type SearchProductsRow struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description"`
+	Sku                string    `json:"sku"`
+	CategoryID         int64     `json:"category_id"`
+	InventoryID        int64     `json:"inventory_id"`
+	Price              string    `json:"price"`
+	Active             bool      `json:"active"`
+	DiscountID         int64     `json:"discount_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Rank               float32   `json:"rank"`
+	NameHighlight      string    `json:"name_highlight"`
+	DescriptionSnippet string    `json:"description_snippet"`
+}
+
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchProducts, arg.Query, arg.AfterRank, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductsRow{}
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Sku,
+			&i.CategoryID,
+			&i.InventoryID,
+			&i.Price,
+			&i.Active,
+			&i.DiscountID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Rank,
+			&i.NameHighlight,
+			&i.DescriptionSnippet,
 		); err != nil {
 			return nil, err
 		}
@@ -148,7 +271,7 @@ category_id = $4,
 price = $5,
 active = $6
 WHERE id = $1
-RETURNING id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at
+RETURNING id, name, description, sku, category_id, inventory_id, price, active, discount_id, created_at, updated_at, search_vector
 `
 
 type UpdateProductParams struct {
@@ -182,6 +305,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.DiscountID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -7,6 +7,17 @@ import (
 	"context"
 )
 
+const countProductCategories = `-- name: CountProductCategories :one
+SELECT count(*) FROM "product_category"
+`
+
+func (q *Queries) CountProductCategories(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProductCategories)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProductCategory = `-- name: CreateProductCategory :one
 INSERT INTO "product_category" (
   name,
@@ -67,18 +78,18 @@ func (q *Queries) GetProductCategory(ctx context.Context, id int64) (ProductCate
 
 const listProductCategories = `-- name: ListProductCategories :many
 SELECT id, name, description, active, created_at, updated_at FROM "product_category"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListProductCategoriesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListProductCategories(ctx context.Context, arg ListProductCategoriesParams) ([]ProductCategory, error) {
-	rows, err := q.db.QueryContext(ctx, listProductCategories, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listProductCategories, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
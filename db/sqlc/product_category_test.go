@@ -91,12 +91,13 @@ func TestDeleteProductCategory(t *testing.T) {
 }
 
 func TestListProductCategories(t *testing.T) {
+	first := createRandomProductCategory(t)
 	for i := 0; i < 10; i++ {
 		createRandomProductCategory(t)
 	}
 	arg := ListProductCategoriesParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	userCategories, err := testQueires.ListProductCategories(context.Background(), arg)
@@ -105,6 +106,11 @@ func TestListProductCategories(t *testing.T) {
 
 	for _, userCategory := range userCategories {
 		require.NotEmpty(t, userCategory)
+		require.Greater(t, userCategory.ID, first.ID)
 
 	}
+
+	count, err := testQueires.CountProductCategories(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
 }
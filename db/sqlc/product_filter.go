@@ -0,0 +1,260 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProductSort is the order in which the products are listed
+type ProductSort string
+
+const (
+	// ProductSortID lists the products in the order they were created, it is the default
+	ProductSortID ProductSort = ""
+	// ProductSortPrice lists the cheapest products first
+	ProductSortPrice ProductSort = "price"
+	// ProductSortPriceDesc lists the most expensive products first
+	ProductSortPriceDesc ProductSort = "-price"
+	// ProductSortNewest lists the latest products first
+	ProductSortNewest ProductSort = "newest"
+	// ProductSortName lists the products alphabetically
+	ProductSortName ProductSort = "name"
+)
+
+// productSortOrder is how a sort orders the products and pages through them
+type productSortOrder struct {
+	// orderBy is the ORDER BY clause of the sort. The id breaks the ties,
+	// so that the pages never overlap.
+	orderBy string
+	// after keeps the products that come after the last product of the previous
+	// page, given the placeholders of its sort key and id
+	after func(key, id string) string
+	// key is the value of the sorted column of the product, as it is sent back
+	// with the cursor of the next page
+	key func(product FilterProductsRow) string
+}
+
+// productSortOrders are the sorts of the products. Only these clauses ever make it
+// into the query.
+var productSortOrders = map[ProductSort]productSortOrder{
+	ProductSortID: {
+		orderBy: "p.id",
+		after:   func(key, id string) string { return "p.id > " + id },
+	},
+	ProductSortPrice: {
+		orderBy: "vp.min_price, p.id",
+		after:   func(key, id string) string { return "(vp.min_price, p.id) > (" + key + "::decimal, " + id + ")" },
+		key:     func(product FilterProductsRow) string { return product.MinPrice },
+	},
+	ProductSortPriceDesc: {
+		orderBy: "vp.max_price DESC, p.id",
+		after: func(key, id string) string {
+			return "(vp.max_price < " + key + "::decimal OR (vp.max_price = " + key + "::decimal AND p.id > " + id + "))"
+		},
+		key: func(product FilterProductsRow) string { return product.MaxPrice },
+	},
+	ProductSortNewest: {
+		orderBy: "p.created_at DESC, p.id DESC",
+		after:   func(key, id string) string { return "(p.created_at, p.id) < (" + key + "::timestamptz, " + id + ")" },
+		key:     func(product FilterProductsRow) string { return product.CreatedAt.Format(time.RFC3339Nano) },
+	},
+	ProductSortName: {
+		orderBy: "p.name, p.id",
+		after:   func(key, id string) string { return "(p.name, p.id) > (" + key + ", " + id + ")" },
+		key:     func(product FilterProductsRow) string { return product.Name },
+	},
+}
+
+// Key returns the sort key of the product, the value FilterProducts needs in AfterKey
+// to list the products after it. Sorting by id has no key.
+func (s ProductSort) Key(product FilterProductsRow) string {
+	order, ok := productSortOrders[s]
+	if !ok || order.key == nil {
+		return ""
+	}
+	return order.key(product)
+}
+
+const filterProductsColumns = "p.id, p.name, p.description, p.sku, p.category_id, p.inventory_id, p.price, p.active, p.discount_id, p.created_at, p.updated_at, vp.min_price, vp.max_price"
+
+// filterProductsPrices joins the lowest and highest prices the active variants of the
+// product are sold at, a variant without a price of its own being sold at the price of
+// the product. A product without an active variant is ranked at its own price.
+const filterProductsPrices = `CROSS JOIN LATERAL (SELECT COALESCE(MIN(COALESCE(v.price, p.price)), p.price) AS min_price, COALESCE(MAX(COALESCE(v.price, p.price)), p.price) AS max_price FROM "product_variant" AS v WHERE v.product_id = p.id AND v.active) AS vp`
+
+// FilterProductsParams contains the filters, the order and the page of the products
+// to list. Filters that are not set don't restrict the products.
+type FilterProductsParams struct {
+	CategoryID sql.NullInt64 `json:"category_id"`
+	// MinPrice and MaxPrice keep the products with an active variant sold within the range
+	MinPrice sql.NullString `json:"min_price"`
+	MaxPrice sql.NullString `json:"max_price"`
+	Active   sql.NullBool   `json:"active"`
+	// InStock keeps the products with an active variant whose inventory is active and not empty
+	InStock bool `json:"in_stock"`
+	// HasDiscount keeps the products whose discount is active and not zero
+	HasDiscount bool        `json:"has_discount"`
+	Sort        ProductSort `json:"sort"`
+	// AfterID and AfterKey are the id and the sort key of the last product of the
+	// previous page, the first page is listed when AfterID is zero
+	AfterID  int64  `json:"after_id"`
+	AfterKey string `json:"after_key"`
+	Limit    int32  `json:"limit"`
+}
+
+// queryArgs are the arguments of a query written on the fly
+type queryArgs []interface{}
+
+// add appends the value to the arguments and returns its placeholder
+func (args *queryArgs) add(value interface{}) string {
+	*args = append(*args, value)
+	return fmt.Sprintf("$%d", len(*args))
+}
+
+// filterProductsConditions writes the WHERE conditions of the filters, adding their
+// values to the arguments
+func filterProductsConditions(arg FilterProductsParams, args *queryArgs) []string {
+	var conditions []string
+	if arg.CategoryID.Valid {
+		conditions = append(conditions, "p.category_id = "+args.add(arg.CategoryID.Int64))
+	}
+	if arg.MinPrice.Valid || arg.MaxPrice.Valid {
+		// both bounds apply to the same variant
+		priceConditions := []string{"v.product_id = p.id", "v.active"}
+		if arg.MinPrice.Valid {
+			priceConditions = append(priceConditions, "COALESCE(v.price, p.price) >= "+args.add(arg.MinPrice.String)+"::decimal")
+		}
+		if arg.MaxPrice.Valid {
+			priceConditions = append(priceConditions, "COALESCE(v.price, p.price) <= "+args.add(arg.MaxPrice.String)+"::decimal")
+		}
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM "product_variant" AS v WHERE `+strings.Join(priceConditions, " AND ")+`)`)
+	}
+	if arg.Active.Valid {
+		conditions = append(conditions, "p.active = "+args.add(arg.Active.Bool))
+	}
+	if arg.InStock {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM "product_variant" AS v JOIN "product_inventory" AS i ON i.id = v.inventory_id WHERE v.product_id = p.id AND v.active AND i.active AND i.quantity > 0)`)
+	}
+	if arg.HasDiscount {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM "discount" AS d WHERE d.id = p.discount_id AND d.active AND d.discount_percent > 0)`)
+	}
+	return conditions
+}
+
+// buildFilterProductsQuery writes the query listing the products that pass the filters.
+// The values of the filters are always passed as arguments of the query, the only
+// text taken from the params is the sort, picked from productSortOrders.
+func buildFilterProductsQuery(arg FilterProductsParams) (string, []interface{}, error) {
+	order, ok := productSortOrders[arg.Sort]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown product sort %q", arg.Sort)
+	}
+
+	var args queryArgs
+	conditions := filterProductsConditions(arg, &args)
+	if arg.AfterID > 0 {
+		var key string
+		if order.key != nil {
+			key = args.add(arg.AfterKey)
+		}
+		conditions = append(conditions, order.after(key, args.add(arg.AfterID)))
+	}
+
+	var query strings.Builder
+	query.WriteString(`SELECT ` + filterProductsColumns + ` FROM "product" AS p ` + filterProductsPrices)
+	if len(conditions) > 0 {
+		query.WriteString("\nWHERE " + strings.Join(conditions, "\nAND "))
+	}
+	query.WriteString("\nORDER BY " + order.orderBy)
+	query.WriteString("\nLIMIT " + args.add(arg.Limit))
+
+	return query.String(), args, nil
+}
+
+// buildCountFilteredProductsQuery writes the query counting all the products that
+// pass the filters, whatever the page
+func buildCountFilteredProductsQuery(arg FilterProductsParams) (string, []interface{}) {
+	var args queryArgs
+	conditions := filterProductsConditions(arg, &args)
+
+	var query strings.Builder
+	query.WriteString(`SELECT count(*) FROM "product" AS p`)
+	if len(conditions) > 0 {
+		query.WriteString("\nWHERE " + strings.Join(conditions, "\nAND "))
+	}
+
+	return query.String(), args
+}
+
+// CountFilteredProducts counts the products that pass the filters
+func (q *Queries) CountFilteredProducts(ctx context.Context, arg FilterProductsParams) (int64, error) {
+	query, args := buildCountFilteredProductsQuery(arg)
+	row := q.db.QueryRowContext(ctx, query, args...)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+// FilterProductsRow is a product listed by FilterProducts, with the range of prices
+// its active variants are sold at
+type FilterProductsRow struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Sku         string    `json:"sku"`
+	CategoryID  int64     `json:"category_id"`
+	InventoryID int64     `json:"inventory_id"`
+	Price       string    `json:"price"`
+	Active      bool      `json:"active"`
+	DiscountID  int64     `json:"discount_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	MinPrice    string    `json:"min_price"`
+	MaxPrice    string    `json:"max_price"`
+}
+
+// FilterProducts lists a page of the products that pass the filters, in the given order
+func (q *Queries) FilterProducts(ctx context.Context, arg FilterProductsParams) ([]FilterProductsRow, error) {
+	query, args, err := buildFilterProductsQuery(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FilterProductsRow{}
+	for rows.Next() {
+		var i FilterProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Sku,
+			&i.CategoryID,
+			&i.InventoryID,
+			&i.Price,
+			&i.Active,
+			&i.DiscountID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MinPrice,
+			&i.MaxPrice,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
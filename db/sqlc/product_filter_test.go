@@ -0,0 +1,490 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	inStockCondition     = `EXISTS (SELECT 1 FROM "product_variant" AS v JOIN "product_inventory" AS i ON i.id = v.inventory_id WHERE v.product_id = p.id AND v.active AND i.active AND i.quantity > 0)`
+	hasDiscountCondition = `EXISTS (SELECT 1 FROM "discount" AS d WHERE d.id = p.discount_id AND d.active AND d.discount_percent > 0)`
+	variantPriceFrom     = `EXISTS (SELECT 1 FROM "product_variant" AS v WHERE v.product_id = p.id AND v.active AND `
+)
+
+func TestBuildFilterProductsQuery(t *testing.T) {
+	selectProducts := `SELECT ` + filterProductsColumns + ` FROM "product" AS p ` + filterProductsPrices
+
+	testCases := []struct {
+		name      string
+		arg       FilterProductsParams
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "NoFilter",
+			arg:       FilterProductsParams{Limit: 5},
+			wantQuery: selectProducts + "\nORDER BY p.id\nLIMIT $1",
+			wantArgs:  []interface{}{int32(5)},
+		},
+		{
+			name:      "Category",
+			arg:       FilterProductsParams{CategoryID: sql.NullInt64{Int64: 3, Valid: true}, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE p.category_id = $1\nORDER BY p.id\nLIMIT $2",
+			wantArgs:  []interface{}{int64(3), int32(5)},
+		},
+		{
+			name:      "MinPrice",
+			arg:       FilterProductsParams{MinPrice: sql.NullString{String: "9.99", Valid: true}, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE " + variantPriceFrom + "COALESCE(v.price, p.price) >= $1::decimal)\nORDER BY p.id\nLIMIT $2",
+			wantArgs:  []interface{}{"9.99", int32(5)},
+		},
+		{
+			name:      "MaxPrice",
+			arg:       FilterProductsParams{MaxPrice: sql.NullString{String: "20", Valid: true}, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE " + variantPriceFrom + "COALESCE(v.price, p.price) <= $1::decimal)\nORDER BY p.id\nLIMIT $2",
+			wantArgs:  []interface{}{"20", int32(5)},
+		},
+		{
+			name:      "Inactive",
+			arg:       FilterProductsParams{Active: sql.NullBool{Bool: false, Valid: true}, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE p.active = $1\nORDER BY p.id\nLIMIT $2",
+			wantArgs:  []interface{}{false, int32(5)},
+		},
+		{
+			name:      "InStock",
+			arg:       FilterProductsParams{InStock: true, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE " + inStockCondition + "\nORDER BY p.id\nLIMIT $1",
+			wantArgs:  []interface{}{int32(5)},
+		},
+		{
+			name:      "HasDiscount",
+			arg:       FilterProductsParams{HasDiscount: true, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE " + hasDiscountCondition + "\nORDER BY p.id\nLIMIT $1",
+			wantArgs:  []interface{}{int32(5)},
+		},
+		{
+			name: "AllFilters",
+			arg: FilterProductsParams{
+				CategoryID:  sql.NullInt64{Int64: 3, Valid: true},
+				MinPrice:    sql.NullString{String: "10", Valid: true},
+				MaxPrice:    sql.NullString{String: "20", Valid: true},
+				Active:      sql.NullBool{Bool: true, Valid: true},
+				InStock:     true,
+				HasDiscount: true,
+				Sort:        ProductSortPriceDesc,
+				AfterID:     9,
+				AfterKey:    "15",
+				Limit:       5,
+			},
+			wantQuery: selectProducts +
+				"\nWHERE p.category_id = $1" +
+				"\nAND " + variantPriceFrom + "COALESCE(v.price, p.price) >= $2::decimal AND COALESCE(v.price, p.price) <= $3::decimal)" +
+				"\nAND p.active = $4" +
+				"\nAND " + inStockCondition +
+				"\nAND " + hasDiscountCondition +
+				"\nAND (vp.max_price < $5::decimal OR (vp.max_price = $5::decimal AND p.id > $6))" +
+				"\nORDER BY vp.max_price DESC, p.id\nLIMIT $7",
+			wantArgs: []interface{}{int64(3), "10", "20", true, "15", int64(9), int32(5)},
+		},
+		{
+			name:      "SortByPrice",
+			arg:       FilterProductsParams{Sort: ProductSortPrice, Limit: 5},
+			wantQuery: selectProducts + "\nORDER BY vp.min_price, p.id\nLIMIT $1",
+			wantArgs:  []interface{}{int32(5)},
+		},
+		{
+			name:      "SortByNewest",
+			arg:       FilterProductsParams{Sort: ProductSortNewest, Limit: 5},
+			wantQuery: selectProducts + "\nORDER BY p.created_at DESC, p.id DESC\nLIMIT $1",
+			wantArgs:  []interface{}{int32(5)},
+		},
+		{
+			name:      "SortByName",
+			arg:       FilterProductsParams{Sort: ProductSortName, Limit: 5},
+			wantQuery: selectProducts + "\nORDER BY p.name, p.id\nLIMIT $1",
+			wantArgs:  []interface{}{int32(5)},
+		},
+		{
+			name:      "AfterID",
+			arg:       FilterProductsParams{AfterID: 9, Limit: 5},
+			wantQuery: selectProducts + "\nWHERE p.id > $1\nORDER BY p.id\nLIMIT $2",
+			wantArgs:  []interface{}{int64(9), int32(5)},
+		},
+		{
+			name:      "AfterPrice",
+			arg:       FilterProductsParams{Sort: ProductSortPrice, AfterID: 9, AfterKey: "15", Limit: 5},
+			wantQuery: selectProducts + "\nWHERE (vp.min_price, p.id) > ($1::decimal, $2)\nORDER BY vp.min_price, p.id\nLIMIT $3",
+			wantArgs:  []interface{}{"15", int64(9), int32(5)},
+		},
+		{
+			name:      "AfterNewest",
+			arg:       FilterProductsParams{Sort: ProductSortNewest, AfterID: 9, AfterKey: "2022-01-02T15:04:05Z", Limit: 5},
+			wantQuery: selectProducts + "\nWHERE (p.created_at, p.id) < ($1::timestamptz, $2)\nORDER BY p.created_at DESC, p.id DESC\nLIMIT $3",
+			wantArgs:  []interface{}{"2022-01-02T15:04:05Z", int64(9), int32(5)},
+		},
+		{
+			name:      "AfterName",
+			arg:       FilterProductsParams{Sort: ProductSortName, AfterID: 9, AfterKey: "chair", Limit: 5},
+			wantQuery: selectProducts + "\nWHERE (p.name, p.id) > ($1, $2)\nORDER BY p.name, p.id\nLIMIT $3",
+			wantArgs:  []interface{}{"chair", int64(9), int32(5)},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			query, args, err := buildFilterProductsQuery(tc.arg)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantQuery, query)
+			require.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
+
+func TestBuildFilterProductsQueryUnknownSort(t *testing.T) {
+	_, _, err := buildFilterProductsQuery(FilterProductsParams{Sort: "id; DROP TABLE product", Limit: 5})
+	require.Error(t, err)
+}
+
+func TestBuildCountFilteredProductsQuery(t *testing.T) {
+	query, args := buildCountFilteredProductsQuery(FilterProductsParams{
+		CategoryID: sql.NullInt64{Int64: 3, Valid: true},
+		InStock:    true,
+		Sort:       ProductSortPrice,
+		AfterID:    9,
+		AfterKey:   "15",
+		Limit:      5,
+	})
+	require.Equal(t, `SELECT count(*) FROM "product" AS p`+"\nWHERE p.category_id = $1\nAND "+inStockCondition, query)
+	require.Equal(t, []interface{}{int64(3)}, args)
+
+	query, args = buildCountFilteredProductsQuery(FilterProductsParams{Limit: 5})
+	require.Equal(t, `SELECT count(*) FROM "product" AS p`, query)
+	require.Empty(t, args)
+}
+
+// createFilterProduct creates a product of the category with the given price, state,
+// stock and discount
+func createFilterProduct(t *testing.T, category ProductCategory, price string, active bool, quantity int32, discounted bool) Product {
+	inventory := createRandomProductInventory(t)
+	_, err := testQueires.UpdateProductInventory(context.Background(), UpdateProductInventoryParams{
+		ID:       inventory.ID,
+		Quantity: quantity,
+		Active:   true,
+	})
+	require.NoError(t, err)
+
+	discount := createRandomDiscount(t)
+	_, err = testQueires.UpdateDiscount(context.Background(), UpdateDiscountParams{
+		ID:     discount.ID,
+		Active: discounted,
+	})
+	require.NoError(t, err)
+
+	product, err := testQueires.CreateProduct(context.Background(), CreateProductParams{
+		Name:        util.RandomString(8),
+		Description: util.RandomUser(),
+		Sku:         util.RandomString(8),
+		CategoryID:  category.ID,
+		InventoryID: inventory.ID,
+		Price:       price,
+		DiscountID:  discount.ID,
+	})
+	require.NoError(t, err)
+
+	product, err = testQueires.UpdateProduct(context.Background(), UpdateProductParams{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		Price:       product.Price,
+		Active:      active,
+	})
+	require.NoError(t, err)
+
+	return product
+}
+
+func TestFilterProducts(t *testing.T) {
+	category := createRandomProductCategory(t)
+	inCategory := sql.NullInt64{Int64: category.ID, Valid: true}
+
+	cheap := createFilterProduct(t, category, "10", true, 5, true)
+	soldOut := createFilterProduct(t, category, "20", true, 0, false)
+	inactive := createFilterProduct(t, category, "30", false, 5, true)
+	expensive := createFilterProduct(t, category, "40", true, 5, false)
+
+	byName := []Product{cheap, soldOut, inactive, expensive}
+	sort.Slice(byName, func(i, j int) bool { return byName[i].Name < byName[j].Name })
+
+	testCases := []struct {
+		name string
+		arg  FilterProductsParams
+		want []Product
+	}{
+		{
+			name: "Category",
+			arg:  FilterProductsParams{CategoryID: inCategory},
+			want: []Product{cheap, soldOut, inactive, expensive},
+		},
+		{
+			name: "MinPrice",
+			arg:  FilterProductsParams{CategoryID: inCategory, MinPrice: sql.NullString{String: "15", Valid: true}},
+			want: []Product{soldOut, inactive, expensive},
+		},
+		{
+			name: "MaxPrice",
+			arg:  FilterProductsParams{CategoryID: inCategory, MaxPrice: sql.NullString{String: "20", Valid: true}},
+			want: []Product{cheap, soldOut},
+		},
+		{
+			name: "PriceRange",
+			arg: FilterProductsParams{
+				CategoryID: inCategory,
+				MinPrice:   sql.NullString{String: "15.50", Valid: true},
+				MaxPrice:   sql.NullString{String: "35", Valid: true},
+			},
+			want: []Product{soldOut, inactive},
+		},
+		{
+			name: "Active",
+			arg:  FilterProductsParams{CategoryID: inCategory, Active: sql.NullBool{Bool: true, Valid: true}},
+			want: []Product{cheap, soldOut, expensive},
+		},
+		{
+			name: "Inactive",
+			arg:  FilterProductsParams{CategoryID: inCategory, Active: sql.NullBool{Bool: false, Valid: true}},
+			want: []Product{inactive},
+		},
+		{
+			name: "InStock",
+			arg:  FilterProductsParams{CategoryID: inCategory, InStock: true},
+			want: []Product{cheap, inactive, expensive},
+		},
+		{
+			name: "HasDiscount",
+			arg:  FilterProductsParams{CategoryID: inCategory, HasDiscount: true},
+			want: []Product{cheap, inactive},
+		},
+		{
+			name: "ActiveInStockWithDiscount",
+			arg: FilterProductsParams{
+				CategoryID:  inCategory,
+				Active:      sql.NullBool{Bool: true, Valid: true},
+				InStock:     true,
+				HasDiscount: true,
+			},
+			want: []Product{cheap},
+		},
+		{
+			name: "PriceRangeInStock",
+			arg: FilterProductsParams{
+				CategoryID: inCategory,
+				MinPrice:   sql.NullString{String: "15", Valid: true},
+				MaxPrice:   sql.NullString{String: "35", Valid: true},
+				InStock:    true,
+			},
+			want: []Product{inactive},
+		},
+		{
+			name: "SortByPrice",
+			arg:  FilterProductsParams{CategoryID: inCategory, Sort: ProductSortPrice},
+			want: []Product{cheap, soldOut, inactive, expensive},
+		},
+		{
+			name: "SortByPriceDesc",
+			arg:  FilterProductsParams{CategoryID: inCategory, Sort: ProductSortPriceDesc},
+			want: []Product{expensive, inactive, soldOut, cheap},
+		},
+		{
+			name: "SortByNewest",
+			arg:  FilterProductsParams{CategoryID: inCategory, Sort: ProductSortNewest},
+			want: []Product{expensive, inactive, soldOut, cheap},
+		},
+		{
+			name: "SortByName",
+			arg:  FilterProductsParams{CategoryID: inCategory, Sort: ProductSortName},
+			want: byName,
+		},
+		{
+			name: "Page",
+			arg: FilterProductsParams{
+				CategoryID: inCategory,
+				Sort:       ProductSortPrice,
+				AfterID:    soldOut.ID,
+				// the default variant of the product is sold at its price
+				AfterKey: soldOut.Price,
+				Limit:    2,
+			},
+			want: []Product{inactive, expensive},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.arg.Limit == 0 {
+				tc.arg.Limit = 10
+			}
+
+			products, err := testQueires.FilterProducts(context.Background(), tc.arg)
+			require.NoError(t, err)
+
+			ids := make([]int64, len(products))
+			for i, product := range products {
+				ids[i] = product.ID
+			}
+			wantIDs := make([]int64, len(tc.want))
+			for i, product := range tc.want {
+				wantIDs[i] = product.ID
+			}
+			require.Equal(t, wantIDs, ids)
+
+			if tc.arg.AfterID == 0 {
+				count, err := testQueires.CountFilteredProducts(context.Background(), tc.arg)
+				require.NoError(t, err)
+				require.Equal(t, int64(len(tc.want)), count)
+			}
+		})
+	}
+}
+
+func TestFilterProductsByVariantPrice(t *testing.T) {
+	store := NewStore(testDB)
+
+	category := createRandomProductCategory(t)
+	inCategory := sql.NullInt64{Int64: category.ID, Valid: true}
+
+	plain := createFilterProduct(t, category, "30", true, 5, false)
+	varied := createFilterProduct(t, category, "50", true, 5, false)
+
+	size, err := store.CreateProductOptionTx(context.Background(), CreateProductOptionTxParams{
+		ProductID: varied.ID,
+		Name:      "size",
+		Values:    []string{"S", "M"},
+	})
+	require.NoError(t, err)
+
+	small, err := store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+		ProductID:      varied.ID,
+		Sku:            util.RandomString(8),
+		Price:          sql.NullString{String: "12", Valid: true},
+		Quantity:       1,
+		OptionValueIDs: []int64{size.Values[0].ID},
+	})
+	require.NoError(t, err)
+
+	// the medium size is sold at the price of the product
+	_, err = store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+		ProductID:      varied.ID,
+		Sku:            util.RandomString(8),
+		Quantity:       1,
+		OptionValueIDs: []int64{size.Values[1].ID},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		arg  FilterProductsParams
+		want []Product
+	}{
+		{
+			name: "MaxPrice",
+			arg:  FilterProductsParams{CategoryID: inCategory, MaxPrice: sql.NullString{String: "20", Valid: true}},
+			want: []Product{varied},
+		},
+		{
+			name: "MinPrice",
+			arg:  FilterProductsParams{CategoryID: inCategory, MinPrice: sql.NullString{String: "40", Valid: true}},
+			want: []Product{varied},
+		},
+		{
+			name: "PriceRangeBetweenVariants",
+			arg: FilterProductsParams{
+				CategoryID: inCategory,
+				MinPrice:   sql.NullString{String: "13", Valid: true},
+				MaxPrice:   sql.NullString{String: "40", Valid: true},
+			},
+			want: []Product{plain},
+		},
+		{
+			name: "SortByPrice",
+			arg:  FilterProductsParams{CategoryID: inCategory, Sort: ProductSortPrice},
+			want: []Product{varied, plain},
+		},
+		{
+			name: "SortByPriceDesc",
+			arg:  FilterProductsParams{CategoryID: inCategory, Sort: ProductSortPriceDesc},
+			want: []Product{varied, plain},
+		},
+		{
+			name: "PageByPrice",
+			arg: FilterProductsParams{
+				CategoryID: inCategory,
+				Sort:       ProductSortPrice,
+				AfterID:    varied.ID,
+				AfterKey:   "12",
+				Limit:      10,
+			},
+			want: []Product{plain},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.arg.Limit == 0 {
+				tc.arg.Limit = 10
+			}
+
+			products, err := testQueires.FilterProducts(context.Background(), tc.arg)
+			require.NoError(t, err)
+
+			ids := make([]int64, len(products))
+			for i, product := range products {
+				ids[i] = product.ID
+			}
+			wantIDs := make([]int64, len(tc.want))
+			for i, product := range tc.want {
+				wantIDs[i] = product.ID
+			}
+			require.Equal(t, wantIDs, ids)
+		})
+	}
+
+	products, err := testQueires.FilterProducts(context.Background(), FilterProductsParams{
+		CategoryID: inCategory,
+		Sort:       ProductSortPrice,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	require.Equal(t, "12", products[0].MinPrice)
+	require.Equal(t, varied.Price, products[0].MaxPrice)
+	require.Equal(t, "12", ProductSortPrice.Key(products[0]))
+	require.Equal(t, varied.Price, ProductSortPriceDesc.Key(products[0]))
+
+	// an inactive variant is no longer sold at its price
+	_, err = store.UpdateProductVariant(context.Background(), UpdateProductVariantParams{
+		ID:        small.Variant.ID,
+		ProductID: varied.ID,
+		Active:    false,
+	})
+	require.NoError(t, err)
+
+	products, err = testQueires.FilterProducts(context.Background(), FilterProductsParams{
+		CategoryID: inCategory,
+		MaxPrice:   sql.NullString{String: "20", Valid: true},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, products)
+}
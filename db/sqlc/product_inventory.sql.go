@@ -7,6 +7,17 @@ import (
 	"context"
 )
 
+const countProductInventories = `-- name: CountProductInventories :one
+SELECT count(*) FROM "product_inventory"
+`
+
+func (q *Queries) CountProductInventories(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProductInventories)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProductInventory = `-- name: CreateProductInventory :one
 INSERT INTO "product_inventory" (
   quantity
@@ -78,18 +89,18 @@ func (q *Queries) GetProductInventoryForUpdate(ctx context.Context, id int64) (P
 
 const listProductInventories = `-- name: ListProductInventories :many
 SELECT id, quantity, active, created_at, updated_at FROM "product_inventory"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListProductInventoriesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListProductInventories(ctx context.Context, arg ListProductInventoriesParams) ([]ProductInventory, error) {
-	rows, err := q.db.QueryContext(ctx, listProductInventories, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listProductInventories, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -103,12 +103,13 @@ func TestDeleteProductInventory(t *testing.T) {
 }
 
 func TestListProductInventories(t *testing.T) {
+	first := createRandomProductInventory(t)
 	for i := 0; i < 10; i++ {
 		createRandomProductInventory(t)
 	}
 	arg := ListProductInventoriesParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	productInventories, err := testQueires.ListProductInventories(context.Background(), arg)
@@ -117,6 +118,11 @@ func TestListProductInventories(t *testing.T) {
 
 	for _, productInventory := range productInventories {
 		require.NotEmpty(t, productInventory)
+		require.Greater(t, productInventory.ID, first.ID)
 
 	}
+
+	count, err := testQueires.CountProductInventories(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
 }
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomProductOption(t *testing.T, product Product, position int32) ProductOption {
+	arg := CreateProductOptionParams{
+		ProductID: product.ID,
+		Name:      util.RandomString(6),
+		Position:  position,
+	}
+
+	productOption, err := testQueires.CreateProductOption(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, productOption)
+
+	require.Equal(t, arg.ProductID, productOption.ProductID)
+	require.Equal(t, arg.Name, productOption.Name)
+	require.Equal(t, arg.Position, productOption.Position)
+
+	require.NotEmpty(t, productOption.ID)
+	require.WithinDuration(t, time.Now(), productOption.CreatedAt, time.Minute)
+
+	return productOption
+}
+
+func createRandomProductOptionValue(t *testing.T, productOption ProductOption, position int32) ProductOptionValue {
+	arg := CreateProductOptionValueParams{
+		OptionID: productOption.ID,
+		Value:    util.RandomString(4),
+		Position: position,
+	}
+
+	productOptionValue, err := testQueires.CreateProductOptionValue(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, productOptionValue)
+
+	require.Equal(t, arg.OptionID, productOptionValue.OptionID)
+	require.Equal(t, arg.Value, productOptionValue.Value)
+	require.Equal(t, arg.Position, productOptionValue.Position)
+
+	require.NotEmpty(t, productOptionValue.ID)
+
+	return productOptionValue
+}
+
+func TestCreateProductOption(t *testing.T) {
+	createRandomProductOption(t, createRandomProduct(t), 0)
+}
+
+func TestCreateDuplicateProductOption(t *testing.T) {
+	productOption := createRandomProductOption(t, createRandomProduct(t), 0)
+
+	_, err := testQueires.CreateProductOption(context.Background(), CreateProductOptionParams{
+		ProductID: productOption.ProductID,
+		Name:      productOption.Name,
+		Position:  1,
+	})
+	require.Error(t, err)
+
+	// another product can have an option of the same name
+	_, err = testQueires.CreateProductOption(context.Background(), CreateProductOptionParams{
+		ProductID: createRandomProduct(t).ID,
+		Name:      productOption.Name,
+	})
+	require.NoError(t, err)
+}
+
+func TestListProductOptionsByProductID(t *testing.T) {
+	product := createRandomProduct(t)
+	second := createRandomProductOption(t, product, 1)
+	first := createRandomProductOption(t, product, 0)
+
+	productOptions, err := testQueires.ListProductOptionsByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, productOptions, 2)
+	require.Equal(t, first.ID, productOptions[0].ID)
+	require.Equal(t, second.ID, productOptions[1].ID)
+}
+
+func TestCreateDuplicateProductOptionValue(t *testing.T) {
+	productOptionValue := createRandomProductOptionValue(t, createRandomProductOption(t, createRandomProduct(t), 0), 0)
+
+	_, err := testQueires.CreateProductOptionValue(context.Background(), CreateProductOptionValueParams{
+		OptionID: productOptionValue.OptionID,
+		Value:    productOptionValue.Value,
+		Position: 1,
+	})
+	require.Error(t, err)
+}
+
+func TestListProductOptionValuesByProductID(t *testing.T) {
+	product := createRandomProduct(t)
+	size := createRandomProductOption(t, product, 0)
+	colour := createRandomProductOption(t, product, 1)
+
+	red := createRandomProductOptionValue(t, colour, 0)
+	large := createRandomProductOptionValue(t, size, 1)
+	small := createRandomProductOptionValue(t, size, 0)
+
+	// the values of another product are left out
+	createRandomProductOptionValue(t, createRandomProductOption(t, createRandomProduct(t), 0), 0)
+
+	productOptionValues, err := testQueires.ListProductOptionValuesByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, productOptionValues, 3)
+	require.Equal(t, small.ID, productOptionValues[0].ID)
+	require.Equal(t, large.ID, productOptionValues[1].ID)
+	require.Equal(t, red.ID, productOptionValues[2].ID)
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxSearchTerms bounds the number of words a product search is made of
+const maxSearchTerms = 8
+
+// ProductSearchQuery turns the words typed by a user into a tsquery matching the
+// products that hold all of them. Every word is matched as a prefix, so that the
+// results show up while the user is still typing. Anything but letters and digits
+// separates words, which keeps the tsquery operators out of the user input.
+// An empty string is returned when there is nothing to search for.
+func ProductSearchQuery(search string) string {
+	terms := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductSearchQuery(t *testing.T) {
+	require.Equal(t, "red:* & shoe:*", ProductSearchQuery("Red shoe"))
+	require.Equal(t, "abc:* & 123:*", ProductSearchQuery("  ABC-123 "))
+	require.Equal(t, "a:* & b:* & c:*", ProductSearchQuery("a & !b | (c:*)"))
+	require.Equal(t, "حذاء:*", ProductSearchQuery("حذاء"))
+	require.Empty(t, ProductSearchQuery(" &|!():* "))
+	require.Len(t, ProductSearchQuery("a b c d e f g h i j"), len("a:* & ")*maxSearchTerms-len(" & "))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +45,23 @@ func createRandomProduct(t *testing.T) Product {
 
 	return product
 }
+
+// createActiveProduct creates a product that is on sale
+func createActiveProduct(t *testing.T) Product {
+	product := createRandomProduct(t)
+
+	product, err := testQueires.UpdateProduct(context.Background(), UpdateProductParams{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		Price:       product.Price,
+		Active:      true,
+	})
+	require.NoError(t, err)
+
+	return product
+}
 func TestCreateProduct(t *testing.T) {
 	createRandomProduct(t)
 }
@@ -117,12 +135,13 @@ func TestDeleteProduct(t *testing.T) {
 }
 
 func TestListProducts(t *testing.T) {
+	first := createRandomProduct(t)
 	for i := 0; i < 10; i++ {
 		createRandomProduct(t)
 	}
 	arg := ListProductsParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	products, err := testQueires.ListProducts(context.Background(), arg)
@@ -132,6 +151,118 @@ func TestListProducts(t *testing.T) {
 
 	for _, product := range products {
 		require.NotEmpty(t, product)
+		require.Greater(t, product.ID, first.ID)
 	}
 
 }
+
+// searchProduct returns the search result of the product, if it was found by the search
+func searchProduct(t *testing.T, search string, productID int64) (SearchProductsRow, bool) {
+	results, err := testQueires.SearchProducts(context.Background(), SearchProductsParams{
+		Query: ProductSearchQuery(search),
+		Limit: 100,
+	})
+	require.NoError(t, err)
+
+	for _, result := range results {
+		if result.ID == productID {
+			return result, true
+		}
+	}
+	return SearchProductsRow{}, false
+}
+
+func TestSearchProducts(t *testing.T) {
+	product := createActiveProduct(t)
+
+	// the beginning of a word is enough to find the product
+	result, ok := searchProduct(t, product.Name[:4], product.ID)
+	require.True(t, ok)
+	require.Equal(t, product.Name, result.Name)
+	require.Positive(t, result.Rank)
+	require.Contains(t, result.NameHighlight, "<b>")
+
+	result, ok = searchProduct(t, strings.ToUpper(product.Sku), product.ID)
+	require.True(t, ok)
+	require.Equal(t, product.Sku, result.Sku)
+
+	_, ok = searchProduct(t, product.Name+" "+util.RandomString(12), product.ID)
+	require.False(t, ok)
+}
+
+func TestSearchProductsInactive(t *testing.T) {
+	product := createRandomProduct(t)
+	require.False(t, product.Active)
+
+	_, ok := searchProduct(t, product.Name, product.ID)
+	require.False(t, ok)
+
+	count, err := testQueires.CountSearchProducts(context.Background(), ProductSearchQuery(product.Name))
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestSearchProductsAfterUpdate(t *testing.T) {
+	product := createRandomProduct(t)
+	description := util.RandomString(10)
+
+	_, err := testQueires.UpdateProduct(context.Background(), UpdateProductParams{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: "made of " + description,
+		CategoryID:  product.CategoryID,
+		Price:       product.Price,
+		Active:      true,
+	})
+	require.NoError(t, err)
+
+	result, ok := searchProduct(t, description, product.ID)
+	require.True(t, ok)
+	require.Contains(t, result.DescriptionSnippet, "<b>"+description+"</b>")
+
+	_, ok = searchProduct(t, product.Description, product.ID)
+	require.False(t, ok)
+}
+
+func TestSearchProductsPages(t *testing.T) {
+	word := util.RandomString(12)
+	for i := 0; i < 3; i++ {
+		product := createRandomProduct(t)
+		_, err := testQueires.UpdateProduct(context.Background(), UpdateProductParams{
+			ID:          product.ID,
+			Name:        word + " " + product.Name,
+			Description: product.Description,
+			CategoryID:  product.CategoryID,
+			Price:       product.Price,
+			Active:      true,
+		})
+		require.NoError(t, err)
+	}
+	query := ProductSearchQuery(word)
+
+	count, err := testQueires.CountSearchProducts(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	firstPage, err := testQueires.SearchProducts(context.Background(), SearchProductsParams{
+		Query: query,
+		Limit: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+
+	last := firstPage[len(firstPage)-1]
+	nextPage, err := testQueires.SearchProducts(context.Background(), SearchProductsParams{
+		Query:     query,
+		AfterRank: sql.NullFloat64{Float64: float64(last.Rank), Valid: true},
+		AfterID:   last.ID,
+		Limit:     2,
+	})
+	require.NoError(t, err)
+	require.Len(t, nextPage, 1)
+
+	for _, result := range firstPage {
+		require.NotEqual(t, result.ID, nextPage[0].ID)
+	}
+	require.LessOrEqual(t, nextPage[0].Rank, last.Rank)
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProductOptionValues is an option of a product, such as its size, along with
+// the values it can take
+type ProductOptionValues struct {
+	Option ProductOption        `json:"option"`
+	Values []ProductOptionValue `json:"values"`
+}
+
+// ProductVariantOptions is a variant of a product along with the option values
+// it is made of, one for every option of the product
+type ProductVariantOptions struct {
+	Variant        ListProductVariantsByProductIDRow `json:"variant"`
+	OptionValueIDs []int64                           `json:"option_value_ids"`
+}
+
+// ProductVariantMatrix lists the options of a product and the variants it is sold in
+type ProductVariantMatrix struct {
+	Options  []ProductOptionValues   `json:"options"`
+	Variants []ProductVariantOptions `json:"variants"`
+}
+
+// newProductVariantMatrix groups the option values by option and the variant
+// option values by variant, keeping the order the rows were listed in
+func newProductVariantMatrix(
+	options []ProductOption,
+	values []ProductOptionValue,
+	variants []ListProductVariantsByProductIDRow,
+	variantValues []ProductVariantOptionValue,
+) ProductVariantMatrix {
+	matrix := ProductVariantMatrix{
+		Options:  make([]ProductOptionValues, len(options)),
+		Variants: make([]ProductVariantOptions, len(variants)),
+	}
+
+	optionIndex := make(map[int64]int, len(options))
+	for i, option := range options {
+		optionIndex[option.ID] = i
+		matrix.Options[i] = ProductOptionValues{Option: option, Values: []ProductOptionValue{}}
+	}
+	for _, value := range values {
+		if i, ok := optionIndex[value.OptionID]; ok {
+			matrix.Options[i].Values = append(matrix.Options[i].Values, value)
+		}
+	}
+
+	variantIndex := make(map[int64]int, len(variants))
+	for i, variant := range variants {
+		variantIndex[variant.ID] = i
+		matrix.Variants[i] = ProductVariantOptions{Variant: variant, OptionValueIDs: []int64{}}
+	}
+	for _, variantValue := range variantValues {
+		if i, ok := variantIndex[variantValue.VariantID]; ok {
+			matrix.Variants[i].OptionValueIDs = append(matrix.Variants[i].OptionValueIDs, variantValue.OptionValueID)
+		}
+	}
+
+	return matrix
+}
+
+// variantOptionsKey identifies the combination of option values a variant is
+// made of, whatever the order they are given in
+func variantOptionsKey(optionValueIDs []int64) string {
+	ids := make([]int64, len(optionValueIDs))
+	copy(ids, optionValueIDs)
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(keys, ",")
+}
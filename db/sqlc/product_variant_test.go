@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultProductVariant returns the variant created along with the product
+func defaultProductVariant(t *testing.T, product Product) ProductVariant {
+	variants, err := testQueires.ListProductVariantsByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, variants)
+
+	variant, err := testQueires.GetProductVariant(context.Background(), variants[0].ID)
+	require.NoError(t, err)
+
+	return variant
+}
+
+func createRandomProductVariant(t *testing.T, product Product) ProductVariant {
+	productInventory := createRandomProductInventory(t)
+	arg := CreateProductVariantParams{
+		ProductID:   product.ID,
+		Sku:         util.RandomString(8),
+		Price:       sql.NullString{String: util.RandomDecimal(1, 100), Valid: true},
+		InventoryID: productInventory.ID,
+	}
+
+	productVariant, err := testQueires.CreateProductVariant(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, productVariant)
+
+	require.Equal(t, arg.ProductID, productVariant.ProductID)
+	require.Equal(t, arg.Sku, productVariant.Sku)
+	require.Equal(t, arg.Price, productVariant.Price)
+	require.Equal(t, arg.InventoryID, productVariant.InventoryID)
+	require.True(t, productVariant.Active)
+
+	require.NotEmpty(t, productVariant.ID)
+	require.NotEmpty(t, productVariant.CreatedAt)
+	require.True(t, productVariant.UpdatedAt.IsZero())
+
+	return productVariant
+}
+
+func TestDefaultProductVariant(t *testing.T) {
+	product := createRandomProduct(t)
+
+	variants, err := testQueires.ListProductVariantsByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+
+	// the variant shares the sku and inventory of its product, and its price
+	require.Equal(t, product.ID, variants[0].ProductID)
+	require.Equal(t, product.Sku, variants[0].Sku)
+	require.Equal(t, product.InventoryID, variants[0].InventoryID)
+	require.False(t, variants[0].Price.Valid)
+	require.True(t, variants[0].Active)
+	require.True(t, variants[0].InStock)
+}
+
+func TestCreateProductVariant(t *testing.T) {
+	createRandomProductVariant(t, createRandomProduct(t))
+}
+
+func TestGetProductVariant(t *testing.T) {
+	productVariant1 := createRandomProductVariant(t, createRandomProduct(t))
+
+	productVariant2, err := testQueires.GetProductVariant(context.Background(), productVariant1.ID)
+	require.NoError(t, err)
+	require.Equal(t, productVariant1, productVariant2)
+}
+
+func TestUpdateProductVariant(t *testing.T) {
+	productVariant1 := createRandomProductVariant(t, createRandomProduct(t))
+
+	arg := UpdateProductVariantParams{
+		ID:        productVariant1.ID,
+		ProductID: productVariant1.ProductID,
+		Active:    false,
+	}
+	productVariant2, err := testQueires.UpdateProductVariant(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, productVariant1.ID, productVariant2.ID)
+	require.Equal(t, productVariant1.Sku, productVariant2.Sku)
+	require.False(t, productVariant2.Price.Valid)
+	require.False(t, productVariant2.Active)
+	require.False(t, productVariant2.UpdatedAt.IsZero())
+
+	// a variant is only updated through its own product
+	arg.ProductID = createRandomProduct(t).ID
+	_, err = testQueires.UpdateProductVariant(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestListProductVariantsByProductID(t *testing.T) {
+	product := createRandomProduct(t)
+	productVariant := createRandomProductVariant(t, product)
+
+	_, err := testQueires.UpdateProductInventory(context.Background(), UpdateProductInventoryParams{
+		ID:       productVariant.InventoryID,
+		Quantity: 0,
+		Active:   true,
+	})
+	require.NoError(t, err)
+
+	variants, err := testQueires.ListProductVariantsByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, variants, 2)
+	require.Equal(t, product.InventoryID, variants[0].InventoryID)
+	require.True(t, variants[0].InStock)
+	require.Equal(t, productVariant.ID, variants[1].ID)
+	require.False(t, variants[1].InStock)
+}
+
+func TestGetProductVariantName(t *testing.T) {
+	product := createRandomProduct(t)
+	colour := createRandomProductOption(t, product, 1)
+	size := createRandomProductOption(t, product, 0)
+	red := createRandomProductOptionValue(t, colour, 0)
+	small := createRandomProductOptionValue(t, size, 0)
+
+	productVariant := createRandomProductVariant(t, product)
+	for _, value := range []ProductOptionValue{red, small} {
+		_, err := testQueires.CreateProductVariantOptionValue(context.Background(), CreateProductVariantOptionValueParams{
+			VariantID:     productVariant.ID,
+			OptionID:      value.OptionID,
+			OptionValueID: value.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	// the values follow the order of the options
+	variantName, err := testQueires.GetProductVariantName(context.Background(), productVariant.ID)
+	require.NoError(t, err)
+	require.Equal(t, small.Value+" / "+red.Value, variantName)
+
+	variantName, err = testQueires.GetProductVariantName(context.Background(), defaultProductVariant(t, product).ID)
+	require.NoError(t, err)
+	require.Empty(t, variantName)
+}
+
+func TestCreateProductVariantOptionValueOfAnotherOption(t *testing.T) {
+	product := createRandomProduct(t)
+	size := createRandomProductOption(t, product, 0)
+	colour := createRandomProductOption(t, product, 1)
+	red := createRandomProductOptionValue(t, colour, 0)
+
+	_, err := testQueires.CreateProductVariantOptionValue(context.Background(), CreateProductVariantOptionValueParams{
+		VariantID:     createRandomProductVariant(t, product).ID,
+		OptionID:      size.ID,
+		OptionValueID: red.ID,
+	})
+	require.Error(t, err)
+}
+
+func TestVariantOptionsKey(t *testing.T) {
+	require.Equal(t, "", variantOptionsKey(nil))
+	require.Equal(t, "3,12", variantOptionsKey([]int64{12, 3}))
+	require.Equal(t, variantOptionsKey([]int64{3, 12}), variantOptionsKey([]int64{12, 3}))
+
+	ids := []int64{2, 1}
+	variantOptionsKey(ids)
+	require.Equal(t, []int64{2, 1}, ids)
+}
+
+func TestNewProductVariantMatrix(t *testing.T) {
+	options := []ProductOption{{ID: 1, Name: "size"}, {ID: 2, Name: "colour"}}
+	values := []ProductOptionValue{
+		{ID: 10, OptionID: 1, Value: "S"},
+		{ID: 11, OptionID: 1, Value: "M"},
+		{ID: 20, OptionID: 2, Value: "red"},
+	}
+	variants := []ListProductVariantsByProductIDRow{{ID: 100}, {ID: 101}, {ID: 102}}
+	variantValues := []ProductVariantOptionValue{
+		{VariantID: 101, OptionID: 1, OptionValueID: 10},
+		{VariantID: 101, OptionID: 2, OptionValueID: 20},
+		{VariantID: 102, OptionID: 1, OptionValueID: 11},
+		{VariantID: 102, OptionID: 2, OptionValueID: 20},
+	}
+
+	matrix := newProductVariantMatrix(options, values, variants, variantValues)
+
+	require.Len(t, matrix.Options, 2)
+	require.Equal(t, options[0], matrix.Options[0].Option)
+	require.Equal(t, values[:2], matrix.Options[0].Values)
+	require.Equal(t, options[1], matrix.Options[1].Option)
+	require.Equal(t, values[2:], matrix.Options[1].Values)
+
+	require.Len(t, matrix.Variants, 3)
+	require.Equal(t, int64(100), matrix.Variants[0].Variant.ID)
+	require.Empty(t, matrix.Variants[0].OptionValueIDs)
+	require.NotNil(t, matrix.Variants[0].OptionValueIDs)
+	require.Equal(t, []int64{10, 20}, matrix.Variants[1].OptionValueIDs)
+	require.Equal(t, []int64{11, 20}, matrix.Variants[2].OptionValueIDs)
+}
@@ -4,77 +4,189 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	BlockAdminSessions(ctx context.Context, adminID int64) error
+	BlockAllUserSessions(ctx context.Context, userID int64) error
+	BlockOtherUserSessions(ctx context.Context, arg BlockOtherUserSessionsParams) error
+	BlockUserSession(ctx context.Context, arg BlockUserSessionParams) (UserSession, error)
+	BlockUserSessionFamily(ctx context.Context, arg BlockUserSessionFamilyParams) error
+	CheckAdminSession(ctx context.Context, arg CheckAdminSessionParams) (CheckAdminSessionRow, error)
+	CheckUserSession(ctx context.Context, arg CheckUserSessionParams) (bool, error)
+	ClaimGuestShoppingSession(ctx context.Context, arg ClaimGuestShoppingSessionParams) (ShoppingSession, error)
+	ConfirmAdminTOTP(ctx context.Context, adminID int64) (AdminTotp, error)
+	ConfirmUserTOTP(ctx context.Context, userID int64) (UserTotp, error)
+	CountAPIKeys(ctx context.Context) (int64, error)
+	CountAdminRecoveryCodes(ctx context.Context, adminID int64) (int64, error)
+	CountAdminTypes(ctx context.Context) (int64, error)
+	CountAdmins(ctx context.Context) (int64, error)
+	CountDiscounts(ctx context.Context) (int64, error)
+	CountLockedUsers(ctx context.Context) (int64, error)
+	CountOrderDetails(ctx context.Context, userID int64) (int64, error)
+	CountOrderDetailsOfAllUsers(ctx context.Context) (int64, error)
+	CountOrderItems(ctx context.Context, userID int64) (int64, error)
+	CountPaymentDetails(ctx context.Context, userID int64) (int64, error)
+	CountProductCategories(ctx context.Context) (int64, error)
+	CountProductInventories(ctx context.Context) (int64, error)
+	CountSearchAdmins(ctx context.Context, search string) (int64, error)
+	CountSearchProducts(ctx context.Context, query string) (int64, error)
+	CountUserAddresses(ctx context.Context, userID int64) (int64, error)
+	CountUserPayments(ctx context.Context, userID int64) (int64, error)
+	CountUserRecoveryCodes(ctx context.Context, userID int64) (int64, error)
+	CountUsers(ctx context.Context) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateAPIKeyPermission(ctx context.Context, arg CreateAPIKeyPermissionParams) (ApiKeyPermission, error)
 	CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin, error)
+	CreateAdminRecoveryCode(ctx context.Context, arg CreateAdminRecoveryCodeParams) (AdminRecoveryCode, error)
+	CreateAdminSession(ctx context.Context, arg CreateAdminSessionParams) (AdminSession, error)
+	CreateAdminTOTP(ctx context.Context, arg CreateAdminTOTPParams) (AdminTotp, error)
 	CreateAdminType(ctx context.Context, adminType string) (AdminType, error)
+	CreateAdminTypePermission(ctx context.Context, arg CreateAdminTypePermissionParams) (AdminTypePermission, error)
 	CreateCartItem(ctx context.Context, arg CreateCartItemParams) (CartItem, error)
 	CreateDiscount(ctx context.Context, arg CreateDiscountParams) (Discount, error)
+	CreateEmailChangeToken(ctx context.Context, arg CreateEmailChangeTokenParams) (EmailChangeToken, error)
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error)
+	CreateGuestShoppingSession(ctx context.Context, arg CreateGuestShoppingSessionParams) (ShoppingSession, error)
 	CreateOrderDetailAndPaymentDetail(ctx context.Context, arg CreateOrderDetailAndPaymentDetailParams) (OrderDetail, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
 	CreatePaymentDetail(ctx context.Context, arg CreatePaymentDetailParams) (PaymentDetail, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
 	CreateProductCategory(ctx context.Context, arg CreateProductCategoryParams) (ProductCategory, error)
 	CreateProductInventory(ctx context.Context, quantity int32) (ProductInventory, error)
+	CreateProductOption(ctx context.Context, arg CreateProductOptionParams) (ProductOption, error)
+	CreateProductOptionValue(ctx context.Context, arg CreateProductOptionValueParams) (ProductOptionValue, error)
+	CreateProductVariant(ctx context.Context, arg CreateProductVariantParams) (ProductVariant, error)
+	CreateProductVariantOptionValue(ctx context.Context, arg CreateProductVariantOptionValueParams) (ProductVariantOptionValue, error)
 	CreateShoppingSession(ctx context.Context, arg CreateShoppingSessionParams) (ShoppingSession, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateUserAddress(ctx context.Context, arg CreateUserAddressParams) (UserAddress, error)
 	CreateUserPayment(ctx context.Context, arg CreateUserPaymentParams) (UserPayment, error)
+	CreateUserRecoveryCode(ctx context.Context, arg CreateUserRecoveryCodeParams) (UserRecoveryCode, error)
 	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
+	CreateUserTOTP(ctx context.Context, arg CreateUserTOTPParams) (UserTotp, error)
+	DeactivateDefaultProductVariant(ctx context.Context, productID int64) error
+	DeleteAPIKey(ctx context.Context, id int64) error
 	DeleteAdmin(ctx context.Context, id int64) error
+	DeleteAdminRecoveryCodes(ctx context.Context, adminID int64) error
+	DeleteAdminTOTP(ctx context.Context, adminID int64) error
 	DeleteAdminTypeByID(ctx context.Context, id int64) error
 	DeleteAdminTypeByType(ctx context.Context, adminType string) error
+	DeleteAdminTypePermissions(ctx context.Context, adminTypeID int64) error
 	DeleteCartItem(ctx context.Context, id int64) error
+	DeleteCartItemsByUserID(ctx context.Context, userID int64) error
 	DeleteDiscount(ctx context.Context, id int64) error
+	DeleteEmailChangeTokens(ctx context.Context, userID int64) error
+	DeleteEmailVerificationTokens(ctx context.Context, userID int64) error
+	DeleteExpiredGuestShoppingSessions(ctx context.Context, createdBefore time.Time) error
+	DeleteExpiredStockReservations(ctx context.Context) error
 	DeleteOrderDetail(ctx context.Context, id int64) error
 	DeleteOrderItem(ctx context.Context, id int64) error
+	DeletePasswordResetTokens(ctx context.Context, userID int64) error
 	DeletePaymentDetail(ctx context.Context, id int64) error
 	DeleteProduct(ctx context.Context, id int64) error
 	DeleteProductCategory(ctx context.Context, id int64) error
 	DeleteProductInventory(ctx context.Context, id int64) error
 	DeleteShoppingSession(ctx context.Context, id int64) error
+	DeleteShoppingSessionsByUserID(ctx context.Context, userID int64) error
+	DeleteStockReservationsBySessionID(ctx context.Context, sessionID int64) error
 	DeleteUser(ctx context.Context, id int64) error
 	DeleteUserAddress(ctx context.Context, id int64) error
+	DeleteUserAddresses(ctx context.Context, userID int64) error
 	DeleteUserPayment(ctx context.Context, arg DeleteUserPaymentParams) error
+	DeleteUserPayments(ctx context.Context, userID int64) error
+	DeleteUserRecoveryCodes(ctx context.Context, userID int64) error
+	DeleteUserSessions(ctx context.Context, userID int64) error
+	DeleteUserTOTP(ctx context.Context, userID int64) error
+	EraseUser(ctx context.Context, id int64) (User, error)
+	GetAPIKey(ctx context.Context, id int64) (ApiKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	GetAdmin(ctx context.Context, id int64) (Admin, error)
 	GetAdminByEmail(ctx context.Context, email string) (Admin, error)
+	GetAdminSession(ctx context.Context, id uuid.UUID) (AdminSession, error)
+	GetAdminTOTP(ctx context.Context, adminID int64) (AdminTotp, error)
 	GetAdminType(ctx context.Context, id int64) (AdminType, error)
 	GetCartItemByID(ctx context.Context, id int64) (CartItem, error)
 	GetCartItemBySessionID(ctx context.Context, sessionID int64) (CartItem, error)
 	GetDiscount(ctx context.Context, id int64) (Discount, error)
+	GetLastEmailVerificationToken(ctx context.Context, userID int64) (EmailVerificationToken, error)
 	GetOrderDetail(ctx context.Context, id int64) (OrderDetail, error)
+	GetOrderDetailForUpdate(ctx context.Context, id int64) (OrderDetail, error)
 	GetOrderItem(ctx context.Context, arg GetOrderItemParams) (OrderItem, error)
 	GetPaymentDetail(ctx context.Context, arg GetPaymentDetailParams) (PaymentDetail, error)
+	GetPaymentDetailStatus(ctx context.Context, id int64) (string, error)
 	GetProduct(ctx context.Context, id int64) (Product, error)
 	GetProductCategory(ctx context.Context, id int64) (ProductCategory, error)
+	GetProductForUpdate(ctx context.Context, id int64) (Product, error)
 	GetProductInventory(ctx context.Context, id int64) (ProductInventory, error)
 	GetProductInventoryForUpdate(ctx context.Context, id int64) (ProductInventory, error)
+	GetProductVariant(ctx context.Context, id int64) (ProductVariant, error)
+	GetProductVariantName(ctx context.Context, variantID int64) (string, error)
+	GetReservedQuantity(ctx context.Context, arg GetReservedQuantityParams) (int32, error)
 	GetShoppingSession(ctx context.Context, id int64) (ShoppingSession, error)
+	GetShoppingSessionByGuestTokenHash(ctx context.Context, guestTokenHash string) (ShoppingSession, error)
+	GetShoppingSessionByUserID(ctx context.Context, userID int64) (ShoppingSession, error)
 	GetUser(ctx context.Context, id int64) (User, error)
 	GetUserAddress(ctx context.Context, arg GetUserAddressParams) (UserAddress, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 	GetUserPayment(ctx context.Context, arg GetUserPaymentParams) (UserPayment, error)
 	GetUserSession(ctx context.Context, id uuid.UUID) (UserSession, error)
+	GetUserTOTP(ctx context.Context, userID int64) (UserTotp, error)
+	InvalidateEmailChangeTokens(ctx context.Context, userID int64) error
+	InvalidateEmailVerificationTokens(ctx context.Context, userID int64) error
+	InvalidatePasswordResetTokens(ctx context.Context, userID int64) error
+	ListAPIKeyPermissions(ctx context.Context, apiKeyID int64) ([]string, error)
+	ListAPIKeys(ctx context.Context, arg ListAPIKeysParams) ([]ApiKey, error)
+	ListActiveUserSessions(ctx context.Context, userID int64) ([]UserSession, error)
+	ListAdminTypePermissions(ctx context.Context, adminTypeID int64) ([]string, error)
 	ListAdminTypes(ctx context.Context, arg ListAdminTypesParams) ([]AdminType, error)
 	ListAdmins(ctx context.Context, arg ListAdminsParams) ([]Admin, error)
+	ListAllOrderDetails(ctx context.Context, userID int64) ([]OrderDetail, error)
+	ListAllUserAddresses(ctx context.Context, userID int64) ([]UserAddress, error)
+	ListAllUserPayments(ctx context.Context, userID int64) ([]UserPayment, error)
+	ListAllUserSessions(ctx context.Context, userID int64) ([]UserSession, error)
 	ListCartItem(ctx context.Context, arg ListCartItemParams) ([]CartItem, error)
+	ListCartItemsBySessionID(ctx context.Context, sessionID int64) ([]CartItem, error)
 	ListDiscounts(ctx context.Context, arg ListDiscountsParams) ([]Discount, error)
+	ListLockedUsers(ctx context.Context, arg ListLockedUsersParams) ([]User, error)
 	ListOrderDetails(ctx context.Context, arg ListOrderDetailsParams) ([]OrderDetail, error)
+	ListOrderDetailsOfAllUsers(ctx context.Context, arg ListOrderDetailsOfAllUsersParams) ([]OrderDetail, error)
 	ListOrderItems(ctx context.Context, arg ListOrderItemsParams) ([]OrderItem, error)
+	ListOrderItemsByOrderID(ctx context.Context, orderID int64) ([]OrderItem, error)
 	ListPaymentDetails(ctx context.Context, arg ListPaymentDetailsParams) ([]ListPaymentDetailsRow, error)
 	ListProductCategories(ctx context.Context, arg ListProductCategoriesParams) ([]ProductCategory, error)
 	ListProductInventories(ctx context.Context, arg ListProductInventoriesParams) ([]ProductInventory, error)
+	ListProductOptionValuesByProductID(ctx context.Context, productID int64) ([]ProductOptionValue, error)
+	ListProductOptionsByProductID(ctx context.Context, productID int64) ([]ProductOption, error)
+	ListProductVariantOptionValuesByProductID(ctx context.Context, productID int64) ([]ProductVariantOptionValue, error)
+	ListProductVariantsByProductID(ctx context.Context, productID int64) ([]ListProductVariantsByProductIDRow, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListShoppingSessions(ctx context.Context, arg ListShoppingSessionsParams) ([]ShoppingSession, error)
+	ListStockReservationsBySessionID(ctx context.Context, sessionID int64) ([]StockReservation, error)
 	ListUserAddresses(ctx context.Context, arg ListUserAddressesParams) ([]UserAddress, error)
 	ListUserPayments(ctx context.Context, arg ListUserPaymentsParams) ([]UserPayment, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	LockAdmin(ctx context.Context, arg LockAdminParams) (Admin, error)
+	LockShoppingSessionByGuestTokenHash(ctx context.Context, guestTokenHash string) (ShoppingSession, error)
+	LockUser(ctx context.Context, arg LockUserParams) (User, error)
+	RecordFailedAdminLogin(ctx context.Context, id int64) (Admin, error)
+	RecordFailedUserLogin(ctx context.Context, id int64) (User, error)
+	ResetFailedAdminLogins(ctx context.Context, id int64) (Admin, error)
+	ResetFailedUserLogins(ctx context.Context, id int64) (User, error)
+	RotateUserSession(ctx context.Context, id uuid.UUID) (UserSession, error)
+	SearchAdmins(ctx context.Context, arg SearchAdminsParams) ([]Admin, error)
+	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error)
+	UpdateAPIKeyLastUsed(ctx context.Context, id int64) error
 	UpdateAdmin(ctx context.Context, arg UpdateAdminParams) (Admin, error)
+	UpdateAdminLastLogin(ctx context.Context, id int64) (Admin, error)
 	UpdateAdminType(ctx context.Context, arg UpdateAdminTypeParams) (AdminType, error)
+	UpdateAdminTypeID(ctx context.Context, arg UpdateAdminTypeIDParams) (Admin, error)
+	UpdateAdminTypeMFAPolicy(ctx context.Context, arg UpdateAdminTypeMFAPolicyParams) (AdminType, error)
 	UpdateCartItem(ctx context.Context, arg UpdateCartItemParams) (CartItem, error)
 	UpdateDiscount(ctx context.Context, arg UpdateDiscountParams) (Discount, error)
 	UpdateOrderDetail(ctx context.Context, arg UpdateOrderDetailParams) (OrderDetail, error)
@@ -84,11 +196,23 @@ type Querier interface {
 	UpdateProductCategory(ctx context.Context, arg UpdateProductCategoryParams) (ProductCategory, error)
 	UpdateProductInventory(ctx context.Context, arg UpdateProductInventoryParams) (ProductInventory, error)
 	UpdateProductQuantity(ctx context.Context, arg UpdateProductQuantityParams) (ProductInventory, error)
+	UpdateProductVariant(ctx context.Context, arg UpdateProductVariantParams) (ProductVariant, error)
 	UpdateShoppingSession(ctx context.Context, arg UpdateShoppingSessionParams) (ShoppingSession, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserAddress(ctx context.Context, arg UpdateUserAddressParams) (UserAddress, error)
 	UpdateUserAddressByUserID(ctx context.Context, arg UpdateUserAddressByUserIDParams) (UserAddress, error)
+	UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
 	UpdateUserPayment(ctx context.Context, arg UpdateUserPaymentParams) (UserPayment, error)
+	UpsertStockReservation(ctx context.Context, arg UpsertStockReservationParams) (StockReservation, error)
+	UseAdminRecoveryCode(ctx context.Context, arg UseAdminRecoveryCodeParams) (AdminRecoveryCode, error)
+	UseAdminTOTPStep(ctx context.Context, arg UseAdminTOTPStepParams) (AdminTotp, error)
+	UseEmailChangeToken(ctx context.Context, arg UseEmailChangeTokenParams) (EmailChangeToken, error)
+	UseEmailVerificationToken(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+	UsePasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (UserRecoveryCode, error)
+	UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (UserTotp, error)
+	VerifyUserEmail(ctx context.Context, id int64) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
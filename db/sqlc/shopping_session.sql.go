@@ -5,16 +5,73 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
+const claimGuestShoppingSession = `-- name: ClaimGuestShoppingSession :one
+UPDATE "shopping_session"
+SET user_id = $1::bigint,
+guest_token_hash = NULL
+WHERE id = $2 AND user_id IS NULL
+RETURNING id, user_id, total, created_at, updated_at, guest_token_hash
+`
+
+type ClaimGuestShoppingSessionParams struct {
+	UserID int64 `json:"user_id"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) ClaimGuestShoppingSession(ctx context.Context, arg ClaimGuestShoppingSessionParams) (ShoppingSession, error) {
+	row := q.db.QueryRowContext(ctx, claimGuestShoppingSession, arg.UserID, arg.ID)
+	var i ShoppingSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Total,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GuestTokenHash,
+	)
+	return i, err
+}
+
+const createGuestShoppingSession = `-- name: CreateGuestShoppingSession :one
+INSERT INTO "shopping_session" (
+  guest_token_hash,
+  total
+) VALUES (
+  $1::varchar, $2
+)
+RETURNING id, user_id, total, created_at, updated_at, guest_token_hash
+`
+
+type CreateGuestShoppingSessionParams struct {
+	GuestTokenHash string `json:"guest_token_hash"`
+	Total          string `json:"total"`
+}
+
+func (q *Queries) CreateGuestShoppingSession(ctx context.Context, arg CreateGuestShoppingSessionParams) (ShoppingSession, error) {
+	row := q.db.QueryRowContext(ctx, createGuestShoppingSession, arg.GuestTokenHash, arg.Total)
+	var i ShoppingSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Total,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GuestTokenHash,
+	)
+	return i, err
+}
+
 const createShoppingSession = `-- name: CreateShoppingSession :one
 INSERT INTO "shopping_session" (
   user_id,
   total
 ) VALUES (
-  $1, $2
+  $1::bigint, $2
 )
-RETURNING id, user_id, total, created_at, updated_at
+RETURNING id, user_id, total, created_at, updated_at, guest_token_hash
 `
 
 type CreateShoppingSessionParams struct {
@@ -31,10 +88,21 @@ func (q *Queries) CreateShoppingSession(ctx context.Context, arg CreateShoppingS
 		&i.Total,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.GuestTokenHash,
 	)
 	return i, err
 }
 
+const deleteExpiredGuestShoppingSessions = `-- name: DeleteExpiredGuestShoppingSessions :exec
+DELETE FROM "shopping_session"
+WHERE user_id IS NULL AND created_at < $1
+`
+
+func (q *Queries) DeleteExpiredGuestShoppingSessions(ctx context.Context, createdBefore time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredGuestShoppingSessions, createdBefore)
+	return err
+}
+
 const deleteShoppingSession = `-- name: DeleteShoppingSession :exec
 DELETE FROM "shopping_session"
 WHERE id = $1
@@ -45,8 +113,18 @@ func (q *Queries) DeleteShoppingSession(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteShoppingSessionsByUserID = `-- name: DeleteShoppingSessionsByUserID :exec
+DELETE FROM "shopping_session"
+WHERE user_id = $1::bigint
+`
+
+func (q *Queries) DeleteShoppingSessionsByUserID(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteShoppingSessionsByUserID, userID)
+	return err
+}
+
 const getShoppingSession = `-- name: GetShoppingSession :one
-SELECT id, user_id, total, created_at, updated_at FROM "shopping_session"
+SELECT id, user_id, total, created_at, updated_at, guest_token_hash FROM "shopping_session"
 WHERE id = $1
 LIMIT 1
 `
@@ -60,13 +138,54 @@ func (q *Queries) GetShoppingSession(ctx context.Context, id int64) (ShoppingSes
 		&i.Total,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.GuestTokenHash,
+	)
+	return i, err
+}
+
+const getShoppingSessionByGuestTokenHash = `-- name: GetShoppingSessionByGuestTokenHash :one
+SELECT id, user_id, total, created_at, updated_at, guest_token_hash FROM "shopping_session"
+WHERE guest_token_hash = $1::varchar
+LIMIT 1
+`
+
+func (q *Queries) GetShoppingSessionByGuestTokenHash(ctx context.Context, guestTokenHash string) (ShoppingSession, error) {
+	row := q.db.QueryRowContext(ctx, getShoppingSessionByGuestTokenHash, guestTokenHash)
+	var i ShoppingSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Total,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GuestTokenHash,
+	)
+	return i, err
+}
+
+const getShoppingSessionByUserID = `-- name: GetShoppingSessionByUserID :one
+SELECT id, user_id, total, created_at, updated_at, guest_token_hash FROM "shopping_session"
+WHERE user_id = $1::bigint
+LIMIT 1
+`
+
+func (q *Queries) GetShoppingSessionByUserID(ctx context.Context, userID int64) (ShoppingSession, error) {
+	row := q.db.QueryRowContext(ctx, getShoppingSessionByUserID, userID)
+	var i ShoppingSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Total,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GuestTokenHash,
 	)
 	return i, err
 }
 
 const listShoppingSessions = `-- name: ListShoppingSessions :many
-SELECT id, user_id, total, created_at, updated_at FROM "shopping_session"
-WHERE user_id = $1
+SELECT id, user_id, total, created_at, updated_at, guest_token_hash FROM "shopping_session"
+WHERE user_id = $1::bigint
 ORDER BY id
 LIMIT $2
 OFFSET $3
@@ -93,6 +212,7 @@ func (q *Queries) ListShoppingSessions(ctx context.Context, arg ListShoppingSess
 			&i.Total,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.GuestTokenHash,
 		); err != nil {
 			return nil, err
 		}
@@ -107,11 +227,32 @@ func (q *Queries) ListShoppingSessions(ctx context.Context, arg ListShoppingSess
 	return items, nil
 }
 
+const lockShoppingSessionByGuestTokenHash = `-- name: LockShoppingSessionByGuestTokenHash :one
+SELECT id, user_id, total, created_at, updated_at, guest_token_hash FROM "shopping_session"
+WHERE guest_token_hash = $1::varchar
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) LockShoppingSessionByGuestTokenHash(ctx context.Context, guestTokenHash string) (ShoppingSession, error) {
+	row := q.db.QueryRowContext(ctx, lockShoppingSessionByGuestTokenHash, guestTokenHash)
+	var i ShoppingSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Total,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GuestTokenHash,
+	)
+	return i, err
+}
+
 const updateShoppingSession = `-- name: UpdateShoppingSession :one
 UPDATE "shopping_session"
 SET total = $2
 WHERE id = $1
-RETURNING id, user_id, total, created_at, updated_at
+RETURNING id, user_id, total, created_at, updated_at, guest_token_hash
 `
 
 type UpdateShoppingSessionParams struct {
@@ -128,6 +269,7 @@ func (q *Queries) UpdateShoppingSession(ctx context.Context, arg UpdateShoppingS
 		&i.Total,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.GuestTokenHash,
 	)
 	return i, err
 }
@@ -23,7 +23,9 @@ func createRandomShoppingSession(t *testing.T) ShoppingSession {
 	require.NoError(t, err)
 	require.NotEmpty(t, shoppingSession)
 
-	require.Equal(t, arg.UserID, shoppingSession.UserID)
+	require.Equal(t, arg.UserID, shoppingSession.UserID.Int64)
+	require.True(t, shoppingSession.UserID.Valid)
+	require.False(t, shoppingSession.GuestTokenHash.Valid)
 	require.Equal(t, arg.Total, shoppingSession.Total)
 	require.NotEmpty(t, shoppingSession.ID)
 	require.NotEmpty(t, shoppingSession.CreatedAt)
@@ -36,6 +38,90 @@ func TestCreateShoppingSession(t *testing.T) {
 	createRandomShoppingSession(t)
 }
 
+func createRandomGuestShoppingSession(t *testing.T) ShoppingSession {
+	arg := CreateGuestShoppingSessionParams{
+		GuestTokenHash: util.HashSecret(util.RandomString(32)),
+		Total:          "0",
+	}
+
+	shoppingSession, err := testQueires.CreateGuestShoppingSession(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, shoppingSession)
+
+	require.False(t, shoppingSession.UserID.Valid)
+	require.Equal(t, arg.GuestTokenHash, shoppingSession.GuestTokenHash.String)
+	require.Equal(t, arg.Total, shoppingSession.Total)
+	require.NotEmpty(t, shoppingSession.ID)
+	require.NotEmpty(t, shoppingSession.CreatedAt)
+
+	return shoppingSession
+}
+
+func TestCreateGuestShoppingSession(t *testing.T) {
+	createRandomGuestShoppingSession(t)
+}
+
+func TestGetShoppingSessionByGuestTokenHash(t *testing.T) {
+	shoppingSession1 := createRandomGuestShoppingSession(t)
+	shoppingSession2, err := testQueires.GetShoppingSessionByGuestTokenHash(context.Background(), shoppingSession1.GuestTokenHash.String)
+
+	require.NoError(t, err)
+	require.Equal(t, shoppingSession1, shoppingSession2)
+
+	_, err = testQueires.GetShoppingSessionByGuestTokenHash(context.Background(), util.HashSecret(util.RandomString(32)))
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestGetShoppingSessionByUserID(t *testing.T) {
+	shoppingSession1 := createRandomShoppingSession(t)
+	shoppingSession2, err := testQueires.GetShoppingSessionByUserID(context.Background(), shoppingSession1.UserID.Int64)
+
+	require.NoError(t, err)
+	require.Equal(t, shoppingSession1, shoppingSession2)
+}
+
+func TestClaimGuestShoppingSession(t *testing.T) {
+	user := createRandomUser(t)
+	guestSession := createRandomGuestShoppingSession(t)
+
+	shoppingSession, err := testQueires.ClaimGuestShoppingSession(context.Background(), ClaimGuestShoppingSessionParams{
+		UserID: user.ID,
+		ID:     guestSession.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, guestSession.ID, shoppingSession.ID)
+	require.Equal(t, user.ID, shoppingSession.UserID.Int64)
+	require.False(t, shoppingSession.GuestTokenHash.Valid)
+
+	// a shopping session of a user can't be claimed again
+	_, err = testQueires.ClaimGuestShoppingSession(context.Background(), ClaimGuestShoppingSessionParams{
+		UserID: createRandomUser(t).ID,
+		ID:     guestSession.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestDeleteExpiredGuestShoppingSessions(t *testing.T) {
+	guestSession := createRandomGuestShoppingSession(t)
+	userSession := createRandomShoppingSession(t)
+
+	err := testQueires.DeleteExpiredGuestShoppingSessions(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	_, err = testQueires.GetShoppingSession(context.Background(), guestSession.ID)
+	require.NoError(t, err)
+
+	err = testQueires.DeleteExpiredGuestShoppingSessions(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, err = testQueires.GetShoppingSession(context.Background(), guestSession.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueires.GetShoppingSession(context.Background(), userSession.ID)
+	require.NoError(t, err)
+}
+
 func TestGetShoppingSession(t *testing.T) {
 	shoppingSession1 := createRandomShoppingSession(t)
 	shoppingSession2, err := testQueires.GetShoppingSession(context.Background(), shoppingSession1.ID)
@@ -91,7 +177,7 @@ func TestListShoppingSessions(t *testing.T) {
 	}
 
 	arg := ListShoppingSessionsParams{
-		UserID: lastShoppingSession.UserID,
+		UserID: lastShoppingSession.UserID.Int64,
 		Limit:  5,
 		Offset: 0,
 	}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// InsufficientStockError is returned when an inventory cannot cover the
+// quantity asked for once the reservations held by other sessions are deducted
+type InsufficientStockError struct {
+	InventoryID int64
+	Requested   int32
+	Available   int32
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for inventory %d: requested %d, available %d", e.InventoryID, e.Requested, e.Available)
+}
+
+// stockRequest is the total quantity a session needs from one inventory
+type stockRequest struct {
+	InventoryID int64
+	Quantity    int32
+}
+
+// stockRequests groups the priced lines by inventory, sorted by inventory id
+// so that concurrent transactions always take the row locks in the same order.
+func stockRequests(lines []pricedLine) []stockRequest {
+	quantities := make(map[int64]int32, len(lines))
+	for _, line := range lines {
+		quantities[line.Variant.InventoryID] += line.CartItem.Quantity
+	}
+
+	requests := make([]stockRequest, 0, len(quantities))
+	for inventoryID, quantity := range quantities {
+		requests = append(requests, stockRequest{InventoryID: inventoryID, Quantity: quantity})
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].InventoryID < requests[j].InventoryID
+	})
+
+	return requests
+}
+
+// lockStock locks the inventory row until the end of the transaction and
+// checks that it can cover the request of the session. Units held by the
+// unexpired reservations of other sessions are not available. ErrInvalidQuantity
+// is returned when the request isn't for a positive quantity, which would add
+// units to the inventory instead of taking them.
+func (q *Queries) lockStock(ctx context.Context, sessionID int64, request stockRequest) (ProductInventory, error) {
+	if request.Quantity <= 0 {
+		return ProductInventory{}, ErrInvalidQuantity
+	}
+
+	productInventory, err := q.GetProductInventoryForUpdate(ctx, request.InventoryID)
+	if err != nil {
+		return ProductInventory{}, err
+	}
+
+	reserved, err := q.GetReservedQuantity(ctx, GetReservedQuantityParams{
+		InventoryID: request.InventoryID,
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		return ProductInventory{}, err
+	}
+
+	var available int32
+	if productInventory.Active && productInventory.Quantity > reserved {
+		available = productInventory.Quantity - reserved
+	}
+
+	if available < request.Quantity {
+		return ProductInventory{}, &InsufficientStockError{
+			InventoryID: request.InventoryID,
+			Requested:   request.Quantity,
+			Available:   available,
+		}
+	}
+
+	return productInventory, nil
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomStockReservation(t *testing.T, expiresAt time.Time) StockReservation {
+	shoppingSession := createRandomShoppingSession(t)
+	productInventory := createRandomProductInventory(t)
+	arg := UpsertStockReservationParams{
+		SessionID:   shoppingSession.ID,
+		InventoryID: productInventory.ID,
+		Quantity:    int32(util.RandomInt(1, 9)),
+		ExpiresAt:   expiresAt,
+	}
+
+	stockReservation, err := testQueires.UpsertStockReservation(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, stockReservation)
+
+	require.Equal(t, arg.SessionID, stockReservation.SessionID)
+	require.Equal(t, arg.InventoryID, stockReservation.InventoryID)
+	require.Equal(t, arg.Quantity, stockReservation.Quantity)
+	require.WithinDuration(t, arg.ExpiresAt, stockReservation.ExpiresAt, time.Second)
+
+	require.NotEmpty(t, stockReservation.ID)
+	require.NotEmpty(t, stockReservation.CreatedAt)
+
+	return stockReservation
+}
+
+func TestUpsertStockReservation(t *testing.T) {
+	stockReservation1 := createRandomStockReservation(t, time.Now().Add(time.Minute))
+
+	// reserving the same inventory again replaces the reservation
+	arg := UpsertStockReservationParams{
+		SessionID:   stockReservation1.SessionID,
+		InventoryID: stockReservation1.InventoryID,
+		Quantity:    stockReservation1.Quantity + 1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	stockReservation2, err := testQueires.UpsertStockReservation(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, stockReservation1.ID, stockReservation2.ID)
+	require.Equal(t, arg.Quantity, stockReservation2.Quantity)
+	require.WithinDuration(t, arg.ExpiresAt, stockReservation2.ExpiresAt, time.Second)
+}
+
+func TestGetReservedQuantity(t *testing.T) {
+	stockReservation := createRandomStockReservation(t, time.Now().Add(time.Minute))
+
+	// expired reservations do not hold any stock
+	otherSession := createRandomShoppingSession(t)
+	_, err := testQueires.UpsertStockReservation(context.Background(), UpsertStockReservationParams{
+		SessionID:   otherSession.ID,
+		InventoryID: stockReservation.InventoryID,
+		Quantity:    5,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	reserved, err := testQueires.GetReservedQuantity(context.Background(), GetReservedQuantityParams{
+		InventoryID: stockReservation.InventoryID,
+		SessionID:   otherSession.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, stockReservation.Quantity, reserved)
+
+	// a session never competes with its own reservations
+	reserved, err = testQueires.GetReservedQuantity(context.Background(), GetReservedQuantityParams{
+		InventoryID: stockReservation.InventoryID,
+		SessionID:   stockReservation.SessionID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, reserved)
+}
+
+func TestListStockReservationsBySessionID(t *testing.T) {
+	stockReservation := createRandomStockReservation(t, time.Now().Add(time.Minute))
+
+	stockReservations, err := testQueires.ListStockReservationsBySessionID(context.Background(), stockReservation.SessionID)
+	require.NoError(t, err)
+	require.Len(t, stockReservations, 1)
+	require.Equal(t, stockReservation.ID, stockReservations[0].ID)
+}
+
+func TestDeleteStockReservationsBySessionID(t *testing.T) {
+	stockReservation := createRandomStockReservation(t, time.Now().Add(time.Minute))
+
+	err := testQueires.DeleteStockReservationsBySessionID(context.Background(), stockReservation.SessionID)
+	require.NoError(t, err)
+
+	stockReservations, err := testQueires.ListStockReservationsBySessionID(context.Background(), stockReservation.SessionID)
+	require.NoError(t, err)
+	require.Empty(t, stockReservations)
+}
+
+func TestDeleteExpiredStockReservations(t *testing.T) {
+	expired := createRandomStockReservation(t, time.Now().Add(-time.Minute))
+	active := createRandomStockReservation(t, time.Now().Add(time.Minute))
+
+	err := testQueires.DeleteExpiredStockReservations(context.Background())
+	require.NoError(t, err)
+
+	stockReservations, err := testQueires.ListStockReservationsBySessionID(context.Background(), expired.SessionID)
+	require.NoError(t, err)
+	require.Empty(t, stockReservations)
+
+	stockReservations, err = testQueires.ListStockReservationsBySessionID(context.Background(), active.SessionID)
+	require.NoError(t, err)
+	require.Len(t, stockReservations, 1)
+}
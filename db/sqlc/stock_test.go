@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockRequests(t *testing.T) {
+	lines := []pricedLine{
+		{CartItem: CartItem{Quantity: 2}, Variant: ProductVariant{InventoryID: 9}},
+		{CartItem: CartItem{Quantity: -5}, Variant: ProductVariant{InventoryID: 3}},
+		{CartItem: CartItem{Quantity: 1}, Variant: ProductVariant{InventoryID: 9}},
+	}
+
+	require.Equal(t, []stockRequest{
+		{InventoryID: 3, Quantity: -5},
+		{InventoryID: 9, Quantity: 3},
+	}, stockRequests(lines))
+}
+
+func TestLockStockInvalidQuantity(t *testing.T) {
+	// the quantity is checked before the inventory is locked
+	q := &Queries{}
+
+	for _, quantity := range []int32{0, -5} {
+		productInventory, err := q.lockStock(context.Background(), 1, stockRequest{InventoryID: 3, Quantity: quantity})
+		require.ErrorIs(t, err, ErrInvalidQuantity)
+		require.Empty(t, productInventory)
+	}
+}
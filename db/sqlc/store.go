@@ -3,13 +3,84 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrEmptyCart is returned when a purchase is attempted on a shopping session without cart items
+var ErrEmptyCart = errors.New("shopping session has no cart items")
+
+// ErrInvalidQuantity is returned when a cart item doesn't ask for a positive quantity
+var ErrInvalidQuantity = errors.New("quantity must be positive")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already exchanged for a new one is presented again
+var ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+// ErrInvalidPasswordResetToken is returned when a password reset token is unknown, expired or was already used
+var ErrInvalidPasswordResetToken = errors.New("invalid password reset token")
+
+// ErrInvalidEmailVerificationToken is returned when an email verification token is unknown, expired or was already used
+var ErrInvalidEmailVerificationToken = errors.New("invalid email verification token")
+
+// ErrInvalidEmailChangeToken is returned when an email change token is unknown, expired, was already used or belongs to another user
+var ErrInvalidEmailChangeToken = errors.New("invalid email change token")
+
+// ErrInvalidCartToken is returned when a cart token is unknown or its guest cart has expired
+var ErrInvalidCartToken = errors.New("invalid cart token")
+
+// ErrInvalidVariantOptions is returned when the option values of a new variant don't take a single value of every option of its product
+var ErrInvalidVariantOptions = errors.New("variant must take one value of every option of its product")
+
+// ErrDuplicateVariant is returned when another variant of the product is already made of the same option values
+var ErrDuplicateVariant = errors.New("product already has a variant with these option values")
+
+// ErrActiveVariantsWithoutOption is returned when an option is added to a product that still has active variants, which would take no value of it
+var ErrActiveVariantsWithoutOption = errors.New("product has active variants that would take no value of the new option")
+
+// ErrInactiveProduct is returned when the product or the variant asked for is no longer sold
+var ErrInactiveProduct = errors.New("product is not available")
+
+// ErrOrderFinished is returned when items are added to an order whose payment is already finished
+var ErrOrderFinished = errors.New("order has already been paid")
+
+// ErrTOTPCodeReused is returned when a one-time password is presented again within the time step it was already accepted in
+var ErrTOTPCodeReused = errors.New("totp code has already been used")
+
 // Store provides all functions to execute db queries and transactions
 type Store interface {
 	Querier
+	ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (User, error)
+	ConfirmEmailChangeTx(ctx context.Context, arg ConfirmEmailChangeTxParams) (User, error)
+	CountFilteredProducts(ctx context.Context, arg FilterProductsParams) (int64, error)
+	CreateAPIKeyTx(ctx context.Context, arg CreateAPIKeyTxParams) (APIKeyTxResult, error)
+	CreateAdminTypeTx(ctx context.Context, arg CreateAdminTypeTxParams) (AdminTypeTxResult, error)
+	CreateCartItemTx(ctx context.Context, arg CreateCartItemParams) (CartItemTxResult, error)
+	CreateOrderItemTx(ctx context.Context, arg CreateOrderItemTxParams) (OrderItemTxResult, error)
+	CreateProductOptionTx(ctx context.Context, arg CreateProductOptionTxParams) (ProductOptionValues, error)
+	CreateProductVariantTx(ctx context.Context, arg CreateProductVariantTxParams) (ProductVariantTxResult, error)
+	DeleteCartItemTx(ctx context.Context, arg DeleteCartItemTxParams) (ShoppingSession, error)
+	DisableAdminTOTPTx(ctx context.Context, adminID int64) error
+	DisableUserTOTPTx(ctx context.Context, userID int64) error
+	EnableAdminTOTPTx(ctx context.Context, arg EnableAdminTOTPTxParams) (AdminTotp, error)
+	EnableUserTOTPTx(ctx context.Context, arg EnableUserTOTPTxParams) (UserTotp, error)
+	EraseUserTx(ctx context.Context, userID int64) (User, error)
+	ExportUserDataTx(ctx context.Context, userID int64) (ExportUserDataTxResult, error)
+	FilterProducts(ctx context.Context, arg FilterProductsParams) ([]FilterProductsRow, error)
 	FinishedPurchaseTx(ctx context.Context, arg FinishedPurchaseTxParams) (FinishedPurchaseTxResult, error)
+	GetProductVariantMatrixTx(ctx context.Context, productID int64) (ProductVariantMatrix, error)
+	MergeGuestCartTx(ctx context.Context, arg MergeGuestCartTxParams) (ShoppingSession, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error)
+	ReserveStockTx(ctx context.Context, arg ReserveStockTxParams) (ReserveStockTxResult, error)
+	RotateUserSessionTx(ctx context.Context, arg RotateUserSessionTxParams) (UserSession, error)
+	UpdateAdminTx(ctx context.Context, arg UpdateAdminTxParams) (Admin, error)
+	UpdateAdminTypePermissionsTx(ctx context.Context, arg UpdateAdminTypePermissionsTxParams) (AdminTypeTxResult, error)
+	UpdateCartItemTx(ctx context.Context, arg UpdateCartItemTxParams) (CartItemTxResult, error)
+	UpdateShoppingSessionTotalTx(ctx context.Context, sessionID int64) (ShoppingSession, error)
+	VerifyEmailTx(ctx context.Context, tokenHash string) (User, error)
 }
 
 // Store provides all functions to execute db queries and transactions
@@ -47,89 +118,1362 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 
 }
 
-// OrderItemTx contains the input parameters of the transfer transaction
-type FinishedPurchaseTxParams struct {
-	ShoppingSession  ShoppingSession  `json:"shopping_session"`
-	CartItem         CartItem         `json:"cart_item"`
-	ProductInventory ProductInventory `json:"product_inventory"`
+// UpdateAdminTxParams contains the input parameters of the admin update transaction
+type UpdateAdminTxParams struct {
+	ID     int64 `json:"id"`
+	Active bool  `json:"active"`
+	TypeID int64 `json:"type_id"`
 }
 
-// OrderItemTxResult is the result of the transfer transaction
-type FinishedPurchaseTxResult struct {
-	OrderItem         OrderItem        `json:"order_item"`
-	OrderDetail       OrderDetail      `json:"order_detail"`
-	PaymentDetail     PaymentDetail    `json:"payment_item"`
-	RemainingQuantity ProductInventory `json:"remiainig_quantity"`
+// UpdateAdminTx activates or deactivates an admin and moves them to another admin type.
+// When either changes, the sessions of the admin are blocked so that they have to log in again
+// and get tokens matching their new access. sql.ErrNoRows is returned when the admin doesn't exist.
+func (store *SQLStore) UpdateAdminTx(ctx context.Context, arg UpdateAdminTxParams) (Admin, error) {
+	var admin Admin
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		admin, err = q.GetAdmin(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if admin.Active == arg.Active && admin.TypeID == arg.TypeID {
+			return nil
+		}
+
+		if admin.Active != arg.Active {
+			admin, err = q.UpdateAdmin(ctx, UpdateAdminParams{
+				ID:     arg.ID,
+				Active: arg.Active,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if admin.TypeID != arg.TypeID {
+			admin, err = q.UpdateAdminTypeID(ctx, UpdateAdminTypeIDParams{
+				ID:     arg.ID,
+				TypeID: arg.TypeID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.BlockAdminSessions(ctx, arg.ID)
+	})
+
+	return admin, err
 }
 
-/* OrderItemTx performs a product transfer from products DB to the user's order_item
-once the payments is finished successfully it creates OrderItem record,
-substract from/ update the product DB, adds the products to the users' order_item DB,
-and update products quantity within a single database transaction.*/
-func (store *SQLStore) FinishedPurchaseTx(ctx context.Context, arg FinishedPurchaseTxParams) (FinishedPurchaseTxResult, error) {
-	var result FinishedPurchaseTxResult
+// AdminTypeTxResult is the result of the admin type transactions
+type AdminTypeTxResult struct {
+	AdminType   AdminType `json:"admin_type"`
+	Permissions []string  `json:"permissions"`
+}
+
+// CreateAdminTypeTxParams contains the input parameters of the admin type creation transaction
+type CreateAdminTypeTxParams struct {
+	AdminType   string   `json:"admin_type"`
+	Permissions []string `json:"permissions"`
+}
+
+// CreateAdminTypeTx creates an admin type along with its set of permissions
+func (store *SQLStore) CreateAdminTypeTx(ctx context.Context, arg CreateAdminTypeTxParams) (AdminTypeTxResult, error) {
+	var result AdminTypeTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
 
-		result.OrderDetail, err = q.CreateOrderDetailAndPaymentDetail(ctx, CreateOrderDetailAndPaymentDetailParams{
-			UserID: arg.ShoppingSession.UserID,
-			Total:  arg.ShoppingSession.Total,
+		result.AdminType, err = q.CreateAdminType(ctx, arg.AdminType)
+		if err != nil {
+			return err
+		}
+
+		result.Permissions, err = q.setAdminTypePermissions(ctx, result.AdminType.ID, arg.Permissions)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateAdminTypePermissionsTxParams contains the input parameters of the admin type permissions transaction
+type UpdateAdminTypePermissionsTxParams struct {
+	AdminTypeID int64    `json:"admin_type_id"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateAdminTypePermissionsTx replaces the whole set of permissions of an admin type.
+// sql.ErrNoRows is returned when the admin type doesn't exist.
+func (store *SQLStore) UpdateAdminTypePermissionsTx(ctx context.Context, arg UpdateAdminTypePermissionsTxParams) (AdminTypeTxResult, error) {
+	var result AdminTypeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.AdminType, err = q.GetAdminType(ctx, arg.AdminTypeID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteAdminTypePermissions(ctx, arg.AdminTypeID)
+		if err != nil {
+			return err
+		}
+
+		result.Permissions, err = q.setAdminTypePermissions(ctx, arg.AdminTypeID, arg.Permissions)
+		return err
+	})
+
+	return result, err
+}
+
+// setAdminTypePermissions grants the permissions to the admin type, ignoring duplicates
+func (q *Queries) setAdminTypePermissions(ctx context.Context, adminTypeID int64, permissions []string) ([]string, error) {
+	granted := make([]string, 0, len(permissions))
+	seen := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+
+		adminTypePermission, err := q.CreateAdminTypePermission(ctx, CreateAdminTypePermissionParams{
+			AdminTypeID: adminTypeID,
+			Permission:  permission,
+		})
+		if err != nil {
+			return nil, err
+		}
+		granted = append(granted, adminTypePermission.Permission)
+	}
+	sort.Strings(granted)
+
+	return granted, nil
+}
+
+// CreateAPIKeyTxParams contains the input parameters of the api key creation transaction
+type CreateAPIKeyTxParams struct {
+	Name        string       `json:"name"`
+	Prefix      string       `json:"prefix"`
+	KeyHash     string       `json:"key_hash"`
+	AdminID     int64        `json:"admin_id"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+	Permissions []string     `json:"permissions"`
+}
+
+// APIKeyTxResult is the result of the api key creation transaction
+type APIKeyTxResult struct {
+	APIKey      ApiKey   `json:"api_key"`
+	Permissions []string `json:"permissions"`
+}
+
+// CreateAPIKeyTx creates an api key along with the permissions it is scoped to
+func (store *SQLStore) CreateAPIKeyTx(ctx context.Context, arg CreateAPIKeyTxParams) (APIKeyTxResult, error) {
+	var result APIKeyTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.APIKey, err = q.CreateAPIKey(ctx, CreateAPIKeyParams{
+			Name:      arg.Name,
+			Prefix:    arg.Prefix,
+			KeyHash:   arg.KeyHash,
+			AdminID:   arg.AdminID,
+			ExpiresAt: arg.ExpiresAt,
 		})
 		if err != nil {
-			// log.Fatal("error1: ", err)
+			return err
+		}
+
+		result.Permissions = make([]string, 0, len(arg.Permissions))
+		seen := make(map[string]bool, len(arg.Permissions))
+		for _, permission := range arg.Permissions {
+			if seen[permission] {
+				continue
+			}
+			seen[permission] = true
+
+			apiKeyPermission, err := q.CreateAPIKeyPermission(ctx, CreateAPIKeyPermissionParams{
+				ApiKeyID:   result.APIKey.ID,
+				Permission: permission,
+			})
+			if err != nil {
+				return err
+			}
+			result.Permissions = append(result.Permissions, apiKeyPermission.Permission)
+		}
+		sort.Strings(result.Permissions)
+
+		return nil
+	})
+
+	return result, err
+}
+
+// paymentStatusFinished is the status of the payment of a checked out order
+const paymentStatusFinished = "Finished"
+
+// CreateOrderItemTxParams contains the input parameters of the order item creation transaction
+type CreateOrderItemTxParams struct {
+	OrderID   int64 `json:"order_id"`
+	VariantID int64 `json:"variant_id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+// OrderItemTxResult is the result of the order item creation transaction
+type OrderItemTxResult struct {
+	OrderDetail OrderDetail `json:"order_detail"`
+	OrderItem   OrderItem   `json:"order_item"`
+}
+
+// CreateOrderItemTx adds a line to an order that is not paid yet, priced against
+// the current variant, product and discount, and recomputes the order total from
+// its lines. ErrOrderFinished is returned once the payment of the order is finished,
+// ErrInactiveProduct when the variant or its product is no longer sold, and
+// sql.ErrNoRows when the order or the variant doesn't exist.
+func (store *SQLStore) CreateOrderItemTx(ctx context.Context, arg CreateOrderItemTxParams) (OrderItemTxResult, error) {
+	var result OrderItemTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		orderDetail, err := q.GetOrderDetailForUpdate(ctx, arg.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if orderDetail.PaymentID.Valid {
+			status, err := q.GetPaymentDetailStatus(ctx, orderDetail.PaymentID.Int64)
+			if err != nil {
+				return err
+			}
+			if status == paymentStatusFinished {
+				return ErrOrderFinished
+			}
+		}
+
+		variant, err := q.GetProductVariant(ctx, arg.VariantID)
+		if err != nil {
+			return err
+		}
+
+		product, err := q.GetProduct(ctx, variant.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if !product.Active || !variant.Active {
+			return ErrInactiveProduct
+		}
+
+		discount, err := q.GetDiscount(ctx, product.DiscountID)
+		if err != nil {
+			return err
+		}
+
+		unitPrice, err := UnitPrice(product, variant, discount)
+		if err != nil {
+			return err
+		}
+
+		variantName, err := q.GetProductVariantName(ctx, variant.ID)
+		if err != nil {
 			return err
 		}
 
 		result.OrderItem, err = q.CreateOrderItem(ctx, CreateOrderItemParams{
-			OrderID:   result.OrderDetail.ID,
-			ProductID: arg.CartItem.ProductID,
-			Quantity:  arg.CartItem.Quantity,
+			OrderID:     orderDetail.ID,
+			ProductID:   product.ID,
+			Quantity:    arg.Quantity,
+			ProductName: product.Name,
+			ProductSku:  variant.Sku,
+			Price:       unitPrice.String(),
+			VariantID:   variant.ID,
+			VariantName: variantName,
 		})
 		if err != nil {
-			// log.Fatal("error2: ", err)
 			return err
 		}
 
-		result.PaymentDetail, err = q.UpdatePaymentDetail(ctx, UpdatePaymentDetailParams{
-			ID:       result.OrderDetail.PaymentID.Int64,
-			UserID:   result.OrderDetail.UserID,
-			OrderID:  result.OrderDetail.ID,
-			Amount:   result.OrderItem.Quantity,
-			Provider: "Cash",
-			Status:   "Finished",
+		orderItems, err := q.ListOrderItemsByOrderID(ctx, orderDetail.ID)
+		if err != nil {
+			return err
+		}
+
+		total, err := OrderItemsTotal(orderItems)
+		if err != nil {
+			return err
+		}
+
+		result.OrderDetail, err = q.UpdateOrderDetail(ctx, UpdateOrderDetailParams{
+			ID:    orderDetail.ID,
+			Total: FormatTotal(total),
 		})
+		return err
+	})
+
+	return result, err
+}
+
+// CreateProductOptionTxParams contains the input parameters of the product option creation transaction
+type CreateProductOptionTxParams struct {
+	ProductID int64    `json:"product_id"`
+	Name      string   `json:"name"`
+	Values    []string `json:"values"`
+}
+
+// CreateProductOptionTx adds an option to a product along with its values, in the
+// given order. The option comes after the ones the product already has. The default
+// variant, which takes no option value, is deactivated on the way, and
+// ErrActiveVariantsWithoutOption is returned while any other variant is still active.
+// sql.ErrNoRows is returned when the product doesn't exist.
+func (store *SQLStore) CreateProductOptionTx(ctx context.Context, arg CreateProductOptionTxParams) (ProductOptionValues, error) {
+	var result ProductOptionValues
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetProductForUpdate(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeactivateDefaultProductVariant(ctx, arg.ProductID)
 		if err != nil {
-			// log.Fatal("error3: ", err)
 			return err
 		}
 
-		// update ProductInventory method
+		variants, err := q.ListProductVariantsByProductID(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		for _, variant := range variants {
+			if variant.Active {
+				return ErrActiveVariantsWithoutOption
+			}
+		}
 
-		// fmt.Println("before: ", arg.ProductInventory.Quantity)
-		// fmt.Println("minus: ", result.OrderItem.Quantity)
+		options, err := q.ListProductOptionsByProductID(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
 
-		result.RemainingQuantity, err = q.UpdateProductQuantity(ctx, UpdateProductQuantityParams{
-			ID:       arg.ProductInventory.ID,
-			Quantity: -result.OrderItem.Quantity,
+		result.Option, err = q.CreateProductOption(ctx, CreateProductOptionParams{
+			ProductID: arg.ProductID,
+			Name:      arg.Name,
+			Position:  int32(len(options)),
 		})
-		// fmt.Println("after: ", result.RemainingQuantity.Quantity)
 		if err != nil {
 			return err
 		}
 
-		err = q.DeleteCartItem(ctx, arg.CartItem.ID)
+		result.Values = make([]ProductOptionValue, 0, len(arg.Values))
+		for i, value := range arg.Values {
+			optionValue, err := q.CreateProductOptionValue(ctx, CreateProductOptionValueParams{
+				OptionID: result.Option.ID,
+				Value:    value,
+				Position: int32(i),
+			})
+			if err != nil {
+				return err
+			}
+			result.Values = append(result.Values, optionValue)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// CreateProductVariantTxParams contains the input parameters of the product variant creation transaction
+type CreateProductVariantTxParams struct {
+	ProductID int64  `json:"product_id"`
+	Sku       string `json:"sku"`
+	// the variant is sold at the price of its product while Price is NULL
+	Price          sql.NullString `json:"price"`
+	Quantity       int32          `json:"quantity"`
+	OptionValueIDs []int64        `json:"option_value_ids"`
+}
+
+// ProductVariantTxResult is the result of the product variant creation transaction
+type ProductVariantTxResult struct {
+	Variant      ProductVariant              `json:"variant"`
+	Inventory    ProductInventory            `json:"inventory"`
+	OptionValues []ProductVariantOptionValue `json:"option_values"`
+}
+
+// CreateProductVariantTx adds a variant to a product, with an inventory of its own
+// holding Quantity units. The variant takes a single value of every option of the
+// product, ErrInvalidVariantOptions is returned otherwise, and ErrDuplicateVariant
+// when another variant of the product already takes the same values.
+// sql.ErrNoRows is returned when the product doesn't exist.
+func (store *SQLStore) CreateProductVariantTx(ctx context.Context, arg CreateProductVariantTxParams) (ProductVariantTxResult, error) {
+	var result ProductVariantTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// the product is locked so that two variants with the same values can't be added concurrently
+		_, err := q.GetProductForUpdate(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+
+		options, err := q.ListProductOptionsByProductID(ctx, arg.ProductID)
 		if err != nil {
 			return err
 		}
 
-		err = q.DeleteShoppingSession(ctx, arg.ShoppingSession.ID)
+		values, err := q.ListProductOptionValuesByProductID(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		optionOfValue := make(map[int64]int64, len(values))
+		for _, value := range values {
+			optionOfValue[value.ID] = value.OptionID
+		}
+
+		valueOfOption := make(map[int64]int64, len(options))
+		for _, optionValueID := range arg.OptionValueIDs {
+			optionID, ok := optionOfValue[optionValueID]
+			if !ok {
+				return ErrInvalidVariantOptions
+			}
+			if _, ok := valueOfOption[optionID]; ok {
+				return ErrInvalidVariantOptions
+			}
+			valueOfOption[optionID] = optionValueID
+		}
+		if len(valueOfOption) != len(options) {
+			return ErrInvalidVariantOptions
+		}
+
+		variants, err := q.ListProductVariantsByProductID(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		variantValues, err := q.ListProductVariantOptionValuesByProductID(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+		key := variantOptionsKey(arg.OptionValueIDs)
+		for _, variant := range newProductVariantMatrix(nil, nil, variants, variantValues).Variants {
+			if variantOptionsKey(variant.OptionValueIDs) == key {
+				return ErrDuplicateVariant
+			}
+		}
+
+		result.Inventory, err = q.CreateProductInventory(ctx, arg.Quantity)
+		if err != nil {
+			return err
+		}
+
+		result.Variant, err = q.CreateProductVariant(ctx, CreateProductVariantParams{
+			ProductID:   arg.ProductID,
+			Sku:         arg.Sku,
+			Price:       arg.Price,
+			InventoryID: result.Inventory.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.OptionValues = make([]ProductVariantOptionValue, 0, len(options))
+		for _, option := range options {
+			optionValue, err := q.CreateProductVariantOptionValue(ctx, CreateProductVariantOptionValueParams{
+				VariantID:     result.Variant.ID,
+				OptionID:      option.ID,
+				OptionValueID: valueOfOption[option.ID],
+			})
+			if err != nil {
+				return err
+			}
+			result.OptionValues = append(result.OptionValues, optionValue)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// GetProductVariantMatrixTx lists the options of a product with their values and
+// the variants of the product with the option values they are made of, within a
+// single transaction so that both sides agree.
+func (store *SQLStore) GetProductVariantMatrixTx(ctx context.Context, productID int64) (ProductVariantMatrix, error) {
+	var matrix ProductVariantMatrix
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		options, err := q.ListProductOptionsByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		values, err := q.ListProductOptionValuesByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		variants, err := q.ListProductVariantsByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		variantValues, err := q.ListProductVariantOptionValuesByProductID(ctx, productID)
 		if err != nil {
 			return err
 		}
 
+		matrix = newProductVariantMatrix(options, values, variants, variantValues)
 		return nil
 	})
 
+	return matrix, err
+}
+
+// FinishedPurchaseTxParams contains the input parameters of the purchase transaction
+type FinishedPurchaseTxParams struct {
+	ShoppingSession ShoppingSession `json:"shopping_session"`
+	CartItems       []CartItem      `json:"cart_items"`
+}
+
+// FinishedPurchaseTxResult is the result of the purchase transaction
+type FinishedPurchaseTxResult struct {
+	OrderDetail         OrderDetail        `json:"order_detail"`
+	OrderItems          []OrderItem        `json:"order_items"`
+	PaymentDetail       PaymentDetail      `json:"payment_item"`
+	RemainingQuantities []ProductInventory `json:"remaining_quantities"`
+}
+
+// FinishedPurchaseTx turns a shopping session into an order once the payment
+// is finished successfully. Within a single database transaction it prices
+// the cart lines, locks the product inventories and checks they can cover the
+// cart without touching the stock reserved by other sessions, creates the
+// order_detail with the computed total and its payment_detail, converts every
+// cart line into an order_item holding a snapshot of the product variant and its
+// unit price, subtracts the bought quantities from the variant inventories and
+// finally empties the cart and deletes the shopping session with its stock
+// reservations. The total sent by the client is never trusted.
+// An *InsufficientStockError is returned when a line cannot be served.
+func (store *SQLStore) FinishedPurchaseTx(ctx context.Context, arg FinishedPurchaseTxParams) (FinishedPurchaseTxResult, error) {
+	var result FinishedPurchaseTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		if len(arg.CartItems) == 0 {
+			return ErrEmptyCart
+		}
+
+		if !arg.ShoppingSession.UserID.Valid {
+			return fmt.Errorf("shopping session %d belongs to a guest", arg.ShoppingSession.ID)
+		}
+
+		for _, cartItem := range arg.CartItems {
+			if cartItem.SessionID != arg.ShoppingSession.ID {
+				return fmt.Errorf("cart item %d does not belong to shopping session %d", cartItem.ID, arg.ShoppingSession.ID)
+			}
+		}
+
+		lines, total, err := q.priceCartItems(ctx, arg.CartItems)
+		if err != nil {
+			return err
+		}
+
+		for _, request := range stockRequests(lines) {
+			_, err = q.lockStock(ctx, arg.ShoppingSession.ID, request)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.OrderDetail, err = q.CreateOrderDetailAndPaymentDetail(ctx, CreateOrderDetailAndPaymentDetailParams{
+			UserID: arg.ShoppingSession.UserID.Int64,
+			Total:  FormatTotal(total),
+		})
+		if err != nil {
+			return err
+		}
+
+		var totalQuantity int32
+		result.OrderItems = make([]OrderItem, 0, len(lines))
+		result.RemainingQuantities = make([]ProductInventory, 0, len(lines))
+		for _, line := range lines {
+			variantName, err := q.GetProductVariantName(ctx, line.Variant.ID)
+			if err != nil {
+				return err
+			}
+
+			orderItem, err := q.CreateOrderItem(ctx, CreateOrderItemParams{
+				OrderID:     result.OrderDetail.ID,
+				ProductID:   line.Product.ID,
+				Quantity:    line.CartItem.Quantity,
+				ProductName: line.Product.Name,
+				ProductSku:  line.Variant.Sku,
+				Price:       line.UnitPrice.String(),
+				VariantID:   line.Variant.ID,
+				VariantName: variantName,
+			})
+			if err != nil {
+				return err
+			}
+			result.OrderItems = append(result.OrderItems, orderItem)
+			totalQuantity += orderItem.Quantity
+
+			remainingQuantity, err := q.UpdateProductQuantity(ctx, UpdateProductQuantityParams{
+				ID:       line.Variant.InventoryID,
+				Quantity: -orderItem.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+			result.RemainingQuantities = append(result.RemainingQuantities, remainingQuantity)
+
+			err = q.DeleteCartItem(ctx, line.CartItem.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.PaymentDetail, err = q.UpdatePaymentDetail(ctx, UpdatePaymentDetailParams{
+			ID:       result.OrderDetail.PaymentID.Int64,
+			UserID:   result.OrderDetail.UserID,
+			OrderID:  result.OrderDetail.ID,
+			Amount:   totalQuantity,
+			Provider: "Cash",
+			Status:   paymentStatusFinished,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteStockReservationsBySessionID(ctx, arg.ShoppingSession.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteShoppingSession(ctx, arg.ShoppingSession.ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ReserveStockTxParams contains the input parameters of the stock reservation transaction
+type ReserveStockTxParams struct {
+	ShoppingSession ShoppingSession `json:"shopping_session"`
+	CartItems       []CartItem      `json:"cart_items"`
+	ExpiresAt       time.Time       `json:"expires_at"`
+}
+
+// ReserveStockTxResult is the result of the stock reservation transaction
+type ReserveStockTxResult struct {
+	Reservations []StockReservation `json:"reservations"`
+}
+
+// ReserveStockTx holds the stock needed by the cart of a shopping session
+// until ExpiresAt, so it is not sold to anybody else while the payment is
+// in progress. Reserving again replaces the previous reservations of the
+// session, and expired reservations are cleaned up on the way. An *InsufficientStockError is returned when a line cannot be served.
+func (store *SQLStore) ReserveStockTx(ctx context.Context, arg ReserveStockTxParams) (ReserveStockTxResult, error) {
+	var result ReserveStockTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if len(arg.CartItems) == 0 {
+			return ErrEmptyCart
+		}
+
+		for _, cartItem := range arg.CartItems {
+			if cartItem.SessionID != arg.ShoppingSession.ID {
+				return fmt.Errorf("cart item %d does not belong to shopping session %d", cartItem.ID, arg.ShoppingSession.ID)
+			}
+		}
+
+		lines, _, err := q.priceCartItems(ctx, arg.CartItems)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteExpiredStockReservations(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteStockReservationsBySessionID(ctx, arg.ShoppingSession.ID)
+		if err != nil {
+			return err
+		}
+
+		requests := stockRequests(lines)
+		result.Reservations = make([]StockReservation, 0, len(requests))
+		for _, request := range requests {
+			_, err = q.lockStock(ctx, arg.ShoppingSession.ID, request)
+			if err != nil {
+				return err
+			}
+
+			reservation, err := q.UpsertStockReservation(ctx, UpsertStockReservationParams{
+				SessionID:   arg.ShoppingSession.ID,
+				InventoryID: request.InventoryID,
+				Quantity:    request.Quantity,
+				ExpiresAt:   arg.ExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.Reservations = append(result.Reservations, reservation)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ChangePasswordTxParams contains the input parameters of the password change transaction
+type ChangePasswordTxParams struct {
+	UserID         int64     `json:"user_id"`
+	SessionID      uuid.UUID `json:"session_id"`
+	HashedPassword string    `json:"hashed_password"`
+}
+
+// ChangePasswordTx sets a new password for a user and blocks all of their sessions
+// but the one changing it. Pending password reset tokens are invalidated as well.
+func (store *SQLStore) ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			ID:       arg.UserID,
+			Password: arg.HashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidatePasswordResetTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.BlockOtherUserSessions(ctx, BlockOtherUserSessionsParams{
+			UserID: user.ID,
+			ID:     arg.SessionID,
+		})
+	})
+
+	return user, err
+}
+
+// ConfirmEmailChangeTxParams contains the input parameters of the email change confirmation transaction
+type ConfirmEmailChangeTxParams struct {
+	UserID    int64     `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	TokenHash string    `json:"token_hash"`
+}
+
+// ConfirmEmailChangeTx uses up an email change token of the user to swap their email
+// for the confirmed one. The other email change and password reset tokens of the user
+// are invalidated and all of their sessions but the confirming one are blocked.
+// ErrInvalidEmailChangeToken is returned when the token can't be used.
+func (store *SQLStore) ConfirmEmailChangeTx(ctx context.Context, arg ConfirmEmailChangeTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		changeToken, err := q.UseEmailChangeToken(ctx, UseEmailChangeTokenParams{
+			TokenHash: arg.TokenHash,
+			UserID:    arg.UserID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInvalidEmailChangeToken
+			}
+			return err
+		}
+
+		user, err = q.UpdateUserEmail(ctx, UpdateUserEmailParams{
+			ID:    changeToken.UserID,
+			Email: changeToken.NewEmail,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidateEmailChangeTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidatePasswordResetTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.BlockOtherUserSessions(ctx, BlockOtherUserSessionsParams{
+			UserID: user.ID,
+			ID:     arg.SessionID,
+		})
+	})
+
+	return user, err
+}
+
+// ResetPasswordTxParams contains the input parameters of the password reset transaction
+type ResetPasswordTxParams struct {
+	TokenHash      string `json:"token_hash"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+// ResetPasswordTx uses up a password reset token to set a new password for its user.
+// The other reset tokens of the user are invalidated and all of their sessions are blocked.
+// ErrInvalidPasswordResetToken is returned when the token can't be used.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		resetToken, err := q.UsePasswordResetToken(ctx, arg.TokenHash)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInvalidPasswordResetToken
+			}
+			return err
+		}
+
+		user, err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			ID:       resetToken.UserID,
+			Password: arg.HashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidatePasswordResetTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.BlockAllUserSessions(ctx, user.ID)
+	})
+
+	return user, err
+}
+
+// VerifyEmailTx uses up an email verification token to mark the email of its user as verified,
+// the other verification tokens of the user are invalidated.
+// ErrInvalidEmailVerificationToken is returned when the token can't be used.
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, tokenHash string) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		verificationToken, err := q.UseEmailVerificationToken(ctx, tokenHash)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInvalidEmailVerificationToken
+			}
+			return err
+		}
+
+		user, err = q.VerifyUserEmail(ctx, verificationToken.UserID)
+		if err != nil {
+			return err
+		}
+
+		return q.InvalidateEmailVerificationTokens(ctx, user.ID)
+	})
+
+	return user, err
+}
+
+// RotateUserSessionTxParams contains the input parameters of the session rotation transaction
+type RotateUserSessionTxParams struct {
+	SessionID  uuid.UUID               `json:"session_id"`
+	NewSession CreateUserSessionParams `json:"new_session"`
+}
+
+// RotateUserSessionTx marks a user session as rotated and stores the session
+// of the refresh token replacing it, in the same family as the rotated one.
+// ErrRefreshTokenReused is returned when the session was already rotated or
+// blocked, e.g. by a concurrent renewal with the same refresh token.
+func (store *SQLStore) RotateUserSessionTx(ctx context.Context, arg RotateUserSessionTxParams) (UserSession, error) {
+	var userSession UserSession
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		rotatedSession, err := q.RotateUserSession(ctx, arg.SessionID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrRefreshTokenReused
+			}
+			return err
+		}
+
+		newSession := arg.NewSession
+		newSession.UserID = rotatedSession.UserID
+		newSession.FamilyID = rotatedSession.FamilyID
+
+		userSession, err = q.CreateUserSession(ctx, newSession)
+		return err
+	})
+
+	return userSession, err
+}
+
+// CartItemTxResult is the result of the transactions changing a cart item
+type CartItemTxResult struct {
+	CartItem        CartItem        `json:"cart_item"`
+	ShoppingSession ShoppingSession `json:"shopping_session"`
+}
+
+// CreateCartItemTx adds a variant to the cart of a shopping session and
+// recomputes the total of the session. sql.ErrNoRows is returned when the
+// variant doesn't exist or is inactive.
+func (store *SQLStore) CreateCartItemTx(ctx context.Context, arg CreateCartItemParams) (CartItemTxResult, error) {
+	var result CartItemTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.CartItem, err = q.CreateCartItem(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.ShoppingSession, err = q.updateShoppingSessionTotal(ctx, arg.SessionID)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateCartItemTxParams contains the input parameters of the cart item update transaction
+type UpdateCartItemTxParams struct {
+	SessionID int64 `json:"session_id"`
+	ID        int64 `json:"id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+// UpdateCartItemTx changes the quantity of a cart item of the shopping session
+// and recomputes the total of the session. sql.ErrNoRows is returned when the
+// cart item doesn't belong to the session.
+func (store *SQLStore) UpdateCartItemTx(ctx context.Context, arg UpdateCartItemTxParams) (CartItemTxResult, error) {
+	var result CartItemTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.CartItem, err = q.UpdateCartItem(ctx, UpdateCartItemParams{
+			ID:       arg.ID,
+			Quantity: arg.Quantity,
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.CartItem.SessionID != arg.SessionID {
+			return sql.ErrNoRows
+		}
+
+		result.ShoppingSession, err = q.updateShoppingSessionTotal(ctx, arg.SessionID)
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteCartItemTxParams contains the input parameters of the cart item deletion transaction
+type DeleteCartItemTxParams struct {
+	SessionID int64 `json:"session_id"`
+	ID        int64 `json:"id"`
+}
+
+// DeleteCartItemTx removes a cart item from the shopping session and recomputes
+// the total of the session. sql.ErrNoRows is returned when the cart item doesn't
+// belong to the session.
+func (store *SQLStore) DeleteCartItemTx(ctx context.Context, arg DeleteCartItemTxParams) (ShoppingSession, error) {
+	var shoppingSession ShoppingSession
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		cartItem, err := q.GetCartItemByID(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if cartItem.SessionID != arg.SessionID {
+			return sql.ErrNoRows
+		}
+
+		err = q.DeleteCartItem(ctx, cartItem.ID)
+		if err != nil {
+			return err
+		}
+
+		shoppingSession, err = q.updateShoppingSessionTotal(ctx, arg.SessionID)
+		return err
+	})
+
+	return shoppingSession, err
+}
+
+// UpdateShoppingSessionTotalTx recomputes the total of a shopping session from
+// its cart items, the current product prices and their active discounts.
+// The cart item transactions already recompute it as they change the cart.
+func (store *SQLStore) UpdateShoppingSessionTotalTx(ctx context.Context, sessionID int64) (ShoppingSession, error) {
+	var shoppingSession ShoppingSession
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		shoppingSession, err = q.updateShoppingSessionTotal(ctx, sessionID)
+		return err
+	})
+
+	return shoppingSession, err
+}
+
+func (q *Queries) updateShoppingSessionTotal(ctx context.Context, sessionID int64) (ShoppingSession, error) {
+	cartItems, err := q.ListCartItemsBySessionID(ctx, sessionID)
+	if err != nil {
+		return ShoppingSession{}, err
+	}
+
+	_, total, err := q.priceCartItems(ctx, cartItems)
+	if err != nil {
+		return ShoppingSession{}, err
+	}
+
+	return q.UpdateShoppingSession(ctx, UpdateShoppingSessionParams{
+		ID:    sessionID,
+		Total: FormatTotal(total),
+	})
+}
+
+// MergeGuestCartTxParams contains the input parameters of the guest cart merging transaction
+type MergeGuestCartTxParams struct {
+	UserID         int64         `json:"user_id"`
+	GuestTokenHash string        `json:"guest_token_hash"`
+	Rule           CartMergeRule `json:"rule"`
+	// guest carts created before CreatedAfter are expired
+	CreatedAfter time.Time `json:"created_after"`
+}
+
+// MergeGuestCartTx hands the cart of a guest over to a user who just signed in
+// or up. The guest shopping session becomes the one of the user when they have
+// none, otherwise its cart items are moved into the shopping session of the user,
+// the quantities of the variants found in both carts being reconciled by Rule,
+// and the guest shopping session is deleted. Variants deactivated since the guest
+// added them are left behind. Expired guest carts are cleaned up
+// on the way. ErrInvalidCartToken is returned when the guest cart is unknown or
+// expired.
+func (store *SQLStore) MergeGuestCartTx(ctx context.Context, arg MergeGuestCartTxParams) (ShoppingSession, error) {
+	var shoppingSession ShoppingSession
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		guestSession, err := q.LockShoppingSessionByGuestTokenHash(ctx, arg.GuestTokenHash)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidCartToken
+			}
+			return err
+		}
+		if guestSession.CreatedAt.Before(arg.CreatedAfter) {
+			return ErrInvalidCartToken
+		}
+
+		err = q.DeleteExpiredGuestShoppingSessions(ctx, arg.CreatedAfter)
+		if err != nil {
+			return err
+		}
+
+		userSession, err := q.GetShoppingSessionByUserID(ctx, arg.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				shoppingSession, err = q.ClaimGuestShoppingSession(ctx, ClaimGuestShoppingSessionParams{
+					UserID: arg.UserID,
+					ID:     guestSession.ID,
+				})
+			}
+			return err
+		}
+
+		userItems, err := q.ListCartItemsBySessionID(ctx, userSession.ID)
+		if err != nil {
+			return err
+		}
+		userItemsByVariant := make(map[int64]CartItem, len(userItems))
+		for _, userItem := range userItems {
+			userItemsByVariant[userItem.VariantID] = userItem
+		}
+
+		guestItems, err := q.ListCartItemsBySessionID(ctx, guestSession.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, guestItem := range guestItems {
+			userItem, ok := userItemsByVariant[guestItem.VariantID]
+			if !ok {
+				_, err = q.CreateCartItem(ctx, CreateCartItemParams{
+					SessionID: userSession.ID,
+					Quantity:  guestItem.Quantity,
+					VariantID: guestItem.VariantID,
+				})
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+				continue
+			}
+
+			quantity := arg.Rule.mergeQuantity(userItem.Quantity, guestItem.Quantity)
+			if quantity == userItem.Quantity {
+				continue
+			}
+			_, err = q.UpdateCartItem(ctx, UpdateCartItemParams{
+				ID:       userItem.ID,
+				Quantity: quantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// the cart items of the guest go along with their shopping session
+		err = q.DeleteShoppingSession(ctx, guestSession.ID)
+		if err != nil {
+			return err
+		}
+
+		shoppingSession, err = q.updateShoppingSessionTotal(ctx, userSession.ID)
+		return err
+	})
+
+	return shoppingSession, err
+}
+
+// EnableAdminTOTPTxParams contains the input parameters of the admin totp enabling transaction
+type EnableAdminTOTPTxParams struct {
+	AdminID            int64    `json:"admin_id"`
+	Step               int64    `json:"step"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+// EnableAdminTOTPTx confirms the pending totp enrollment of an admin with the time step
+// of their first valid code, and replaces their recovery codes with the given ones.
+// ErrTOTPCodeReused is returned when the step was already used, and sql.ErrNoRows
+// when there is no pending enrollment.
+func (store *SQLStore) EnableAdminTOTPTx(ctx context.Context, arg EnableAdminTOTPTxParams) (AdminTotp, error) {
+	var totp AdminTotp
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.UseAdminTOTPStep(ctx, UseAdminTOTPStepParams{
+			AdminID:      arg.AdminID,
+			LastUsedStep: arg.Step,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrTOTPCodeReused
+			}
+			return err
+		}
+
+		totp, err = q.ConfirmAdminTOTP(ctx, arg.AdminID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteAdminRecoveryCodes(ctx, arg.AdminID)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			_, err = q.CreateAdminRecoveryCode(ctx, CreateAdminRecoveryCodeParams{
+				AdminID:  arg.AdminID,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return totp, err
+}
+
+// DisableAdminTOTPTx removes the totp secret and the recovery codes of an admin.
+func (store *SQLStore) DisableAdminTOTPTx(ctx context.Context, adminID int64) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteAdminRecoveryCodes(ctx, adminID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteAdminTOTP(ctx, adminID)
+	})
+}
+
+// EnableUserTOTPTxParams contains the input parameters of the user totp enabling transaction
+type EnableUserTOTPTxParams struct {
+	UserID             int64    `json:"user_id"`
+	Step               int64    `json:"step"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+// EnableUserTOTPTx confirms the pending totp enrollment of a user with the time step
+// of their first valid code, and replaces their recovery codes with the given ones.
+// ErrTOTPCodeReused is returned when the step was already used, and sql.ErrNoRows
+// when there is no pending enrollment.
+func (store *SQLStore) EnableUserTOTPTx(ctx context.Context, arg EnableUserTOTPTxParams) (UserTotp, error) {
+	var totp UserTotp
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.UseUserTOTPStep(ctx, UseUserTOTPStepParams{
+			UserID:       arg.UserID,
+			LastUsedStep: arg.Step,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrTOTPCodeReused
+			}
+			return err
+		}
+
+		totp, err = q.ConfirmUserTOTP(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteUserRecoveryCodes(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			_, err = q.CreateUserRecoveryCode(ctx, CreateUserRecoveryCodeParams{
+				UserID:   arg.UserID,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return totp, err
+}
+
+// DisableUserTOTPTx removes the totp secret and the recovery codes of a user.
+func (store *SQLStore) DisableUserTOTPTx(ctx context.Context, userID int64) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteUserRecoveryCodes(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteUserTOTP(ctx, userID)
+	})
+}
+
+// EraseUserTx anonymizes the personal data of a user on their request. The user row
+// is kept, stripped of anything identifying them, so that their orders are preserved
+// for accounting; their addresses, payment methods, cart, sessions, pending tokens
+// and second factor are deleted. sql.ErrNoRows is returned when the user doesn't
+// exist or was already erased.
+func (store *SQLStore) EraseUserTx(ctx context.Context, userID int64) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.EraseUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		deletes := []func(context.Context, int64) error{
+			q.DeleteCartItemsByUserID,
+			q.DeleteShoppingSessionsByUserID,
+			q.DeleteUserAddresses,
+			q.DeleteUserPayments,
+			q.DeleteUserSessions,
+			q.DeletePasswordResetTokens,
+			q.DeleteEmailVerificationTokens,
+			q.DeleteEmailChangeTokens,
+			q.DeleteUserRecoveryCodes,
+			q.DeleteUserTOTP,
+		}
+		for _, deleteFn := range deletes {
+			err = deleteFn(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return user, err
+}
+
+// UserDataOrder is an order of a user along with its items
+type UserDataOrder struct {
+	Order OrderDetail `json:"order"`
+	Items []OrderItem `json:"items"`
+}
+
+// ExportUserDataTxResult is the personal data held about a user
+type ExportUserDataTxResult struct {
+	User      User            `json:"user"`
+	Addresses []UserAddress   `json:"addresses"`
+	Payments  []UserPayment   `json:"payments"`
+	Orders    []UserDataOrder `json:"orders"`
+	Sessions  []UserSession   `json:"sessions"`
+}
+
+// ExportUserDataTx gathers the personal data held about a user within a single
+// transaction: their profile, addresses, payment methods, orders and sessions.
+func (store *SQLStore) ExportUserDataTx(ctx context.Context, userID int64) (ExportUserDataTxResult, error) {
+	var result ExportUserDataTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.GetUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result.Addresses, err = q.ListAllUserAddresses(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result.Payments, err = q.ListAllUserPayments(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		orders, err := q.ListAllOrderDetails(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result.Orders = make([]UserDataOrder, 0, len(orders))
+		for _, order := range orders {
+			items, err := q.ListOrderItemsByOrderID(ctx, order.ID)
+			if err != nil {
+				return err
+			}
+			result.Orders = append(result.Orders, UserDataOrder{Order: order, Items: items})
+		}
+
+		result.Sessions, err = q.ListAllUserSessions(ctx, userID)
+		return err
+	})
+
 	return result, err
 }
@@ -2,104 +2,1463 @@ package db
 
 import (
 	"context"
-	"log"
+	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
 func TestFinishedPurchaseTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	cartItem1 := createRandomCartItem(t)
-	shoppingSession1, err := store.GetShoppingSession(context.Background(), cartItem1.SessionID)
-	if err != nil {
-		log.Fatal("err is: ", err)
+	shoppingSession := createRandomShoppingSession(t)
+
+	n := 3
+	cartItems := make([]CartItem, n)
+	products := make([]Product, n)
+	productInventories := make([]ProductInventory, n)
+	for i := 0; i < n; i++ {
+		products[i] = createRandomProduct(t)
+
+		var err error
+		productInventories[i], err = store.GetProductInventory(context.Background(), products[i].InventoryID)
+		require.NoError(t, err)
+
+		cartItems[i], err = store.CreateCartItem(context.Background(), CreateCartItemParams{
+			SessionID: shoppingSession.ID,
+			Quantity:  int32(util.RandomInt(1, 9)),
+			VariantID: defaultProductVariant(t, products[i]).ID,
+		})
+		require.NoError(t, err)
 	}
-	product, err := store.GetProduct(context.Background(), cartItem1.ProductID)
-	if err != nil {
-		log.Fatal("err is: ", err)
+
+	result, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       cartItems,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result)
+
+	// check order detail
+	orderDetail, err := store.GetOrderDetail(context.Background(), result.OrderDetail.ID)
+	require.NoError(t, err)
+	require.Equal(t, shoppingSession.UserID.Int64, orderDetail.UserID)
+	require.Equal(t, cartTotal(t, store, products, cartItems), orderDetail.Total)
+
+	// check payment detail
+	var totalQuantity int32
+	for _, cartItem := range cartItems {
+		totalQuantity += cartItem.Quantity
 	}
-	productInventory, err := store.GetProductInventory(context.Background(), product.InventoryID)
-	if err != nil {
-		log.Fatal("err is: ", err)
+	require.Equal(t, orderDetail.ID, result.PaymentDetail.OrderID)
+	require.Equal(t, totalQuantity, result.PaymentDetail.Amount)
+
+	// every cart line became an order item and left the inventory
+	require.Len(t, result.OrderItems, n)
+	require.Len(t, result.RemainingQuantities, n)
+	for i := 0; i < n; i++ {
+		orderItem, err := store.GetOrderItem(context.Background(), GetOrderItemParams{
+			ID:     result.OrderItems[i].ID,
+			UserID: shoppingSession.UserID.Int64,
+		})
+		require.NoError(t, err)
+		require.Equal(t, orderDetail.ID, orderItem.OrderID)
+		require.Equal(t, cartItems[i].ProductID, orderItem.ProductID)
+		require.Equal(t, cartItems[i].VariantID, orderItem.VariantID)
+		require.Equal(t, cartItems[i].Quantity, orderItem.Quantity)
+
+		// the order item keeps a snapshot of the product variant as it was sold
+		variant, err := store.GetProductVariant(context.Background(), cartItems[i].VariantID)
+		require.NoError(t, err)
+		discount, err := store.GetDiscount(context.Background(), products[i].DiscountID)
+		require.NoError(t, err)
+		unitPrice, err := UnitPrice(products[i], variant, discount)
+		require.NoError(t, err)
+		price, err := decimal.NewFromString(orderItem.Price)
+		require.NoError(t, err)
+		require.True(t, unitPrice.Equal(price))
+		require.Equal(t, products[i].Name, orderItem.ProductName)
+		require.Equal(t, variant.Sku, orderItem.ProductSku)
+		require.Empty(t, orderItem.VariantName)
+
+		productInventory, err := store.GetProductInventory(context.Background(), products[i].InventoryID)
+		require.NoError(t, err)
+		require.Equal(t, productInventories[i].Quantity-cartItems[i].Quantity, productInventory.Quantity)
 	}
 
-	// run a concurrent FinishedPurchase
-	n := 2
+	// check if the cart items and the shopping session are deleted
+	cartItems2, err := store.ListCartItemsBySessionID(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Empty(t, cartItems2)
 
-	errs := make(chan error)
-	results := make(chan FinishedPurchaseTxResult)
+	shoppingSession2, err := store.GetShoppingSession(context.Background(), shoppingSession.ID)
+	require.Error(t, err)
+	require.Empty(t, shoppingSession2)
+}
+
+func TestFinishedPurchaseTxEmptyCart(t *testing.T) {
+	store := NewStore(testDB)
+
+	shoppingSession := createRandomShoppingSession(t)
+
+	result, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+	})
+	require.ErrorIs(t, err, ErrEmptyCart)
+	require.Empty(t, result.OrderItems)
 
+	// nothing was rolled into an order, so the session is still there
+	shoppingSession2, err := store.GetShoppingSession(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Equal(t, shoppingSession.ID, shoppingSession2.ID)
+}
+
+func TestUpdateShoppingSessionTotalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	shoppingSession := createRandomShoppingSession(t)
+
+	n := 3
+	cartItems := make([]CartItem, n)
+	products := make([]Product, n)
 	for i := 0; i < n; i++ {
-		go func() {
-			result, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
-				ShoppingSession:  shoppingSession1,
-				CartItem:         cartItem1,
-				ProductInventory: productInventory,
-			})
+		products[i] = createRandomProduct(t)
+
+		var err error
+		cartItems[i], err = store.CreateCartItem(context.Background(), CreateCartItemParams{
+			SessionID: shoppingSession.ID,
+			Quantity:  int32(util.RandomInt(1, 9)),
+			VariantID: defaultProductVariant(t, products[i]).ID,
+		})
+		require.NoError(t, err)
+	}
+
+	shoppingSession2, err := store.UpdateShoppingSessionTotalTx(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Equal(t, shoppingSession.ID, shoppingSession2.ID)
+	require.Equal(t, cartTotal(t, store, products, cartItems), shoppingSession2.Total)
+
+	// removing a line lowers the total accordingly
+	err = store.DeleteCartItem(context.Background(), cartItems[n-1].ID)
+	require.NoError(t, err)
+
+	shoppingSession3, err := store.UpdateShoppingSessionTotalTx(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Equal(t, cartTotal(t, store, products[:n-1], cartItems[:n-1]), shoppingSession3.Total)
+}
+
+func TestUpdateShoppingSessionTotalTxEmptyCart(t *testing.T) {
+	store := NewStore(testDB)
+
+	shoppingSession := createRandomShoppingSession(t)
+
+	shoppingSession2, err := store.UpdateShoppingSessionTotalTx(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Equal(t, "0.00", shoppingSession2.Total)
+}
+
+func TestCartItemTxs(t *testing.T) {
+	store := NewStore(testDB)
+
+	shoppingSession := createRandomShoppingSession(t)
+	products := []Product{createRandomProduct(t), createRandomProduct(t)}
+
+	var cartItems []CartItem
+	for _, product := range products {
+		result, err := store.CreateCartItemTx(context.Background(), CreateCartItemParams{
+			SessionID: shoppingSession.ID,
+			Quantity:  2,
+			VariantID: defaultProductVariant(t, product).ID,
+		})
+		require.NoError(t, err)
+		cartItems = append(cartItems, result.CartItem)
+		require.Equal(t, cartTotal(t, store, products[:len(cartItems)], cartItems), result.ShoppingSession.Total)
+	}
+
+	result, err := store.UpdateCartItemTx(context.Background(), UpdateCartItemTxParams{
+		SessionID: shoppingSession.ID,
+		ID:        cartItems[0].ID,
+		Quantity:  5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(5), result.CartItem.Quantity)
+	cartItems[0] = result.CartItem
+	require.Equal(t, cartTotal(t, store, products, cartItems), result.ShoppingSession.Total)
+
+	shoppingSession2, err := store.DeleteCartItemTx(context.Background(), DeleteCartItemTxParams{
+		SessionID: shoppingSession.ID,
+		ID:        cartItems[1].ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, cartTotal(t, store, products[:1], cartItems[:1]), shoppingSession2.Total)
+}
+
+func TestCartItemTxsOfAnotherSession(t *testing.T) {
+	store := NewStore(testDB)
+
+	_, cartItems := createCart(t, createRandomProduct(t), 2)
+	otherSession := createRandomShoppingSession(t)
+
+	_, err := store.UpdateCartItemTx(context.Background(), UpdateCartItemTxParams{
+		SessionID: otherSession.ID,
+		ID:        cartItems[0].ID,
+		Quantity:  5,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.DeleteCartItemTx(context.Background(), DeleteCartItemTxParams{
+		SessionID: otherSession.ID,
+		ID:        cartItems[0].ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the cart item was left untouched
+	cartItem, err := store.GetCartItemByID(context.Background(), cartItems[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, cartItems[0].Quantity, cartItem.Quantity)
+}
+
+func TestMergeGuestCartTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	shared := createRandomProduct(t)
+	guestOnly := createRandomProduct(t)
+
+	userSession, _ := createCart(t, shared, 2)
+	guestSession := createRandomGuestShoppingSession(t)
+	for _, item := range []CreateCartItemParams{
+		{SessionID: guestSession.ID, Quantity: 3, VariantID: defaultProductVariant(t, shared).ID},
+		{SessionID: guestSession.ID, Quantity: 1, VariantID: defaultProductVariant(t, guestOnly).ID},
+	} {
+		_, err := store.CreateCartItem(context.Background(), item)
+		require.NoError(t, err)
+	}
+
+	shoppingSession, err := store.MergeGuestCartTx(context.Background(), MergeGuestCartTxParams{
+		UserID:         userSession.UserID.Int64,
+		GuestTokenHash: guestSession.GuestTokenHash.String,
+		Rule:           CartMergeRuleSum,
+		CreatedAfter:   time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, userSession.ID, shoppingSession.ID)
+
+	cartItems, err := store.ListCartItemsBySessionID(context.Background(), userSession.ID)
+	require.NoError(t, err)
+	require.Len(t, cartItems, 2)
+	require.Equal(t, shared.ID, cartItems[0].ProductID)
+	require.Equal(t, int32(5), cartItems[0].Quantity)
+	require.Equal(t, guestOnly.ID, cartItems[1].ProductID)
+	require.Equal(t, int32(1), cartItems[1].Quantity)
+	require.Equal(t, cartTotal(t, store, []Product{shared, guestOnly}, cartItems), shoppingSession.Total)
+
+	_, err = store.GetShoppingSession(context.Background(), guestSession.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	guestItems, err := store.ListCartItemsBySessionID(context.Background(), guestSession.ID)
+	require.NoError(t, err)
+	require.Empty(t, guestItems)
+}
+
+func TestMergeGuestCartTxWithoutUserSession(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	guestSession := createRandomGuestShoppingSession(t)
+
+	shoppingSession, err := store.MergeGuestCartTx(context.Background(), MergeGuestCartTxParams{
+		UserID:         user.ID,
+		GuestTokenHash: guestSession.GuestTokenHash.String,
+		Rule:           CartMergeRuleSum,
+		CreatedAfter:   time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, guestSession.ID, shoppingSession.ID)
+	require.Equal(t, user.ID, shoppingSession.UserID.Int64)
+	require.False(t, shoppingSession.GuestTokenHash.Valid)
+}
+
+func TestMergeGuestCartTxInvalidCartToken(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	guestSession := createRandomGuestShoppingSession(t)
+
+	_, err := store.MergeGuestCartTx(context.Background(), MergeGuestCartTxParams{
+		UserID:         user.ID,
+		GuestTokenHash: util.HashSecret(util.RandomString(32)),
+		Rule:           CartMergeRuleSum,
+		CreatedAfter:   time.Now().Add(-time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInvalidCartToken)
+
+	// the guest cart has expired
+	_, err = store.MergeGuestCartTx(context.Background(), MergeGuestCartTxParams{
+		UserID:         user.ID,
+		GuestTokenHash: guestSession.GuestTokenHash.String,
+		Rule:           CartMergeRuleSum,
+		CreatedAfter:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInvalidCartToken)
+}
+
+// cartTotal computes the expected total of the cart items from their products.
+func cartTotal(t *testing.T, store Store, products []Product, cartItems []CartItem) string {
+	total := decimal.Zero
+	for i := range cartItems {
+		variant, err := store.GetProductVariant(context.Background(), cartItems[i].VariantID)
+		require.NoError(t, err)
+
+		discount, err := store.GetDiscount(context.Background(), products[i].DiscountID)
+		require.NoError(t, err)
+
+		line, err := LineTotal(products[i], variant, discount, cartItems[i].Quantity)
+		require.NoError(t, err)
+		total = total.Add(line)
+	}
+	return FormatTotal(total)
+}
+
+// createStockedProduct creates a product whose inventory holds exactly quantity units
+func createStockedProduct(t *testing.T, quantity int32) Product {
+	product := createRandomProduct(t)
+
+	_, err := testQueires.UpdateProductInventory(context.Background(), UpdateProductInventoryParams{
+		ID:       product.InventoryID,
+		Quantity: quantity,
+		Active:   true,
+	})
+	require.NoError(t, err)
+
+	return product
+}
+
+// createCart creates a shopping session holding quantity units of the product
+func createCart(t *testing.T, product Product, quantity int32) (ShoppingSession, []CartItem) {
+	shoppingSession := createRandomShoppingSession(t)
+
+	cartItem, err := testQueires.CreateCartItem(context.Background(), CreateCartItemParams{
+		SessionID: shoppingSession.ID,
+		Quantity:  quantity,
+		VariantID: defaultProductVariant(t, product).ID,
+	})
+	require.NoError(t, err)
+
+	return shoppingSession, []CartItem{cartItem}
+}
+
+func TestFinishedPurchaseTxInsufficientStock(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createStockedProduct(t, 2)
+	shoppingSession, cartItems := createCart(t, product, 3)
 
+	_, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       cartItems,
+	})
+
+	var stockErr *InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Equal(t, product.InventoryID, stockErr.InventoryID)
+	require.Equal(t, int32(3), stockErr.Requested)
+	require.Equal(t, int32(2), stockErr.Available)
+
+	// the transaction was rolled back
+	productInventory, err := store.GetProductInventory(context.Background(), product.InventoryID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), productInventory.Quantity)
+
+	cartItems2, err := store.ListCartItemsBySessionID(context.Background(), shoppingSession.ID)
+	require.NoError(t, err)
+	require.Len(t, cartItems2, 1)
+}
+
+func TestFinishedPurchaseTxNegativeQuantity(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createStockedProduct(t, 2)
+	shoppingSession, cartItems := createCart(t, product, 1)
+
+	// a negative line would put units back into the inventory
+	cartItems[0].Quantity = -3
+
+	_, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       cartItems,
+	})
+	require.ErrorIs(t, err, ErrInvalidQuantity)
+
+	productInventory, err := store.GetProductInventory(context.Background(), product.InventoryID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), productInventory.Quantity)
+}
+
+func TestFinishedPurchaseTxOfVariant(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createRandomProduct(t)
+	size, err := store.CreateProductOptionTx(context.Background(), CreateProductOptionTxParams{
+		ProductID: product.ID,
+		Name:      "size",
+		Values:    []string{"S", "M"},
+	})
+	require.NoError(t, err)
+
+	variant, err := store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+		ProductID:      product.ID,
+		Sku:            util.RandomString(8),
+		Price:          sql.NullString{String: "12.5", Valid: true},
+		Quantity:       4,
+		OptionValueIDs: []int64{size.Values[1].ID},
+	})
+	require.NoError(t, err)
+
+	shoppingSession := createRandomShoppingSession(t)
+	cartItem, err := store.CreateCartItem(context.Background(), CreateCartItemParams{
+		SessionID: shoppingSession.ID,
+		Quantity:  3,
+		VariantID: variant.Variant.ID,
+	})
+	require.NoError(t, err)
+
+	result, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       []CartItem{cartItem},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.OrderItems, 1)
+
+	// the order item is priced and named after the variant
+	orderItem := result.OrderItems[0]
+	require.Equal(t, product.ID, orderItem.ProductID)
+	require.Equal(t, variant.Variant.ID, orderItem.VariantID)
+	require.Equal(t, variant.Variant.Sku, orderItem.ProductSku)
+	require.Equal(t, "M", orderItem.VariantName)
+
+	discount, err := store.GetDiscount(context.Background(), product.DiscountID)
+	require.NoError(t, err)
+	unitPrice, err := UnitPrice(product, variant.Variant, discount)
+	require.NoError(t, err)
+	price, err := decimal.NewFromString(orderItem.Price)
+	require.NoError(t, err)
+	require.True(t, unitPrice.Equal(price))
+
+	// the units left the inventory of the variant, not the one of the product
+	require.Len(t, result.RemainingQuantities, 1)
+	require.Equal(t, variant.Inventory.ID, result.RemainingQuantities[0].ID)
+	require.Equal(t, int32(1), result.RemainingQuantities[0].Quantity)
+
+	productInventory, err := store.GetProductInventory(context.Background(), product.InventoryID)
+	require.NoError(t, err)
+	require.True(t, productInventory.UpdatedAt.IsZero())
+}
+
+func TestFinishedPurchaseTxConcurrentNoOversell(t *testing.T) {
+	store := NewStore(testDB)
+
+	stock := 5
+	n := 10
+	product := createStockedProduct(t, int32(stock))
+
+	shoppingSessions := make([]ShoppingSession, n)
+	carts := make([][]CartItem, n)
+	for i := 0; i < n; i++ {
+		shoppingSessions[i], carts[i] = createCart(t, product, 1)
+	}
+
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			_, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+				ShoppingSession: shoppingSessions[i],
+				CartItems:       carts[i],
+			})
 			errs <- err
-			results <- result
-		}()
+		}(i)
 	}
 
-	// check results
+	var sold int
 	for i := 0; i < n; i++ {
 		err := <-errs
-		require.NoError(t, err)
+		if err == nil {
+			sold++
+			continue
+		}
+		var stockErr *InsufficientStockError
+		require.ErrorAs(t, err, &stockErr)
+	}
+	require.Equal(t, stock, sold)
 
-		result := <-results
-		require.NotEmpty(t, result)
+	productInventory, err := store.GetProductInventory(context.Background(), product.InventoryID)
+	require.NoError(t, err)
+	require.Zero(t, productInventory.Quantity)
+}
+
+func TestCreateOrderItemTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
 
-		// check finishedPurchase
-		finishedPurchase := result.PaymentDetail
-		// remainingQuantity := result.RemainingQuantity.Quantity
-		require.NotEmpty(t, finishedPurchase)
-		require.Equal(t, cartItem1.Quantity, finishedPurchase.Amount)
+	orderDetail, err := store.CreateOrderDetailAndPaymentDetail(context.Background(), CreateOrderDetailAndPaymentDetailParams{
+		UserID: user.ID,
+		Total:  FormatTotal(decimal.Zero),
+	})
+	require.NoError(t, err)
 
-		orderDetail, err := store.GetOrderDetail(context.Background(), finishedPurchase.OrderID)
+	var result OrderItemTxResult
+	var orderItems []OrderItem
+	for i := 0; i < 2; i++ {
+		product := createActiveProduct(t)
+		result, err = store.CreateOrderItemTx(context.Background(), CreateOrderItemTxParams{
+			OrderID:   orderDetail.ID,
+			VariantID: defaultProductVariant(t, product).ID,
+			Quantity:  int32(i + 1),
+		})
 		require.NoError(t, err)
-		require.NotEmpty(t, orderDetail)
+		require.Equal(t, orderDetail.ID, result.OrderItem.OrderID)
+		require.Equal(t, product.ID, result.OrderItem.ProductID)
+		orderItems = append(orderItems, result.OrderItem)
+	}
+
+	// the total of the order follows its lines
+	total, err := OrderItemsTotal(orderItems)
+	require.NoError(t, err)
+	require.Equal(t, FormatTotal(total), result.OrderDetail.Total)
+
+	orderDetail, err = store.GetOrderDetail(context.Background(), orderDetail.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.OrderDetail.Total, orderDetail.Total)
+}
+
+func TestCreateOrderItemTxInactiveProduct(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	orderDetail, err := store.CreateOrderDetailAndPaymentDetail(context.Background(), CreateOrderDetailAndPaymentDetailParams{
+		UserID: user.ID,
+		Total:  FormatTotal(decimal.Zero),
+	})
+	require.NoError(t, err)
+
+	// the product is not on sale
+	_, err = store.CreateOrderItemTx(context.Background(), CreateOrderItemTxParams{
+		OrderID:   orderDetail.ID,
+		VariantID: defaultProductVariant(t, createRandomProduct(t)).ID,
+		Quantity:  1,
+	})
+	require.ErrorIs(t, err, ErrInactiveProduct)
+
+	// the product is on sale but not the variant
+	product := createActiveProduct(t)
+	variant := defaultProductVariant(t, product)
+	_, err = store.UpdateProductVariant(context.Background(), UpdateProductVariantParams{
+		ID:        variant.ID,
+		ProductID: product.ID,
+		Active:    false,
+	})
+	require.NoError(t, err)
 
-		// arg := GetOrderItemParams{
-		// 	ID:     ,
-		// 	UserID: orderDetail.UserID,
-		// }
+	_, err = store.CreateOrderItemTx(context.Background(), CreateOrderItemTxParams{
+		OrderID:   orderDetail.ID,
+		VariantID: variant.ID,
+		Quantity:  1,
+	})
+	require.ErrorIs(t, err, ErrInactiveProduct)
 
-		// orderItem, err := store.GetOrderItem(context.Background(), finishedPurchase.OrderID)
-		// require.NoError(t, err)
-		// require.NotEmpty(t, orderItem)
+	orderItems, err := store.ListOrderItemsByOrderID(context.Background(), orderDetail.ID)
+	require.NoError(t, err)
+	require.Empty(t, orderItems)
+}
+
+func TestCreateOrderItemTxFinishedOrder(t *testing.T) {
+	store := NewStore(testDB)
+	product := createStockedProduct(t, 5)
+	shoppingSession, cartItems := createCart(t, product, 1)
+
+	purchase, err := store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession,
+		CartItems:       cartItems,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateOrderItemTx(context.Background(), CreateOrderItemTxParams{
+		OrderID:   purchase.OrderDetail.ID,
+		VariantID: cartItems[0].VariantID,
+		Quantity:  1,
+	})
+	require.ErrorIs(t, err, ErrOrderFinished)
+
+	orderItems, err := store.ListOrderItemsByOrderID(context.Background(), purchase.OrderDetail.ID)
+	require.NoError(t, err)
+	require.Len(t, orderItems, 1)
+}
+
+func TestReserveStockTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createStockedProduct(t, 5)
+	shoppingSession1, cartItems1 := createCart(t, product, 4)
+	shoppingSession2, cartItems2 := createCart(t, product, 2)
+
+	result, err := store.ReserveStockTx(context.Background(), ReserveStockTxParams{
+		ShoppingSession: shoppingSession1,
+		CartItems:       cartItems1,
+		ExpiresAt:       time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Reservations, 1)
+	require.Equal(t, product.InventoryID, result.Reservations[0].InventoryID)
+	require.Equal(t, int32(4), result.Reservations[0].Quantity)
+
+	// the units held for the first session cannot be sold to the second one
+	_, err = store.ReserveStockTx(context.Background(), ReserveStockTxParams{
+		ShoppingSession: shoppingSession2,
+		CartItems:       cartItems2,
+		ExpiresAt:       time.Now().Add(time.Minute),
+	})
+	var stockErr *InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Equal(t, int32(1), stockErr.Available)
+
+	_, err = store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession2,
+		CartItems:       cartItems2,
+	})
+	require.ErrorAs(t, err, &stockErr)
+
+	// while the owner of the reservation can still buy
+	_, err = store.FinishedPurchaseTx(context.Background(), FinishedPurchaseTxParams{
+		ShoppingSession: shoppingSession1,
+		CartItems:       cartItems1,
+	})
+	require.NoError(t, err)
+
+	stockReservations, err := store.ListStockReservationsBySessionID(context.Background(), shoppingSession1.ID)
+	require.NoError(t, err)
+	require.Empty(t, stockReservations)
+
+	productInventory, err := store.GetProductInventory(context.Background(), product.InventoryID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), productInventory.Quantity)
+}
+
+func TestRotateUserSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	userSession1 := createRandomUserSession(t)
+
+	arg := RotateUserSessionTxParams{
+		SessionID: userSession1.ID,
+		NewSession: CreateUserSessionParams{
+			ID:           uuid.New(),
+			RefreshToken: util.RandomString(100),
+			UserAgent:    util.RandomString(6),
+			ClientIp:     util.RandomString(10),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		},
+	}
+
+	userSession2, err := store.RotateUserSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.NewSession.ID, userSession2.ID)
+	require.Equal(t, userSession1.UserID, userSession2.UserID)
+	require.Equal(t, userSession1.FamilyID, userSession2.FamilyID)
+	require.Equal(t, arg.NewSession.RefreshToken, userSession2.RefreshToken)
+	require.False(t, userSession2.RotatedAt.Valid)
+
+	rotatedSession, err := store.GetUserSession(context.Background(), userSession1.ID)
+	require.NoError(t, err)
+	require.True(t, rotatedSession.RotatedAt.Valid)
 
-		// require.Equal(t, finishedPurchase.Amount, orderItem.Quantity)
+	// rotating the same session again is a reuse of its refresh token
+	arg.NewSession.ID = uuid.New()
+	userSession3, err := store.RotateUserSessionTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+	require.Empty(t, userSession3)
 
-		//update product quantity
+	_, err = store.GetUserSession(context.Background(), arg.NewSession.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateAdminTypeTx(t *testing.T) {
+	store := NewStore(testDB)
 
-		// diff1 := remainingQuantity
-		// diff2 := productInventory.Quantity - orderItem.Quantity
-		// // diff3 := productInventory.Quantity
-		// // diff4 := remainingQuantity + orderItem.Quantity
+	arg := CreateAdminTypeTxParams{
+		AdminType:   util.RandomString(6),
+		Permissions: []string{"users.read", "catalog.manage", "users.read"},
+	}
 
-		// require.NoError(t, err)
-		// require.Equal(t, diff1, diff2)
-		// // require.Equal(t, diff3, diff4)
-		// require.True(t, diff1 > 0)
+	result, err := store.CreateAdminTypeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AdminType, result.AdminType.AdminType)
+	require.Equal(t, []string{"catalog.manage", "users.read"}, result.Permissions)
 
-		// check if shopping session, and cartItem is deleted
-		shoppingSession2, SSerr := store.GetShoppingSession(context.Background(), shoppingSession1.ID)
-		require.Error(t, SSerr)
-		require.Empty(t, shoppingSession2)
+	permissions, err := store.ListAdminTypePermissions(context.Background(), result.AdminType.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Permissions, permissions)
+}
+
+func TestUpdateAdminTypePermissionsTx(t *testing.T) {
+	store := NewStore(testDB)
 
-		cartItem2, CIerr := store.GetShoppingSession(context.Background(), shoppingSession1.ID)
-		require.Error(t, CIerr)
-		require.Empty(t, cartItem2)
+	adminType := createRandomAdminType(t)
+	createPermissionForAdminType(t, adminType, "users.read")
 
-		// arg := GetPaymentDetailParams{
-		// 	ID:     finishedPurchase.ID,
-		// 	UserID: shoppingSession2.UserID,
-		// }
-		// _, err = store.GetPaymentDetail(context.Background(), arg)
-		// require.NoError(t, err)
+	arg := UpdateAdminTypePermissionsTxParams{
+		AdminTypeID: adminType.ID,
+		Permissions: []string{"orders.manage"},
 	}
 
+	result, err := store.UpdateAdminTypePermissionsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, adminType.ID, result.AdminType.ID)
+	require.Equal(t, arg.Permissions, result.Permissions)
+
+	permissions, err := store.ListAdminTypePermissions(context.Background(), adminType.ID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Permissions, permissions)
+}
+
+func TestUpdateAdminTypePermissionsTxNotFound(t *testing.T) {
+	store := NewStore(testDB)
+
+	arg := UpdateAdminTypePermissionsTxParams{
+		AdminTypeID: -1,
+		Permissions: []string{"orders.manage"},
+	}
+
+	result, err := store.UpdateAdminTypePermissionsTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.Empty(t, result)
+}
+
+func TestCreateProductOptionTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createRandomProduct(t)
+	createRandomProductOption(t, product, 0)
+
+	arg := CreateProductOptionTxParams{
+		ProductID: product.ID,
+		Name:      util.RandomString(6),
+		Values:    []string{"red", "green", "blue"},
+	}
+
+	result, err := store.CreateProductOptionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, product.ID, result.Option.ProductID)
+	require.Equal(t, arg.Name, result.Option.Name)
+	require.Equal(t, int32(1), result.Option.Position)
+
+	require.Len(t, result.Values, 3)
+	for i, value := range result.Values {
+		require.Equal(t, result.Option.ID, value.OptionID)
+		require.Equal(t, arg.Values[i], value.Value)
+		require.Equal(t, int32(i), value.Position)
+	}
+
+	// the default variant takes no value of the option, it is no longer sold
+	variants, err := store.ListProductVariantsByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	require.Equal(t, product.InventoryID, variants[0].InventoryID)
+	require.False(t, variants[0].Active)
+}
+
+func TestCreateProductOptionTxActiveVariants(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createRandomProduct(t)
+	size, err := store.CreateProductOptionTx(context.Background(), CreateProductOptionTxParams{
+		ProductID: product.ID,
+		Name:      "size",
+		Values:    []string{"S", "M"},
+	})
+	require.NoError(t, err)
+
+	variant, err := store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+		ProductID:      product.ID,
+		Sku:            util.RandomString(8),
+		Quantity:       1,
+		OptionValueIDs: []int64{size.Values[0].ID},
+	})
+	require.NoError(t, err)
+
+	arg := CreateProductOptionTxParams{
+		ProductID: product.ID,
+		Name:      "colour",
+		Values:    []string{"red", "blue"},
+	}
+
+	_, err = store.CreateProductOptionTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrActiveVariantsWithoutOption)
+
+	// the option can be added once the variants are no longer sold
+	_, err = store.UpdateProductVariant(context.Background(), UpdateProductVariantParams{
+		ID:        variant.Variant.ID,
+		ProductID: product.ID,
+		Active:    false,
+	})
+	require.NoError(t, err)
+
+	colour, err := store.CreateProductOptionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), colour.Option.Position)
+}
+
+func TestCreateProductOptionTxNotFound(t *testing.T) {
+	store := NewStore(testDB)
+
+	_, err := store.CreateProductOptionTx(context.Background(), CreateProductOptionTxParams{
+		ProductID: -1,
+		Name:      util.RandomString(6),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// createProductOptions gives the product a size and a colour option, with two values each
+func createProductOptions(t *testing.T, store Store, product Product) (ProductOptionValues, ProductOptionValues) {
+	size, err := store.CreateProductOptionTx(context.Background(), CreateProductOptionTxParams{
+		ProductID: product.ID,
+		Name:      "size",
+		Values:    []string{"S", "M"},
+	})
+	require.NoError(t, err)
+
+	colour, err := store.CreateProductOptionTx(context.Background(), CreateProductOptionTxParams{
+		ProductID: product.ID,
+		Name:      "colour",
+		Values:    []string{"red", "blue"},
+	})
+	require.NoError(t, err)
+
+	return size, colour
+}
+
+func TestCreateProductVariantTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createRandomProduct(t)
+	size, colour := createProductOptions(t, store, product)
+
+	arg := CreateProductVariantTxParams{
+		ProductID:      product.ID,
+		Sku:            util.RandomString(8),
+		Quantity:       7,
+		OptionValueIDs: []int64{colour.Values[1].ID, size.Values[0].ID},
+	}
+
+	result, err := store.CreateProductVariantTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, product.ID, result.Variant.ProductID)
+	require.Equal(t, arg.Sku, result.Variant.Sku)
+	require.False(t, result.Variant.Price.Valid)
+	require.True(t, result.Variant.Active)
+
+	// the variant has an inventory of its own
+	require.Equal(t, result.Inventory.ID, result.Variant.InventoryID)
+	require.NotEqual(t, product.InventoryID, result.Inventory.ID)
+	require.Equal(t, arg.Quantity, result.Inventory.Quantity)
+
+	require.Equal(t, []ProductVariantOptionValue{
+		{VariantID: result.Variant.ID, OptionID: size.Option.ID, OptionValueID: size.Values[0].ID},
+		{VariantID: result.Variant.ID, OptionID: colour.Option.ID, OptionValueID: colour.Values[1].ID},
+	}, result.OptionValues)
+
+	// the same values can't make another variant
+	arg.Sku = util.RandomString(8)
+	_, err = store.CreateProductVariantTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrDuplicateVariant)
+}
+
+func TestCreateProductVariantTxInvalidOptions(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createRandomProduct(t)
+	size, colour := createProductOptions(t, store, product)
+	otherSize, _ := createProductOptions(t, store, createRandomProduct(t))
+
+	testCases := []struct {
+		name           string
+		optionValueIDs []int64
+	}{
+		{
+			name:           "MissingOption",
+			optionValueIDs: []int64{size.Values[0].ID},
+		},
+		{
+			name:           "TwoValuesOfAnOption",
+			optionValueIDs: []int64{size.Values[0].ID, size.Values[1].ID, colour.Values[0].ID},
+		},
+		{
+			name:           "ValueOfAnotherProduct",
+			optionValueIDs: []int64{otherSize.Values[0].ID, colour.Values[0].ID},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+				ProductID:      product.ID,
+				Sku:            util.RandomString(8),
+				Quantity:       1,
+				OptionValueIDs: tc.optionValueIDs,
+			})
+			require.ErrorIs(t, err, ErrInvalidVariantOptions)
+		})
+	}
+}
+
+func TestCreateProductVariantTxWithoutOptions(t *testing.T) {
+	store := NewStore(testDB)
+
+	// a product without options is sold through its default variant only
+	_, err := store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+		ProductID: createRandomProduct(t).ID,
+		Sku:       util.RandomString(8),
+		Quantity:  1,
+	})
+	require.ErrorIs(t, err, ErrDuplicateVariant)
+}
+
+func TestGetProductVariantMatrixTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	product := createRandomProduct(t)
+	size, colour := createProductOptions(t, store, product)
+
+	variant, err := store.CreateProductVariantTx(context.Background(), CreateProductVariantTxParams{
+		ProductID:      product.ID,
+		Sku:            util.RandomString(8),
+		Quantity:       1,
+		OptionValueIDs: []int64{size.Values[1].ID, colour.Values[0].ID},
+	})
+	require.NoError(t, err)
+
+	matrix, err := store.GetProductVariantMatrixTx(context.Background(), product.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, []ProductOptionValues{size, colour}, matrix.Options)
+
+	require.Len(t, matrix.Variants, 2)
+	require.Equal(t, product.InventoryID, matrix.Variants[0].Variant.InventoryID)
+	require.Empty(t, matrix.Variants[0].OptionValueIDs)
+	require.Equal(t, variant.Variant.ID, matrix.Variants[1].Variant.ID)
+	require.True(t, matrix.Variants[1].Variant.InStock)
+	require.Equal(t, []int64{size.Values[1].ID, colour.Values[0].ID}, matrix.Variants[1].OptionValueIDs)
+}
+
+func TestUpdateAdminTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	adminSession := createRandomAdminSession(t)
+	adminType := createRandomAdminType(t)
+
+	arg := UpdateAdminTxParams{
+		ID:     adminSession.AdminID,
+		Active: false,
+		TypeID: adminType.ID,
+	}
+
+	admin, err := store.UpdateAdminTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, admin.ID)
+	require.Equal(t, arg.Active, admin.Active)
+	require.Equal(t, arg.TypeID, admin.TypeID)
+
+	blockedSession, err := store.GetAdminSession(context.Background(), adminSession.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+}
+
+func TestUpdateAdminTxUnchanged(t *testing.T) {
+	store := NewStore(testDB)
+
+	adminSession := createRandomAdminSession(t)
+	admin1, err := store.GetAdmin(context.Background(), adminSession.AdminID)
+	require.NoError(t, err)
+
+	arg := UpdateAdminTxParams{
+		ID:     admin1.ID,
+		Active: admin1.Active,
+		TypeID: admin1.TypeID,
+	}
+
+	admin2, err := store.UpdateAdminTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, admin1, admin2)
+
+	session, err := store.GetAdminSession(context.Background(), adminSession.ID)
+	require.NoError(t, err)
+	require.False(t, session.IsBlocked)
+}
+
+func TestUpdateAdminTxNotFound(t *testing.T) {
+	store := NewStore(testDB)
+
+	arg := UpdateAdminTxParams{
+		ID:     -1,
+		Active: true,
+		TypeID: 1,
+	}
+
+	_, err := store.UpdateAdminTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	userSession := createActiveUserSessionForUser(t, user)
+	resetToken1 := createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+	resetToken2 := createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := ResetPasswordTxParams{
+		TokenHash:      resetToken1.TokenHash,
+		HashedPassword: hashedPassword,
+	}
+
+	updatedUser, err := store.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, updatedUser.ID)
+	require.Equal(t, hashedPassword, updatedUser.Password)
+
+	blockedSession, err := store.GetUserSession(context.Background(), userSession.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	// neither the used token nor the other tokens of the user can be used anymore
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidPasswordResetToken)
+
+	arg.TokenHash = resetToken2.TokenHash
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidPasswordResetToken)
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	verificationToken1 := createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+	verificationToken2 := createEmailVerificationTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	verifiedUser, err := store.VerifyEmailTx(context.Background(), verificationToken1.TokenHash)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, verifiedUser.ID)
+	require.True(t, verifiedUser.VerifiedAt.Valid)
+
+	// neither the used token nor the other tokens of the user can be used anymore
+	_, err = store.VerifyEmailTx(context.Background(), verificationToken1.TokenHash)
+	require.ErrorIs(t, err, ErrInvalidEmailVerificationToken)
+
+	_, err = store.VerifyEmailTx(context.Background(), verificationToken2.TokenHash)
+	require.ErrorIs(t, err, ErrInvalidEmailVerificationToken)
+}
+
+func TestChangePasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	currentSession := createActiveUserSessionForUser(t, user)
+	otherSession := createActiveUserSessionForUser(t, user)
+	resetToken := createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	updatedUser, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		UserID:         user.ID,
+		SessionID:      currentSession.ID,
+		HashedPassword: hashedPassword,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, updatedUser.ID)
+	require.Equal(t, hashedPassword, updatedUser.Password)
+
+	userSessions, err := store.ListActiveUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+	require.Equal(t, currentSession.ID, userSessions[0].ID)
+
+	blockedSession, err := store.GetUserSession(context.Background(), otherSession.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	_, err = store.UsePasswordResetToken(context.Background(), resetToken.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestConfirmEmailChangeTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	currentSession := createActiveUserSessionForUser(t, user)
+	otherSession := createActiveUserSessionForUser(t, user)
+	changeToken1 := createEmailChangeTokenForUser(t, user, time.Now().Add(time.Hour))
+	changeToken2 := createEmailChangeTokenForUser(t, user, time.Now().Add(time.Hour))
+
+	arg := ConfirmEmailChangeTxParams{
+		UserID:    user.ID,
+		SessionID: currentSession.ID,
+		TokenHash: changeToken1.TokenHash,
+	}
+
+	updatedUser, err := store.ConfirmEmailChangeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, updatedUser.ID)
+	require.Equal(t, changeToken1.NewEmail, updatedUser.Email)
+	require.True(t, updatedUser.VerifiedAt.Valid)
+
+	blockedSession, err := store.GetUserSession(context.Background(), otherSession.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	activeSession, err := store.GetUserSession(context.Background(), currentSession.ID)
+	require.NoError(t, err)
+	require.False(t, activeSession.IsBlocked)
+
+	// neither the used token nor the other tokens of the user can be used anymore
+	_, err = store.ConfirmEmailChangeTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidEmailChangeToken)
+
+	arg.TokenHash = changeToken2.TokenHash
+	_, err = store.ConfirmEmailChangeTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidEmailChangeToken)
+}
+
+func TestConfirmEmailChangeTxOfAnotherUser(t *testing.T) {
+	store := NewStore(testDB)
+
+	user1 := createRandomUser(t)
+	user2 := createRandomUser(t)
+	changeToken := createEmailChangeTokenForUser(t, user1, time.Now().Add(time.Hour))
+
+	_, err := store.ConfirmEmailChangeTx(context.Background(), ConfirmEmailChangeTxParams{
+		UserID:    user2.ID,
+		SessionID: uuid.New(),
+		TokenHash: changeToken.TokenHash,
+	})
+	require.ErrorIs(t, err, ErrInvalidEmailChangeToken)
+
+	unchangedUser, err := store.GetUser(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, user1.Email, unchangedUser.Email)
+}
+
+func TestEnableAdminTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+	_, oldCode := createAdminRecoveryCodeForAdmin(t, admin)
+
+	hashes := []string{util.HashRecoveryCode("aaaaa-aaaaa"), util.HashRecoveryCode("bbbbb-bbbbb")}
+	arg := EnableAdminTOTPTxParams{
+		AdminID:            admin.ID,
+		Step:               100,
+		RecoveryCodeHashes: hashes,
+	}
+
+	totp, err := store.EnableAdminTOTPTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, totp.ConfirmedAt.Valid)
+	require.Equal(t, arg.Step, totp.LastUsedStep)
+
+	// the recovery codes are replaced by the new ones
+	count, err := store.CountAdminRecoveryCodes(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(hashes)), count)
+
+	_, err = store.UseAdminRecoveryCode(context.Background(), UseAdminRecoveryCodeParams{
+		AdminID:  admin.ID,
+		CodeHash: util.HashRecoveryCode(oldCode),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// an enabled totp can't be enabled again
+	arg.Step = 101
+	_, err = store.EnableAdminTOTPTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestEnableAdminTOTPTxWithUsedStep(t *testing.T) {
+	store := NewStore(testDB)
+
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+
+	_, err := store.UseAdminTOTPStep(context.Background(), UseAdminTOTPStepParams{
+		AdminID:      admin.ID,
+		LastUsedStep: 100,
+	})
+	require.NoError(t, err)
+
+	_, err = store.EnableAdminTOTPTx(context.Background(), EnableAdminTOTPTxParams{
+		AdminID: admin.ID,
+		Step:    100,
+	})
+	require.ErrorIs(t, err, ErrTOTPCodeReused)
+
+	// nothing was confirmed
+	totp, err := store.GetAdminTOTP(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.False(t, totp.ConfirmedAt.Valid)
+}
+
+func TestDisableAdminTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	admin := createRandomAdmin(t)
+	createAdminTOTPForAdmin(t, admin)
+	createAdminRecoveryCodeForAdmin(t, admin)
+
+	err := store.DisableAdminTOTPTx(context.Background(), admin.ID)
+	require.NoError(t, err)
+
+	_, err = store.GetAdminTOTP(context.Background(), admin.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	count, err := store.CountAdminRecoveryCodes(context.Background(), admin.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestEnableUserTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+	_, oldCode := createUserRecoveryCodeForUser(t, user)
+
+	hashes := []string{util.HashRecoveryCode("aaaaa-aaaaa"), util.HashRecoveryCode("bbbbb-bbbbb")}
+	arg := EnableUserTOTPTxParams{
+		UserID:             user.ID,
+		Step:               100,
+		RecoveryCodeHashes: hashes,
+	}
+
+	totp, err := store.EnableUserTOTPTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, totp.ConfirmedAt.Valid)
+	require.Equal(t, arg.Step, totp.LastUsedStep)
+
+	// the recovery codes are replaced by the new ones
+	count, err := store.CountUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(hashes)), count)
+
+	_, err = store.UseUserRecoveryCode(context.Background(), UseUserRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: util.HashRecoveryCode(oldCode),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// an enabled totp can't be enabled again
+	arg.Step = 101
+	_, err = store.EnableUserTOTPTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestEnableUserTOTPTxWithUsedStep(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+
+	_, err := store.UseUserTOTPStep(context.Background(), UseUserTOTPStepParams{
+		UserID:       user.ID,
+		LastUsedStep: 100,
+	})
+	require.NoError(t, err)
+
+	_, err = store.EnableUserTOTPTx(context.Background(), EnableUserTOTPTxParams{
+		UserID: user.ID,
+		Step:   100,
+	})
+	require.ErrorIs(t, err, ErrTOTPCodeReused)
+
+	// nothing was confirmed
+	totp, err := store.GetUserTOTP(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.False(t, totp.ConfirmedAt.Valid)
+}
+
+func TestDisableUserTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+	createUserRecoveryCodeForUser(t, user)
+
+	err := store.DisableUserTOTPTx(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = store.GetUserTOTP(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	count, err := store.CountUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestCreateAPIKeyTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	admin := createRandomAdmin(t)
+
+	arg := CreateAPIKeyTxParams{
+		Name:        util.RandomUser(),
+		Prefix:      "esk_" + util.RandomString(8),
+		KeyHash:     util.HashSecret(util.RandomString(32)),
+		AdminID:     admin.ID,
+		Permissions: []string{"orders.manage", "catalog.manage", "orders.manage"},
+	}
+
+	result, err := store.CreateAPIKeyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, result.APIKey.Name)
+	require.Equal(t, arg.KeyHash, result.APIKey.KeyHash)
+	require.Equal(t, []string{"catalog.manage", "orders.manage"}, result.Permissions)
+
+	permissions, err := store.ListAPIKeyPermissions(context.Background(), result.APIKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Permissions, permissions)
+
+	// the same prefix can't be handed out twice
+	arg.KeyHash = util.HashSecret(util.RandomString(32))
+	_, err = store.CreateAPIKeyTx(context.Background(), arg)
+	require.Error(t, err)
+}
+
+// createUserData gives a user an address, a payment method, a cart, an order and a session
+func createUserData(t *testing.T, user User) (OrderDetail, OrderItem) {
+	_, err := testQueires.CreateUserAddress(context.Background(), CreateUserAddressParams{
+		UserID:      user.ID,
+		AddressLine: util.RandomString(5),
+		City:        util.RandomString(5),
+		Telephone:   user.Telephone,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueires.CreateUserPayment(context.Background(), CreateUserPaymentParams{
+		UserID:      user.ID,
+		PaymentType: util.RandomUser(),
+		Provider:    util.RandomUser(),
+		AccountNo:   int32(util.RandomInt(1000, 9999)),
+		Expiry:      time.Now().Local(),
+	})
+	require.NoError(t, err)
+
+	shoppingSession, err := testQueires.CreateShoppingSession(context.Background(), CreateShoppingSessionParams{
+		UserID: user.ID,
+		Total:  "0",
+	})
+	require.NoError(t, err)
+
+	_, err = testQueires.CreateCartItem(context.Background(), CreateCartItemParams{
+		SessionID: shoppingSession.ID,
+		Quantity:  1,
+		VariantID: defaultProductVariant(t, createRandomProduct(t)).ID,
+	})
+	require.NoError(t, err)
+
+	orderDetail, err := testQueires.CreateOrderDetailAndPaymentDetail(context.Background(), CreateOrderDetailAndPaymentDetailParams{
+		UserID: user.ID,
+		Total:  util.RandomDecimal(1, 100),
+	})
+	require.NoError(t, err)
+	orderItem := createRandomOrderItemForOrder(t, orderDetail)
+
+	createActiveUserSessionForUser(t, user)
+
+	return orderDetail, orderItem
+}
+
+func TestEraseUserTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	orderDetail, orderItem := createUserData(t, user)
+	createPasswordResetTokenForUser(t, user, time.Now().Add(time.Hour))
+	createEmailChangeTokenForUser(t, user, time.Now().Add(time.Hour))
+	createUserTOTPForUser(t, user)
+	createUserRecoveryCodeForUser(t, user)
+
+	erasedUser, err := store.EraseUserTx(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, erasedUser.ID)
+	require.NotEqual(t, user.Username, erasedUser.Username)
+	require.NotEqual(t, user.Email, erasedUser.Email)
+	require.Empty(t, erasedUser.Password)
+	require.Empty(t, erasedUser.Telephone)
+	require.False(t, erasedUser.VerifiedAt.Valid)
+	require.True(t, erasedUser.ErasedAt.Valid)
+
+	addresses, err := store.ListAllUserAddresses(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+
+	payments, err := store.ListAllUserPayments(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, payments)
+
+	shoppingSessions, err := store.ListShoppingSessions(context.Background(), ListShoppingSessionsParams{
+		UserID: user.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, shoppingSessions)
+
+	userSessions, err := store.ListAllUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, userSessions)
+
+	_, err = store.GetUserTOTP(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	count, err := store.CountUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// the orders are kept for accounting
+	orders, err := store.ListAllOrderDetails(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, []OrderDetail{orderDetail}, orders)
+
+	orderItems, err := store.ListOrderItemsByOrderID(context.Background(), orderDetail.ID)
+	require.NoError(t, err)
+	require.Equal(t, []OrderItem{orderItem}, orderItems)
+
+	_, err = store.EraseUserTx(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestExportUserDataTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	orderDetail, orderItem := createUserData(t, user)
+
+	data, err := store.ExportUserDataTx(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user, data.User)
+	require.Len(t, data.Addresses, 1)
+	require.Len(t, data.Payments, 1)
+	require.Len(t, data.Sessions, 1)
+	require.Equal(t, []UserDataOrder{{Order: orderDetail, Items: []OrderItem{orderItem}}}, data.Orders)
+
+	_, err = store.ExportUserDataTx(context.Background(), 0)
+	require.ErrorIs(t, err, sql.ErrNoRows)
 }
@@ -5,8 +5,32 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const countLockedUsers = `-- name: CountLockedUsers :one
+SELECT count(*) FROM "user"
+WHERE locked_until > now()
+`
+
+func (q *Queries) CountLockedUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLockedUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT count(*) FROM "user"
+`
+
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO "user" (
   username,
@@ -16,14 +40,14 @@ INSERT INTO "user" (
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING id, username, email, password, telephone, created_at, updated_at
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
 `
 
 type CreateUserParams struct {
 	Username  string `json:"username"`
 	Email     string `json:"email"`
 	Password  string `json:"password"`
-	Telephone int32  `json:"telephone"`
+	Telephone string `json:"telephone"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -42,6 +66,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Telephone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
 	)
 	return i, err
 }
@@ -56,8 +84,42 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 	return err
 }
 
+const eraseUser = `-- name: EraseUser :one
+UPDATE "user"
+SET username = 'erased-user-' || id,
+email = 'erased-user-' || id,
+password = '',
+telephone = '',
+verified_at = NULL,
+failed_login_attempts = 0,
+locked_until = NULL,
+erased_at = now()
+WHERE id = $1
+AND erased_at IS NULL
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+func (q *Queries) EraseUser(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, eraseUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, username, email, password, telephone, created_at, updated_at FROM "user"
+SELECT id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at FROM "user"
 WHERE id = $1 LIMIT 1
 `
 
@@ -72,12 +134,16 @@ func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
 		&i.Telephone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, password, telephone, created_at, updated_at FROM "user"
+SELECT id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at FROM "user"
 WHERE email = $1 LIMIT 1
 `
 
@@ -92,12 +158,16 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Telephone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, email, password, telephone, created_at, updated_at FROM "user"
+SELECT id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at FROM "user"
 WHERE username = $1 LIMIT 1
 `
 
@@ -112,24 +182,78 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.Telephone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
 	)
 	return i, err
 }
 
+const listLockedUsers = `-- name: ListLockedUsers :many
+SELECT id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at FROM "user"
+WHERE locked_until > now()
+AND ($1::timestamptz IS NULL
+OR (locked_until, id) < ($1::timestamptz, $2::bigint))
+ORDER BY locked_until DESC, id DESC
+LIMIT $3
+`
+
+type ListLockedUsersParams struct {
+	AfterLockedUntil sql.NullTime `json:"after_locked_until"`
+	AfterID          int64        `json:"after_id"`
+	Limit            int32        `json:"limit"`
+}
+
+func (q *Queries) ListLockedUsers(ctx context.Context, arg ListLockedUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listLockedUsers, arg.AfterLockedUntil, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.Password,
+			&i.Telephone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VerifiedAt,
+			&i.FailedLoginAttempts,
+			&i.LockedUntil,
+			&i.ErasedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT id, username, email, password, telephone, created_at, updated_at FROM "user"
+SELECT id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at FROM "user"
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUsers, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +269,10 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.Telephone,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VerifiedAt,
+			&i.FailedLoginAttempts,
+			&i.LockedUntil,
+			&i.ErasedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -159,16 +287,100 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const lockUser = `-- name: LockUser :one
+UPDATE "user"
+SET locked_until = $2
+WHERE id = $1
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+type LockUserParams struct {
+	ID          int64        `json:"id"`
+	LockedUntil sql.NullTime `json:"locked_until"`
+}
+
+func (q *Queries) LockUser(ctx context.Context, arg LockUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, lockUser, arg.ID, arg.LockedUntil)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const recordFailedUserLogin = `-- name: RecordFailedUserLogin :one
+UPDATE "user"
+SET failed_login_attempts = failed_login_attempts + 1
+WHERE id = $1
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+func (q *Queries) RecordFailedUserLogin(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, recordFailedUserLogin, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const resetFailedUserLogins = `-- name: ResetFailedUserLogins :one
+UPDATE "user"
+SET failed_login_attempts = 0,
+locked_until = NULL
+WHERE id = $1
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+func (q *Queries) ResetFailedUserLogins(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, resetFailedUserLogins, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE "user"
 SET telephone = $2
 WHERE id = $1
-RETURNING id, username, email, password, telephone, created_at, updated_at
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
 `
 
 type UpdateUserParams struct {
-	ID        int64 `json:"id"`
-	Telephone int32 `json:"telephone"`
+	ID        int64  `json:"id"`
+	Telephone string `json:"telephone"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -182,6 +394,99 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Telephone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const updateUserEmail = `-- name: UpdateUserEmail :one
+UPDATE "user"
+SET email = $2,
+verified_at = now()
+WHERE id = $1
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+type UpdateUserEmailParams struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserEmail, arg.ID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE "user"
+SET password = $2
+WHERE id = $1
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+type UpdateUserPasswordParams struct {
+	ID       int64  `json:"id"`
+	Password string `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword, arg.ID, arg.Password)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :one
+UPDATE "user"
+SET verified_at = COALESCE(verified_at, now())
+WHERE id = $1
+RETURNING id, username, email, password, telephone, created_at, updated_at, verified_at, failed_login_attempts, locked_until, erased_at
+`
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, verifyUserEmail, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.Telephone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.FailedLoginAttempts,
+		&i.LockedUntil,
+		&i.ErasedAt,
 	)
 	return i, err
 }
@@ -7,6 +7,18 @@ import (
 	"context"
 )
 
+const countUserAddresses = `-- name: CountUserAddresses :one
+SELECT count(*) FROM "user_address"
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserAddresses(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserAddresses, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUserAddress = `-- name: CreateUserAddress :one
 INSERT INTO "user_address" (
   user_id,
@@ -23,7 +35,7 @@ type CreateUserAddressParams struct {
 	UserID      int64  `json:"user_id"`
 	AddressLine string `json:"address_line"`
 	City        string `json:"city"`
-	Telephone   int32  `json:"telephone"`
+	Telephone   string `json:"telephone"`
 }
 
 func (q *Queries) CreateUserAddress(ctx context.Context, arg CreateUserAddressParams) (UserAddress, error) {
@@ -54,6 +66,16 @@ func (q *Queries) DeleteUserAddress(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteUserAddresses = `-- name: DeleteUserAddresses :exec
+DELETE FROM "user_address"
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserAddresses(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserAddresses, userID)
+	return err
+}
+
 const getUserAddress = `-- name: GetUserAddress :one
 SELECT id, user_id, address_line, city, telephone FROM "user_address"
 WHERE id = $1 
@@ -79,22 +101,57 @@ func (q *Queries) GetUserAddress(ctx context.Context, arg GetUserAddressParams)
 	return i, err
 }
 
+const listAllUserAddresses = `-- name: ListAllUserAddresses :many
+SELECT id, user_id, address_line, city, telephone FROM "user_address"
+WHERE user_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAllUserAddresses(ctx context.Context, userID int64) ([]UserAddress, error) {
+	rows, err := q.db.QueryContext(ctx, listAllUserAddresses, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserAddress{}
+	for rows.Next() {
+		var i UserAddress
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.AddressLine,
+			&i.City,
+			&i.Telephone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserAddresses = `-- name: ListUserAddresses :many
 SELECT id, user_id, address_line, city, telephone FROM "user_address"
 WHERE user_id = $1
+AND id > $2
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $3
 `
 
 type ListUserAddressesParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID  int64 `json:"user_id"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListUserAddresses(ctx context.Context, arg ListUserAddressesParams) ([]UserAddress, error) {
-	rows, err := q.db.QueryContext(ctx, listUserAddresses, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUserAddresses, arg.UserID, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +192,7 @@ type UpdateUserAddressParams struct {
 	ID          int64  `json:"id"`
 	AddressLine string `json:"address_line"`
 	City        string `json:"city"`
-	Telephone   int32  `json:"telephone"`
+	Telephone   string `json:"telephone"`
 }
 
 func (q *Queries) UpdateUserAddress(ctx context.Context, arg UpdateUserAddressParams) (UserAddress, error) {
@@ -171,7 +228,7 @@ type UpdateUserAddressByUserIDParams struct {
 	ID          int64  `json:"id"`
 	AddressLine string `json:"address_line"`
 	City        string `json:"city"`
-	Telephone   int32  `json:"telephone"`
+	Telephone   string `json:"telephone"`
 }
 
 func (q *Queries) UpdateUserAddressByUserID(ctx context.Context, arg UpdateUserAddressByUserIDParams) (UserAddress, error) {
@@ -61,7 +61,7 @@ func TestUpdateUserAddress(t *testing.T) {
 		ID:          userAddress1.ID,
 		AddressLine: "NewAddress",
 		City:        "Benghazi",
-		Telephone:   123456,
+		Telephone:   util.RandomPhoneNumber(),
 	}
 
 	userAddress2, err := testQueires.UpdateUserAddress(context.Background(), arg)
@@ -103,7 +103,6 @@ func TestListUserAddresses(t *testing.T) {
 	arg := ListUserAddressesParams{
 		UserID: lastUserAddress.UserID,
 		Limit:  5,
-		Offset: 0,
 	}
 
 	userAddresses, err := testQueires.ListUserAddresses(context.Background(), arg)
@@ -119,4 +118,8 @@ func TestListUserAddresses(t *testing.T) {
 		require.Equal(t, lastUserAddress.Telephone, userAddress.Telephone)
 
 	}
+
+	count, err := testQueires.CountUserAddresses(context.Background(), arg.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(userAddresses)), count)
 }
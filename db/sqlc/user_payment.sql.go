@@ -8,6 +8,18 @@ import (
 	"time"
 )
 
+const countUserPayments = `-- name: CountUserPayments :one
+SELECT count(*) FROM "user_payment"
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserPayments(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserPayments, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUserPayment = `-- name: CreateUserPayment :one
 INSERT INTO "user_payment" (
   user_id,
@@ -65,6 +77,16 @@ func (q *Queries) DeleteUserPayment(ctx context.Context, arg DeleteUserPaymentPa
 	return err
 }
 
+const deleteUserPayments = `-- name: DeleteUserPayments :exec
+DELETE FROM "user_payment"
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserPayments(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserPayments, userID)
+	return err
+}
+
 const getUserPayment = `-- name: GetUserPayment :one
 SELECT id, user_id, payment_type, provider, account_no, expiry FROM "user_payment"
 WHERE id = $1 
@@ -91,22 +113,58 @@ func (q *Queries) GetUserPayment(ctx context.Context, arg GetUserPaymentParams)
 	return i, err
 }
 
+const listAllUserPayments = `-- name: ListAllUserPayments :many
+SELECT id, user_id, payment_type, provider, account_no, expiry FROM "user_payment"
+WHERE user_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAllUserPayments(ctx context.Context, userID int64) ([]UserPayment, error) {
+	rows, err := q.db.QueryContext(ctx, listAllUserPayments, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserPayment{}
+	for rows.Next() {
+		var i UserPayment
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PaymentType,
+			&i.Provider,
+			&i.AccountNo,
+			&i.Expiry,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserPayments = `-- name: ListUserPayments :many
 SELECT id, user_id, payment_type, provider, account_no, expiry FROM "user_payment"
 WHERE user_id = $1
+AND id > $2
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $3
 `
 
 type ListUserPaymentsParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID  int64 `json:"user_id"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListUserPayments(ctx context.Context, arg ListUserPaymentsParams) ([]UserPayment, error) {
-	rows, err := q.db.QueryContext(ctx, listUserPayments, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUserPayments, arg.UserID, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +107,6 @@ func TestListUserPayments(t *testing.T) {
 	arg := ListUserPaymentsParams{
 		UserID: lastUserPayment.UserID,
 		Limit:  5,
-		Offset: 0,
 	}
 
 	userPayments, err := testQueires.ListUserPayments(context.Background(), arg)
@@ -124,4 +123,8 @@ func TestListUserPayments(t *testing.T) {
 		require.Equal(t, lastUserPayment.Expiry, userPayment.Expiry)
 
 	}
+
+	count, err := testQueires.CountUserPayments(context.Background(), arg.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(userPayments)), count)
 }
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createUserRecoveryCodeForUser(t *testing.T, user User) (UserRecoveryCode, string) {
+	code, err := util.RandomRecoveryCode()
+	require.NoError(t, err)
+
+	arg := CreateUserRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	}
+
+	recoveryCode, err := testQueires.CreateUserRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, recoveryCode)
+
+	require.Equal(t, arg.UserID, recoveryCode.UserID)
+	require.Equal(t, arg.CodeHash, recoveryCode.CodeHash)
+	require.False(t, recoveryCode.UsedAt.Valid)
+	require.NotZero(t, recoveryCode.CreatedAt)
+
+	return recoveryCode, code
+}
+
+func TestCreateUserRecoveryCode(t *testing.T) {
+	user := createRandomUser(t)
+	createUserRecoveryCodeForUser(t, user)
+}
+
+func TestUseUserRecoveryCode(t *testing.T) {
+	user := createRandomUser(t)
+	recoveryCode1, code := createUserRecoveryCodeForUser(t, user)
+
+	arg := UseUserRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	}
+
+	recoveryCode2, err := testQueires.UseUserRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, recoveryCode1.ID, recoveryCode2.ID)
+	require.True(t, recoveryCode2.UsedAt.Valid)
+
+	// a recovery code can only be used once
+	_, err = testQueires.UseUserRecoveryCode(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseUserRecoveryCodeOfAnotherUser(t *testing.T) {
+	user1 := createRandomUser(t)
+	user2 := createRandomUser(t)
+	_, code := createUserRecoveryCodeForUser(t, user1)
+
+	_, err := testQueires.UseUserRecoveryCode(context.Background(), UseUserRecoveryCodeParams{
+		UserID:   user2.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCountUserRecoveryCodes(t *testing.T) {
+	user := createRandomUser(t)
+	_, code := createUserRecoveryCodeForUser(t, user)
+	createUserRecoveryCodeForUser(t, user)
+
+	count, err := testQueires.CountUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	_, err = testQueires.UseUserRecoveryCode(context.Background(), UseUserRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: util.HashRecoveryCode(code),
+	})
+	require.NoError(t, err)
+
+	// only the unused codes are counted
+	count, err = testQueires.CountUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestDeleteUserRecoveryCodes(t *testing.T) {
+	user := createRandomUser(t)
+	createUserRecoveryCodeForUser(t, user)
+	createUserRecoveryCodeForUser(t, user)
+
+	err := testQueires.DeleteUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	count, err := testQueires.CountUserRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
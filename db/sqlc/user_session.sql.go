@@ -10,6 +10,102 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockAllUserSessions = `-- name: BlockAllUserSessions :exec
+UPDATE "user_session"
+SET is_blocked = true
+WHERE user_id = $1
+AND is_blocked = false
+`
+
+func (q *Queries) BlockAllUserSessions(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, blockAllUserSessions, userID)
+	return err
+}
+
+const blockOtherUserSessions = `-- name: BlockOtherUserSessions :exec
+UPDATE "user_session"
+SET is_blocked = true
+WHERE user_id = $1
+AND id <> $2
+AND is_blocked = false
+`
+
+type BlockOtherUserSessionsParams struct {
+	UserID int64     `json:"user_id"`
+	ID     uuid.UUID `json:"id"`
+}
+
+func (q *Queries) BlockOtherUserSessions(ctx context.Context, arg BlockOtherUserSessionsParams) error {
+	_, err := q.db.ExecContext(ctx, blockOtherUserSessions, arg.UserID, arg.ID)
+	return err
+}
+
+const blockUserSession = `-- name: BlockUserSession :one
+UPDATE "user_session"
+SET is_blocked = true
+WHERE id = $1
+AND user_id = $2
+RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at, family_id, rotated_at
+`
+
+type BlockUserSessionParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID int64     `json:"user_id"`
+}
+
+func (q *Queries) BlockUserSession(ctx context.Context, arg BlockUserSessionParams) (UserSession, error) {
+	row := q.db.QueryRowContext(ctx, blockUserSession, arg.ID, arg.UserID)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.FamilyID,
+		&i.RotatedAt,
+	)
+	return i, err
+}
+
+const blockUserSessionFamily = `-- name: BlockUserSessionFamily :exec
+UPDATE "user_session"
+SET is_blocked = true
+WHERE family_id = $1
+AND user_id = $2
+`
+
+type BlockUserSessionFamilyParams struct {
+	FamilyID uuid.UUID `json:"family_id"`
+	UserID   int64     `json:"user_id"`
+}
+
+func (q *Queries) BlockUserSessionFamily(ctx context.Context, arg BlockUserSessionFamilyParams) error {
+	_, err := q.db.ExecContext(ctx, blockUserSessionFamily, arg.FamilyID, arg.UserID)
+	return err
+}
+
+const checkUserSession = `-- name: CheckUserSession :one
+SELECT is_blocked FROM "user_session"
+WHERE id = $1
+AND user_id = $2 LIMIT 1
+`
+
+type CheckUserSessionParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID int64     `json:"user_id"`
+}
+
+func (q *Queries) CheckUserSession(ctx context.Context, arg CheckUserSessionParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, checkUserSession, arg.ID, arg.UserID)
+	var is_blocked bool
+	err := row.Scan(&is_blocked)
+	return is_blocked, err
+}
+
 const createUserSession = `-- name: CreateUserSession :one
 INSERT INTO "user_session" (
   id,
@@ -18,11 +114,12 @@ INSERT INTO "user_session" (
   user_agent,
   client_ip,
   is_blocked,
-  expires_at
+  expires_at,
+  family_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
-RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at
+RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at, family_id, rotated_at
 `
 
 type CreateUserSessionParams struct {
@@ -33,6 +130,7 @@ type CreateUserSessionParams struct {
 	ClientIp     string    `json:"client_ip"`
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	FamilyID     uuid.UUID `json:"family_id"`
 }
 
 func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error) {
@@ -44,6 +142,7 @@ func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionPa
 		arg.ClientIp,
 		arg.IsBlocked,
 		arg.ExpiresAt,
+		arg.FamilyID,
 	)
 	var i UserSession
 	err := row.Scan(
@@ -55,12 +154,24 @@ func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionPa
 		&i.IsBlocked,
 		&i.CreatedAt,
 		&i.ExpiresAt,
+		&i.FamilyID,
+		&i.RotatedAt,
 	)
 	return i, err
 }
 
+const deleteUserSessions = `-- name: DeleteUserSessions :exec
+DELETE FROM "user_session"
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserSessions, userID)
+	return err
+}
+
 const getUserSession = `-- name: GetUserSession :one
-SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at FROM "user_session"
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at, family_id, rotated_at FROM "user_session"
 WHERE id = $1 LIMIT 1
 `
 
@@ -76,6 +187,118 @@ func (q *Queries) GetUserSession(ctx context.Context, id uuid.UUID) (UserSession
 		&i.IsBlocked,
 		&i.CreatedAt,
 		&i.ExpiresAt,
+		&i.FamilyID,
+		&i.RotatedAt,
+	)
+	return i, err
+}
+
+const listActiveUserSessions = `-- name: ListActiveUserSessions :many
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at, family_id, rotated_at FROM "user_session"
+WHERE user_id = $1
+AND is_blocked = false
+AND rotated_at IS NULL
+AND expires_at > now()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveUserSessions(ctx context.Context, userID int64) ([]UserSession, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.FamilyID,
+			&i.RotatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllUserSessions = `-- name: ListAllUserSessions :many
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at, family_id, rotated_at FROM "user_session"
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListAllUserSessions(ctx context.Context, userID int64) ([]UserSession, error) {
+	rows, err := q.db.QueryContext(ctx, listAllUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.FamilyID,
+			&i.RotatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rotateUserSession = `-- name: RotateUserSession :one
+UPDATE "user_session"
+SET rotated_at = now()
+WHERE id = $1
+AND rotated_at IS NULL
+AND is_blocked = false
+RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, created_at, expires_at, family_id, rotated_at
+`
+
+func (q *Queries) RotateUserSession(ctx context.Context, id uuid.UUID) (UserSession, error) {
+	row := q.db.QueryRowContext(ctx, rotateUserSession, id)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.FamilyID,
+		&i.RotatedAt,
 	)
 	return i, err
 }
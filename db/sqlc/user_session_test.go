@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -12,14 +13,16 @@ import (
 
 func createRandomUserSession(t *testing.T) UserSession {
 	user1 := createRandomUser(t)
+	sessionID := uuid.New()
 	arg := CreateUserSessionParams{
-		ID:           uuid.New(),
+		ID:           sessionID,
 		UserID:       user1.ID,
 		RefreshToken: util.RandomString(100),
 		UserAgent:    util.RandomString(6),
 		ClientIp:     util.RandomString(10),
 		IsBlocked:    false,
 		ExpiresAt:    time.Now().Local().UTC(),
+		FamilyID:     sessionID,
 	}
 
 	userSession, err := testQueires.CreateUserSession(context.Background(), arg)
@@ -33,6 +36,8 @@ func createRandomUserSession(t *testing.T) UserSession {
 	require.Equal(t, arg.ClientIp, userSession.ClientIp)
 	require.Equal(t, arg.IsBlocked, userSession.IsBlocked)
 	require.WithinDuration(t, arg.ExpiresAt, userSession.ExpiresAt, time.Second)
+	require.Equal(t, arg.FamilyID, userSession.FamilyID)
+	require.False(t, userSession.RotatedAt.Valid)
 
 	require.NotEmpty(t, userSession.CreatedAt)
 
@@ -59,3 +64,190 @@ func TestGetUserSession(t *testing.T) {
 	require.Equal(t, userSession1.CreatedAt, userSession2.CreatedAt)
 	require.Equal(t, userSession1.ExpiresAt, userSession2.ExpiresAt)
 }
+
+func createActiveUserSessionForUser(t *testing.T, user User) UserSession {
+	sessionID := uuid.New()
+	arg := CreateUserSessionParams{
+		ID:           sessionID,
+		UserID:       user.ID,
+		RefreshToken: util.RandomString(100),
+		UserAgent:    util.RandomString(6),
+		ClientIp:     util.RandomString(10),
+		IsBlocked:    false,
+		ExpiresAt:    time.Now().Add(time.Hour).UTC(),
+		FamilyID:     sessionID,
+	}
+
+	userSession, err := testQueires.CreateUserSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, userSession)
+
+	return userSession
+}
+
+func TestListActiveUserSessions(t *testing.T) {
+	user := createRandomUser(t)
+
+	n := 3
+	for i := 0; i < n; i++ {
+		createActiveUserSessionForUser(t, user)
+	}
+
+	blocked := createActiveUserSessionForUser(t, user)
+	_, err := testQueires.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:     blocked.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+
+	userSessions, err := testQueires.ListActiveUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, userSessions, n)
+
+	for _, userSession := range userSessions {
+		require.Equal(t, user.ID, userSession.UserID)
+		require.False(t, userSession.IsBlocked)
+		require.NotEqual(t, blocked.ID, userSession.ID)
+	}
+}
+
+func TestBlockUserSession(t *testing.T) {
+	userSession1 := createRandomUserSession(t)
+
+	arg := BlockUserSessionParams{
+		ID:     userSession1.ID,
+		UserID: userSession1.UserID,
+	}
+
+	userSession2, err := testQueires.BlockUserSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, userSession2)
+
+	require.Equal(t, userSession1.ID, userSession2.ID)
+	require.True(t, userSession2.IsBlocked)
+}
+
+func TestBlockUserSessionOfAnotherUser(t *testing.T) {
+	userSession1 := createRandomUserSession(t)
+	user := createRandomUser(t)
+
+	arg := BlockUserSessionParams{
+		ID:     userSession1.ID,
+		UserID: user.ID,
+	}
+
+	userSession2, err := testQueires.BlockUserSession(context.Background(), arg)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, userSession2)
+}
+
+func TestBlockAllUserSessions(t *testing.T) {
+	user := createRandomUser(t)
+
+	for i := 0; i < 3; i++ {
+		createActiveUserSessionForUser(t, user)
+	}
+
+	err := testQueires.BlockAllUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	userSessions, err := testQueires.ListActiveUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, userSessions)
+}
+
+func TestBlockOtherUserSessions(t *testing.T) {
+	user := createRandomUser(t)
+
+	current := createActiveUserSessionForUser(t, user)
+	for i := 0; i < 3; i++ {
+		createActiveUserSessionForUser(t, user)
+	}
+
+	err := testQueires.BlockOtherUserSessions(context.Background(), BlockOtherUserSessionsParams{
+		UserID: user.ID,
+		ID:     current.ID,
+	})
+	require.NoError(t, err)
+
+	userSessions, err := testQueires.ListActiveUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+	require.Equal(t, current.ID, userSessions[0].ID)
+}
+
+func TestRotateUserSession(t *testing.T) {
+	userSession1 := createRandomUserSession(t)
+
+	userSession2, err := testQueires.RotateUserSession(context.Background(), userSession1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, userSession2)
+
+	require.Equal(t, userSession1.ID, userSession2.ID)
+	require.True(t, userSession2.RotatedAt.Valid)
+	require.WithinDuration(t, time.Now(), userSession2.RotatedAt.Time, time.Second)
+
+	// a session can only be rotated once
+	userSession3, err := testQueires.RotateUserSession(context.Background(), userSession1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, userSession3)
+}
+
+func TestBlockUserSessionFamily(t *testing.T) {
+	user := createRandomUser(t)
+	userSession1 := createActiveUserSessionForUser(t, user)
+	userSession2 := createActiveUserSessionForUser(t, user)
+
+	arg := BlockUserSessionFamilyParams{
+		FamilyID: userSession1.FamilyID,
+		UserID:   user.ID,
+	}
+
+	err := testQueires.BlockUserSessionFamily(context.Background(), arg)
+	require.NoError(t, err)
+
+	userSessions, err := testQueires.ListActiveUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+	require.Equal(t, userSession2.ID, userSessions[0].ID)
+}
+
+func TestCheckUserSession(t *testing.T) {
+	userSession := createRandomUserSession(t)
+
+	arg := CheckUserSessionParams{
+		ID:     userSession.ID,
+		UserID: userSession.UserID,
+	}
+
+	isBlocked, err := testQueires.CheckUserSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, isBlocked)
+
+	_, err = testQueires.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:     userSession.ID,
+		UserID: userSession.UserID,
+	})
+	require.NoError(t, err)
+
+	isBlocked, err = testQueires.CheckUserSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, isBlocked)
+}
+
+func TestCheckUserSessionOfDeletedUser(t *testing.T) {
+	userSession := createRandomUserSession(t)
+
+	err := testQueires.DeleteUser(context.Background(), userSession.UserID)
+	require.NoError(t, err)
+
+	isBlocked, err := testQueires.CheckUserSession(context.Background(), CheckUserSessionParams{
+		ID:     userSession.ID,
+		UserID: userSession.UserID,
+	})
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.False(t, isBlocked)
+}
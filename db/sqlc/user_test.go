@@ -19,7 +19,7 @@ func createRandomUser(t *testing.T) User {
 		Username:  util.RandomUser(),
 		Email:     util.RandomEmail(),
 		Password:  hashedPassword,
-		Telephone: int32(util.RandomInt(0, 7)),
+		Telephone: util.RandomPhoneNumber(),
 	}
 	user, err := testQueires.CreateUser(context.Background(), arg)
 	require.NoError(t, err)
@@ -94,7 +94,7 @@ func TestUpdateUser(t *testing.T) {
 
 	arg := UpdateUserParams{
 		ID:        user1.ID,
-		Telephone: int32(util.RandomInt(0, 10)),
+		Telephone: util.RandomPhoneNumber(),
 	}
 
 	user2, err := testQueires.UpdateUser(context.Background(), arg)
@@ -125,12 +125,13 @@ func TestDeleteUser(t *testing.T) {
 }
 
 func TestListUsers(t *testing.T) {
+	first := createRandomUser(t)
 	for i := 0; i < 10; i++ {
 		createRandomUser(t)
 	}
 	arg := ListUsersParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	users, err := testQueires.ListUsers(context.Background(), arg)
@@ -139,6 +140,172 @@ func TestListUsers(t *testing.T) {
 
 	for _, user := range users {
 		require.NotEmpty(t, user)
+		require.Greater(t, user.ID, first.ID)
 
 	}
+
+	count, err := testQueires.CountUsers(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(11))
+}
+
+func TestUpdateUserPassword(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := UpdateUserPasswordParams{
+		ID:       user1.ID,
+		Password: hashedPassword,
+	}
+
+	user2, err := testQueires.UpdateUserPassword(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, user2)
+
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, user1.Email, user2.Email)
+	require.Equal(t, arg.Password, user2.Password)
+	require.NotEqual(t, user1.Password, user2.Password)
+}
+
+func TestUpdateUserEmail(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	arg := UpdateUserEmailParams{
+		ID:    user1.ID,
+		Email: util.RandomEmail(),
+	}
+
+	user2, err := testQueires.UpdateUserEmail(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, user2)
+
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, arg.Email, user2.Email)
+	require.True(t, user2.VerifiedAt.Valid)
+}
+
+func TestVerifyUserEmail(t *testing.T) {
+	user1 := createRandomUser(t)
+	require.False(t, user1.VerifiedAt.Valid)
+
+	user2, err := testQueires.VerifyUserEmail(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, user1.ID, user2.ID)
+	require.True(t, user2.VerifiedAt.Valid)
+	require.WithinDuration(t, time.Now(), user2.VerifiedAt.Time, time.Second)
+
+	// verifying again keeps the first verification time
+	user3, err := testQueires.VerifyUserEmail(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, user2.VerifiedAt.Time, user3.VerifiedAt.Time)
+}
+
+func TestRecordFailedUserLogin(t *testing.T) {
+	user1 := createRandomUser(t)
+	require.Zero(t, user1.FailedLoginAttempts)
+
+	user2, err := testQueires.RecordFailedUserLogin(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), user2.FailedLoginAttempts)
+
+	user3, err := testQueires.RecordFailedUserLogin(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), user3.FailedLoginAttempts)
+}
+
+func TestLockUser(t *testing.T) {
+	user1 := createRandomUser(t)
+	require.False(t, user1.LockedUntil.Valid)
+
+	arg := LockUserParams{
+		ID:          user1.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	user2, err := testQueires.LockUser(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, user2.LockedUntil.Valid)
+	require.WithinDuration(t, arg.LockedUntil.Time, user2.LockedUntil.Time, time.Second)
+}
+
+func TestResetFailedUserLogins(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	_, err := testQueires.RecordFailedUserLogin(context.Background(), user1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.LockUser(context.Background(), LockUserParams{
+		ID:          user1.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	user2, err := testQueires.ResetFailedUserLogins(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Zero(t, user2.FailedLoginAttempts)
+	require.False(t, user2.LockedUntil.Valid)
+}
+
+func TestListLockedUsers(t *testing.T) {
+	lockedUser := createRandomUser(t)
+	_, err := testQueires.LockUser(context.Background(), LockUserParams{
+		ID:          lockedUser.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(100 * time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	soonUnlockedUser := createRandomUser(t)
+	_, err = testQueires.LockUser(context.Background(), LockUserParams{
+		ID:          soonUnlockedUser.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(99 * time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	expiredUser := createRandomUser(t)
+	_, err = testQueires.LockUser(context.Background(), LockUserParams{
+		ID:          expiredUser.ID,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+
+	arg := ListLockedUsersParams{
+		Limit: 5,
+	}
+
+	users, err := testQueires.ListLockedUsers(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, users)
+
+	// the user whose lockout ends last comes first
+	require.Equal(t, lockedUser.ID, users[0].ID)
+	soonUnlockedFound := false
+	for i, user := range users {
+		soonUnlockedFound = soonUnlockedFound || user.ID == soonUnlockedUser.ID
+		require.NotEqual(t, expiredUser.ID, user.ID)
+		require.True(t, user.LockedUntil.Time.After(time.Now()))
+		if i > 0 {
+			require.False(t, user.LockedUntil.Time.After(users[i-1].LockedUntil.Time))
+		}
+	}
+	require.True(t, soonUnlockedFound)
+
+	// the next page starts after the lockout of the last user of the page
+	last := users[len(users)-1]
+	nextUsers, err := testQueires.ListLockedUsers(context.Background(), ListLockedUsersParams{
+		AfterLockedUntil: last.LockedUntil,
+		AfterID:          last.ID,
+		Limit:            5,
+	})
+	require.NoError(t, err)
+	for _, user := range nextUsers {
+		require.NotEqual(t, last.ID, user.ID)
+		require.False(t, user.LockedUntil.Time.After(last.LockedUntil.Time))
+	}
+
+	count, err := testQueires.CountLockedUsers(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(len(users)))
 }
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createUserTOTPForUser(t *testing.T, user User) UserTotp {
+	secret, err := util.RandomTOTPSecret()
+	require.NoError(t, err)
+
+	arg := CreateUserTOTPParams{
+		UserID: user.ID,
+		Secret: secret,
+	}
+
+	totp, err := testQueires.CreateUserTOTP(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, totp)
+
+	require.Equal(t, arg.UserID, totp.UserID)
+	require.Equal(t, arg.Secret, totp.Secret)
+	require.False(t, totp.ConfirmedAt.Valid)
+	require.Zero(t, totp.LastUsedStep)
+	require.NotZero(t, totp.CreatedAt)
+
+	return totp
+}
+
+func TestCreateUserTOTP(t *testing.T) {
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+}
+
+func TestCreateUserTOTPReplacesPendingSecret(t *testing.T) {
+	user := createRandomUser(t)
+	totp1 := createUserTOTPForUser(t, user)
+	totp2 := createUserTOTPForUser(t, user)
+	require.NotEqual(t, totp1.Secret, totp2.Secret)
+
+	totp3, err := testQueires.GetUserTOTP(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, totp2.Secret, totp3.Secret)
+}
+
+func TestCreateUserTOTPWhenConfirmed(t *testing.T) {
+	user := createRandomUser(t)
+	totp := createUserTOTPForUser(t, user)
+
+	_, err := testQueires.ConfirmUserTOTP(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	// an enabled secret can't be swapped for another one
+	_, err = testQueires.CreateUserTOTP(context.Background(), CreateUserTOTPParams{
+		UserID: user.ID,
+		Secret: totp.Secret + "A",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestConfirmUserTOTP(t *testing.T) {
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+
+	totp, err := testQueires.ConfirmUserTOTP(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.True(t, totp.ConfirmedAt.Valid)
+
+	_, err = testQueires.ConfirmUserTOTP(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseUserTOTPStep(t *testing.T) {
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+
+	arg := UseUserTOTPStepParams{
+		UserID:       user.ID,
+		LastUsedStep: 100,
+	}
+
+	totp, err := testQueires.UseUserTOTPStep(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.LastUsedStep, totp.LastUsedStep)
+
+	// neither the same step nor an earlier one can be used again
+	_, err = testQueires.UseUserTOTPStep(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.LastUsedStep = 99
+	_, err = testQueires.UseUserTOTPStep(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.LastUsedStep = 101
+	_, err = testQueires.UseUserTOTPStep(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestDeleteUserTOTP(t *testing.T) {
+	user := createRandomUser(t)
+	createUserTOTPForUser(t, user)
+
+	err := testQueires.DeleteUserTOTP(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = testQueires.GetUserTOTP(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,124 @@
+package mail
+
+import (
+	"fmt"
+	"io"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+)
+
+// Mailer sends emails to the users of the shop
+type Mailer interface {
+	SendEmail(subject string, content string, to []string) error
+}
+
+// NewMailer creates the mailer selected by the MAILER setting:
+// "smtp" sends the emails through the SMTP server, while "log" or no setting at
+// all writes them to MAIL_LOG_FILE, or to the standard output, for local development.
+// Any other value is an error rather than a silent fallback to writing the emails,
+// and the tokens they carry, to the logs.
+func NewMailer(config util.Config) (Mailer, error) {
+	switch config.Mailer {
+	case "smtp":
+		return NewSMTPMailer(
+			config.SMTPHost,
+			config.SMTPPort,
+			config.SMTPUsername,
+			config.SMTPPassword,
+			config.EmailSenderAddress,
+		), nil
+	case "log", "":
+		if config.MailLogFile == "" {
+			return NewLogMailer(os.Stdout, config.EmailSenderAddress), nil
+		}
+
+		file, err := os.OpenFile(config.MailLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open mail log file: %w", err)
+		}
+		return NewLogMailer(file, config.EmailSenderAddress), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", config.Mailer)
+	}
+}
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	address       string
+	auth          smtp.Auth
+	senderAddress string
+}
+
+// NewSMTPMailer creates a new SMTPMailer
+func NewSMTPMailer(host string, port int, username string, password string, senderAddress string) Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		address:       fmt.Sprintf("%s:%d", host, port),
+		auth:          auth,
+		senderAddress: senderAddress,
+	}
+}
+
+// SendEmail sends a plain text email to the recipients
+func (mailer *SMTPMailer) SendEmail(subject string, content string, to []string) error {
+	msg := newMessage(mailer.senderAddress, subject, content, to)
+
+	err := smtp.SendMail(mailer.address, mailer.auth, mailer.senderAddress, to, msg)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// LogMailer writes the emails to a writer instead of sending them
+type LogMailer struct {
+	mu            sync.Mutex
+	writer        io.Writer
+	senderAddress string
+}
+
+// NewLogMailer creates a new LogMailer
+func NewLogMailer(writer io.Writer, senderAddress string) Mailer {
+	return &LogMailer{
+		writer:        writer,
+		senderAddress: senderAddress,
+	}
+}
+
+// SendEmail writes the email as it would have been sent
+func (mailer *LogMailer) SendEmail(subject string, content string, to []string) error {
+	msg := newMessage(mailer.senderAddress, subject, content, to)
+
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+
+	_, err := fmt.Fprintf(mailer.writer, "%s\r\n\r\n", msg)
+	if err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
+
+func newMessage(from string, subject string, content string, to []string) []byte {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&sb, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(content)
+
+	return []byte(sb.String())
+}
@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogMailer(t *testing.T) {
+	var buf bytes.Buffer
+	mailer := NewLogMailer(&buf, "shop@example.com")
+
+	to := []string{util.RandomEmail()}
+	content := util.RandomString(32)
+
+	err := mailer.SendEmail("Reset your password", content, to)
+	require.NoError(t, err)
+
+	msg := buf.String()
+	require.Contains(t, msg, "From: shop@example.com\r\n")
+	require.Contains(t, msg, "To: "+to[0]+"\r\n")
+	require.Contains(t, msg, "Subject: Reset your password\r\n")
+	require.Contains(t, msg, "\r\n\r\n"+content)
+}
+
+func TestNewMailer(t *testing.T) {
+	mailer, err := NewMailer(util.Config{Mailer: "smtp", SMTPHost: "localhost", SMTPPort: 25})
+	require.NoError(t, err)
+	require.IsType(t, &SMTPMailer{}, mailer)
+
+	mailer, err = NewMailer(util.Config{})
+	require.NoError(t, err)
+	require.IsType(t, &LogMailer{}, mailer)
+
+	mailer, err = NewMailer(util.Config{Mailer: "log"})
+	require.NoError(t, err)
+	require.IsType(t, &LogMailer{}, mailer)
+}
+
+func TestNewMailerUnknown(t *testing.T) {
+	// a typo must not send the emails to the logs
+	mailer, err := NewMailer(util.Config{Mailer: "SMTP"})
+	require.Error(t, err)
+	require.Nil(t, mailer)
+}
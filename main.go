@@ -6,6 +6,7 @@ import (
 
 	"github.com/DarkHeros09/e-shop/v2/api"
 	db "github.com/DarkHeros09/e-shop/v2/db/sqlc"
+	"github.com/DarkHeros09/e-shop/v2/mail"
 	"github.com/DarkHeros09/e-shop/v2/util"
 
 	_ "github.com/lib/pq"
@@ -22,7 +23,13 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
-	server, err := api.NewServer(config, store)
+
+	mailer, err := mail.NewMailer(config)
+	if err != nil {
+		log.Fatal("cannot create mailer:", err)
+	}
+
+	server, err := api.NewServer(config, store, mailer)
 	if err != nil {
 		log.Fatal("cannot start server:", err)
 	}
@@ -0,0 +1,203 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	jwtAlgorithm = "EdDSA"
+	jwtType      = "JWT"
+	// jwtMFAType marks the mfa pending tokens
+	jwtMFAType = "mfa+jwt"
+)
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// JWTMaker is a JSON Web Token maker signing with Ed25519 (EdDSA), the tokens it
+// creates can be verified by anyone holding its public keys
+type JWTMaker struct {
+	keys *KeySet
+}
+
+// NewJWTMaker creates a new JWTMaker
+func NewJWTMaker(keys *KeySet) Maker {
+	return &JWTMaker{
+		keys: keys,
+	}
+}
+
+// sign creates a token of type typ with the fields of payload as claims, along
+// with the registered iat and exp claims for the other services reading them
+func (maker *JWTMaker) sign(payload interface{}, issuedAt time.Time, expiredAt time.Time, typ string) (string, error) {
+	header, err := json.Marshal(jwtHeader{
+		Algorithm: jwtAlgorithm,
+		Type:      typ,
+		KeyID:     maker.keys.keyID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	claims := make(map[string]interface{})
+	err = json.Unmarshal(data, &claims)
+	if err != nil {
+		return "", err
+	}
+	claims["iat"] = issuedAt.Unix()
+	claims["exp"] = expiredAt.Unix()
+
+	data, err = json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(data)
+	signature := ed25519.Sign(maker.keys.privateKey, []byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// verify checks the signature of the token with the key named in its header,
+// and that it is of type typ
+func (maker *JWTMaker) verify(token string, payload interface{}, typ string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	var header jwtHeader
+	err = json.Unmarshal(data, &header)
+	if err != nil || header.Algorithm != jwtAlgorithm || header.Type != typ {
+		return ErrInvalidToken
+	}
+
+	publicKey, ok := maker.keys.publicKey(header.KeyID)
+	if !ok {
+		return ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(publicKey, []byte(parts[0]+"."+parts[1]), signature) {
+		return ErrInvalidToken
+	}
+
+	data, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	err = json.Unmarshal(data, payload)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// CreateToken creates a new token for specific username and duration
+func (maker *JWTMaker) CreateTokenForUser(userID int64, username string, sessionID uuid.UUID, duration time.Duration) (string, *UserPayload, error) {
+	payload, err := NewPayloadForUser(userID, username, sessionID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload, payload.IssuedAt, payload.ExpiredAt, jwtType)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *JWTMaker) VerifyTokenForUser(token string) (*UserPayload, error) {
+	userPayload := &UserPayload{}
+
+	err := maker.verify(token, userPayload, jwtType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = userPayload.ValidUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return userPayload, nil
+}
+
+// CreateToken creates a new admin token for specific admin and duration
+func (maker *JWTMaker) CreateTokenForAdmin(adminID int64, username string, type_id int64, active bool, sessionID uuid.UUID, duration time.Duration) (string, *AdminPayload, error) {
+	payload, err := NewPayloadForAdmin(adminID, username, type_id, active, sessionID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload, payload.IssuedAt, payload.ExpiredAt, jwtType)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *JWTMaker) VerifyTokenForAdmin(token string) (*AdminPayload, error) {
+	adminPayload := &AdminPayload{}
+
+	err := maker.verify(token, adminPayload, jwtType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = adminPayload.ValidAdmin()
+	if err != nil {
+		return nil, err
+	}
+
+	return adminPayload, nil
+}
+
+// CreateMFAToken creates a new mfa pending token for a user or an admin
+func (maker *JWTMaker) CreateMFAToken(ownerID int64, admin bool, duration time.Duration) (string, *MFAPayload, error) {
+	payload, err := NewMFAPayload(ownerID, admin, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload, payload.IssuedAt, payload.ExpiredAt, jwtMFAType)
+	return token, payload, err
+}
+
+// VerifyMFAToken checks if the mfa pending token is valid or not
+func (maker *JWTMaker) VerifyMFAToken(token string) (*MFAPayload, error) {
+	mfaPayload := &MFAPayload{}
+
+	err := maker.verify(token, mfaPayload, jwtMFAType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mfaPayload.ValidMFA()
+	if err != nil {
+		return nil, err
+	}
+
+	return mfaPayload, nil
+}
+
+// PublicKeys returns the keys the tokens are verified with
+func (maker *JWTMaker) PublicKeys() []PublicKey {
+	return maker.keys.PublicKeys()
+}
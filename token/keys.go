@@ -0,0 +1,120 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PublicKey is a key tokens can be verified with, named by the key id carried in the tokens
+type PublicKey struct {
+	KeyID string
+	Key   ed25519.PublicKey
+}
+
+// JWK is the JSON Web Key representation of a public key
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyID     string `json:"kid"`
+	X         string `json:"x"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+// JWK returns the public key as an Ed25519 JSON Web Key
+func (key PublicKey) JWK() JWK {
+	return JWK{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		KeyID:     key.KeyID,
+		X:         base64.RawURLEncoding.EncodeToString(key.Key),
+		Use:       "sig",
+		Algorithm: "EdDSA",
+	}
+}
+
+// KeySet holds the Ed25519 key new tokens are signed with, and every key tokens
+// are still verified with. Keeping the public keys of the previous signing keys
+// around lets the tokens they signed live out their duration after a rotation.
+type KeySet struct {
+	keyID      string
+	privateKey ed25519.PrivateKey
+	publicKeys map[string]ed25519.PublicKey
+}
+
+// NewKeySet creates a new KeySet signing with the base64 encoded Ed25519 seed
+// privateKey under keyID. verificationKeys lists the other keys tokens are accepted
+// with as comma separated "kid:base64 public key" pairs.
+func NewKeySet(keyID string, privateKey string, verificationKeys string) (*KeySet, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("missing key id")
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key size: must be exactly %d bytes", ed25519.SeedSize)
+	}
+
+	keys := &KeySet{
+		keyID:      keyID,
+		privateKey: ed25519.NewKeyFromSeed(seed),
+		publicKeys: make(map[string]ed25519.PublicKey),
+	}
+	keys.publicKeys[keyID] = keys.privateKey.Public().(ed25519.PublicKey)
+
+	for _, pair := range strings.Split(verificationKeys, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kid, encoded, found := strings.Cut(pair, ":")
+		if !found || kid == "" {
+			return nil, fmt.Errorf("invalid verification key %q: must be kid:key", pair)
+		}
+		if _, ok := keys.publicKeys[kid]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", kid)
+		}
+
+		publicKey, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key %q: %w", kid, err)
+		}
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid verification key %q size: must be exactly %d bytes", kid, ed25519.PublicKeySize)
+		}
+		keys.publicKeys[kid] = ed25519.PublicKey(publicKey)
+	}
+
+	return keys, nil
+}
+
+// publicKey returns the key a token signed under keyID is verified with
+func (keys *KeySet) publicKey(keyID string) (ed25519.PublicKey, bool) {
+	publicKey, ok := keys.publicKeys[keyID]
+	return publicKey, ok
+}
+
+// PublicKeys returns every key tokens are verified with, the signing one first
+func (keys *KeySet) PublicKeys() []PublicKey {
+	publicKeys := []PublicKey{{KeyID: keys.keyID, Key: keys.publicKeys[keys.keyID]}}
+
+	kids := make([]string, 0, len(keys.publicKeys)-1)
+	for kid := range keys.publicKeys {
+		if kid != keys.keyID {
+			kids = append(kids, kid)
+		}
+	}
+	sort.Strings(kids)
+
+	for _, kid := range kids {
+		publicKeys = append(publicKeys, PublicKey{KeyID: kid, Key: keys.publicKeys[kid]})
+	}
+	return publicKeys
+}
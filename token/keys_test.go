@@ -0,0 +1,122 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPrivateKey(t *testing.T) string {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(seed)
+}
+
+func randomKeySet(t *testing.T, verificationKeys string) *KeySet {
+	keys, err := NewKeySet(util.RandomString(8), randomPrivateKey(t), verificationKeys)
+	require.NoError(t, err)
+	return keys
+}
+
+// verificationKey returns the signing key of keys as a TOKEN_VERIFICATION_KEYS entry
+func verificationKey(keys *KeySet) string {
+	return keys.keyID + ":" + base64.StdEncoding.EncodeToString(keys.PublicKeys()[0].Key)
+}
+
+func TestNewKeySet(t *testing.T) {
+	oldKeys1 := randomKeySet(t, "")
+	oldKeys2 := randomKeySet(t, "")
+
+	keys := randomKeySet(t, verificationKey(oldKeys2)+", "+verificationKey(oldKeys1))
+
+	publicKeys := keys.PublicKeys()
+	require.Len(t, publicKeys, 3)
+	require.Equal(t, keys.keyID, publicKeys[0].KeyID)
+	require.Equal(t, keys.privateKey.Public(), publicKeys[0].Key)
+
+	// the retiring keys follow the signing one, sorted by key id
+	retiring := map[string]ed25519.PublicKey{
+		oldKeys1.keyID: oldKeys1.PublicKeys()[0].Key,
+		oldKeys2.keyID: oldKeys2.PublicKeys()[0].Key,
+	}
+	require.Less(t, publicKeys[1].KeyID, publicKeys[2].KeyID)
+	for _, publicKey := range publicKeys[1:] {
+		require.Equal(t, retiring[publicKey.KeyID], publicKey.Key)
+	}
+}
+
+func TestNewKeySetInvalid(t *testing.T) {
+	privateKey := randomPrivateKey(t)
+	otherKeys := randomKeySet(t, "")
+
+	testCases := []struct {
+		name             string
+		keyID            string
+		privateKey       string
+		verificationKeys string
+	}{
+		{
+			name:       "MissingKeyID",
+			privateKey: privateKey,
+		},
+		{
+			name:       "PrivateKeyNotBase64",
+			keyID:      "key-1",
+			privateKey: "not base64!",
+		},
+		{
+			name:       "PrivateKeySize",
+			keyID:      "key-1",
+			privateKey: base64.StdEncoding.EncodeToString([]byte(util.RandomString(16))),
+		},
+		{
+			name:             "VerificationKeyWithoutID",
+			keyID:            "key-1",
+			privateKey:       privateKey,
+			verificationKeys: base64.StdEncoding.EncodeToString(otherKeys.PublicKeys()[0].Key),
+		},
+		{
+			name:             "VerificationKeySize",
+			keyID:            "key-1",
+			privateKey:       privateKey,
+			verificationKeys: "key-0:" + base64.StdEncoding.EncodeToString([]byte(util.RandomString(16))),
+		},
+		{
+			name:             "DuplicateKeyID",
+			keyID:            "key-1",
+			privateKey:       privateKey,
+			verificationKeys: "key-1:" + base64.StdEncoding.EncodeToString(otherKeys.PublicKeys()[0].Key),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := NewKeySet(tc.keyID, tc.privateKey, tc.verificationKeys)
+			require.Error(t, err)
+			require.Nil(t, keys)
+		})
+	}
+}
+
+func TestPublicKeyJWK(t *testing.T) {
+	keys := randomKeySet(t, "")
+	publicKey := keys.PublicKeys()[0]
+
+	jwk := publicKey.JWK()
+	require.Equal(t, "OKP", jwk.KeyType)
+	require.Equal(t, "Ed25519", jwk.Curve)
+	require.Equal(t, publicKey.KeyID, jwk.KeyID)
+	require.Equal(t, "sig", jwk.Use)
+	require.Equal(t, "EdDSA", jwk.Algorithm)
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+	require.Equal(t, []byte(publicKey.Key), x)
+}
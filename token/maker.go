@@ -1,18 +1,62 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/google/uuid"
+)
 
 // Maker is an interface for manging tokens
 type Maker interface {
-	// CreateToken creates a new user token for specific username and duration
-	CreateTokenForUser(userID int64, username string, duration time.Duration) (string, *UserPayload, error)
+	// CreateToken creates a new user token for specific username and duration,
+	// bound to sessionID or to a new session when sessionID is uuid.Nil
+	CreateTokenForUser(userID int64, username string, sessionID uuid.UUID, duration time.Duration) (string, *UserPayload, error)
 
 	// VerifyTokenForUser checks if the user token is valid or not
 	VerifyTokenForUser(token string) (*UserPayload, error)
 
-	// CreateToken creates a new admin token for specific admin and duration
-	CreateTokenForAdmin(userID int64, username string, type_id int64, active bool, duration time.Duration) (string, *AdminPayload, error)
+	// CreateToken creates a new admin token for specific admin and duration,
+	// bound to sessionID or to a new session when sessionID is uuid.Nil
+	CreateTokenForAdmin(userID int64, username string, type_id int64, active bool, sessionID uuid.UUID, duration time.Duration) (string, *AdminPayload, error)
 
 	// VerifyTokenForAdmin checks if the admin token is valid or not
 	VerifyTokenForAdmin(token string) (*AdminPayload, error)
+
+	// CreateMFAToken creates a short-lived token for a user or an admin who still
+	// has to pass the second step of their login
+	CreateMFAToken(ownerID int64, admin bool, duration time.Duration) (string, *MFAPayload, error)
+
+	// VerifyMFAToken checks if the mfa pending token is valid or not,
+	// it is never accepted in place of a user or an admin token
+	VerifyMFAToken(token string) (*MFAPayload, error)
+
+	// PublicKeys returns the keys other services can verify the tokens with,
+	// none for a maker with a symmetric key
+	PublicKeys() []PublicKey
+}
+
+// NewMaker creates the token maker selected by the TOKEN_MAKER setting:
+// "paseto-public" signs v2.public PASETO tokens and "jwt" signs JSON Web Tokens,
+// both with the Ed25519 TOKEN_PRIVATE_KEY, while "paseto" or no setting at all
+// encrypts v2.local PASETO tokens with TOKEN_SYMMETRIC_KEY. Any other value is
+// an error rather than a silent fallback to symmetric tokens.
+func NewMaker(config util.Config) (Maker, error) {
+	switch config.TokenMaker {
+	case "paseto-public", "jwt":
+		keys, err := NewKeySet(config.TokenKeyID, config.TokenPrivateKey, config.TokenVerificationKeys)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load token keys: %w", err)
+		}
+
+		if config.TokenMaker == "jwt" {
+			return NewJWTMaker(keys), nil
+		}
+		return NewPasetoPublicMaker(keys), nil
+	case "paseto", "":
+		return NewPasetoMaker(config.TokenSymmetricKey)
+	default:
+		return nil, fmt.Errorf("unknown token maker %q", config.TokenMaker)
+	}
 }
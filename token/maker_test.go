@@ -0,0 +1,52 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaker(t *testing.T) {
+	maker, err := NewMaker(util.Config{TokenSymmetricKey: util.RandomString(32)})
+	require.NoError(t, err)
+	require.IsType(t, &PasetoMaker{}, maker)
+	require.Empty(t, maker.PublicKeys())
+
+	maker, err = NewMaker(util.Config{TokenMaker: "paseto", TokenSymmetricKey: util.RandomString(32)})
+	require.NoError(t, err)
+	require.IsType(t, &PasetoMaker{}, maker)
+
+	config := util.Config{
+		TokenMaker:      "paseto-public",
+		TokenKeyID:      "key-1",
+		TokenPrivateKey: randomPrivateKey(t),
+	}
+
+	maker, err = NewMaker(config)
+	require.NoError(t, err)
+	require.IsType(t, &PasetoPublicMaker{}, maker)
+	require.Len(t, maker.PublicKeys(), 1)
+
+	config.TokenMaker = "jwt"
+	maker, err = NewMaker(config)
+	require.NoError(t, err)
+	require.IsType(t, &JWTMaker{}, maker)
+	require.Len(t, maker.PublicKeys(), 1)
+
+	config.TokenPrivateKey = ""
+	maker, err = NewMaker(config)
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
+
+func TestNewMakerUnknown(t *testing.T) {
+	config := util.Config{
+		TokenMaker:        "pasteo-public",
+		TokenSymmetricKey: util.RandomString(32),
+	}
+
+	maker, err := NewMaker(config)
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 	"golang.org/x/crypto/chacha20poly1305"
 )
 
+// mfaTokenFooter marks the mfa pending tokens, whose payload could otherwise
+// be decrypted as the one of an access token
+const mfaTokenFooter = "mfa"
+
 // Paseto is a PASETO token maker
 type PasetoMaker struct {
 	paseto       *paseto.V2
@@ -29,8 +34,8 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 }
 
 // CreateToken creates a new token for specific username and duration
-func (maker *PasetoMaker) CreateTokenForUser(userID int64, username string, duration time.Duration) (string, *UserPayload, error) {
-	payload, err := NewPayloadForUser(userID, username, duration)
+func (maker *PasetoMaker) CreateTokenForUser(userID int64, username string, sessionID uuid.UUID, duration time.Duration) (string, *UserPayload, error) {
+	payload, err := NewPayloadForUser(userID, username, sessionID, duration)
 	if err != nil {
 		return "", payload, err
 	}
@@ -46,8 +51,9 @@ func (maker *PasetoMaker) CreateTokenForUser(userID int64, username string, dura
 func (maker *PasetoMaker) VerifyTokenForUser(token string) (*UserPayload, error) {
 	userPayload := &UserPayload{}
 
-	err := maker.paseto.Decrypt(token, maker.symmetricKey, userPayload, nil)
-	if err != nil {
+	var footer string
+	err := maker.paseto.Decrypt(token, maker.symmetricKey, userPayload, &footer)
+	if err != nil || footer == mfaTokenFooter {
 		return nil, ErrInvalidToken
 	}
 
@@ -60,8 +66,8 @@ func (maker *PasetoMaker) VerifyTokenForUser(token string) (*UserPayload, error)
 }
 
 // CreateToken creates a new admin token for specific admin and duration
-func (maker *PasetoMaker) CreateTokenForAdmin(adminID int64, username string, type_id int64, active bool, duration time.Duration) (string, *AdminPayload, error) {
-	payload, err := NewPayloadForAdmin(adminID, username, type_id, active, duration)
+func (maker *PasetoMaker) CreateTokenForAdmin(adminID int64, username string, type_id int64, active bool, sessionID uuid.UUID, duration time.Duration) (string, *AdminPayload, error) {
+	payload, err := NewPayloadForAdmin(adminID, username, type_id, active, sessionID, duration)
 	if err != nil {
 		return "", payload, err
 	}
@@ -77,8 +83,9 @@ func (maker *PasetoMaker) CreateTokenForAdmin(adminID int64, username string, ty
 func (maker *PasetoMaker) VerifyTokenForAdmin(token string) (*AdminPayload, error) {
 	adminPayload := &AdminPayload{}
 
-	err := maker.paseto.Decrypt(token, maker.symmetricKey, adminPayload, nil)
-	if err != nil {
+	var footer string
+	err := maker.paseto.Decrypt(token, maker.symmetricKey, adminPayload, &footer)
+	if err != nil || footer == mfaTokenFooter {
 		return nil, ErrInvalidToken
 	}
 
@@ -89,3 +96,40 @@ func (maker *PasetoMaker) VerifyTokenForAdmin(token string) (*AdminPayload, erro
 
 	return adminPayload, nil
 }
+
+// CreateMFAToken creates a new mfa pending token for a user or an admin
+func (maker *PasetoMaker) CreateMFAToken(ownerID int64, admin bool, duration time.Duration) (string, *MFAPayload, error) {
+	payload, err := NewMFAPayload(ownerID, admin, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, mfaTokenFooter)
+	if err != nil {
+		return "", payload, err
+	}
+	return token, payload, err
+}
+
+// VerifyMFAToken checks if the mfa pending token is valid or not
+func (maker *PasetoMaker) VerifyMFAToken(token string) (*MFAPayload, error) {
+	mfaPayload := &MFAPayload{}
+
+	var footer string
+	err := maker.paseto.Decrypt(token, maker.symmetricKey, mfaPayload, &footer)
+	if err != nil || footer != mfaTokenFooter {
+		return nil, ErrInvalidToken
+	}
+
+	err = mfaPayload.ValidMFA()
+	if err != nil {
+		return nil, err
+	}
+
+	return mfaPayload, nil
+}
+
+// PublicKeys returns no key, the symmetric key can't be shared
+func (maker *PasetoMaker) PublicKeys() []PublicKey {
+	return nil
+}
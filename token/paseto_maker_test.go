@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,12 +15,13 @@ func TestPasetoMakerForUser(t *testing.T) {
 
 	userID := util.RandomMoney()
 	username := util.RandomUser()
+	sessionID := uuid.New()
 	duration := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, payload, err := maker.CreateTokenForUser(userID, username, duration)
+	token, payload, err := maker.CreateTokenForUser(userID, username, sessionID, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 	require.NotEmpty(t, token)
@@ -29,6 +31,7 @@ func TestPasetoMakerForUser(t *testing.T) {
 	require.NotEmpty(t, token)
 
 	require.NotZero(t, payload.ID)
+	require.Equal(t, sessionID, payload.SessionID)
 	require.Equal(t, username, payload.Username)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
@@ -38,7 +41,7 @@ func TestExpiredPasetoTokenForUser(t *testing.T) {
 	maker, err := NewPasetoMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateTokenForUser(util.RandomMoney(), util.RandomUser(), -time.Minute)
+	token, payload, err := maker.CreateTokenForUser(util.RandomMoney(), util.RandomUser(), uuid.Nil, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 	require.NotEmpty(t, token)
@@ -57,12 +60,13 @@ func TestPasetoMakerForAdmin(t *testing.T) {
 	username := util.RandomUser()
 	typeID := util.RandomMoney()
 	active := util.RandomBool()
+	sessionID := uuid.New()
 	duration := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, payload, err := maker.CreateTokenForAdmin(adminID, username, typeID, active, duration)
+	token, payload, err := maker.CreateTokenForAdmin(adminID, username, typeID, active, sessionID, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 	require.NotEmpty(t, token)
@@ -72,6 +76,7 @@ func TestPasetoMakerForAdmin(t *testing.T) {
 	require.NotEmpty(t, token)
 
 	require.NotZero(t, payload.ID)
+	require.Equal(t, sessionID, payload.SessionID)
 	require.Equal(t, username, payload.Username)
 	require.Equal(t, typeID, payload.TypeID)
 	require.Equal(t, active, payload.Active)
@@ -83,7 +88,7 @@ func TestExpiredPasetoTokenForAdmin(t *testing.T) {
 	maker, err := NewPasetoMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateTokenForAdmin(util.RandomMoney(), util.RandomUser(), util.RandomMoney(), util.RandomBool(), -time.Minute)
+	token, payload, err := maker.CreateTokenForAdmin(util.RandomMoney(), util.RandomUser(), util.RandomMoney(), util.RandomBool(), uuid.Nil, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 	require.NotEmpty(t, token)
@@ -93,3 +98,72 @@ func TestExpiredPasetoTokenForAdmin(t *testing.T) {
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestPasetoMakerNewSession(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateTokenForUser(util.RandomMoney(), util.RandomUser(), uuid.Nil, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.Equal(t, payload.ID, payload.SessionID)
+
+	token, adminPayload, err := maker.CreateTokenForAdmin(util.RandomMoney(), util.RandomUser(), util.RandomMoney(), util.RandomBool(), uuid.Nil, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.Equal(t, adminPayload.ID, adminPayload.SessionID)
+}
+
+func TestPasetoMakerMFAToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	ownerID := util.RandomMoney()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateMFAToken(ownerID, true, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyMFAToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, ownerID, payload.OwnerID)
+	require.True(t, payload.Admin)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+	// an mfa pending token is no access token, and the other way around
+	_, err = maker.VerifyTokenForAdmin(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = maker.VerifyTokenForUser(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	adminToken, _, err := maker.CreateTokenForAdmin(ownerID, util.RandomUser(), 1, true, uuid.Nil, duration)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyMFAToken(adminToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestExpiredPasetoMFAToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateMFAToken(util.RandomMoney(), false, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyMFAToken(token)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
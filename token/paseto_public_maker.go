@@ -0,0 +1,148 @@
+package token
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/o1egl/paseto"
+)
+
+// pasetoFooter names the key a v2.public token is signed with, and marks the
+// mfa pending tokens
+type pasetoFooter struct {
+	KeyID   string `json:"kid"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// PasetoPublicMaker is a PASETO v2.public token maker, the tokens it creates can
+// be verified by anyone holding its public keys
+type PasetoPublicMaker struct {
+	paseto *paseto.V2
+	keys   *KeySet
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker
+func NewPasetoPublicMaker(keys *KeySet) Maker {
+	return &PasetoPublicMaker{
+		paseto: paseto.NewV2(),
+		keys:   keys,
+	}
+}
+
+func (maker *PasetoPublicMaker) sign(payload interface{}, purpose string) (string, error) {
+	footer := pasetoFooter{
+		KeyID:   maker.keys.keyID,
+		Purpose: purpose,
+	}
+	return maker.paseto.Sign(maker.keys.privateKey, payload, footer)
+}
+
+// verify checks the signature of the token with the key named in its footer,
+// and that it was created for purpose
+func (maker *PasetoPublicMaker) verify(token string, payload interface{}, purpose string) error {
+	var footer pasetoFooter
+	err := paseto.ParseFooter(token, &footer)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	publicKey, ok := maker.keys.publicKey(footer.KeyID)
+	if !ok || footer.Purpose != purpose {
+		return ErrInvalidToken
+	}
+
+	err = maker.paseto.Verify(token, publicKey, payload, nil)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// CreateToken creates a new token for specific username and duration
+func (maker *PasetoPublicMaker) CreateTokenForUser(userID int64, username string, sessionID uuid.UUID, duration time.Duration) (string, *UserPayload, error) {
+	payload, err := NewPayloadForUser(userID, username, sessionID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload, "")
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *PasetoPublicMaker) VerifyTokenForUser(token string) (*UserPayload, error) {
+	userPayload := &UserPayload{}
+
+	err := maker.verify(token, userPayload, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = userPayload.ValidUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return userPayload, nil
+}
+
+// CreateToken creates a new admin token for specific admin and duration
+func (maker *PasetoPublicMaker) CreateTokenForAdmin(adminID int64, username string, type_id int64, active bool, sessionID uuid.UUID, duration time.Duration) (string, *AdminPayload, error) {
+	payload, err := NewPayloadForAdmin(adminID, username, type_id, active, sessionID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload, "")
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *PasetoPublicMaker) VerifyTokenForAdmin(token string) (*AdminPayload, error) {
+	adminPayload := &AdminPayload{}
+
+	err := maker.verify(token, adminPayload, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = adminPayload.ValidAdmin()
+	if err != nil {
+		return nil, err
+	}
+
+	return adminPayload, nil
+}
+
+// CreateMFAToken creates a new mfa pending token for a user or an admin
+func (maker *PasetoPublicMaker) CreateMFAToken(ownerID int64, admin bool, duration time.Duration) (string, *MFAPayload, error) {
+	payload, err := NewMFAPayload(ownerID, admin, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.sign(payload, mfaTokenFooter)
+	return token, payload, err
+}
+
+// VerifyMFAToken checks if the mfa pending token is valid or not
+func (maker *PasetoPublicMaker) VerifyMFAToken(token string) (*MFAPayload, error) {
+	mfaPayload := &MFAPayload{}
+
+	err := maker.verify(token, mfaPayload, mfaTokenFooter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mfaPayload.ValidMFA()
+	if err != nil {
+		return nil, err
+	}
+
+	return mfaPayload, nil
+}
+
+// PublicKeys returns the keys the tokens are verified with
+func (maker *PasetoPublicMaker) PublicKeys() []PublicKey {
+	return maker.keys.PublicKeys()
+}
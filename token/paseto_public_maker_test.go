@@ -0,0 +1,172 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/DarkHeros09/e-shop/v2/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoPublicMakerForUser(t *testing.T) {
+	maker := NewPasetoPublicMaker(randomKeySet(t, ""))
+
+	userID := util.RandomMoney()
+	username := util.RandomUser()
+	sessionID := uuid.New()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateTokenForUser(userID, username, sessionID, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+	require.NotEmpty(t, token)
+
+	payload, err = maker.VerifyTokenForUser(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, sessionID, payload.SessionID)
+	require.Equal(t, userID, payload.UserID)
+	require.Equal(t, username, payload.Username)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoPublicTokenForUser(t *testing.T) {
+	maker := NewPasetoPublicMaker(randomKeySet(t, ""))
+
+	token, payload, err := maker.CreateTokenForUser(util.RandomMoney(), util.RandomUser(), uuid.Nil, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+	require.NotEmpty(t, token)
+
+	payload, err = maker.VerifyTokenForUser(token)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicMakerForAdmin(t *testing.T) {
+	maker := NewPasetoPublicMaker(randomKeySet(t, ""))
+
+	adminID := util.RandomMoney()
+	username := util.RandomUser()
+	typeID := util.RandomMoney()
+	active := util.RandomBool()
+	sessionID := uuid.New()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateTokenForAdmin(adminID, username, typeID, active, sessionID, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+	require.NotEmpty(t, token)
+
+	payload, err = maker.VerifyTokenForAdmin(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, sessionID, payload.SessionID)
+	require.Equal(t, adminID, payload.AdminID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, typeID, payload.TypeID)
+	require.Equal(t, active, payload.Active)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoPublicTokenForAdmin(t *testing.T) {
+	maker := NewPasetoPublicMaker(randomKeySet(t, ""))
+
+	token, payload, err := maker.CreateTokenForAdmin(util.RandomMoney(), util.RandomUser(), util.RandomMoney(), util.RandomBool(), uuid.Nil, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+	require.NotEmpty(t, token)
+
+	payload, err = maker.VerifyTokenForAdmin(token)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicMakerMFAToken(t *testing.T) {
+	maker := NewPasetoPublicMaker(randomKeySet(t, ""))
+
+	ownerID := util.RandomMoney()
+	duration := time.Minute
+
+	token, payload, err := maker.CreateMFAToken(ownerID, true, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyMFAToken(token)
+	require.NoError(t, err)
+	require.Equal(t, ownerID, payload.OwnerID)
+	require.True(t, payload.Admin)
+
+	// an mfa pending token is no access token, and the other way around
+	_, err = maker.VerifyTokenForAdmin(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = maker.VerifyTokenForUser(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	adminToken, _, err := maker.CreateTokenForAdmin(ownerID, util.RandomUser(), 1, true, uuid.Nil, duration)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyMFAToken(adminToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPasetoPublicMakerKeyRotation(t *testing.T) {
+	oldKeys := randomKeySet(t, "")
+	oldMaker := NewPasetoPublicMaker(oldKeys)
+
+	token, _, err := oldMaker.CreateTokenForUser(util.RandomMoney(), util.RandomUser(), uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	// the tokens of the retiring key are accepted as long as it is listed
+	maker := NewPasetoPublicMaker(randomKeySet(t, verificationKey(oldKeys)))
+	_, err = maker.VerifyTokenForUser(token)
+	require.NoError(t, err)
+
+	newToken, _, err := maker.CreateTokenForUser(util.RandomMoney(), util.RandomUser(), uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = oldMaker.VerifyTokenForUser(newToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	maker = NewPasetoPublicMaker(randomKeySet(t, ""))
+	_, err = maker.VerifyTokenForUser(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPasetoPublicMakerForgedKeyID(t *testing.T) {
+	keys := randomKeySet(t, "")
+	maker := NewPasetoPublicMaker(keys)
+
+	// a token signed with another key under the same key id
+	forgedKeys := randomKeySet(t, "")
+	forgedKey := forgedKeys.PublicKeys()[0].Key
+	forgedKeys.keyID = keys.keyID
+	forgedKeys.publicKeys = map[string]ed25519.PublicKey{keys.keyID: forgedKey}
+
+	token, _, err := NewPasetoPublicMaker(forgedKeys).CreateTokenForAdmin(util.RandomMoney(), util.RandomUser(), 1, true, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyTokenForAdmin(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = maker.VerifyTokenForAdmin("not-a-token")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
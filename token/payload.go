@@ -16,6 +16,7 @@ var (
 // Payload contains the user payload data of the token
 type UserPayload struct {
 	ID        uuid.UUID `json:"id"`
+	SessionID uuid.UUID `json:"session_id"`
 	UserID    int64     `json:"user_id"`
 	Username  string    `json:"username"`
 	IssuedAt  time.Time `json:"issued_at"`
@@ -23,14 +24,20 @@ type UserPayload struct {
 }
 
 // NewPayload creates a new token payload with a specific username and duration
-func NewPayloadForUser(userID int64, username string, duration time.Duration) (*UserPayload, error) {
+// bound to the given session, a nil sessionID starts a new session named after the token
+func NewPayloadForUser(userID int64, username string, sessionID uuid.UUID, duration time.Duration) (*UserPayload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	if sessionID == uuid.Nil {
+		sessionID = tokenID
+	}
+
 	payload := &UserPayload{
 		ID:        tokenID,
+		SessionID: sessionID,
 		UserID:    userID,
 		Username:  username,
 		IssuedAt:  time.Now(),
@@ -50,6 +57,7 @@ func (payload *UserPayload) ValidUser() error {
 // Payload contains the admin payload data of the token
 type AdminPayload struct {
 	ID        uuid.UUID `json:"id"`
+	SessionID uuid.UUID `json:"session_id"`
 	AdminID   int64     `json:"admin_id"`
 	Username  string    `json:"username"`
 	TypeID    int64     `json:"type_id"`
@@ -59,14 +67,20 @@ type AdminPayload struct {
 }
 
 // NewPayload creates a new token payload with a specific Admin and duration
-func NewPayloadForAdmin(adminID int64, username string, type_id int64, active bool, duration time.Duration) (*AdminPayload, error) {
+// bound to the given session, a nil sessionID starts a new session named after the token
+func NewPayloadForAdmin(adminID int64, username string, type_id int64, active bool, sessionID uuid.UUID, duration time.Duration) (*AdminPayload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	if sessionID == uuid.Nil {
+		sessionID = tokenID
+	}
+
 	payload := &AdminPayload{
 		ID:        tokenID,
+		SessionID: sessionID,
 		AdminID:   adminID,
 		Username:  username,
 		TypeID:    type_id,
@@ -84,3 +98,39 @@ func (payload *AdminPayload) ValidAdmin() error {
 	}
 	return nil
 }
+
+// MFAPayload contains the data of the token proving that a user or an admin
+// passed the first step of a login, it can only be traded for the real tokens
+// along with a second factor.
+type MFAPayload struct {
+	ID        uuid.UUID `json:"id"`
+	OwnerID   int64     `json:"owner_id"`
+	Admin     bool      `json:"admin"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// NewMFAPayload creates a new mfa pending payload for a user or an admin
+func NewMFAPayload(ownerID int64, admin bool, duration time.Duration) (*MFAPayload, error) {
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &MFAPayload{
+		ID:        tokenID,
+		OwnerID:   ownerID,
+		Admin:     admin,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(duration),
+	}
+
+	return payload, nil
+}
+
+func (payload *MFAPayload) ValidMFA() error {
+	if time.Now().After(payload.ExpiredAt) {
+		return ErrExpiredToken
+	}
+	return nil
+}
@@ -9,12 +9,48 @@ import (
 // config stores all configuration of the application
 // The values are read by viper from a config file or eviroment virable.
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	DBDriver                        string        `mapstructure:"DB_DRIVER"`
+	DBSource                        string        `mapstructure:"DB_SOURCE"`
+	ServerAddress                   string        `mapstructure:"SERVER_ADDRESS"`
+	TokenMaker                      string        `mapstructure:"TOKEN_MAKER"`
+	TokenSymmetricKey               string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenKeyID                      string        `mapstructure:"TOKEN_KEY_ID"`
+	TokenPrivateKey                 string        `mapstructure:"TOKEN_PRIVATE_KEY"`
+	TokenVerificationKeys           string        `mapstructure:"TOKEN_VERIFICATION_KEYS"`
+	AccessTokenDuration             time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration            time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	StockReservationDuration        time.Duration `mapstructure:"STOCK_RESERVATION_DURATION"`
+	SessionCacheDuration            time.Duration `mapstructure:"SESSION_CACHE_DURATION"`
+	Mailer                          string        `mapstructure:"MAILER"`
+	SMTPHost                        string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                        int           `mapstructure:"SMTP_PORT"`
+	SMTPUsername                    string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword                    string        `mapstructure:"SMTP_PASSWORD"`
+	EmailSenderAddress              string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	MailLogFile                     string        `mapstructure:"MAIL_LOG_FILE"`
+	PasswordResetDuration           time.Duration `mapstructure:"PASSWORD_RESET_DURATION"`
+	PasswordResetURL                string        `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetResendInterval     time.Duration `mapstructure:"PASSWORD_RESET_RESEND_INTERVAL"`
+	PasswordResetIPMaxRequests      int           `mapstructure:"PASSWORD_RESET_IP_MAX_REQUESTS"`
+	PasswordResetIPWindow           time.Duration `mapstructure:"PASSWORD_RESET_IP_WINDOW"`
+	EmailVerificationDuration       time.Duration `mapstructure:"EMAIL_VERIFICATION_DURATION"`
+	EmailVerificationURL            string        `mapstructure:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationResendInterval time.Duration `mapstructure:"EMAIL_VERIFICATION_RESEND_INTERVAL"`
+	RequireVerifiedEmailForCheckout bool          `mapstructure:"REQUIRE_VERIFIED_EMAIL_FOR_CHECKOUT"`
+	EmailChangeDuration             time.Duration `mapstructure:"EMAIL_CHANGE_DURATION"`
+	EmailChangeURL                  string        `mapstructure:"EMAIL_CHANGE_URL"`
+	LoginMaxAttempts                int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginLockoutDuration            time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	LoginIPMaxAttempts              int           `mapstructure:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginIPWindow                   time.Duration `mapstructure:"LOGIN_IP_WINDOW"`
+	MFATokenDuration                time.Duration `mapstructure:"MFA_TOKEN_DURATION"`
+	MFAIssuer                       string        `mapstructure:"MFA_ISSUER"`
+	PhoneDefaultCountryCode         string        `mapstructure:"PHONE_DEFAULT_COUNTRY_CODE"`
+	PhoneAllowedPrefixes            string        `mapstructure:"PHONE_ALLOWED_PREFIXES"`
+	GuestCartDuration               time.Duration `mapstructure:"GUEST_CART_DURATION"`
+	CartMergeRule                   string        `mapstructure:"CART_MERGE_RULE"`
+	DefaultPageSize                 int           `mapstructure:"DEFAULT_PAGE_SIZE"`
+	MaxPageSize                     int           `mapstructure:"MAX_PAGE_SIZE"`
 }
 
 // LoadConfig reads configuration from file or eviroment virable.
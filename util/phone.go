@@ -0,0 +1,102 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// e164MinDigits and e164MaxDigits bound the digits of a number after the +
+	e164MinDigits = 7
+	e164MaxDigits = 15
+)
+
+var (
+	ErrInvalidPhoneNumber    = errors.New("invalid phone number")
+	ErrPhoneNumberNotAllowed = errors.New("phone number is not allowed")
+)
+
+// PhoneRules tells how phone numbers are normalized to E.164 and which ones are accepted
+type PhoneRules struct {
+	// DefaultCountryCode is the calling code of the numbers given in national format
+	DefaultCountryCode string
+	// AllowedPrefixes are the E.164 prefixes accepted, e.g. +218 or +21891,
+	// every number is accepted when there are none
+	AllowedPrefixes []string
+}
+
+// NewPhoneRules parses the phone rules of the configuration, allowedPrefixes being
+// a comma separated list of E.164 prefixes
+func NewPhoneRules(defaultCountryCode string, allowedPrefixes string) (PhoneRules, error) {
+	rules := PhoneRules{
+		DefaultCountryCode: strings.TrimPrefix(strings.TrimSpace(defaultCountryCode), "+"),
+	}
+
+	if rules.DefaultCountryCode != "" && (len(rules.DefaultCountryCode) > 3 || !isDigits(rules.DefaultCountryCode)) {
+		return PhoneRules{}, fmt.Errorf("invalid default country code %q", defaultCountryCode)
+	}
+
+	for _, prefix := range strings.Split(allowedPrefixes, ",") {
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" {
+			continue
+		}
+		if !strings.HasPrefix(prefix, "+") || !isDigits(prefix[1:]) {
+			return PhoneRules{}, fmt.Errorf("invalid allowed phone prefix %q", prefix)
+		}
+		rules.AllowedPrefixes = append(rules.AllowedPrefixes, prefix)
+	}
+
+	return rules, nil
+}
+
+// Normalize returns the phone number in E.164 format. The number may be written with
+// spaces, dashes, dots or parentheses, start with + or 00 for an international number,
+// or be in national format, optionally with its leading 0, to use the default country code.
+func (rules PhoneRules) Normalize(number string) (string, error) {
+	number = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, number)
+
+	var digits string
+	switch {
+	case strings.HasPrefix(number, "+"):
+		digits = number[1:]
+	case strings.HasPrefix(number, "00"):
+		digits = number[2:]
+	case rules.DefaultCountryCode != "":
+		digits = rules.DefaultCountryCode + strings.TrimPrefix(number, "0")
+	default:
+		return "", ErrInvalidPhoneNumber
+	}
+
+	// calling codes never start with 0
+	if len(digits) < e164MinDigits || len(digits) > e164MaxDigits || !isDigits(digits) || digits[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	normalized := "+" + digits
+	if len(rules.AllowedPrefixes) == 0 {
+		return normalized, nil
+	}
+	for _, prefix := range rules.AllowedPrefixes {
+		if strings.HasPrefix(normalized, prefix) {
+			return normalized, nil
+		}
+	}
+	return "", ErrPhoneNumberNotAllowed
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	rules, err := NewPhoneRules("218", "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		number string
+		e164   string
+		err    error
+	}{
+		{name: "E164", number: "+218912345678", e164: "+218912345678"},
+		{name: "Formatted", number: "+218 (91) 234-5678", e164: "+218912345678"},
+		{name: "InternationalPrefix", number: "00218912345678", e164: "+218912345678"},
+		{name: "NationalWithTrunkPrefix", number: "091-234-5678", e164: "+218912345678"},
+		{name: "National", number: "912345678", e164: "+218912345678"},
+		{name: "Landline", number: "021 333 4444", e164: "+218213334444"},
+		{name: "Foreign", number: "+44 20 7946 0958", e164: "+442079460958"},
+		{name: "Letters", number: "+21891234567a", err: ErrInvalidPhoneNumber},
+		{name: "TooShort", number: "+12345", err: ErrInvalidPhoneNumber},
+		{name: "TooLong", number: "+1234567890123456", err: ErrInvalidPhoneNumber},
+		{name: "LeadingZeroCallingCode", number: "+0912345678", err: ErrInvalidPhoneNumber},
+		{name: "Empty", number: "", err: ErrInvalidPhoneNumber},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			e164, err := rules.Normalize(tc.number)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.e164, e164)
+		})
+	}
+}
+
+func TestPhoneRulesAllowedPrefixes(t *testing.T) {
+	rules, err := NewPhoneRules("+218", "+21891, +21892")
+	require.NoError(t, err)
+	require.Equal(t, "218", rules.DefaultCountryCode)
+	require.Equal(t, []string{"+21891", "+21892"}, rules.AllowedPrefixes)
+
+	e164, err := rules.Normalize("0921234567")
+	require.NoError(t, err)
+	require.Equal(t, "+218921234567", e164)
+
+	_, err = rules.Normalize("0213334444")
+	require.ErrorIs(t, err, ErrPhoneNumberNotAllowed)
+
+	_, err = rules.Normalize("+442079460958")
+	require.ErrorIs(t, err, ErrPhoneNumberNotAllowed)
+}
+
+func TestPhoneRulesWithoutDefaultCountryCode(t *testing.T) {
+	rules, err := NewPhoneRules("", "")
+	require.NoError(t, err)
+
+	e164, err := rules.Normalize("+218912345678")
+	require.NoError(t, err)
+	require.Equal(t, "+218912345678", e164)
+
+	_, err = rules.Normalize("0912345678")
+	require.ErrorIs(t, err, ErrInvalidPhoneNumber)
+}
+
+func TestNewPhoneRulesInvalid(t *testing.T) {
+	_, err := NewPhoneRules("2180", "")
+	require.Error(t, err)
+
+	_, err = NewPhoneRules("ly", "")
+	require.Error(t, err)
+
+	_, err = NewPhoneRules("218", "21891")
+	require.Error(t, err)
+
+	_, err = NewPhoneRules("218", "+2189x")
+	require.Error(t, err)
+}
+
+func TestRandomPhoneNumber(t *testing.T) {
+	rules, err := NewPhoneRules("", "+21891,+21892")
+	require.NoError(t, err)
+
+	number := RandomPhoneNumber()
+	e164, err := rules.Normalize(number)
+	require.NoError(t, err)
+	require.Equal(t, number, e164)
+}
@@ -52,6 +52,11 @@ func RandomEmail() string {
 	return fmt.Sprintf("%s@email.com", RandomString(6))
 }
 
+// RandomPhoneNumber generates a random libyan mobile number in E.164 format
+func RandomPhoneNumber() string {
+	return fmt.Sprintf("+2189%d%07d", RandomInt(1, 2), RandomInt(0, 9999999))
+}
+
 /*
 RandBool
     This function returns a random boolean value based on the current time
@@ -0,0 +1,22 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	secret1, err := RandomSecret(32)
+	require.NoError(t, err)
+	require.Len(t, secret1, 43)
+
+	secret2, err := RandomSecret(32)
+	require.NoError(t, err)
+	require.NotEqual(t, secret1, secret2)
+
+	hash := HashSecret(secret1)
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, HashSecret(secret1))
+	require.NotEqual(t, hash, HashSecret(secret2))
+}
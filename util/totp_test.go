@@ -0,0 +1,101 @@
+package util
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the SHA1 test vectors of RFC 6238 appendix B, truncated to 6 digits
+func TestTOTPCode(t *testing.T) {
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tc := range testCases {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := RandomTOTPSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	now := time.Now()
+	step := TOTPStep(now)
+
+	code, err := TOTPCode(secret, step)
+	require.NoError(t, err)
+
+	matched, ok := ValidateTOTP(secret, code, now)
+	require.True(t, ok)
+	require.Equal(t, step, matched)
+
+	// the previous code is still accepted to make up for clock drift
+	previousCode, err := TOTPCode(secret, step-1)
+	require.NoError(t, err)
+	matched, ok = ValidateTOTP(secret, previousCode, now)
+	require.True(t, ok)
+	require.Equal(t, step-1, matched)
+
+	oldCode, err := TOTPCode(secret, step-3)
+	require.NoError(t, err)
+	if oldCode != code && oldCode != previousCode {
+		_, ok = ValidateTOTP(secret, oldCode, now)
+		require.False(t, ok)
+	}
+
+	_, ok = ValidateTOTP(secret, "12345", now)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTP("not base32!", "123456", now)
+	require.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	secret, err := RandomTOTPSecret()
+	require.NoError(t, err)
+
+	uri, err := url.Parse(TOTPProvisioningURI("e-shop", "admin@eshop.local", secret))
+	require.NoError(t, err)
+
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/e-shop:admin@eshop.local", uri.Path)
+	require.Equal(t, secret, uri.Query().Get("secret"))
+	require.Equal(t, "e-shop", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestRecoveryCode(t *testing.T) {
+	code1, err := RandomRecoveryCode()
+	require.NoError(t, err)
+	require.Len(t, code1, 11)
+	require.Equal(t, "-", code1[5:6])
+
+	code2, err := RandomRecoveryCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code1, code2)
+
+	hash := HashRecoveryCode(code1)
+	require.Equal(t, hash, HashRecoveryCode(strings.ToUpper(code1)))
+	require.Equal(t, hash, HashRecoveryCode(strings.ReplaceAll(code1, "-", "")))
+	require.NotEqual(t, hash, HashRecoveryCode(code2))
+}